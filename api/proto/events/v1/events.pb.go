@@ -0,0 +1,1025 @@
+// Domain events of the PR reviewer service, as published to message brokers
+// and streamed to subscribers. The payload mirrors entity.PullRequestEvent.
+//
+// Schema evolution policy:
+//   - Messages in this package are append-only. New fields get new numbers;
+//     existing numbers and names are never changed or reused.
+//   - A field is removed by marking it deprecated first and, once no
+//     supported producer sets it, by adding its number and name to
+//     `reserved`.
+//   - A field's type is never changed, except between types the protobuf
+//     wire format declares compatible (int32/int64/uint32/uint64/bool).
+//   - Enum values are append-only as well; the zero value is always
+//     *_UNSPECIFIED, and consumers must treat unknown values as such.
+//   - Any change that breaks these rules goes to a new package
+//     (pr_reviewer.events.v2), published side by side with v1 until
+//     consumers have moved over.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        (unknown)
+// source: events/v1/events.proto
+
+package eventsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PullRequestStatus int32
+
+const (
+	PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED       PullRequestStatus = 0
+	PullRequestStatus_PULL_REQUEST_STATUS_OPEN              PullRequestStatus = 1
+	PullRequestStatus_PULL_REQUEST_STATUS_MERGED            PullRequestStatus = 2
+	PullRequestStatus_PULL_REQUEST_STATUS_CLOSED            PullRequestStatus = 3
+	PullRequestStatus_PULL_REQUEST_STATUS_CHANGES_REQUESTED PullRequestStatus = 4
+)
+
+// Enum value maps for PullRequestStatus.
+var (
+	PullRequestStatus_name = map[int32]string{
+		0: "PULL_REQUEST_STATUS_UNSPECIFIED",
+		1: "PULL_REQUEST_STATUS_OPEN",
+		2: "PULL_REQUEST_STATUS_MERGED",
+		3: "PULL_REQUEST_STATUS_CLOSED",
+		4: "PULL_REQUEST_STATUS_CHANGES_REQUESTED",
+	}
+	PullRequestStatus_value = map[string]int32{
+		"PULL_REQUEST_STATUS_UNSPECIFIED":       0,
+		"PULL_REQUEST_STATUS_OPEN":              1,
+		"PULL_REQUEST_STATUS_MERGED":            2,
+		"PULL_REQUEST_STATUS_CLOSED":            3,
+		"PULL_REQUEST_STATUS_CHANGES_REQUESTED": 4,
+	}
+)
+
+func (x PullRequestStatus) Enum() *PullRequestStatus {
+	p := new(PullRequestStatus)
+	*p = x
+	return p
+}
+
+func (x PullRequestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullRequestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_v1_events_proto_enumTypes[0].Descriptor()
+}
+
+func (PullRequestStatus) Type() protoreflect.EnumType {
+	return &file_events_v1_events_proto_enumTypes[0]
+}
+
+func (x PullRequestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullRequestStatus.Descriptor instead.
+func (PullRequestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+type ReviewerSlot int32
+
+const (
+	ReviewerSlot_REVIEWER_SLOT_UNSPECIFIED ReviewerSlot = 0
+	ReviewerSlot_REVIEWER_SLOT_REQUIRED    ReviewerSlot = 1
+	ReviewerSlot_REVIEWER_SLOT_OPTIONAL    ReviewerSlot = 2
+	ReviewerSlot_REVIEWER_SLOT_OWNER       ReviewerSlot = 3
+	ReviewerSlot_REVIEWER_SLOT_PEER        ReviewerSlot = 4
+)
+
+// Enum value maps for ReviewerSlot.
+var (
+	ReviewerSlot_name = map[int32]string{
+		0: "REVIEWER_SLOT_UNSPECIFIED",
+		1: "REVIEWER_SLOT_REQUIRED",
+		2: "REVIEWER_SLOT_OPTIONAL",
+		3: "REVIEWER_SLOT_OWNER",
+		4: "REVIEWER_SLOT_PEER",
+	}
+	ReviewerSlot_value = map[string]int32{
+		"REVIEWER_SLOT_UNSPECIFIED": 0,
+		"REVIEWER_SLOT_REQUIRED":    1,
+		"REVIEWER_SLOT_OPTIONAL":    2,
+		"REVIEWER_SLOT_OWNER":       3,
+		"REVIEWER_SLOT_PEER":        4,
+	}
+)
+
+func (x ReviewerSlot) Enum() *ReviewerSlot {
+	p := new(ReviewerSlot)
+	*p = x
+	return p
+}
+
+func (x ReviewerSlot) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewerSlot) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_v1_events_proto_enumTypes[1].Descriptor()
+}
+
+func (ReviewerSlot) Type() protoreflect.EnumType {
+	return &file_events_v1_events_proto_enumTypes[1]
+}
+
+func (x ReviewerSlot) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewerSlot.Descriptor instead.
+func (ReviewerSlot) EnumDescriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+// Event is the envelope every event is published in. event_id is unique per
+// event and lets consumers deduplicate redeliveries.
+type Event struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EventId        string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	OccurredAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Event_PrCreated
+	//	*Event_ReviewerAssigned
+	//	*Event_ReviewerReplaced
+	//	*Event_PrMerged
+	//	*Event_PrClosed
+	//	*Event_ChangesRequested
+	//	*Event_PrReopened
+	Payload       isEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_events_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Event) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Event) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Event) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *Event) GetPayload() isEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetPrCreated() *PRCreated {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_PrCreated); ok {
+			return x.PrCreated
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetReviewerAssigned() *ReviewerAssigned {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ReviewerAssigned); ok {
+			return x.ReviewerAssigned
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetReviewerReplaced() *ReviewerReplaced {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ReviewerReplaced); ok {
+			return x.ReviewerReplaced
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPrMerged() *PRMerged {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_PrMerged); ok {
+			return x.PrMerged
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPrClosed() *PRClosed {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_PrClosed); ok {
+			return x.PrClosed
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetChangesRequested() *ChangesRequested {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ChangesRequested); ok {
+			return x.ChangesRequested
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPrReopened() *PRReopened {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_PrReopened); ok {
+			return x.PrReopened
+		}
+	}
+	return nil
+}
+
+type isEvent_Payload interface {
+	isEvent_Payload()
+}
+
+type Event_PrCreated struct {
+	PrCreated *PRCreated `protobuf:"bytes,10,opt,name=pr_created,json=prCreated,proto3,oneof"`
+}
+
+type Event_ReviewerAssigned struct {
+	ReviewerAssigned *ReviewerAssigned `protobuf:"bytes,11,opt,name=reviewer_assigned,json=reviewerAssigned,proto3,oneof"`
+}
+
+type Event_ReviewerReplaced struct {
+	ReviewerReplaced *ReviewerReplaced `protobuf:"bytes,12,opt,name=reviewer_replaced,json=reviewerReplaced,proto3,oneof"`
+}
+
+type Event_PrMerged struct {
+	PrMerged *PRMerged `protobuf:"bytes,13,opt,name=pr_merged,json=prMerged,proto3,oneof"`
+}
+
+type Event_PrClosed struct {
+	PrClosed *PRClosed `protobuf:"bytes,14,opt,name=pr_closed,json=prClosed,proto3,oneof"`
+}
+
+type Event_ChangesRequested struct {
+	ChangesRequested *ChangesRequested `protobuf:"bytes,15,opt,name=changes_requested,json=changesRequested,proto3,oneof"`
+}
+
+type Event_PrReopened struct {
+	PrReopened *PRReopened `protobuf:"bytes,16,opt,name=pr_reopened,json=prReopened,proto3,oneof"`
+}
+
+func (*Event_PrCreated) isEvent_Payload() {}
+
+func (*Event_ReviewerAssigned) isEvent_Payload() {}
+
+func (*Event_ReviewerReplaced) isEvent_Payload() {}
+
+func (*Event_PrMerged) isEvent_Payload() {}
+
+func (*Event_PrClosed) isEvent_Payload() {}
+
+func (*Event_ChangesRequested) isEvent_Payload() {}
+
+func (*Event_PrReopened) isEvent_Payload() {}
+
+// PullRequest is the PR as it was right after the change. IDs are UUIDs in
+// their canonical string form.
+type PullRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId     string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName   string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId          string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status            PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=pr_reviewer.events.v1.PullRequestStatus" json:"status,omitempty"`
+	AssignedReviewers []*Reviewer            `protobuf:"bytes,5,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	ExternalId        string                 `protobuf:"bytes,8,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	ClosedAt          *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_events_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequest) GetAssignedReviewers() []*Reviewer {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+type Reviewer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Slot          ReviewerSlot           `protobuf:"varint,2,opt,name=slot,proto3,enum=pr_reviewer.events.v1.ReviewerSlot" json:"slot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reviewer) Reset() {
+	*x = Reviewer{}
+	mi := &file_events_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reviewer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reviewer) ProtoMessage() {}
+
+func (x *Reviewer) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reviewer.ProtoReflect.Descriptor instead.
+func (*Reviewer) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Reviewer) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Reviewer) GetSlot() ReviewerSlot {
+	if x != nil {
+		return x.Slot
+	}
+	return ReviewerSlot_REVIEWER_SLOT_UNSPECIFIED
+}
+
+// PRCreated is published once a PR is stored together with its initial
+// reviewers.
+type PRCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PRCreated) Reset() {
+	*x = PRCreated{}
+	mi := &file_events_v1_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PRCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PRCreated) ProtoMessage() {}
+
+func (x *PRCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PRCreated.ProtoReflect.Descriptor instead.
+func (*PRCreated) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PRCreated) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+// ReviewerAssigned is published for every reviewer that joins an existing
+// PR without replacing anyone, e.g. when an understaffed PR is backfilled.
+type ReviewerAssigned struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	Reviewer      *Reviewer              `protobuf:"bytes,2,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewerAssigned) Reset() {
+	*x = ReviewerAssigned{}
+	mi := &file_events_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerAssigned) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerAssigned) ProtoMessage() {}
+
+func (x *ReviewerAssigned) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerAssigned.ProtoReflect.Descriptor instead.
+func (*ReviewerAssigned) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReviewerAssigned) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *ReviewerAssigned) GetReviewer() *Reviewer {
+	if x != nil {
+		return x.Reviewer
+	}
+	return nil
+}
+
+// ReviewerReplaced is published when a reviewer is reassigned, by hand or
+// by a rebalance.
+type ReviewerReplaced struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest    *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	PreviousUserId string                 `protobuf:"bytes,2,opt,name=previous_user_id,json=previousUserId,proto3" json:"previous_user_id,omitempty"`
+	Reviewer       *Reviewer              `protobuf:"bytes,3,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReviewerReplaced) Reset() {
+	*x = ReviewerReplaced{}
+	mi := &file_events_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerReplaced) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerReplaced) ProtoMessage() {}
+
+func (x *ReviewerReplaced) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerReplaced.ProtoReflect.Descriptor instead.
+func (*ReviewerReplaced) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReviewerReplaced) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *ReviewerReplaced) GetPreviousUserId() string {
+	if x != nil {
+		return x.PreviousUserId
+	}
+	return ""
+}
+
+func (x *ReviewerReplaced) GetReviewer() *Reviewer {
+	if x != nil {
+		return x.Reviewer
+	}
+	return nil
+}
+
+// PRMerged is published once a PR is merged. auto is set when the service
+// merged it itself after the last approval.
+type PRMerged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	Auto          bool                   `protobuf:"varint,2,opt,name=auto,proto3" json:"auto,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PRMerged) Reset() {
+	*x = PRMerged{}
+	mi := &file_events_v1_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PRMerged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PRMerged) ProtoMessage() {}
+
+func (x *PRMerged) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PRMerged.ProtoReflect.Descriptor instead.
+func (*PRMerged) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PRMerged) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *PRMerged) GetAuto() bool {
+	if x != nil {
+		return x.Auto
+	}
+	return false
+}
+
+// PRClosed is published once an open PR is closed without merging.
+type PRClosed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PRClosed) Reset() {
+	*x = PRClosed{}
+	mi := &file_events_v1_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PRClosed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PRClosed) ProtoMessage() {}
+
+func (x *PRClosed) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PRClosed.ProtoReflect.Descriptor instead.
+func (*PRClosed) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PRClosed) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+// ChangesRequested is published when a reviewer sends the PR back to its
+// author. The PR's approvals are already cleared.
+type ChangesRequested struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	ReviewerId    string                 `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangesRequested) Reset() {
+	*x = ChangesRequested{}
+	mi := &file_events_v1_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangesRequested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangesRequested) ProtoMessage() {}
+
+func (x *ChangesRequested) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangesRequested.ProtoReflect.Descriptor instead.
+func (*ChangesRequested) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ChangesRequested) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *ChangesRequested) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+// PRReopened is published when the author returns a PR with requested
+// changes to review.
+type PRReopened struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PRReopened) Reset() {
+	*x = PRReopened{}
+	mi := &file_events_v1_events_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PRReopened) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PRReopened) ProtoMessage() {}
+
+func (x *PRReopened) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PRReopened.ProtoReflect.Descriptor instead.
+func (*PRReopened) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PRReopened) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+var File_events_v1_events_proto protoreflect.FileDescriptor
+
+const file_events_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x16events/v1/events.proto\x12\x15pr_reviewer.events.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa4\x05\n" +
+	"\x05Event\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12;\n" +
+	"\voccurred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12A\n" +
+	"\n" +
+	"pr_created\x18\n" +
+	" \x01(\v2 .pr_reviewer.events.v1.PRCreatedH\x00R\tprCreated\x12V\n" +
+	"\x11reviewer_assigned\x18\v \x01(\v2'.pr_reviewer.events.v1.ReviewerAssignedH\x00R\x10reviewerAssigned\x12V\n" +
+	"\x11reviewer_replaced\x18\f \x01(\v2'.pr_reviewer.events.v1.ReviewerReplacedH\x00R\x10reviewerReplaced\x12>\n" +
+	"\tpr_merged\x18\r \x01(\v2\x1f.pr_reviewer.events.v1.PRMergedH\x00R\bprMerged\x12>\n" +
+	"\tpr_closed\x18\x0e \x01(\v2\x1f.pr_reviewer.events.v1.PRClosedH\x00R\bprClosed\x12V\n" +
+	"\x11changes_requested\x18\x0f \x01(\v2'.pr_reviewer.events.v1.ChangesRequestedH\x00R\x10changesRequested\x12D\n" +
+	"\vpr_reopened\x18\x10 \x01(\v2!.pr_reviewer.events.v1.PRReopenedH\x00R\n" +
+	"prReopenedB\t\n" +
+	"\apayload\"\xde\x03\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12@\n" +
+	"\x06status\x18\x04 \x01(\x0e2(.pr_reviewer.events.v1.PullRequestStatusR\x06status\x12N\n" +
+	"\x12assigned_reviewers\x18\x05 \x03(\v2\x1f.pr_reviewer.events.v1.ReviewerR\x11assignedReviewers\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tmerged_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\x12\x1f\n" +
+	"\vexternal_id\x18\b \x01(\tR\n" +
+	"externalId\x127\n" +
+	"\tclosed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\"\\\n" +
+	"\bReviewer\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x127\n" +
+	"\x04slot\x18\x02 \x01(\x0e2#.pr_reviewer.events.v1.ReviewerSlotR\x04slot\"R\n" +
+	"\tPRCreated\x12E\n" +
+	"\fpull_request\x18\x01 \x01(\v2\".pr_reviewer.events.v1.PullRequestR\vpullRequest\"\x96\x01\n" +
+	"\x10ReviewerAssigned\x12E\n" +
+	"\fpull_request\x18\x01 \x01(\v2\".pr_reviewer.events.v1.PullRequestR\vpullRequest\x12;\n" +
+	"\breviewer\x18\x02 \x01(\v2\x1f.pr_reviewer.events.v1.ReviewerR\breviewer\"\xc0\x01\n" +
+	"\x10ReviewerReplaced\x12E\n" +
+	"\fpull_request\x18\x01 \x01(\v2\".pr_reviewer.events.v1.PullRequestR\vpullRequest\x12(\n" +
+	"\x10previous_user_id\x18\x02 \x01(\tR\x0epreviousUserId\x12;\n" +
+	"\breviewer\x18\x03 \x01(\v2\x1f.pr_reviewer.events.v1.ReviewerR\breviewer\"e\n" +
+	"\bPRMerged\x12E\n" +
+	"\fpull_request\x18\x01 \x01(\v2\".pr_reviewer.events.v1.PullRequestR\vpullRequest\x12\x12\n" +
+	"\x04auto\x18\x02 \x01(\bR\x04auto\"Q\n" +
+	"\bPRClosed\x12E\n" +
+	"\fpull_request\x18\x01 \x01(\v2\".pr_reviewer.events.v1.PullRequestR\vpullRequest\"z\n" +
+	"\x10ChangesRequested\x12E\n" +
+	"\fpull_request\x18\x01 \x01(\v2\".pr_reviewer.events.v1.PullRequestR\vpullRequest\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\"S\n" +
+	"\n" +
+	"PRReopened\x12E\n" +
+	"\fpull_request\x18\x01 \x01(\v2\".pr_reviewer.events.v1.PullRequestR\vpullRequest*\xc1\x01\n" +
+	"\x11PullRequestStatus\x12#\n" +
+	"\x1fPULL_REQUEST_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18PULL_REQUEST_STATUS_OPEN\x10\x01\x12\x1e\n" +
+	"\x1aPULL_REQUEST_STATUS_MERGED\x10\x02\x12\x1e\n" +
+	"\x1aPULL_REQUEST_STATUS_CLOSED\x10\x03\x12)\n" +
+	"%PULL_REQUEST_STATUS_CHANGES_REQUESTED\x10\x04*\x96\x01\n" +
+	"\fReviewerSlot\x12\x1d\n" +
+	"\x19REVIEWER_SLOT_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16REVIEWER_SLOT_REQUIRED\x10\x01\x12\x1a\n" +
+	"\x16REVIEWER_SLOT_OPTIONAL\x10\x02\x12\x17\n" +
+	"\x13REVIEWER_SLOT_OWNER\x10\x03\x12\x16\n" +
+	"\x12REVIEWER_SLOT_PEER\x10\x04B*Z(avito-intro/api/proto/events/v1;eventsv1b\x06proto3"
+
+var (
+	file_events_v1_events_proto_rawDescOnce sync.Once
+	file_events_v1_events_proto_rawDescData []byte
+)
+
+func file_events_v1_events_proto_rawDescGZIP() []byte {
+	file_events_v1_events_proto_rawDescOnce.Do(func() {
+		file_events_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_v1_events_proto_rawDesc), len(file_events_v1_events_proto_rawDesc)))
+	})
+	return file_events_v1_events_proto_rawDescData
+}
+
+var file_events_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_events_v1_events_proto_goTypes = []any{
+	(PullRequestStatus)(0),        // 0: pr_reviewer.events.v1.PullRequestStatus
+	(ReviewerSlot)(0),             // 1: pr_reviewer.events.v1.ReviewerSlot
+	(*Event)(nil),                 // 2: pr_reviewer.events.v1.Event
+	(*PullRequest)(nil),           // 3: pr_reviewer.events.v1.PullRequest
+	(*Reviewer)(nil),              // 4: pr_reviewer.events.v1.Reviewer
+	(*PRCreated)(nil),             // 5: pr_reviewer.events.v1.PRCreated
+	(*ReviewerAssigned)(nil),      // 6: pr_reviewer.events.v1.ReviewerAssigned
+	(*ReviewerReplaced)(nil),      // 7: pr_reviewer.events.v1.ReviewerReplaced
+	(*PRMerged)(nil),              // 8: pr_reviewer.events.v1.PRMerged
+	(*PRClosed)(nil),              // 9: pr_reviewer.events.v1.PRClosed
+	(*ChangesRequested)(nil),      // 10: pr_reviewer.events.v1.ChangesRequested
+	(*PRReopened)(nil),            // 11: pr_reviewer.events.v1.PRReopened
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+}
+var file_events_v1_events_proto_depIdxs = []int32{
+	12, // 0: pr_reviewer.events.v1.Event.occurred_at:type_name -> google.protobuf.Timestamp
+	5,  // 1: pr_reviewer.events.v1.Event.pr_created:type_name -> pr_reviewer.events.v1.PRCreated
+	6,  // 2: pr_reviewer.events.v1.Event.reviewer_assigned:type_name -> pr_reviewer.events.v1.ReviewerAssigned
+	7,  // 3: pr_reviewer.events.v1.Event.reviewer_replaced:type_name -> pr_reviewer.events.v1.ReviewerReplaced
+	8,  // 4: pr_reviewer.events.v1.Event.pr_merged:type_name -> pr_reviewer.events.v1.PRMerged
+	9,  // 5: pr_reviewer.events.v1.Event.pr_closed:type_name -> pr_reviewer.events.v1.PRClosed
+	10, // 6: pr_reviewer.events.v1.Event.changes_requested:type_name -> pr_reviewer.events.v1.ChangesRequested
+	11, // 7: pr_reviewer.events.v1.Event.pr_reopened:type_name -> pr_reviewer.events.v1.PRReopened
+	0,  // 8: pr_reviewer.events.v1.PullRequest.status:type_name -> pr_reviewer.events.v1.PullRequestStatus
+	4,  // 9: pr_reviewer.events.v1.PullRequest.assigned_reviewers:type_name -> pr_reviewer.events.v1.Reviewer
+	12, // 10: pr_reviewer.events.v1.PullRequest.created_at:type_name -> google.protobuf.Timestamp
+	12, // 11: pr_reviewer.events.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	12, // 12: pr_reviewer.events.v1.PullRequest.closed_at:type_name -> google.protobuf.Timestamp
+	1,  // 13: pr_reviewer.events.v1.Reviewer.slot:type_name -> pr_reviewer.events.v1.ReviewerSlot
+	3,  // 14: pr_reviewer.events.v1.PRCreated.pull_request:type_name -> pr_reviewer.events.v1.PullRequest
+	3,  // 15: pr_reviewer.events.v1.ReviewerAssigned.pull_request:type_name -> pr_reviewer.events.v1.PullRequest
+	4,  // 16: pr_reviewer.events.v1.ReviewerAssigned.reviewer:type_name -> pr_reviewer.events.v1.Reviewer
+	3,  // 17: pr_reviewer.events.v1.ReviewerReplaced.pull_request:type_name -> pr_reviewer.events.v1.PullRequest
+	4,  // 18: pr_reviewer.events.v1.ReviewerReplaced.reviewer:type_name -> pr_reviewer.events.v1.Reviewer
+	3,  // 19: pr_reviewer.events.v1.PRMerged.pull_request:type_name -> pr_reviewer.events.v1.PullRequest
+	3,  // 20: pr_reviewer.events.v1.PRClosed.pull_request:type_name -> pr_reviewer.events.v1.PullRequest
+	3,  // 21: pr_reviewer.events.v1.ChangesRequested.pull_request:type_name -> pr_reviewer.events.v1.PullRequest
+	3,  // 22: pr_reviewer.events.v1.PRReopened.pull_request:type_name -> pr_reviewer.events.v1.PullRequest
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_events_v1_events_proto_init() }
+func file_events_v1_events_proto_init() {
+	if File_events_v1_events_proto != nil {
+		return
+	}
+	file_events_v1_events_proto_msgTypes[0].OneofWrappers = []any{
+		(*Event_PrCreated)(nil),
+		(*Event_ReviewerAssigned)(nil),
+		(*Event_ReviewerReplaced)(nil),
+		(*Event_PrMerged)(nil),
+		(*Event_PrClosed)(nil),
+		(*Event_ChangesRequested)(nil),
+		(*Event_PrReopened)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_v1_events_proto_rawDesc), len(file_events_v1_events_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_v1_events_proto_goTypes,
+		DependencyIndexes: file_events_v1_events_proto_depIdxs,
+		EnumInfos:         file_events_v1_events_proto_enumTypes,
+		MessageInfos:      file_events_v1_events_proto_msgTypes,
+	}.Build()
+	File_events_v1_events_proto = out.File
+	file_events_v1_events_proto_goTypes = nil
+	file_events_v1_events_proto_depIdxs = nil
+}
@@ -0,0 +1,121 @@
+package eventsv1
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testPullRequest() *PullRequest {
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	return &PullRequest{
+		PullRequestId:   "6f1c2b1e-8d4a-4c5e-9b7a-3f2d1e0c9b8a",
+		PullRequestName: "Add search",
+		AuthorId:        "11111111-1111-1111-1111-111111111111",
+		Status:          PullRequestStatus_PULL_REQUEST_STATUS_MERGED,
+		AssignedReviewers: []*Reviewer{
+			{UserId: "22222222-2222-2222-2222-222222222222", Slot: ReviewerSlot_REVIEWER_SLOT_REQUIRED},
+			{UserId: "33333333-3333-3333-3333-333333333333", Slot: ReviewerSlot_REVIEWER_SLOT_OWNER},
+		},
+		CreatedAt:  timestamppb.New(created),
+		MergedAt:   timestamppb.New(created.Add(time.Hour)),
+		ExternalId: "github:42",
+	}
+}
+
+func TestEventRoundTrip(t *testing.T) {
+	reviewer := &Reviewer{UserId: "44444444-4444-4444-4444-444444444444", Slot: ReviewerSlot_REVIEWER_SLOT_PEER}
+	payloads := map[string]isEvent_Payload{
+		"PRCreated":        &Event_PrCreated{PrCreated: &PRCreated{PullRequest: testPullRequest()}},
+		"ReviewerAssigned": &Event_ReviewerAssigned{ReviewerAssigned: &ReviewerAssigned{PullRequest: testPullRequest(), Reviewer: reviewer}},
+		"ReviewerReplaced": &Event_ReviewerReplaced{ReviewerReplaced: &ReviewerReplaced{
+			PullRequest:    testPullRequest(),
+			PreviousUserId: "22222222-2222-2222-2222-222222222222",
+			Reviewer:       reviewer,
+		}},
+		"PRMerged":         &Event_PrMerged{PrMerged: &PRMerged{PullRequest: testPullRequest(), Auto: true}},
+		"PRClosed":         &Event_PrClosed{PrClosed: &PRClosed{PullRequest: testPullRequest()}},
+		"ChangesRequested": &Event_ChangesRequested{ChangesRequested: &ChangesRequested{PullRequest: testPullRequest(), ReviewerId: reviewer.UserId}},
+		"PRReopened":       &Event_PrReopened{PrReopened: &PRReopened{PullRequest: testPullRequest()}},
+	}
+
+	for name, payload := range payloads {
+		t.Run(name, func(t *testing.T) {
+			event := &Event{
+				EventId:        "55555555-5555-5555-5555-555555555555",
+				OrganizationId: "default",
+				OccurredAt:     timestamppb.New(time.Date(2026, 10, 1, 13, 0, 0, 0, time.UTC)),
+				Payload:        payload,
+			}
+			data, err := proto.Marshal(event)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var decoded Event
+			if err := proto.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if !proto.Equal(event, &decoded) {
+				t.Fatalf("round trip changed the event:\nsent     %v\nreceived %v", event, &decoded)
+			}
+		})
+	}
+}
+
+// TestBindingsMatchSchema catches bindings left stale after a change to
+// events.proto: every field and enum value of the schema must be in the
+// generated descriptor under the same number, and the other way round.
+func TestBindingsMatchSchema(t *testing.T) {
+	data, err := os.ReadFile("events.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fields and enum values are keyed by their declaring message or enum.
+	schema := make(map[string]int)
+	block := regexp.MustCompile(`(?m)^(?:message|enum) (\w+) \{\n((?:.*\n)*?)\}`)
+	entry := regexp.MustCompile(`(?m)^\s+(?:[\w.]+ )*(\w+) = (\d+);`)
+	for _, b := range block.FindAllStringSubmatch(string(data), -1) {
+		for _, e := range entry.FindAllStringSubmatch(b[2], -1) {
+			n, _ := strconv.Atoi(e[2])
+			schema[b[1]+"."+e[1]] = n
+		}
+	}
+
+	generated := make(map[string]int)
+	file := File_events_v1_events_proto
+	for i := range file.Messages().Len() {
+		msg := file.Messages().Get(i)
+		fields := msg.Fields()
+		for j := range fields.Len() {
+			generated[string(msg.Name())+"."+string(fields.Get(j).Name())] = int(fields.Get(j).Number())
+		}
+	}
+	for i := range file.Enums().Len() {
+		enum := file.Enums().Get(i)
+		values := enum.Values()
+		for j := range values.Len() {
+			generated[string(enum.Name())+"."+string(values.Get(j).Name())] = int(values.Get(j).Number())
+		}
+	}
+
+	for name, n := range schema {
+		if got, ok := generated[name]; !ok || got != n {
+			t.Errorf("%s = %d in events.proto, bindings have %d (present: %t); run make proto", name, n, got, ok)
+		}
+	}
+	for name := range generated {
+		if _, ok := schema[name]; !ok {
+			t.Errorf("%s is in the bindings but not in events.proto; run make proto", name)
+		}
+	}
+	if got := file.Package(); got != protoreflect.FullName("pr_reviewer.events.v1") {
+		t.Errorf("package %s, want pr_reviewer.events.v1", got)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"avito-intro/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+type config struct {
+	addr       string
+	workers    int
+	duration   time.Duration
+	teamSize   int
+	prsPerTeam int
+	mergeRatio float64
+	timeout    time.Duration
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.addr, "addr", "http://localhost:8080", "target service base URL")
+	flag.IntVar(&cfg.workers, "workers", 8, "number of concurrent workers")
+	flag.DurationVar(&cfg.duration, "duration", 30*time.Second, "how long to generate load")
+	flag.IntVar(&cfg.teamSize, "team-size", 5, "members per generated team")
+	flag.IntVar(&cfg.prsPerTeam, "prs-per-team", 50, "PRs a worker creates before starting a new team")
+	flag.Float64Var(&cfg.mergeRatio, "merge-ratio", 0.8, "fraction of created PRs that get merged")
+	flag.DurationVar(&cfg.timeout, "timeout", 5*time.Second, "per-request timeout")
+	flag.Parse()
+
+	if cfg.workers < 1 || cfg.teamSize < 1 || cfg.prsPerTeam < 1 {
+		fmt.Fprintln(os.Stderr, "workers, team-size and prs-per-team must be positive")
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.duration)
+	defer cancel()
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	api := client.New(cfg.addr, client.WithHTTPClient(&http.Client{
+		Timeout: cfg.timeout,
+		Transport: &http.Transport{
+			MaxIdleConnsPerHost: cfg.workers,
+		},
+	}))
+
+	rec := newRecorder()
+
+	fmt.Printf("generating load against %s with %d workers for %s\n", cfg.addr, cfg.workers, cfg.duration)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := range cfg.workers {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			w := &worker{id: id, api: api, cfg: cfg, rec: rec}
+			w.run(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	rec.report(os.Stdout, time.Since(start))
+}
+
+type worker struct {
+	id  int
+	api *client.Client
+	cfg config
+	rec *recorder
+}
+
+func (w *worker) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		team, ok := w.createTeam(ctx)
+		if !ok {
+			continue
+		}
+
+		for i := 0; i < w.cfg.prsPerTeam && ctx.Err() == nil; i++ {
+			author := team.Members[i%len(team.Members)]
+			prID, ok := w.createPR(ctx, author.UserID, i)
+			if !ok {
+				continue
+			}
+
+			if rand.Float64() < w.cfg.mergeRatio {
+				w.mergePR(ctx, prID)
+			}
+		}
+	}
+}
+
+func (w *worker) createTeam(ctx context.Context) (client.Team, bool) {
+	team := client.Team{
+		TeamName: fmt.Sprintf("loadgen-%d-%s", w.id, uuid.NewString()[:8]),
+		Members:  make([]client.TeamMember, w.cfg.teamSize),
+	}
+	for i := range team.Members {
+		team.Members[i] = client.TeamMember{
+			UserID:   uuid.NewString(),
+			Username: fmt.Sprintf("user-%d", i),
+			IsActive: true,
+		}
+	}
+
+	start := time.Now()
+	_, err := w.api.AddTeam(ctx, team)
+	w.rec.record(ctx, "team/add", time.Since(start), err)
+	return team, err == nil
+}
+
+func (w *worker) createPR(ctx context.Context, authorID string, n int) (string, bool) {
+	prID := uuid.NewString()
+
+	start := time.Now()
+	_, err := w.api.CreatePR(ctx, prID, fmt.Sprintf("loadgen PR %d", n), authorID)
+	w.rec.record(ctx, "pullRequest/create", time.Since(start), err)
+	return prID, err == nil
+}
+
+func (w *worker) mergePR(ctx context.Context, prID string) {
+	start := time.Now()
+	_, err := w.api.MergePR(ctx, prID)
+	w.rec.record(ctx, "pullRequest/merge", time.Since(start), err)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+type recorder struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+	}
+}
+
+func (r *recorder) record(ctx context.Context, op string, d time.Duration, err error) {
+	// Requests cut short by the end of the run are not representative.
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		r.errors[op]++
+		return
+	}
+	r.latencies[op] = append(r.latencies[op], d)
+}
+
+func (r *recorder) report(out io.Writer, elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ops := make([]string, 0, len(r.latencies))
+	for op := range r.latencies {
+		ops = append(ops, op)
+	}
+	for op := range r.errors {
+		if _, ok := r.latencies[op]; !ok {
+			ops = append(ops, op)
+		}
+	}
+	sort.Strings(ops)
+
+	fmt.Fprintf(out, "\nelapsed: %s\n\n", elapsed.Round(time.Millisecond))
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "OPERATION\tOK\tERRORS\tRPS\tP50\tP90\tP99\tMAX\t")
+	for _, op := range ops {
+		samples := r.latencies[op]
+		slices.Sort(samples)
+
+		rps := float64(len(samples)) / elapsed.Seconds()
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t\n",
+			op,
+			len(samples),
+			r.errors[op],
+			rps,
+			percentile(samples, 0.50),
+			percentile(samples, 0.90),
+			percentile(samples, 0.99),
+			percentile(samples, 1),
+		)
+	}
+	tw.Flush()
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted))*p+0.5) - 1
+	idx = max(0, min(idx, len(sorted)-1))
+	return sorted[idx].Round(time.Microsecond)
+}
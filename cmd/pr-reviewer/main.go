@@ -11,6 +11,7 @@ import (
 
 	"avito-intro/config"
 	"avito-intro/internal/app"
+	"avito-intro/internal/logging"
 
 	"go.uber.org/zap"
 )
@@ -21,7 +22,7 @@ func main() {
 		panic(fmt.Sprintf("failed to load config: %v", err))
 	}
 
-	logger, err := zap.NewProduction()
+	logger, err := logging.New(cfg.Log)
 	if err != nil {
 		panic(fmt.Sprintf("failed to initialize logger: %v", err))
 	}
@@ -29,7 +30,10 @@ func main() {
 
 	logger.Info("Starting PR Reviewer Service")
 
-	application := app.New(cfg, logger)
+	application, err := app.New(cfg, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize application", zap.Error(err))
+	}
 
 	go func() {
 		if err := application.Run(); err != nil && err != http.ErrServerClosed {
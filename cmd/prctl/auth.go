@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"avito-intro/pkg/client"
+)
+
+func runAuthLogin(ctx context.Context, api *client.Client, args []string) error {
+	fs := flag.NewFlagSet("auth login", flag.ExitOnError)
+	fs.Parse(args)
+
+	fmt.Printf("Open %s/auth/login in a browser and sign in.\n", api.BaseURL())
+	fmt.Println("Copy the token from the response and export it:")
+	fmt.Println()
+	fmt.Println("  export PRCTL_TOKEN=<token>")
+	return nil
+}
+
+func runAuthWhoAmI(ctx context.Context, api *client.Client, args []string) error {
+	fs := flag.NewFlagSet("auth whoami", flag.ExitOnError)
+	fs.Parse(args)
+
+	principal, err := api.WhoAmI(ctx)
+	if err != nil {
+		return err
+	}
+	return printJSON(principal)
+}
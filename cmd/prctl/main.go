@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"avito-intro/pkg/client"
+)
+
+const usage = `prctl is an admin tool for the PR Reviewer Service.
+
+Usage:
+  prctl [global flags] <command> <subcommand> [flags]
+
+Commands:
+  auth login      print how to obtain a token through SSO
+  auth whoami     show the identity behind the current token
+  team create     create a team with members
+  team get        show a team and its members
+  team rebalance  even out open review assignments across a team
+  user set-active activate or deactivate a user
+  user transfer-reviews
+                  move every open review of a user to another one
+  pr overdue      list open PRs waiting longer than the review SLA
+  pr reassign     force reassignment of a reviewer on a PR
+  pr backfill     top up open PRs that have fewer reviewers than required
+  simulate assignment
+                  run the assignment strategy offline against a team definition
+
+Global flags:
+`
+
+type command struct {
+	name string
+	run  func(ctx context.Context, api *client.Client, args []string) error
+}
+
+var commands = map[string][]command{
+	"auth": {
+		{name: "login", run: runAuthLogin},
+		{name: "whoami", run: runAuthWhoAmI},
+	},
+	"team": {
+		{name: "create", run: runTeamCreate},
+		{name: "get", run: runTeamGet},
+		{name: "rebalance", run: runTeamRebalance},
+	},
+	"user": {
+		{name: "set-active", run: runUserSetActive},
+		{name: "transfer-reviews", run: runUserTransferReviews},
+	},
+	"pr": {
+		{name: "overdue", run: runPROverdue},
+		{name: "reassign", run: runPRReassign},
+		{name: "backfill", run: runPRBackfill},
+	},
+	"simulate": {
+		{name: "assignment", run: runSimulateAssignment},
+	},
+}
+
+func main() {
+	global := flag.NewFlagSet("prctl", flag.ExitOnError)
+	addr := global.String("addr", getEnv("PRCTL_ADDR", "http://localhost:8080"), "service base URL (env PRCTL_ADDR)")
+	timeout := global.Duration("timeout", 10*time.Second, "request timeout")
+	token := global.String("token", os.Getenv("PRCTL_TOKEN"), "session or API token for admin commands (env PRCTL_TOKEN)")
+	org := global.String("org", os.Getenv("PRCTL_ORG"), "organization to operate on (env PRCTL_ORG)")
+	global.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		global.PrintDefaults()
+	}
+	global.Parse(os.Args[1:])
+
+	args := global.Args()
+	if len(args) < 2 {
+		global.Usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := findCommand(args[0], args[1])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0]+" "+args[1])
+		global.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	api := client.New(*addr, client.WithToken(*token), client.WithOrganization(*org))
+	if err := cmd.run(ctx, api, args[2:]); err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) {
+			fmt.Fprintf(os.Stderr, "error: %s (%s)\n", apiErr.Message, apiErr.Code)
+		} else {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func findCommand(group, name string) (command, bool) {
+	for _, cmd := range commands[group] {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
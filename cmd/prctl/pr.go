@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"avito-intro/pkg/client"
+)
+
+func runPROverdue(ctx context.Context, api *client.Client, args []string) error {
+	fs := flag.NewFlagSet("pr overdue", flag.ExitOnError)
+	olderThan := fs.Duration("older-than", 0, "age threshold (defaults to the server review SLA)")
+	asJSON := fs.Bool("json", false, "print raw JSON")
+	fs.Parse(args)
+
+	prs, err := api.GetOverduePRs(ctx, *olderThan)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(prs)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PR ID\tNAME\tAUTHOR\tCREATED\tREVIEWERS")
+	for _, pr := range prs {
+		createdAt := ""
+		if pr.CreatedAt != nil {
+			createdAt = *pr.CreatedAt
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			pr.PullRequestID,
+			pr.PullRequestName,
+			pr.AuthorID,
+			createdAt,
+			strings.Join(pr.AssignedReviewers, ","),
+		)
+	}
+	return tw.Flush()
+}
+
+func runPRReassign(ctx context.Context, api *client.Client, args []string) error {
+	fs := flag.NewFlagSet("pr reassign", flag.ExitOnError)
+	prID := fs.String("pr", "", "pull request ID")
+	oldReviewer := fs.String("old-reviewer", "", "ID of the reviewer to replace")
+	fs.Parse(args)
+
+	if *prID == "" || *oldReviewer == "" {
+		return errors.New("-pr and -old-reviewer are required")
+	}
+
+	pr, replacedBy, err := api.ReassignReviewer(ctx, *prID, *oldReviewer)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(struct {
+		PR         client.PullRequest `json:"pr"`
+		ReplacedBy string             `json:"replaced_by"`
+	}{
+		PR:         pr,
+		ReplacedBy: replacedBy,
+	})
+}
+
+func runPRBackfill(ctx context.Context, api *client.Client, args []string) error {
+	fs := flag.NewFlagSet("pr backfill", flag.ExitOnError)
+	fs.Parse(args)
+
+	prs, err := api.BackfillReviewers(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "updated %d pull request(s)\n", len(prs))
+	return printJSON(prs)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"avito-intro/config"
+	"avito-intro/internal/entity"
+	"avito-intro/internal/repository"
+	"avito-intro/internal/usecase"
+	"avito-intro/pkg/client"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type reviewerTally struct {
+	user     entity.User
+	assigned int
+}
+
+func runSimulateAssignment(ctx context.Context, _ *client.Client, args []string) error {
+	defaultStrategy := usecase.StrategyRandom
+	if cfg, err := config.New(); err == nil {
+		defaultStrategy = cfg.Review.AssignmentStrategy
+	}
+
+	fs := flag.NewFlagSet("simulate assignment", flag.ExitOnError)
+	teamFile := fs.String("team", "", "path to a JSON team definition (same shape as POST /team/add)")
+	prCount := fs.Int("prs", 100, "number of hypothetical PRs to create")
+	strategyName := fs.String("strategy", defaultStrategy, fmt.Sprintf("assignment strategy %v (env ASSIGNMENT_STRATEGY)", usecase.AssignmentStrategies()))
+	authorID := fs.String("author", "", "author every PR as this user instead of picking random team members")
+	fs.Parse(args)
+
+	if *teamFile == "" {
+		return errors.New("-team is required")
+	}
+	if *prCount < 1 {
+		return errors.New("-prs must be positive")
+	}
+
+	teamDef, err := readTeamFile(*teamFile)
+	if err != nil {
+		return err
+	}
+
+	// The simulation runs the real usecases against a throwaway in-memory
+	// repository, so it never touches a running service.
+	logger := zap.NewNop()
+	repo := repository.NewMemoryRepository(logger)
+
+	strategy, err := usecase.NewAssignmentStrategy(*strategyName, repo, logger)
+	if err != nil {
+		return err
+	}
+
+	quotaUC := usecase.NewQuotaUsecase(repo, repo, repo, repo, entity.Quotas{}, logger)
+	auditUC := usecase.NewAuditUsecase(repo, time.Now, logger)
+	teamUC := usecase.NewTeamUsecase(repo, repo, repo, quotaUC, auditUC, logger)
+	outboxUC := usecase.NewOutboxUsecase(nil, repo, nil, entity.OutboxPolicy{}, time.Now, logger)
+	prUC := usecase.NewPullRequestUsecase(repo, repo, repo, repo, repo, repo, repo, repo, repo, nil, strategy, usecase.NewAssignmentStrategies(repo, logger), usecase.DefaultReviewSettings(), quotaUC, time.Now, usecase.NewLogNotifier(logger), outboxUC, logger)
+
+	team, members, err := createSimulatedTeam(ctx, teamUC, teamDef)
+	if err != nil {
+		return err
+	}
+
+	authors := members
+	if *authorID != "" {
+		id, err := uuid.Parse(*authorID)
+		if err != nil {
+			return fmt.Errorf("invalid -author: %w", err)
+		}
+		idx := slices.IndexFunc(members, func(m entity.User) bool { return m.UserID == id })
+		if idx < 0 {
+			return fmt.Errorf("author %s is not a member of team %q", id, team.TeamName)
+		}
+		authors = members[idx : idx+1]
+	}
+
+	tallies := make(map[uuid.UUID]*reviewerTally, len(members))
+	for _, member := range members {
+		tallies[member.UserID] = &reviewerTally{user: member}
+	}
+	reviewersPerPR := make(map[int]int)
+
+	for i := range *prCount {
+		author := authors[rand.Intn(len(authors))]
+		pr, _, err := prUC.CreatePR(ctx, uuid.New(), fmt.Sprintf("simulated PR %d", i+1), author.UserID, "", nil)
+		if err != nil {
+			return fmt.Errorf("create simulated PR: %w", err)
+		}
+
+		reviewersPerPR[len(pr.AssignedReviewers)]++
+		for _, reviewerID := range pr.AssignedReviewers {
+			tallies[reviewerID].assigned++
+		}
+	}
+
+	printSimulation(strategy.Name(), *prCount, members, tallies, reviewersPerPR)
+	return nil
+}
+
+func createSimulatedTeam(ctx context.Context, teamUC usecase.TeamUsecase, def client.Team) (entity.Team, []entity.User, error) {
+	if def.TeamName == "" {
+		def.TeamName = "simulated"
+	}
+	if len(def.Members) == 0 {
+		return entity.Team{}, nil, errors.New("team definition has no members")
+	}
+
+	team := entity.Team{TeamName: def.TeamName}
+	members := make([]entity.User, len(def.Members))
+	for i, m := range def.Members {
+		userID, err := uuid.Parse(m.UserID)
+		if err != nil {
+			return entity.Team{}, nil, fmt.Errorf("invalid user_id %q: %w", m.UserID, err)
+		}
+		members[i] = entity.User{
+			UserID:   userID,
+			Username: m.Username,
+			TeamName: def.TeamName,
+			IsActive: m.IsActive,
+		}
+		team.Members = append(team.Members, userID)
+	}
+
+	created, err := teamUC.AddTeam(ctx, team, members)
+	if err != nil {
+		return entity.Team{}, nil, err
+	}
+	return created, members, nil
+}
+
+func printSimulation(strategy string, prCount int, members []entity.User, tallies map[uuid.UUID]*reviewerTally, reviewersPerPR map[int]int) {
+	fmt.Printf("strategy: %s, PRs: %d\n\n", strategy, prCount)
+
+	totalAssigned := 0
+	for _, t := range tallies {
+		totalAssigned += t.assigned
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "USER ID\tUSERNAME\tACTIVE\tASSIGNED\tSHARE")
+	for _, member := range members {
+		t := tallies[member.UserID]
+		share := 0.0
+		if totalAssigned > 0 {
+			share = float64(t.assigned) / float64(totalAssigned) * 100
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%t\t%d\t%.1f%%\n", member.UserID, member.Username, member.IsActive, t.assigned, share)
+	}
+	tw.Flush()
+
+	fmt.Println()
+	counts := make([]int, 0, len(reviewersPerPR))
+	for n := range reviewersPerPR {
+		counts = append(counts, n)
+	}
+	slices.Sort(counts)
+	for _, n := range counts {
+		fmt.Printf("PRs with %d reviewer(s): %d\n", n, reviewersPerPR[n])
+	}
+}
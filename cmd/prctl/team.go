@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"avito-intro/pkg/client"
+)
+
+type memberFlags []client.TeamMember
+
+func (m *memberFlags) String() string {
+	parts := make([]string, len(*m))
+	for i, member := range *m {
+		parts[i] = member.UserID + ":" + member.Username
+	}
+	return strings.Join(parts, ",")
+}
+
+func (m *memberFlags) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("member must be user_id:username[:inactive], got %q", value)
+	}
+
+	member := client.TeamMember{
+		UserID:   parts[0],
+		Username: parts[1],
+		IsActive: true,
+	}
+	if len(parts) == 3 {
+		if parts[2] != "inactive" {
+			return fmt.Errorf("unknown member flag %q", parts[2])
+		}
+		member.IsActive = false
+	}
+
+	*m = append(*m, member)
+	return nil
+}
+
+func runTeamCreate(ctx context.Context, api *client.Client, args []string) error {
+	fs := flag.NewFlagSet("team create", flag.ExitOnError)
+	name := fs.String("name", "", "team name")
+	file := fs.String("file", "", "path to a JSON team definition (same shape as POST /team/add)")
+	var members memberFlags
+	fs.Var(&members, "member", "team member as user_id:username[:inactive], repeatable")
+	fs.Parse(args)
+
+	var team client.Team
+	if *file != "" {
+		var err error
+		if team, err = readTeamFile(*file); err != nil {
+			return err
+		}
+	}
+	if *name != "" {
+		team.TeamName = *name
+	}
+	team.Members = append(team.Members, members...)
+
+	if team.TeamName == "" {
+		return errors.New("team name is required (-name or -file)")
+	}
+
+	created, err := api.AddTeam(ctx, team)
+	if err != nil {
+		return err
+	}
+	return printJSON(created)
+}
+
+func runTeamGet(ctx context.Context, api *client.Client, args []string) error {
+	fs := flag.NewFlagSet("team get", flag.ExitOnError)
+	name := fs.String("name", "", "team name")
+	fs.Parse(args)
+
+	if *name == "" {
+		return errors.New("-name is required")
+	}
+
+	team, err := api.GetTeam(ctx, *name)
+	if err != nil {
+		return err
+	}
+	return printJSON(team)
+}
+
+func runTeamRebalance(ctx context.Context, api *client.Client, args []string) error {
+	fs := flag.NewFlagSet("team rebalance", flag.ExitOnError)
+	name := fs.String("name", "", "team name")
+	dryRun := fs.Bool("dry-run", false, "only show the moves that would be made")
+	fs.Parse(args)
+
+	if *name == "" {
+		return errors.New("-name is required")
+	}
+
+	result, err := api.RebalanceTeam(ctx, *name, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "%d review(s) moved\n", len(result.Moves))
+	return printJSON(result)
+}
+
+func readTeamFile(path string) (client.Team, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return client.Team{}, err
+	}
+
+	var team client.Team
+	if err := json.Unmarshal(data, &team); err != nil {
+		return client.Team{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return team, nil
+}
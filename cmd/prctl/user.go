@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"avito-intro/pkg/client"
+)
+
+func runUserSetActive(ctx context.Context, api *client.Client, args []string) error {
+	fs := flag.NewFlagSet("user set-active", flag.ExitOnError)
+	userID := fs.String("id", "", "user ID")
+	active := fs.Bool("active", true, "desired active status")
+	fs.Parse(args)
+
+	if *userID == "" {
+		return errors.New("-id is required")
+	}
+
+	user, err := api.SetIsActive(ctx, *userID, *active)
+	if err != nil {
+		return err
+	}
+	return printJSON(user)
+}
+
+func runUserTransferReviews(ctx context.Context, api *client.Client, args []string) error {
+	fs := flag.NewFlagSet("user transfer-reviews", flag.ExitOnError)
+	from := fs.String("from", "", "user whose open reviews are moved")
+	to := fs.String("to", "", "user who takes the reviews over (default: picked by the assignment strategy)")
+	fs.Parse(args)
+
+	if *from == "" {
+		return errors.New("-from is required")
+	}
+
+	transfer, err := api.TransferReviews(ctx, *from, *to)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "%d review(s) moved, %d skipped\n", len(transfer.Moves), len(transfer.Skipped))
+	return printJSON(transfer)
+}
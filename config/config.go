@@ -3,12 +3,29 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	Server ServerConfig
-	Log    LogConfig
+	Server      ServerConfig
+	Review      ReviewConfig
+	Seed        SeedConfig
+	GitHub      GitHubConfig
+	SCIM        SCIMConfig
+	Auth        AuthConfig
+	Log         LogConfig
+	StatsD      StatsDConfig
+	Tenancy     TenancyConfig
+	Quota       QuotaConfig
+	Retention   RetentionConfig
+	Outbox      OutboxConfig
+	Backup      BackupConfig
+	UI          UIConfig
+	Storage     StorageConfig
+	Consistency ConsistencyConfig
+	Idle        IdleConfig
 }
 
 type ServerConfig struct {
@@ -16,24 +33,351 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	// CacheControl is sent on successful GET/HEAD responses; writes and
+	// errors are always no-store.
+	CacheControl string
+}
+
+type ReviewConfig struct {
+	SLA                time.Duration
+	AssignmentStrategy string
+	Mode               string
+	RequiredReviewers  int
+	OptionalReviewers  int
+	MergeApprovals     string
+}
+
+type SeedConfig struct {
+	File string
+}
+
+type GitHubConfig struct {
+	APIURL       string
+	Token        string
+	Org          string
+	SyncInterval time.Duration
+}
+
+type SCIMConfig struct {
+	Token string
+}
+
+type AuthConfig struct {
+	SessionTTL time.Duration
+	OIDC       OIDCConfig
+	// AdminToken, when set, is accepted as an API token with the admin role
+	// in every organization, on behalf of AdminUser, a user of the default
+	// organization created on first start.
+	AdminToken string
+	AdminUser  string
+	// PermissionsFile overrides the default action to role matrix.
+	PermissionsFile string
+}
+
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	RolesClaim   string
+}
+
+type TenancyConfig struct {
+	RequireOrganization bool
+}
+
+// QuotaConfig limits are per organization (teams, users, open PRs) or per
+// team (members, open PRs); zero disables a limit.
+type QuotaConfig struct {
+	MaxTeams       int
+	MaxUsers       int
+	MaxOpenPRs     int
+	MaxTeamMembers int
+	MaxTeamOpenPRs int
+}
+
+// RetentionConfig sets how long data is kept before the cleanup worker,
+// running every Interval, deletes it; zero keeps data forever. Merged PRs
+// older than PullRequestDays are archived, or deleted with
+// DeletePullRequests, and archived PRs merged more than
+// ArchivedPullRequestDays ago are deleted. Responses stored for
+// Idempotency-Key retries are kept for IdempotencyKeys.
+type RetentionConfig struct {
+	AuditDays               int
+	PullRequestDays         int
+	DeletePullRequests      bool
+	ArchivedPullRequestDays int
+	IdempotencyKeys         time.Duration
+	Interval                time.Duration
+}
+
+// OutboxConfig enables the outbox of PR events: Sinks lists where the
+// dispatcher, running every Interval, delivers them ("log", "webhook");
+// empty disables the outbox. Failed deliveries are retried after
+// RetryBackoff, doubling up to MaxRetryBackoff. The webhook sink POSTs to
+// WebhookURL and signs with WebhookSecret when it is set.
+type OutboxConfig struct {
+	Sinks           []string
+	Interval        time.Duration
+	BatchSize       int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	WebhookURL      string
+	WebhookSecret   string
+}
+
+// BackupConfig schedules backups of the state of every organization, every
+// Interval, to Destination: "file" writes them under Dir, "s3" uploads them
+// to an S3-compatible bucket. Empty Destination disables backups; zero
+// Interval leaves them to POST /admin/backup.
+type BackupConfig struct {
+	Destination string
+	Interval    time.Duration
+	Dir         string
+	S3          S3Config
+}
+
+// S3Config is the bucket of the s3 backup destination. Endpoint is the base
+// URL of the S3 API; objects are addressed path-style, so MinIO and other
+// S3-compatible stores work too. Keys start with Prefix.
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	Prefix    string
+	AccessKey string
+	SecretKey string
+}
+
+// ConsistencyConfig schedules the background consistency check of every
+// organization; zero Interval leaves it to GET /admin/consistency.
+type ConsistencyConfig struct {
+	Interval time.Duration
+}
+
+// IdleConfig is the idle user policy: active users without activity for
+// Days are flagged or, with Deactivate, deactivated after a warning WarnDays
+// ahead. Exempt lists user IDs the policy skips. Zero Days disables it; the
+// check runs every Interval.
+type IdleConfig struct {
+	Days       int
+	WarnDays   int
+	Deactivate bool
+	Exempt     []string
+	Interval   time.Duration
+}
+
+// StorageConfig selects the storage Driver ("memory", "redis" or "badger") and
+// bounds every storage call: ReadTimeout and WriteTimeout apply by kind,
+// OperationTimeouts overrides them per Storage method ("ListAudit=30s").
+// Zero disables a timeout. AssertInvariants makes every PR write panic on a
+// broken invariant; it is meant for staging. EventLog records every write
+// in the storage's event log.
+type StorageConfig struct {
+	Driver            string
+	Redis             RedisConfig
+	Badger            BadgerConfig
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	OperationTimeouts map[string]time.Duration
+	AssertInvariants  bool
+	EventLog          bool
+}
+
+// RedisConfig is the connection of the redis storage driver. Keys of the
+// default organization start with KeyPrefix.
+type RedisConfig struct {
+	Addr      string
+	Password  string
+	DB        int
+	KeyPrefix string
+}
+
+// BadgerConfig is the database of the badger storage driver, kept in Dir.
+// SyncWrites fsyncs every commit; without it a crash may lose the last
+// writes but throughput is much higher.
+type BadgerConfig struct {
+	Dir        string
+	SyncWrites bool
+}
+
+// UIConfig enables the reviewer board frontend under /ui, served from Dir
+// when set and from the frontend embedded in the binary otherwise.
+type UIConfig struct {
+	Enabled bool
+	Dir     string
+}
+
+type StatsDConfig struct {
+	Addr   string
+	Prefix string
+	Tags   []string
 }
 
 type LogConfig struct {
 	Level string
+
+	// Zap sampling: per second, the first SamplingInitial entries with the
+	// same level and message are logged, then every SamplingThereafter-th.
+	// SamplingInitial of 0 disables sampling.
+	SamplingInitial    int
+	SamplingThereafter int
+
+	// File enables logging to a rotating file instead of stderr.
+	File       string
+	MaxSizeMB  int
+	MaxAge     time.Duration
+	MaxBackups int
 }
 
 func New() (*Config, error) {
-	return &Config{
+	cfg := &Config{
 		Server: ServerConfig{
 			Port:         getEnv("SERVER_PORT", "8080"),
 			ReadTimeout:  getEnvAsDuration("SERVER_READ_TIMEOUT", 10*time.Second),
 			WriteTimeout: getEnvAsDuration("SERVER_WRITE_TIMEOUT", 10*time.Second),
 			IdleTimeout:  getEnvAsDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			CacheControl: getEnv("SERVER_CACHE_CONTROL", "private, no-cache"),
+		},
+		Review: ReviewConfig{
+			SLA:                getEnvAsDuration("REVIEW_SLA", 48*time.Hour),
+			AssignmentStrategy: getEnv("ASSIGNMENT_STRATEGY", "random"),
+			Mode:               getEnv("REVIEW_MODE", "standard"),
+			RequiredReviewers:  getEnvAsInt("REVIEW_REQUIRED_REVIEWERS", 2),
+			OptionalReviewers:  getEnvAsInt("REVIEW_OPTIONAL_REVIEWERS", 0),
+			MergeApprovals:     getEnv("REVIEW_MERGE_APPROVALS", "none"),
+		},
+		Seed: SeedConfig{
+			File: getEnv("SEED_FILE", ""),
+		},
+		GitHub: GitHubConfig{
+			APIURL:       getEnv("GITHUB_API_URL", "https://api.github.com"),
+			Token:        getEnv("GITHUB_TOKEN", ""),
+			Org:          getEnv("GITHUB_ORG", ""),
+			SyncInterval: getEnvAsDuration("GITHUB_SYNC_INTERVAL", time.Hour),
+		},
+		SCIM: SCIMConfig{
+			Token: getEnv("SCIM_TOKEN", ""),
+		},
+		Auth: AuthConfig{
+			SessionTTL:      getEnvAsDuration("AUTH_SESSION_TTL", 12*time.Hour),
+			AdminToken:      getEnv("ADMIN_TOKEN", ""),
+			AdminUser:       getEnv("ADMIN_USER", "admin"),
+			PermissionsFile: getEnv("PERMISSIONS_FILE", ""),
+			OIDC: OIDCConfig{
+				IssuerURL:    getEnv("OIDC_ISSUER_URL", ""),
+				ClientID:     getEnv("OIDC_CLIENT_ID", ""),
+				ClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+				RedirectURL:  getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/auth/callback"),
+				Scopes:       getEnvAsSlice("OIDC_SCOPES", []string{"openid", "profile", "email"}),
+				RolesClaim:   getEnv("OIDC_ROLES_CLAIM", "groups"),
+			},
 		},
 		Log: LogConfig{
-			Level: getEnv("LOG_LEVEL", "info"),
+			Level:              getEnv("LOG_LEVEL", "info"),
+			SamplingInitial:    getEnvAsInt("LOG_SAMPLING_INITIAL", 100),
+			SamplingThereafter: getEnvAsInt("LOG_SAMPLING_THEREAFTER", 100),
+			File:               getEnv("LOG_FILE", ""),
+			MaxSizeMB:          getEnvAsInt("LOG_MAX_SIZE_MB", 100),
+			MaxAge:             getEnvAsDuration("LOG_MAX_AGE", 7*24*time.Hour),
+			MaxBackups:         getEnvAsInt("LOG_MAX_BACKUPS", 5),
 		},
-	}, nil
+		StatsD: StatsDConfig{
+			Addr:   getEnv("STATSD_ADDR", ""),
+			Prefix: getEnv("STATSD_PREFIX", "pr_reviewer"),
+			Tags:   getEnvAsSlice("STATSD_TAGS", nil),
+		},
+		Tenancy: TenancyConfig{
+			RequireOrganization: getEnvAsBool("ORG_REQUIRED", false),
+		},
+		Quota: QuotaConfig{
+			MaxTeams:       getEnvAsInt("QUOTA_MAX_TEAMS", 0),
+			MaxUsers:       getEnvAsInt("QUOTA_MAX_USERS", 0),
+			MaxOpenPRs:     getEnvAsInt("QUOTA_MAX_OPEN_PRS", 0),
+			MaxTeamMembers: getEnvAsInt("QUOTA_MAX_TEAM_MEMBERS", 0),
+			MaxTeamOpenPRs: getEnvAsInt("QUOTA_MAX_TEAM_OPEN_PRS", 0),
+		},
+		UI: UIConfig{
+			Enabled: getEnvAsBool("UI_ENABLED", false),
+			Dir:     getEnv("UI_DIR", ""),
+		},
+		Retention: RetentionConfig{
+			AuditDays:               getEnvAsInt("RETENTION_AUDIT_DAYS", 0),
+			PullRequestDays:         getEnvAsInt("PR_RETENTION_DAYS", 0),
+			DeletePullRequests:      getEnvAsBool("PR_RETENTION_DELETE", false),
+			ArchivedPullRequestDays: getEnvAsInt("ARCHIVED_PR_RETENTION_DAYS", 0),
+			IdempotencyKeys:         getEnvAsDuration("RETENTION_IDEMPOTENCY_KEYS", 24*time.Hour),
+			Interval:                getEnvAsDuration("RETENTION_INTERVAL", time.Hour),
+		},
+		Outbox: OutboxConfig{
+			Sinks:           getEnvAsSlice("OUTBOX_SINKS", nil),
+			Interval:        getEnvAsDuration("OUTBOX_INTERVAL", 5*time.Second),
+			BatchSize:       getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+			RetryBackoff:    getEnvAsDuration("OUTBOX_RETRY_BACKOFF", 10*time.Second),
+			MaxRetryBackoff: getEnvAsDuration("OUTBOX_MAX_RETRY_BACKOFF", 10*time.Minute),
+			WebhookURL:      getEnv("OUTBOX_WEBHOOK_URL", ""),
+			WebhookSecret:   getEnv("OUTBOX_WEBHOOK_SECRET", ""),
+		},
+		Backup: BackupConfig{
+			Destination: getEnv("BACKUP_DESTINATION", ""),
+			Interval:    getEnvAsDuration("BACKUP_INTERVAL", 24*time.Hour),
+			Dir:         getEnv("BACKUP_DIR", "data/backups"),
+			S3: S3Config{
+				Endpoint:  getEnv("BACKUP_S3_ENDPOINT", "https://s3.amazonaws.com"),
+				Region:    getEnv("BACKUP_S3_REGION", "us-east-1"),
+				Bucket:    getEnv("BACKUP_S3_BUCKET", ""),
+				Prefix:    getEnv("BACKUP_S3_PREFIX", "backups/"),
+				AccessKey: getEnv("BACKUP_S3_ACCESS_KEY", ""),
+				SecretKey: getEnv("BACKUP_S3_SECRET_KEY", ""),
+			},
+		},
+		Storage: StorageConfig{
+			Driver: getEnv("STORAGE_DRIVER", "memory"),
+			Redis: RedisConfig{
+				Addr:      getEnv("REDIS_ADDR", "localhost:6379"),
+				Password:  getEnv("REDIS_PASSWORD", ""),
+				DB:        getEnvAsInt("REDIS_DB", 0),
+				KeyPrefix: getEnv("REDIS_KEY_PREFIX", "pr_reviewer:"),
+			},
+			Badger: BadgerConfig{
+				Dir:        getEnv("BADGER_DIR", "data/badger"),
+				SyncWrites: getEnvAsBool("BADGER_SYNC_WRITES", false),
+			},
+			ReadTimeout:      getEnvAsDuration("STORAGE_READ_TIMEOUT", 5*time.Second),
+			WriteTimeout:     getEnvAsDuration("STORAGE_WRITE_TIMEOUT", 5*time.Second),
+			AssertInvariants: getEnvAsBool("STORAGE_ASSERT_INVARIANTS", false),
+			EventLog:         getEnvAsBool("STORAGE_EVENT_LOG", false),
+		},
+		Consistency: ConsistencyConfig{
+			Interval: getEnvAsDuration("CONSISTENCY_CHECK_INTERVAL", 0),
+		},
+		Idle: IdleConfig{
+			Days:       getEnvAsInt("IDLE_USER_DAYS", 0),
+			WarnDays:   getEnvAsInt("IDLE_USER_WARN_DAYS", 7),
+			Deactivate: getEnvAsBool("IDLE_USER_DEACTIVATE", false),
+			Exempt:     getEnvAsSlice("IDLE_USER_EXEMPT", nil),
+			Interval:   getEnvAsDuration("IDLE_CHECK_INTERVAL", 24*time.Hour),
+		},
+	}
+
+	// Expired PRs left their IDs behind in the indexes and free for reuse;
+	// the retention worker removes them properly.
+	if os.Getenv("REDIS_MERGED_PR_TTL") != "" {
+		return nil, fmt.Errorf("REDIS_MERGED_PR_TTL is no longer supported, use PR_RETENTION_DAYS")
+	}
+
+	operationTimeouts, err := getEnvAsDurationMap("STORAGE_OPERATION_TIMEOUTS")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Storage.OperationTimeouts = operationTimeouts
+
+	if err := cfg.resolveSecrets(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -43,6 +387,22 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvAsInt(key string, defaultValue int) int {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.Atoi(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	valueStr := getEnv(key, "")
 	if value, err := time.ParseDuration(valueStr); err == nil {
@@ -51,6 +411,51 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	return strings.FieldsFunc(valueStr, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+// getEnvAsDurationMap parses "key=duration" pairs separated by commas. Unlike
+// the other helpers it fails on malformed input: a dropped pair would go
+// unnoticed.
+func getEnvAsDurationMap(key string) (map[string]time.Duration, error) {
+	pairs := getEnvAsSlice(key, nil)
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[string]time.Duration, len(pairs))
+	for _, pair := range pairs {
+		name, raw, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s: expected name=duration, got %q", key, pair)
+		}
+		value, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid duration for %s: %w", key, name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+func (c *Config) OIDCEnabled() bool {
+	return c.Auth.OIDC.IssuerURL != ""
+}
+
+// AuthRequired reports whether admin routes must be authenticated: once
+// there is a way to log in, anonymous access is no longer allowed.
+func (c *Config) AuthRequired() bool {
+	return c.OIDCEnabled() || c.Auth.AdminToken != ""
+}
+
 func (c *Config) ServerAddr() string {
 	return fmt.Sprintf(":%s", c.Server.Port)
 }
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const vaultPrefix = "vault:"
+
+// resolveSecrets replaces sensitive settings with their real values. For a
+// variable KEY the value is read from the file named by KEY_FILE when set;
+// otherwise a value of the form "vault:<path>#<field>" is fetched from Vault
+// (VAULT_ADDR, VAULT_TOKEN or VAULT_TOKEN_FILE). Plain values are kept.
+func (c *Config) resolveSecrets() error {
+	secrets := []struct {
+		key   string
+		value *string
+	}{
+		{"GITHUB_TOKEN", &c.GitHub.Token},
+		{"SCIM_TOKEN", &c.SCIM.Token},
+		{"OIDC_CLIENT_SECRET", &c.Auth.OIDC.ClientSecret},
+		{"ADMIN_TOKEN", &c.Auth.AdminToken},
+		{"REDIS_PASSWORD", &c.Storage.Redis.Password},
+		{"BACKUP_S3_SECRET_KEY", &c.Backup.S3.SecretKey},
+	}
+
+	var vault *vaultClient
+	for _, s := range secrets {
+		if path := os.Getenv(s.key + "_FILE"); path != "" {
+			value, err := readSecretFile(path)
+			if err != nil {
+				return fmt.Errorf("%s_FILE: %w", s.key, err)
+			}
+			*s.value = value
+			continue
+		}
+
+		ref, ok := strings.CutPrefix(*s.value, vaultPrefix)
+		if !ok {
+			continue
+		}
+		if vault == nil {
+			var err error
+			if vault, err = newVaultClient(); err != nil {
+				return fmt.Errorf("%s: %w", s.key, err)
+			}
+		}
+		value, err := vault.read(ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.key, err)
+		}
+		*s.value = value
+	}
+
+	return nil
+}
+
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+type vaultClient struct {
+	addr       string
+	token      string
+	httpClient *http.Client
+}
+
+func newVaultClient() (*vaultClient, error) {
+	addr := getEnv("VAULT_ADDR", "")
+	if addr == "" {
+		return nil, fmt.Errorf("vault reference used but VAULT_ADDR is not set")
+	}
+
+	token := getEnv("VAULT_TOKEN", "")
+	if path := os.Getenv("VAULT_TOKEN_FILE"); path != "" {
+		var err error
+		if token, err = readSecretFile(path); err != nil {
+			return nil, fmt.Errorf("VAULT_TOKEN_FILE: %w", err)
+		}
+	}
+
+	return &vaultClient{
+		addr:       strings.TrimRight(addr, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// read fetches field from the secret at path, e.g. "secret/data/app#token".
+// Both KV v2 (data.data) and KV v1 (data) responses are understood.
+func (v *vaultClient) read(ref string) (string, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || field == "" {
+		return "", fmt.Errorf("invalid vault reference %q, want vault:<path>#<field>", ref)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, v.addr+"/v1/"+strings.TrimLeft(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("read vault secret %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("read vault secret %s: unexpected status %s", path, resp.Status)
+	}
+
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode vault secret %s: %w", path, err)
+	}
+
+	data := body.Data
+	if nested, ok := data["data"].(map[string]any); ok {
+		data = nested
+	}
+	value, ok := data[field].(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no string field %q", path, field)
+	}
+	return value, nil
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const redacted = "[redacted]"
+
+// Summary returns the effective configuration keyed by environment variable
+// for runtime diagnostics. Secrets are never included: a set secret reads
+// as "[redacted]", an unset one as an empty string.
+func (c *Config) Summary() map[string]string {
+	secret := func(value string) string {
+		if value == "" {
+			return ""
+		}
+		return redacted
+	}
+
+	return map[string]string{
+		"SERVER_PORT":          c.Server.Port,
+		"SERVER_READ_TIMEOUT":  c.Server.ReadTimeout.String(),
+		"SERVER_WRITE_TIMEOUT": c.Server.WriteTimeout.String(),
+		"SERVER_IDLE_TIMEOUT":  c.Server.IdleTimeout.String(),
+		"SERVER_CACHE_CONTROL": c.Server.CacheControl,
+
+		"REVIEW_SLA":                c.Review.SLA.String(),
+		"ASSIGNMENT_STRATEGY":       c.Review.AssignmentStrategy,
+		"REVIEW_MODE":               c.Review.Mode,
+		"REVIEW_REQUIRED_REVIEWERS": strconv.Itoa(c.Review.RequiredReviewers),
+		"REVIEW_OPTIONAL_REVIEWERS": strconv.Itoa(c.Review.OptionalReviewers),
+		"REVIEW_MERGE_APPROVALS":    c.Review.MergeApprovals,
+
+		"SEED_FILE": c.Seed.File,
+
+		"GITHUB_API_URL":       c.GitHub.APIURL,
+		"GITHUB_TOKEN":         secret(c.GitHub.Token),
+		"GITHUB_ORG":           c.GitHub.Org,
+		"GITHUB_SYNC_INTERVAL": c.GitHub.SyncInterval.String(),
+
+		"SCIM_TOKEN": secret(c.SCIM.Token),
+
+		"AUTH_SESSION_TTL":   c.Auth.SessionTTL.String(),
+		"ADMIN_TOKEN":        secret(c.Auth.AdminToken),
+		"ADMIN_USER":         c.Auth.AdminUser,
+		"PERMISSIONS_FILE":   c.Auth.PermissionsFile,
+		"OIDC_ISSUER_URL":    c.Auth.OIDC.IssuerURL,
+		"OIDC_CLIENT_ID":     c.Auth.OIDC.ClientID,
+		"OIDC_CLIENT_SECRET": secret(c.Auth.OIDC.ClientSecret),
+		"OIDC_REDIRECT_URL":  c.Auth.OIDC.RedirectURL,
+		"OIDC_SCOPES":        strings.Join(c.Auth.OIDC.Scopes, ","),
+		"OIDC_ROLES_CLAIM":   c.Auth.OIDC.RolesClaim,
+
+		"UI_ENABLED": strconv.FormatBool(c.UI.Enabled),
+		"UI_DIR":     c.UI.Dir,
+
+		"LOG_LEVEL":               c.Log.Level,
+		"LOG_SAMPLING_INITIAL":    strconv.Itoa(c.Log.SamplingInitial),
+		"LOG_SAMPLING_THEREAFTER": strconv.Itoa(c.Log.SamplingThereafter),
+		"LOG_FILE":                c.Log.File,
+		"LOG_MAX_SIZE_MB":         strconv.Itoa(c.Log.MaxSizeMB),
+		"LOG_MAX_AGE":             c.Log.MaxAge.String(),
+		"LOG_MAX_BACKUPS":         strconv.Itoa(c.Log.MaxBackups),
+
+		"STATSD_ADDR":   c.StatsD.Addr,
+		"STATSD_PREFIX": c.StatsD.Prefix,
+		"STATSD_TAGS":   strings.Join(c.StatsD.Tags, ","),
+
+		"ORG_REQUIRED": strconv.FormatBool(c.Tenancy.RequireOrganization),
+
+		"QUOTA_MAX_TEAMS":         strconv.Itoa(c.Quota.MaxTeams),
+		"QUOTA_MAX_USERS":         strconv.Itoa(c.Quota.MaxUsers),
+		"QUOTA_MAX_OPEN_PRS":      strconv.Itoa(c.Quota.MaxOpenPRs),
+		"QUOTA_MAX_TEAM_MEMBERS":  strconv.Itoa(c.Quota.MaxTeamMembers),
+		"QUOTA_MAX_TEAM_OPEN_PRS": strconv.Itoa(c.Quota.MaxTeamOpenPRs),
+
+		"RETENTION_AUDIT_DAYS":       strconv.Itoa(c.Retention.AuditDays),
+		"RETENTION_INTERVAL":         c.Retention.Interval.String(),
+		"RETENTION_IDEMPOTENCY_KEYS": c.Retention.IdempotencyKeys.String(),
+		"PR_RETENTION_DAYS":          strconv.Itoa(c.Retention.PullRequestDays),
+		"PR_RETENTION_DELETE":        strconv.FormatBool(c.Retention.DeletePullRequests),
+		"ARCHIVED_PR_RETENTION_DAYS": strconv.Itoa(c.Retention.ArchivedPullRequestDays),
+
+		"OUTBOX_SINKS":             strings.Join(c.Outbox.Sinks, ","),
+		"OUTBOX_INTERVAL":          c.Outbox.Interval.String(),
+		"OUTBOX_BATCH_SIZE":        strconv.Itoa(c.Outbox.BatchSize),
+		"OUTBOX_RETRY_BACKOFF":     c.Outbox.RetryBackoff.String(),
+		"OUTBOX_MAX_RETRY_BACKOFF": c.Outbox.MaxRetryBackoff.String(),
+		"OUTBOX_WEBHOOK_URL":       c.Outbox.WebhookURL,
+		"OUTBOX_WEBHOOK_SECRET":    secret(c.Outbox.WebhookSecret),
+
+		"BACKUP_DESTINATION":   c.Backup.Destination,
+		"BACKUP_INTERVAL":      c.Backup.Interval.String(),
+		"BACKUP_DIR":           c.Backup.Dir,
+		"BACKUP_S3_ENDPOINT":   c.Backup.S3.Endpoint,
+		"BACKUP_S3_REGION":     c.Backup.S3.Region,
+		"BACKUP_S3_BUCKET":     c.Backup.S3.Bucket,
+		"BACKUP_S3_PREFIX":     c.Backup.S3.Prefix,
+		"BACKUP_S3_ACCESS_KEY": c.Backup.S3.AccessKey,
+		"BACKUP_S3_SECRET_KEY": secret(c.Backup.S3.SecretKey),
+
+		"STORAGE_DRIVER":             c.Storage.Driver,
+		"STORAGE_READ_TIMEOUT":       c.Storage.ReadTimeout.String(),
+		"STORAGE_WRITE_TIMEOUT":      c.Storage.WriteTimeout.String(),
+		"STORAGE_OPERATION_TIMEOUTS": durationMap(c.Storage.OperationTimeouts),
+		"STORAGE_ASSERT_INVARIANTS":  strconv.FormatBool(c.Storage.AssertInvariants),
+		"STORAGE_EVENT_LOG":          strconv.FormatBool(c.Storage.EventLog),
+
+		"REDIS_ADDR":       c.Storage.Redis.Addr,
+		"REDIS_PASSWORD":   secret(c.Storage.Redis.Password),
+		"REDIS_DB":         strconv.Itoa(c.Storage.Redis.DB),
+		"REDIS_KEY_PREFIX": c.Storage.Redis.KeyPrefix,
+
+		"BADGER_DIR":         c.Storage.Badger.Dir,
+		"BADGER_SYNC_WRITES": strconv.FormatBool(c.Storage.Badger.SyncWrites),
+
+		"CONSISTENCY_CHECK_INTERVAL": c.Consistency.Interval.String(),
+
+		"IDLE_USER_DAYS":       strconv.Itoa(c.Idle.Days),
+		"IDLE_USER_WARN_DAYS":  strconv.Itoa(c.Idle.WarnDays),
+		"IDLE_USER_DEACTIVATE": strconv.FormatBool(c.Idle.Deactivate),
+		"IDLE_USER_EXEMPT":     strings.Join(c.Idle.Exempt, ","),
+		"IDLE_CHECK_INTERVAL":  c.Idle.Interval.String(),
+	}
+}
+
+func durationMap(values map[string]time.Duration) string {
+	pairs := make([]string, 0, len(values))
+	for name, value := range values {
+		pairs = append(pairs, name+"="+value.String())
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
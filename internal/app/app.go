@@ -2,66 +2,507 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"time"
 
 	"avito-intro/config"
+	"avito-intro/internal/auth"
 	"avito-intro/internal/controller"
+	"avito-intro/internal/entity"
+	"avito-intro/internal/integration/github"
+	"avito-intro/internal/integration/statsd"
 	"avito-intro/internal/repository"
+	"avito-intro/internal/seed"
+	"avito-intro/internal/ui"
 	"avito-intro/internal/usecase"
+	"avito-intro/internal/worker"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 type App struct {
-	server *http.Server
-	logger *zap.Logger
-	config *config.Config
+	server  *http.Server
+	logger  *zap.Logger
+	config  *config.Config
+	workers *worker.Registry
+	hooks   []shutdownHook
+	ctx     context.Context
+	cancel  context.CancelFunc
 }
 
-func New(cfg *config.Config, logger *zap.Logger) *App {
-	repo := repository.NewMemoryRepository(logger)
+func New(cfg *config.Config, logger *zap.Logger, opts ...Option) (*App, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// The transaction check below looks at backend.defaultStorage: every
+	// decorator wrapped around it implements Transactor, whether or not the
+	// storage beneath supports transactions.
+	backend := repositoryStorage(o.repo, logger)
+	if backend == nil {
+		var err error
+		backend, err = newStorage(cfg.Storage, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	repo := backend.defaultStorage
+	clock := o.clock
+	if clock == nil {
+		clock = time.Now
+	}
+	notifier := o.notifier
+	if notifier == nil {
+		notifier = usecase.NewLogNotifier(logger)
+	}
+
+	permissions := auth.DefaultPermissions()
+	if cfg.Auth.PermissionsFile != "" {
+		var err error
+		permissions, err = auth.LoadPermissions(cfg.Auth.PermissionsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	authz := auth.NewAuthorizer(permissions)
+
+	if err := prepareStorage(context.Background(), repo); err != nil {
+		return nil, err
+	}
+
+	newOrgStorage := backend.newStorage
+	if cfg.Storage.EventLog {
+		repo = repository.NewEventLoggingRepository(repo)
+		newOrgStorage = func(orgID string) repository.Storage {
+			return repository.NewEventLoggingRepository(backend.newStorage(orgID))
+		}
+	}
+
+	if cfg.Seed.File != "" {
+		loader := seed.NewLoader(repo, repo, repo, logger)
+		if err := loader.LoadFile(context.Background(), cfg.Seed.File); err != nil {
+			return nil, fmt.Errorf("load seed data: %w", err)
+		}
+	}
+
+	// Seed data belongs to the default organization; every other
+	// organization gets its own empty storage.
+	tenants := repository.NewTenantRepository(repo, newOrgStorage)
+	var storage repository.Storage = tenants
+	if cfg.Storage.AssertInvariants {
+		logger.Warn("storage invariant assertions enabled, invalid PR writes will panic")
+		storage = repository.NewAssertingRepository(storage)
+	}
+	timeouts, err := repository.NewTimeoutRepository(storage, repository.Timeouts{
+		Read:       cfg.Storage.ReadTimeout,
+		Write:      cfg.Storage.WriteTimeout,
+		Operations: cfg.Storage.OperationTimeouts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	repo = timeouts
+
+	quotaUC := usecase.NewQuotaUsecase(repo, repo, repo, repo, entity.Quotas{
+		MaxTeams:       cfg.Quota.MaxTeams,
+		MaxUsers:       cfg.Quota.MaxUsers,
+		MaxOpenPRs:     cfg.Quota.MaxOpenPRs,
+		MaxTeamMembers: cfg.Quota.MaxTeamMembers,
+		MaxTeamOpenPRs: cfg.Quota.MaxTeamOpenPRs,
+	}, logger)
+	auditUC := usecase.NewAuditUsecase(repo, clock, logger)
+	eventLogUC := usecase.NewEventLogUsecase(repo, logger)
+	stateUC := usecase.NewStateUsecase(repo, repo, repo, timeouts, auditUC, clock, logger)
+	notifier = usecase.NewAuditNotifier(auditUC, notifier)
+	teamUC := usecase.NewTeamUsecase(repo, repo, timeouts, quotaUC, auditUC, logger)
+	userUC := usecase.NewUserUsecase(repo, repo, repo, timeouts, quotaUC, auditUC, clock, logger)
+	strategy := o.strategy
+	if strategy == nil {
+		var err error
+		strategy, err = usecase.NewAssignmentStrategy(cfg.Review.AssignmentStrategy, repo, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	reviewSettings := usecase.ReviewSettings{
+		SLA:               cfg.Review.SLA,
+		Mode:              cfg.Review.Mode,
+		RequiredReviewers: cfg.Review.RequiredReviewers,
+		OptionalReviewers: cfg.Review.OptionalReviewers,
+		MergeApprovals:    cfg.Review.MergeApprovals,
+	}
+	if err := reviewSettings.Validate(); err != nil {
+		return nil, err
+	}
+	var changedFiles usecase.ChangedFilesProvider
+	if cfg.GitHub.Token != "" {
+		changedFiles = github.NewClient(cfg.GitHub.APIURL, cfg.GitHub.Token)
+	}
+	strategies := usecase.NewAssignmentStrategies(repo, logger)
+	outboxSinks, err := newOutboxSinks(cfg.Outbox, logger)
+	if err != nil {
+		return nil, err
+	}
+	// Events are queued in the transaction of the change they are about.
+	// Without transactions, as in Redis, a failed or interrupted change
+	// could still publish its event, or lose it.
+	if _, ok := backend.defaultStorage.(repository.Transactor); len(outboxSinks) > 0 && !ok {
+		return nil, fmt.Errorf("outbox sinks need a storage with transactions, %s has none", backend.name)
+	}
+	outboxUC := usecase.NewOutboxUsecase(tenants, repo, outboxSinks, entity.OutboxPolicy{
+		BatchSize:       cfg.Outbox.BatchSize,
+		RetryBackoff:    cfg.Outbox.RetryBackoff,
+		MaxRetryBackoff: cfg.Outbox.MaxRetryBackoff,
+	}, clock, logger)
+	prUC := usecase.NewPullRequestUsecase(repo, repo, repo, repo, repo, repo, repo, timeouts, timeouts, changedFiles, strategy, strategies, reviewSettings, quotaUC, clock, notifier, outboxUC, logger)
+	statsUC := usecase.NewStatsUsecase(repo, repo, repo, logger)
+	milestoneUC := usecase.NewMilestoneUsecase(repo, repo, logger)
+	checklistUC := usecase.NewChecklistUsecase(repo, repo, repo, logger)
+	ownershipUC := usecase.NewOwnershipUsecase(repo, repo, repo, logger)
+	mergePolicyUC := usecase.NewMergePolicyUsecase(repo, repo, logger)
+	teamSettingsUC := usecase.NewTeamSettingsUsecase(repo, repo, repo, reviewSettings, strategy.Name(), auditUC, logger)
+	globalSettingsUC := usecase.NewGlobalSettingsUsecase(repo, reviewSettings, auditUC, logger)
+	orgUC := usecase.NewOrganizationUsecase(tenants, auditUC, logger)
+	roleUC := usecase.NewRoleUsecase(repo, authz, auditUC, logger)
+
+	workers := worker.NewRegistry(clock, logger)
+
+	var githubSyncUC usecase.TeamSyncUsecase
+	if cfg.GitHub.Org != "" {
+		syncer := github.NewSyncer(
+			github.NewClient(cfg.GitHub.APIURL, cfg.GitHub.Token),
+			cfg.GitHub.Org,
+			teamUC,
+			userUC,
+			logger,
+		)
+		githubSyncUC = syncer
+
+		if cfg.GitHub.SyncInterval > 0 {
+			workers.Periodic("github_sync", false, cfg.GitHub.SyncInterval, syncer.SyncScheduled)
+		}
+	}
+
+	idempotency := controller.NewIdempotency(logger)
+	retentionPolicy := entity.RetentionPolicy{
+		AuditEntries:             time.Duration(cfg.Retention.AuditDays) * 24 * time.Hour,
+		MergedPullRequests:       time.Duration(cfg.Retention.PullRequestDays) * 24 * time.Hour,
+		DeleteMergedPullRequests: cfg.Retention.DeletePullRequests,
+		ArchivedPullRequests:     time.Duration(cfg.Retention.ArchivedPullRequestDays) * 24 * time.Hour,
+		IdempotencyKeys:          cfg.Retention.IdempotencyKeys,
+	}
+	retentionUC := usecase.NewRetentionUsecase(tenants, repo, repo, auditUC, idempotency, retentionPolicy, clock, logger)
+	retentionEnabled := cfg.Retention.AuditDays > 0 || cfg.Retention.PullRequestDays > 0 ||
+		cfg.Retention.ArchivedPullRequestDays > 0 || cfg.Retention.IdempotencyKeys > 0
+	if retentionEnabled && cfg.Retention.Interval > 0 {
+		workers.Periodic("retention", true, cfg.Retention.Interval, func(ctx context.Context) error {
+			_, err := retentionUC.Cleanup(ctx)
+			return err
+		})
+	}
+
+	if len(outboxSinks) > 0 && cfg.Outbox.Interval > 0 {
+		workers.Periodic("outbox", true, cfg.Outbox.Interval, func(ctx context.Context) error {
+			_, err := outboxUC.Dispatch(ctx)
+			return err
+		})
+	}
+
+	var backupUC usecase.BackupUsecase
+	backupStore, err := newBackupStore(cfg.Backup)
+	if err != nil {
+		return nil, err
+	}
+	if backupStore != nil {
+		backupUC = usecase.NewBackupUsecase(tenants, stateUC, backupStore, auditUC, clock, logger)
 
-	teamUC := usecase.NewTeamUsecase(repo, repo, logger)
-	userUC := usecase.NewUserUsecase(repo, logger)
-	prUC := usecase.NewPullRequestUsecase(repo, repo, logger)
+		if cfg.Backup.Interval > 0 {
+			workers.Periodic("backup", false, cfg.Backup.Interval, func(ctx context.Context) error {
+				_, err := backupUC.Backup(ctx)
+				return err
+			})
+		}
+	}
+
+	consistencyUC := usecase.NewConsistencyUsecase(repo, repo, repo, tenants, prUC, auditUC, clock, logger)
+	if cfg.Consistency.Interval > 0 {
+		workers.Periodic("consistency_check", false, cfg.Consistency.Interval, func(ctx context.Context) error {
+			_, err := consistencyUC.CheckAll(ctx)
+			return err
+		})
+	}
+
+	var idleUC usecase.IdleUsecase
+	if cfg.Idle.Days > 0 {
+		exempt := make([]uuid.UUID, 0, len(cfg.Idle.Exempt))
+		for _, raw := range cfg.Idle.Exempt {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid IDLE_USER_EXEMPT entry %q: %w", raw, err)
+			}
+			exempt = append(exempt, id)
+		}
+
+		checker := usecase.NewIdleUsecase(repo, repo, repo, tenants, userUC, auditUC, entity.IdlePolicy{
+			IdleAfter:  time.Duration(cfg.Idle.Days) * 24 * time.Hour,
+			WarnBefore: time.Duration(cfg.Idle.WarnDays) * 24 * time.Hour,
+			Deactivate: cfg.Idle.Deactivate,
+			Exempt:     exempt,
+		}, clock, logger)
+		idleUC = checker
+
+		if cfg.Idle.Interval > 0 {
+			workers.Periodic("idle_users", false, cfg.Idle.Interval, func(ctx context.Context) error {
+				_, err := checker.CheckAll(ctx)
+				return err
+			})
+		}
+	}
+
+	var statsdClient *statsd.Client
+	errorMetrics := controller.NewErrorMetrics(nil)
+	if cfg.StatsD.Addr != "" {
+		var err error
+		statsdClient, err = statsd.NewClient(cfg.StatsD.Addr, cfg.StatsD.Prefix, cfg.StatsD.Tags)
+		if err != nil {
+			return nil, err
+		}
+		errorMetrics = controller.NewErrorMetrics(statsdClient)
+		logger.Info("StatsD metrics enabled", zap.String("addr", cfg.StatsD.Addr))
+	}
 
 	teamController := controller.NewTeamController(teamUC, logger)
 	userController := controller.NewUserController(userUC, prUC, logger)
-	prController := controller.NewPullRequestController(prUC, logger)
+	prController := controller.NewPullRequestController(prUC, cfg.AuthRequired(), logger)
+	milestoneController := controller.NewMilestoneController(milestoneUC, logger)
+	checklistController := controller.NewChecklistController(checklistUC, cfg.AuthRequired(), logger)
+	ownershipController := controller.NewOwnershipController(ownershipUC, logger)
+	mergePolicyController := controller.NewMergePolicyController(mergePolicyUC, logger)
+	teamSettingsController := controller.NewTeamSettingsController(teamSettingsUC, logger)
+	globalSettingsController := controller.NewGlobalSettingsController(globalSettingsUC, logger)
+	metricsController := controller.NewMetricsController(statsUC, errorMetrics, logger)
+	healthController := controller.NewHealthController(repo, workers, logger)
+	roleController := controller.NewRoleController(roleUC, logger)
+	auditController := controller.NewAuditController(auditUC, logger)
+	eventLogController := controller.NewEventLogController(eventLogUC, logger)
+	stateController := controller.NewStateController(stateUC, backupUC, logger)
+	dashboardController := controller.NewDashboardController(userUC, prUC, cfg.Review.SLA, logger)
+	statusController := controller.NewStatusController(teamUC, prUC, cfg.Review.SLA, logger)
+	orgController := controller.NewOrganizationController(orgUC, cfg.Tenancy.RequireOrganization, logger)
+	adminController := controller.NewAdminController(prUC, statsUC, quotaUC, githubSyncUC, consistencyUC, idleUC, logger)
+	runtimeController := controller.NewRuntimeController(cfg.Summary(), time.Now(), logger)
 
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("POST /team/add", teamController.AddTeam)
+	sessions := auth.NewSessionStore(cfg.Auth.SessionTTL)
+	apiTokens := auth.NewAPITokenStore(repo)
+	authMiddleware := controller.NewAuthMiddleware(sessions, apiTokens, authz, roleUC, logger)
+	apiTokenController := controller.NewAPITokenController(apiTokens, authz, auditUC, logger)
+	if err := bootstrapAdmin(context.Background(), cfg, repo, apiTokens, logger); err != nil {
+		return nil, err
+	}
+
+	// Without any way to log in admin routes stay open, but a presented API
+	// token is still verified and binds the request to its organization.
+	// Workflow routes stay open to anonymous callers unless the permission
+	// matrix restricts their action.
+	adminRoute := func(action auth.Action, h http.HandlerFunc) http.Handler {
+		guarded := authMiddleware.RequirePermission(action, h)
+		if cfg.AuthRequired() {
+			return authMiddleware.Require(guarded)
+		}
+		return authMiddleware.Optional(guarded)
+	}
+	guardedRoute := func(action auth.Action, h http.HandlerFunc) http.Handler {
+		guarded := authMiddleware.RequirePermission(action, h)
+		if cfg.AuthRequired() && authz.Restricted(action) {
+			return authMiddleware.Require(guarded)
+		}
+		return authMiddleware.Optional(guarded)
+	}
+	if cfg.OIDCEnabled() {
+		provider := auth.NewOIDCProvider(auth.OIDCConfig{
+			IssuerURL:    cfg.Auth.OIDC.IssuerURL,
+			ClientID:     cfg.Auth.OIDC.ClientID,
+			ClientSecret: cfg.Auth.OIDC.ClientSecret,
+			RedirectURL:  cfg.Auth.OIDC.RedirectURL,
+			Scopes:       cfg.Auth.OIDC.Scopes,
+			RolesClaim:   cfg.Auth.OIDC.RolesClaim,
+		}, logger)
+
+		authController := controller.NewAuthController(provider, sessions, logger)
+
+		mux.HandleFunc("GET /auth/login", authController.Login)
+		mux.HandleFunc("GET /auth/callback", authController.Callback)
+		mux.HandleFunc("POST /auth/logout", authController.Logout)
+		mux.Handle("GET /auth/me", authMiddleware.Require(http.HandlerFunc(authController.Me)))
+	}
+
+	mux.Handle("POST /team/add", guardedRoute(auth.ActionTeamCreate, teamController.AddTeam))
+	mux.Handle("POST /team/update", guardedRoute(auth.ActionTeamConfigure, teamController.UpdateTeam))
 	mux.HandleFunc("GET /team/get", teamController.GetTeam)
+	mux.HandleFunc("GET /team/list", teamController.ListTeams)
+	mux.Handle("POST /team/import", guardedRoute(auth.ActionTeamCreate, teamController.ImportTeams))
+	mux.Handle("POST /team/checklist/set", guardedRoute(auth.ActionTeamConfigure, checklistController.SetTemplate))
+	mux.HandleFunc("GET /team/checklist/get", checklistController.GetTemplate)
+	mux.Handle("POST /team/owners/set", guardedRoute(auth.ActionTeamConfigure, ownershipController.SetTeamOwners))
+	mux.HandleFunc("GET /team/owners/get", ownershipController.GetTeamOwners)
+	mux.Handle("POST /team/components/set", guardedRoute(auth.ActionTeamConfigure, ownershipController.SetComponentOwners))
+	mux.HandleFunc("GET /team/components/get", ownershipController.GetComponentOwners)
+	mux.Handle("POST /team/mergePolicy/set", guardedRoute(auth.ActionTeamConfigure, mergePolicyController.SetPolicy))
+	mux.HandleFunc("GET /team/mergePolicy/get", mergePolicyController.GetPolicy)
+	mux.Handle("POST /team/settings", guardedRoute(auth.ActionTeamConfigure, teamSettingsController.SetSettings))
+	mux.HandleFunc("GET /team/settings", teamSettingsController.GetSettings)
 
-	mux.HandleFunc("POST /users/setIsActive", userController.SetIsActive)
+	mux.Handle("POST /users/setIsActive", guardedRoute(auth.ActionUserManage, userController.SetIsActive))
+	mux.Handle("POST /users/setIsActiveBulk", guardedRoute(auth.ActionUserManage, userController.SetIsActiveBulk))
+	mux.Handle("POST /users/snooze", guardedRoute(auth.ActionUserManage, userController.Snooze))
+	mux.Handle("DELETE /users", guardedRoute(auth.ActionUserManage, userController.DeleteUser))
+	mux.Handle("POST /users/transferReviews", guardedRoute(auth.ActionUserManage, userController.TransferReviews))
+	// Role management is checked per role by the usecase: role.manage_member
+	// is the minimum needed to reach it.
+	mux.Handle("POST /users/roles/grant", adminRoute(auth.ActionRoleManageMember, roleController.GrantRole))
+	mux.Handle("POST /users/roles/revoke", adminRoute(auth.ActionRoleManageMember, roleController.RevokeRole))
+	mux.Handle("GET /users/roles/get", adminRoute(auth.ActionRoleView, roleController.GetUserRoles))
 	mux.HandleFunc("GET /users/getReview", userController.GetReview)
+	mux.HandleFunc("GET /users/getAuthored", userController.GetAuthored)
+	mux.HandleFunc("GET /users/list", userController.ListUsers)
+	mux.HandleFunc("POST /users/getByIDs", userController.GetUsersByIDs)
 
-	mux.HandleFunc("POST /pullRequest/create", prController.CreatePR)
-	mux.HandleFunc("POST /pullRequest/merge", prController.MergePR)
-	mux.HandleFunc("POST /pullRequest/reassign", prController.ReassignReviewer)
+	mux.Handle("POST /pullRequest/create", guardedRoute(auth.ActionPRCreate, idempotency.Wrap(prController.CreatePR)))
+	mux.Handle("POST /pullRequest/merge", guardedRoute(auth.ActionPRMerge, idempotency.Wrap(prController.MergePR)))
+	mux.Handle("POST /pullRequest/close", guardedRoute(auth.ActionPRClose, prController.ClosePR))
+	mux.Handle("POST /pullRequest/approve", guardedRoute(auth.ActionPRApprove, prController.ApprovePR))
+	mux.Handle("POST /pullRequest/requestChanges", guardedRoute(auth.ActionPRRequestChanges, prController.RequestChanges))
+	mux.Handle("POST /pullRequest/reopen", guardedRoute(auth.ActionPRReopen, prController.ReopenPR))
+	mux.Handle("POST /pullRequest/override", guardedRoute(auth.ActionPROverride, prController.OverrideApproval))
+	mux.Handle("POST /pullRequest/setAutoMerge", guardedRoute(auth.ActionPRAutoMerge, prController.SetAutoMerge))
+	mux.Handle("POST /pullRequest/update", guardedRoute(auth.ActionPRUpdate, prController.UpdatePR))
+	mux.Handle("POST /pullRequest/reassign", guardedRoute(auth.ActionPRReassign, idempotency.Wrap(prController.ReassignReviewer)))
+	mux.Handle("POST /pullRequest/decline", guardedRoute(auth.ActionPRDecline, prController.DeclineReview))
+	mux.HandleFunc("GET /pullRequest/overdue", prController.GetOverduePRs)
+	mux.HandleFunc("GET /pullRequest/byTeam", prController.GetTeamPRs)
+	mux.HandleFunc("GET /pullRequest/list", prController.ListPRs)
+	mux.Handle("POST /pullRequest/setMilestone", guardedRoute(auth.ActionPRSetMilestone, milestoneController.SetPRMilestone))
+	mux.Handle("POST /pullRequest/checklist/check", guardedRoute(auth.ActionPRCheckChecklist, checklistController.CheckItem))
+
+	mux.Handle("POST /milestone/create", guardedRoute(auth.ActionMilestoneCreate, milestoneController.CreateMilestone))
+	mux.HandleFunc("GET /milestone/get", milestoneController.GetMilestone)
+	mux.HandleFunc("GET /milestone/list", milestoneController.ListMilestones)
+	mux.Handle("POST /milestone/update", guardedRoute(auth.ActionMilestoneUpdate, milestoneController.UpdateMilestone))
+	mux.Handle("POST /milestone/delete", guardedRoute(auth.ActionMilestoneDelete, milestoneController.DeleteMilestone))
+	mux.HandleFunc("GET /milestone/stats", milestoneController.GetMilestoneStats)
+
+	mux.HandleFunc("GET /status/{team}", statusController.TeamStatus)
+	mux.HandleFunc("GET /metrics", metricsController.Metrics)
+	mux.HandleFunc("GET /readyz", healthController.Readyz)
+
+	mux.Handle("POST /org/create", adminRoute(auth.ActionOrgManage, orgController.CreateOrganization))
+	mux.Handle("GET /org/list", adminRoute(auth.ActionOrgManage, orgController.ListOrganizations))
+	mux.Handle("POST /org/tokens/issue", adminRoute(auth.ActionTokenManage, apiTokenController.IssueToken))
+	mux.Handle("GET /org/tokens/list", adminRoute(auth.ActionTokenManage, apiTokenController.ListTokens))
+	mux.Handle("POST /org/tokens/revoke", adminRoute(auth.ActionTokenManage, apiTokenController.RevokeToken))
+
+	mux.Handle("GET /admin", adminRoute(auth.ActionStatsView, dashboardController.Show))
+	mux.Handle("POST /admin/reassign", adminRoute(auth.ActionPRReassign, dashboardController.Reassign))
+	mux.Handle("POST /admin/backfillReviewers", adminRoute(auth.ActionAdminOperate, adminController.BackfillReviewers))
+	mux.Handle("POST /admin/rebalance", adminRoute(auth.ActionAdminOperate, adminController.Rebalance))
+	mux.Handle("GET /admin/consistency", adminRoute(auth.ActionAdminOperate, adminController.CheckConsistency))
+	mux.Handle("POST /admin/repair", adminRoute(auth.ActionAdminOperate, adminController.Repair))
+	mux.Handle("POST /admin/export", adminRoute(auth.ActionAdminOperate, stateController.Export))
+	mux.Handle("POST /admin/import", adminRoute(auth.ActionAdminOperate, stateController.Import))
+	mux.Handle("POST /admin/backup", adminRoute(auth.ActionAdminOperate, stateController.Backup))
+	mux.Handle("POST /admin/idleUsers", adminRoute(auth.ActionAdminOperate, adminController.CheckIdleUsers))
+	mux.Handle("GET /admin/stats", adminRoute(auth.ActionStatsView, adminController.GetStats))
+	mux.Handle("GET /admin/quotas", adminRoute(auth.ActionStatsView, adminController.GetQuotas))
+	mux.Handle("GET /audit", adminRoute(auth.ActionAuditView, auditController.ListEntries))
+	mux.Handle("GET /admin/events", adminRoute(auth.ActionAuditView, eventLogController.ListEvents))
+	mux.Handle("GET /admin/stats/review", adminRoute(auth.ActionStatsView, adminController.GetReviewStats))
+	mux.Handle("GET /admin/runtime", adminRoute(auth.ActionAdminOperate, runtimeController.GetRuntime))
+	mux.Handle("GET /admin/settings", adminRoute(auth.ActionAdminOperate, globalSettingsController.GetSettings))
+	mux.Handle("POST /admin/settings", adminRoute(auth.ActionAdminOperate, globalSettingsController.SetSettings))
+	mux.Handle("POST /admin/sync/github", adminRoute(auth.ActionAdminOperate, adminController.SyncGitHub))
+
+	if cfg.UI.Enabled {
+		files := ui.FS()
+		if cfg.UI.Dir != "" {
+			files = os.DirFS(cfg.UI.Dir)
+		}
+		spa, err := controller.NewSPAHandler(files)
+		if err != nil {
+			return nil, fmt.Errorf("load ui: %w", err)
+		}
+		mux.Handle("GET /ui/", http.StripPrefix("/ui", spa))
+		mux.Handle("GET /ui", http.RedirectHandler("/ui/", http.StatusMovedPermanently))
+	}
+
+	if cfg.SCIM.Token != "" {
+		scimController := controller.NewScimController(userUC, cfg.SCIM.Token, logger)
+
+		mux.HandleFunc("GET /scim/v2/Users", scimController.ListUsers)
+		mux.HandleFunc("POST /scim/v2/Users", scimController.CreateUser)
+		mux.HandleFunc("GET /scim/v2/Users/{id}", scimController.GetUser)
+		mux.HandleFunc("PUT /scim/v2/Users/{id}", scimController.ReplaceUser)
+		mux.HandleFunc("PATCH /scim/v2/Users/{id}", scimController.PatchUser)
+		mux.HandleFunc("DELETE /scim/v2/Users/{id}", scimController.DeleteUser)
+	}
+
+	var handler http.Handler = controller.RouteErrors(mux)
+	handler = errorMetrics.Instrument(handler)
+	if statsdClient != nil {
+		handler = controller.NewMetricsMiddleware(statsdClient).Instrument(handler)
+	}
+	handler = orgController.Scope(handler)
+	handler = controller.RequestContext(handler)
+	handler = controller.CacheHeaders(cfg.Server.CacheControl, handler)
+	handler = controller.HandleHEAD(handler)
+	handler = controller.NegotiateErrors(handler)
+
+	if err := logStartupReport(context.Background(), cfg, repo, strategy, reviewSettings, logger); err != nil {
+		return nil, err
+	}
 
 	server := &http.Server{
 		Addr:         cfg.ServerAddr(),
-		Handler:      mux,
+		Handler:      handler,
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
-	return &App{
-		server: server,
-		logger: logger,
-		config: cfg,
+	ctx, cancel := context.WithCancel(context.Background())
+
+	a := &App{
+		server:  server,
+		logger:  logger,
+		config:  cfg,
+		workers: workers,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	a.OnShutdown("storage", func(context.Context) error { return backend.close() })
+	if statsdClient != nil {
+		a.OnShutdown("statsd", func(context.Context) error { return statsdClient.Close() })
 	}
+
+	return a, nil
 }
 
 func (a *App) Run() error {
+	a.workers.Start(a.ctx)
+
 	a.logger.Info("Server starting", zap.String("addr", a.server.Addr))
 	return a.server.ListenAndServe()
 }
 
 func (a *App) Shutdown(ctx context.Context) error {
 	a.logger.Info("Server shutting down...")
-	return a.server.Shutdown(ctx)
+	a.cancel()
+	err := a.server.Shutdown(ctx)
+	return errors.Join(err, a.runShutdownHooks(ctx))
 }
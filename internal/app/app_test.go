@@ -0,0 +1,162 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"avito-intro/config"
+	"avito-intro/internal/entity"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const testAdminToken = "test-admin-token-0123456789"
+
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+
+	t.Setenv("STORAGE_DRIVER", "memory")
+	t.Setenv("ADMIN_TOKEN", testAdminToken)
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("config.New: %v", err)
+	}
+	a, err := New(cfg, zap.NewNop())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = a.Shutdown(context.Background()) })
+	return a
+}
+
+func serve(a *App, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	a.server.Handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// issueToken issues an API token with role through the bootstrap admin
+// token and returns its secret.
+func issueToken(t *testing.T, a *App, role string) string {
+	t.Helper()
+
+	rec := serve(a, http.MethodPost, "/org/tokens/issue", testAdminToken, `{"name": "test", "roles": ["`+role+`"]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("issue token: status %d, body %s", rec.Code, rec.Body)
+	}
+	var issued struct {
+		Secret string `json:"secret"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &issued); err != nil {
+		t.Fatalf("decode issued token: %v", err)
+	}
+	return issued.Secret
+}
+
+func TestAdminRouteForbidsMemberToken(t *testing.T) {
+	a := newTestApp(t)
+
+	member := issueToken(t, a, "member")
+
+	if rec := serve(a, http.MethodGet, "/admin/runtime", member, ""); rec.Code != http.StatusForbidden {
+		t.Fatalf("member token on an admin route: status %d, want 403", rec.Code)
+	}
+	if rec := serve(a, http.MethodGet, "/admin/runtime", testAdminToken, ""); rec.Code != http.StatusOK {
+		t.Fatalf("admin token on an admin route: status %d, want 200", rec.Code)
+	}
+}
+
+func TestMilestoneRoutesFollowPermissions(t *testing.T) {
+	permissions := filepath.Join(t.TempDir(), "permissions.yaml")
+	if err := os.WriteFile(permissions, []byte("milestone.create: [lead]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PERMISSIONS_FILE", permissions)
+	a := newTestApp(t)
+	member := issueToken(t, a, "member")
+	lead := issueToken(t, a, "lead")
+
+	body := `{"title": "v1.0"}`
+	if rec := serve(a, http.MethodPost, "/milestone/create", "", body); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("anonymous milestone create: status %d, want 401", rec.Code)
+	}
+	if rec := serve(a, http.MethodPost, "/milestone/create", member, body); rec.Code != http.StatusForbidden {
+		t.Fatalf("member milestone create: status %d, want 403", rec.Code)
+	}
+	if rec := serve(a, http.MethodPost, "/milestone/create", lead, body); rec.Code != http.StatusCreated {
+		t.Fatalf("lead milestone create: status %d, body %s", rec.Code, rec.Body)
+	}
+}
+
+// plainStorage hides the Transactor of the storage it wraps.
+type plainStorage struct {
+	repository.Storage
+}
+
+func TestWithRepositoryReplacesStorageDriver(t *testing.T) {
+	// A driver that cannot start must not be opened when a repository is
+	// given.
+	t.Setenv("STORAGE_DRIVER", "badger")
+	t.Setenv("BADGER_DIR", filepath.Join(os.DevNull, "badger"))
+	t.Setenv("OUTBOX_SINKS", "log")
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("config.New: %v", err)
+	}
+
+	a, err := New(cfg, zap.NewNop(), WithRepository(repository.NewMemoryRepository(zap.NewNop())))
+	if err != nil {
+		t.Fatalf("New with a transactional repository: %v", err)
+	}
+	_ = a.Shutdown(context.Background())
+
+	plain := plainStorage{repository.NewMemoryRepository(zap.NewNop())}
+	if _, err := New(cfg, zap.NewNop(), WithRepository(plain)); err == nil {
+		t.Fatal("New accepted outbox sinks on a repository without transactions")
+	}
+}
+
+func TestBootstrapAdminUserIsCreatedOnce(t *testing.T) {
+	t.Setenv("STORAGE_DRIVER", "memory")
+	t.Setenv("ADMIN_TOKEN", testAdminToken)
+	t.Setenv("ADMIN_USER", "root")
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("config.New: %v", err)
+	}
+	repo := repository.NewMemoryRepository(zap.NewNop())
+
+	// A restart on the same storage finds the user created by the first
+	// start and links the token to it again.
+	for range 2 {
+		a, err := New(cfg, zap.NewNop(), WithRepository(repo))
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		_ = a.Shutdown(context.Background())
+	}
+
+	users, total, err := repo.ListUsers(context.Background(), entity.UserFilter{})
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if total != 1 || users[0].Username != "root" {
+		t.Fatalf("users after two starts: %d, want the admin user once", total)
+	}
+	if users[0].UserID != uuid.NewSHA1(adminUserNamespace, []byte("root")) {
+		t.Fatalf("admin user ID %s is not derived from its name", users[0].UserID)
+	}
+}
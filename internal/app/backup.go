@@ -0,0 +1,34 @@
+package app
+
+import (
+	"fmt"
+
+	"avito-intro/config"
+	"avito-intro/internal/integration/backup"
+	"avito-intro/internal/usecase"
+)
+
+// newBackupStore builds the store selected by BACKUP_DESTINATION, or nil
+// when backups are disabled.
+func newBackupStore(cfg config.BackupConfig) (usecase.BackupStore, error) {
+	switch cfg.Destination {
+	case "":
+		return nil, nil
+	case "file":
+		return backup.NewFileStore(cfg.Dir), nil
+	case "s3":
+		if cfg.S3.Bucket == "" {
+			return nil, fmt.Errorf("backup destination s3 requires BACKUP_S3_BUCKET")
+		}
+		return backup.NewS3Store(backup.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			Prefix:    cfg.S3.Prefix,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown backup destination %q (use file or s3)", cfg.Destination)
+	}
+}
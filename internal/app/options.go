@@ -0,0 +1,38 @@
+package app
+
+import (
+	"avito-intro/internal/repository"
+	"avito-intro/internal/usecase"
+)
+
+// Option overrides one of the dependencies New would otherwise build from
+// the config.
+type Option func(*options)
+
+type options struct {
+	repo     repository.Storage
+	clock    usecase.Clock
+	strategy usecase.AssignmentStrategy
+	notifier usecase.Notifier
+}
+
+// WithRepository replaces the storage selected by STORAGE_DRIVER for the
+// default organization; other organizations are kept in memory.
+func WithRepository(repo repository.Storage) Option {
+	return func(o *options) { o.repo = repo }
+}
+
+// WithClock replaces time.Now for PR timestamps and SLA checks.
+func WithClock(clock usecase.Clock) Option {
+	return func(o *options) { o.clock = clock }
+}
+
+// WithAssignmentStrategy replaces the strategy selected by ASSIGNMENT_STRATEGY.
+func WithAssignmentStrategy(strategy usecase.AssignmentStrategy) Option {
+	return func(o *options) { o.strategy = strategy }
+}
+
+// WithNotifier replaces the default notifier that only logs PR events.
+func WithNotifier(notifier usecase.Notifier) Option {
+	return func(o *options) { o.notifier = notifier }
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"fmt"
+
+	"avito-intro/config"
+	"avito-intro/internal/integration/webhook"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+// newOutboxSinks builds the sinks listed in OUTBOX_SINKS. An empty list
+// disables the outbox.
+func newOutboxSinks(cfg config.OutboxConfig, logger *zap.Logger) ([]usecase.OutboxSink, error) {
+	sinks := make([]usecase.OutboxSink, 0, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		switch name {
+		case "log":
+			sinks = append(sinks, usecase.NewLogSink(logger))
+		case "webhook":
+			if cfg.WebhookURL == "" {
+				return nil, fmt.Errorf("outbox sink webhook requires OUTBOX_WEBHOOK_URL")
+			}
+			sinks = append(sinks, webhook.NewSink(cfg.WebhookURL, cfg.WebhookSecret))
+		default:
+			return nil, fmt.Errorf("unknown outbox sink %q (use log or webhook)", name)
+		}
+	}
+	return sinks, nil
+}
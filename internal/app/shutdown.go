@@ -0,0 +1,34 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// OnShutdown registers a cleanup function that Shutdown runs after the HTTP
+// server has stopped. Hooks run in registration order; a failing hook does
+// not prevent the remaining ones from running.
+func (a *App) OnShutdown(name string, fn func(ctx context.Context) error) {
+	a.hooks = append(a.hooks, shutdownHook{name: name, fn: fn})
+}
+
+func (a *App) runShutdownHooks(ctx context.Context) error {
+	var errs []error
+	for _, hook := range a.hooks {
+		if err := hook.fn(ctx); err != nil {
+			a.logger.Error("shutdown hook failed", zap.String("hook", hook.name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", hook.name, err))
+			continue
+		}
+		a.logger.Info("shutdown hook completed", zap.String("hook", hook.name))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"avito-intro/config"
+	"avito-intro/internal/auth"
+	"avito-intro/internal/entity"
+	"avito-intro/internal/repository"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const startupCheckTimeout = 10 * time.Second
+
+// prepareStorage makes sure the backend is reachable and its schema is up to
+// date before anything else touches it. Startup fails otherwise.
+func prepareStorage(ctx context.Context, storage repository.Storage) error {
+	ctx, cancel := context.WithTimeout(ctx, startupCheckTimeout)
+	defer cancel()
+
+	if err := storage.Ping(ctx); err != nil {
+		return fmt.Errorf("storage is not reachable: %w", err)
+	}
+
+	if migrator, ok := storage.(repository.Migrator); ok {
+		if err := migrator.Migrate(ctx); err != nil {
+			return fmt.Errorf("run storage migrations: %w", err)
+		}
+	}
+
+	return nil
+}
+
+const minAdminTokenLen = 16
+
+// adminUserNamespace derives the ID of the ADMIN_USER user from its name.
+var adminUserNamespace = uuid.MustParse("9f3c6a52-4d1e-4b8a-9a57-2c1e8f0d7b61")
+
+// bootstrapAdmin registers ADMIN_TOKEN as an admin API token valid in every
+// organization, so the protected API can be used before any identity
+// provider is configured. The token acts as ADMIN_USER, a user of the
+// default organization created on first start. Its ID is derived from the
+// name, so every replica and restart links the token to the same user.
+func bootstrapAdmin(ctx context.Context, cfg *config.Config, users repository.UserRepository, tokens *auth.APITokenStore, logger *zap.Logger) error {
+	if cfg.Auth.AdminToken == "" {
+		return nil
+	}
+	if len(cfg.Auth.AdminToken) < minAdminTokenLen {
+		return fmt.Errorf("ADMIN_TOKEN must be at least %d characters long", minAdminTokenLen)
+	}
+
+	// The admin is not on a team and never assigned reviews.
+	admin := &entity.User{
+		UserID:   uuid.NewSHA1(adminUserNamespace, []byte(cfg.Auth.AdminUser)),
+		Username: cfg.Auth.AdminUser,
+	}
+	exists, err := users.UserExists(ctx, admin.UserID)
+	if err != nil {
+		return fmt.Errorf("look up admin user %s: %w", cfg.Auth.AdminUser, err)
+	}
+	if !exists {
+		// Another replica starting at the same time may create it first.
+		err := users.CreateUser(ctx, admin)
+		if err != nil && !errors.Is(err, repository.ErrAlreadyExists) {
+			return fmt.Errorf("create admin user %s: %w", cfg.Auth.AdminUser, err)
+		}
+		if err == nil {
+			logger.Info("bootstrap admin user created", zap.String("user_id", admin.UserID.String()))
+		}
+	}
+
+	tokens.Register(cfg.Auth.AdminToken, auth.APIToken{
+		ID:        uuid.New(),
+		Name:      cfg.Auth.AdminUser,
+		Roles:     []string{string(entity.RoleAdmin)},
+		UserID:    admin.UserID,
+		CreatedBy: "config",
+		CreatedAt: time.Now(),
+	})
+	logger.Info("bootstrap admin token registered",
+		zap.String("admin_user", cfg.Auth.AdminUser),
+		zap.String("user_id", admin.UserID.String()),
+	)
+	return nil
+}
+
+// logStartupReport writes a single structured line describing the effective
+// configuration and storage contents once all startup checks have passed.
+func logStartupReport(ctx context.Context, cfg *config.Config, storage repository.Storage, strategy usecase.AssignmentStrategy, review usecase.ReviewSettings, logger *zap.Logger) error {
+	stats, err := storage.Stats(ctx)
+	if err != nil {
+		return fmt.Errorf("collect storage stats: %w", err)
+	}
+
+	prs := 0
+	for _, n := range stats.PullRequests {
+		prs += n
+	}
+
+	logger.Info("startup self-check passed",
+		zap.String("storage_backend", stats.Backend),
+		zap.Int("users", stats.Users),
+		zap.Int("teams", stats.Teams),
+		zap.Int("pull_requests", prs),
+		zap.String("assignment_strategy", strategy.Name()),
+		zap.String("review_mode", review.Mode),
+		zap.Int("required_reviewers", review.RequiredReviewers),
+		zap.Int("optional_reviewers", review.OptionalReviewers),
+		zap.String("merge_approvals", review.MergeApprovals),
+		zap.Duration("review_sla", review.SLA),
+		zap.Bool("seed", cfg.Seed.File != ""),
+		zap.Bool("github_sync", cfg.GitHub.Org != ""),
+		zap.Bool("github_changed_files", cfg.GitHub.Token != ""),
+		zap.Bool("oidc", cfg.OIDCEnabled()),
+		zap.Bool("admin_token", cfg.Auth.AdminToken != ""),
+		zap.Bool("scim", cfg.SCIM.Token != ""),
+		zap.Bool("statsd", cfg.StatsD.Addr != ""),
+		zap.Int("retention_audit_days", cfg.Retention.AuditDays),
+		zap.Int("retention_pr_days", cfg.Retention.PullRequestDays),
+		zap.Int("retention_archived_pr_days", cfg.Retention.ArchivedPullRequestDays),
+	)
+	return nil
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"fmt"
+
+	"avito-intro/config"
+	"avito-intro/internal/repository"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// storage is the backend selected by STORAGE_DRIVER: the storage of the
+// default organization, a constructor for the storage of every
+// organization created later, and a close function for its connections.
+type storage struct {
+	name           string
+	defaultStorage repository.Storage
+	newStorage     func(orgID string) repository.Storage
+	close          func() error
+}
+
+// repositoryStorage is the backend for a repository given with
+// WithRepository, or nil without one. The repository holds the default
+// organization; other organizations are kept in memory, and closing the
+// repository is left to whoever opened it.
+func repositoryStorage(repo repository.Storage, logger *zap.Logger) *storage {
+	if repo == nil {
+		return nil
+	}
+	return &storage{
+		name:           fmt.Sprintf("%T", repo),
+		defaultStorage: repo,
+		newStorage: func(string) repository.Storage {
+			return repository.NewMemoryRepository(logger)
+		},
+		close: func() error { return nil },
+	}
+}
+
+func newStorage(cfg config.StorageConfig, logger *zap.Logger) (*storage, error) {
+	switch cfg.Driver {
+	case "", "memory":
+		return &storage{
+			name:           "memory",
+			defaultStorage: repository.NewMemoryRepository(logger),
+			newStorage: func(string) repository.Storage {
+				return repository.NewMemoryRepository(logger)
+			},
+			close: func() error { return nil },
+		}, nil
+	case "redis":
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		prefix := cfg.Redis.KeyPrefix
+		return &storage{
+			name:           "redis",
+			defaultStorage: repository.NewRedisRepository(client, prefix, logger),
+			// Organizations share the database under their own prefix.
+			newStorage: func(orgID string) repository.Storage {
+				return repository.NewRedisRepository(client, prefix+"org:"+orgID+":", logger)
+			},
+			close: client.Close,
+		}, nil
+	case "badger":
+		opts := badger.DefaultOptions(cfg.Badger.Dir).
+			WithSyncWrites(cfg.Badger.SyncWrites).
+			WithLogger(badgerLogger{logger.Sugar().Named("badger")})
+		db, err := badger.Open(opts)
+		if err != nil {
+			return nil, fmt.Errorf("open badger database in %s: %w", cfg.Badger.Dir, err)
+		}
+		return &storage{
+			name:           "badger",
+			defaultStorage: repository.NewBadgerRepository(db, "", logger),
+			// Organizations share the database under their own prefix.
+			newStorage: func(orgID string) repository.Storage {
+				return repository.NewBadgerRepository(db, "org:"+orgID+":", logger)
+			},
+			close: db.Close,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q, use memory, redis or badger", cfg.Driver)
+	}
+}
+
+// badgerLogger routes Badger's own logging to zap. Its info messages are
+// about compactions and such, so they are logged at debug level.
+type badgerLogger struct {
+	*zap.SugaredLogger
+}
+
+func (l badgerLogger) Warningf(template string, args ...any) {
+	l.Warnf(template, args...)
+}
+
+func (l badgerLogger) Infof(template string, args ...any) {
+	l.Debugf(template, args...)
+}
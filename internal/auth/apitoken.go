@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MethodAPIToken = "api_token"
+
+	apiTokenPrefix = "prt_"
+)
+
+var (
+	ErrInvalidAPIToken  = errors.New("invalid or revoked API token")
+	ErrAPITokenNotFound = errors.New("API token not found")
+)
+
+// APIToken is a long-lived credential bound to one organization. Only the
+// SHA-256 of the secret is kept; the secret itself is returned once, on
+// issue.
+type APIToken struct {
+	ID        uuid.UUID
+	OrgID     string
+	Name      string
+	Roles     []string
+	UserID    uuid.UUID
+	CreatedBy string
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+}
+
+func (t APIToken) Principal() Principal {
+	return Principal{
+		Subject: "token:" + t.ID.String(),
+		Name:    t.Name,
+		Roles:   slices.Clone(t.Roles),
+		OrgID:   t.OrgID,
+		UserID:  t.UserID,
+		Method:  MethodAPIToken,
+	}
+}
+
+func IsAPIToken(secret string) bool {
+	return strings.HasPrefix(secret, apiTokenPrefix)
+}
+
+// APITokenStore keeps issued API tokens in the repository, so they are
+// shared by every replica and survive a restart. Tokens registered with a
+// secret from the config are kept in memory only: every replica registers
+// them on start.
+type APITokenStore struct {
+	repo repository.APITokenRepository
+
+	mu     sync.RWMutex
+	static map[string]APIToken
+}
+
+func NewAPITokenStore(repo repository.APITokenRepository) *APITokenStore {
+	return &APITokenStore{repo: repo, static: make(map[string]APIToken)}
+}
+
+// Issue creates a token for orgID and returns it along with its secret.
+// A zero ttl issues a token that never expires.
+func (s *APITokenStore) Issue(ctx context.Context, orgID, name string, roles []string, userID uuid.UUID, ttl time.Duration, createdBy string) (APIToken, string, error) {
+	random, err := randomToken(32)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+	secret := apiTokenPrefix + random
+
+	token := APIToken{
+		ID:        uuid.New(),
+		OrgID:     orgID,
+		Name:      name,
+		Roles:     slices.Clone(roles),
+		UserID:    userID,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+	if ttl > 0 {
+		expiresAt := token.CreatedAt.Add(ttl)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.repo.CreateAPIToken(ctx, token.entity(hashSecret(secret))); err != nil {
+		return APIToken{}, "", fmt.Errorf("store API token: %w", err)
+	}
+	return token, secret, nil
+}
+
+// Register adds a token with a secret chosen outside the service, such as
+// the bootstrap admin token from the config.
+func (s *APITokenStore) Register(secret string, token APIToken) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.static[hashSecret(secret)] = token
+}
+
+// Lookup returns the token with secret. Unknown, revoked and expired tokens
+// are ErrInvalidAPIToken; other errors come from the repository.
+func (s *APITokenStore) Lookup(ctx context.Context, secret string) (APIToken, error) {
+	key := hashSecret(secret)
+
+	s.mu.RLock()
+	token, ok := s.static[key]
+	s.mu.RUnlock()
+	if ok {
+		return token, nil
+	}
+	// Only issued tokens are stored; a session token is not looked for.
+	if !IsAPIToken(secret) {
+		return APIToken{}, ErrInvalidAPIToken
+	}
+
+	stored, err := s.repo.GetAPIToken(ctx, key)
+	if errors.Is(err, repository.ErrNotFound) {
+		return APIToken{}, ErrInvalidAPIToken
+	}
+	if err != nil {
+		return APIToken{}, fmt.Errorf("look up API token: %w", err)
+	}
+	token = apiTokenFromEntity(stored)
+	if token.ExpiresAt != nil && time.Now().After(*token.ExpiresAt) {
+		// Another replica may have deleted it already.
+		if err := s.repo.DeleteAPIToken(ctx, token.OrgID, token.ID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return APIToken{}, fmt.Errorf("delete expired API token: %w", err)
+		}
+		return APIToken{}, ErrInvalidAPIToken
+	}
+	return token, nil
+}
+
+func (s *APITokenStore) List(ctx context.Context, orgID string) ([]APIToken, error) {
+	stored, err := s.repo.ListAPITokens(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("list API tokens: %w", err)
+	}
+	tokens := make([]APIToken, len(stored))
+	for i, token := range stored {
+		tokens[i] = apiTokenFromEntity(token)
+	}
+	return tokens, nil
+}
+
+// Revoke deletes a token of orgID. Tokens of other organizations are
+// reported as not found.
+func (s *APITokenStore) Revoke(ctx context.Context, orgID string, id uuid.UUID) error {
+	err := s.repo.DeleteAPIToken(ctx, orgID, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrAPITokenNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("revoke API token: %w", err)
+	}
+	return nil
+}
+
+func (t APIToken) entity(secretHash string) *entity.APIToken {
+	return &entity.APIToken{
+		ID:         t.ID,
+		SecretHash: secretHash,
+		OrgID:      t.OrgID,
+		Name:       t.Name,
+		Roles:      t.Roles,
+		UserID:     t.UserID,
+		CreatedBy:  t.CreatedBy,
+		CreatedAt:  t.CreatedAt,
+		ExpiresAt:  t.ExpiresAt,
+	}
+}
+
+func apiTokenFromEntity(t *entity.APIToken) APIToken {
+	return APIToken{
+		ID:        t.ID,
+		OrgID:     t.OrgID,
+		Name:      t.Name,
+		Roles:     t.Roles,
+		UserID:    t.UserID,
+		CreatedBy: t.CreatedBy,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+	}
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,313 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	loginTimeout   = 10 * time.Minute
+	clockLeeway    = time.Minute
+	jwksMinRefresh = time.Minute
+)
+
+var ErrInvalidState = errors.New("invalid or expired login state")
+
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	RolesClaim   string
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type pendingLogin struct {
+	nonce     string
+	expiresAt time.Time
+}
+
+// OIDCProvider implements the authorization code flow against an OpenID
+// Connect issuer and turns verified ID tokens into principals.
+type OIDCProvider struct {
+	cfg        OIDCConfig
+	httpClient *http.Client
+	logger     *zap.Logger
+
+	mu            sync.Mutex
+	discovery     *discoveryDocument
+	keys          map[string]*rsa.PublicKey
+	keysFetchedAt time.Time
+	pending       map[string]pendingLogin
+}
+
+func NewOIDCProvider(cfg OIDCConfig, logger *zap.Logger) *OIDCProvider {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+	return &OIDCProvider{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+		keys:       make(map[string]*rsa.PublicKey),
+		pending:    make(map[string]pendingLogin),
+	}
+}
+
+// AuthCodeURL starts a login and returns the issuer URL to redirect the user
+// to, along with the state value the callback must present.
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context) (string, string, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := randomToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomToken(16)
+	if err != nil {
+		return "", "", err
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	for s, pending := range p.pending {
+		if now.After(pending.expiresAt) {
+			delete(p.pending, s)
+		}
+	}
+	p.pending[state] = pendingLogin{nonce: nonce, expiresAt: now.Add(loginTimeout)}
+	p.mu.Unlock()
+
+	query := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.cfg.ClientID},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"scope":         {strings.Join(p.cfg.Scopes, " ")},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+
+	sep := "?"
+	if strings.Contains(doc.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return doc.AuthorizationEndpoint + sep + query.Encode(), state, nil
+}
+
+// Exchange completes a login: it redeems the authorization code and verifies
+// the returned ID token.
+func (p *OIDCProvider) Exchange(ctx context.Context, state, code string) (Principal, error) {
+	p.mu.Lock()
+	pending, ok := p.pending[state]
+	delete(p.pending, state)
+	p.mu.Unlock()
+
+	if !ok || time.Now().After(pending.expiresAt) {
+		return Principal{}, ErrInvalidState
+	}
+
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	idToken, err := p.redeemCode(ctx, doc.TokenEndpoint, code)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	claims, err := verifyRS256(idToken, func(kid string) (*rsa.PublicKey, error) {
+		return p.key(ctx, doc.JWKSURI, kid)
+	})
+	if err != nil {
+		return Principal{}, err
+	}
+
+	if err := p.validateClaims(claims, doc.Issuer, pending.nonce); err != nil {
+		return Principal{}, err
+	}
+
+	principal := Principal{
+		Subject: claimString(claims, "sub"),
+		Email:   claimString(claims, "email"),
+		Name:    claimString(claims, "name"),
+		Method:  MethodSession,
+	}
+	if p.cfg.RolesClaim != "" {
+		principal.Roles = claimStrings(claims, p.cfg.RolesClaim)
+	}
+
+	p.logger.Info("oidc login succeeded",
+		zap.String("subject", principal.Subject),
+		zap.Strings("roles", principal.Roles),
+	)
+	return principal, nil
+}
+
+func (p *OIDCProvider) validateClaims(claims map[string]interface{}, issuer, nonce string) error {
+	if claimString(claims, "iss") != issuer {
+		return fmt.Errorf("%w: issuer mismatch", ErrInvalidToken)
+	}
+	if !slices.Contains(claimStrings(claims, "aud"), p.cfg.ClientID) {
+		return fmt.Errorf("%w: audience mismatch", ErrInvalidToken)
+	}
+	if claimString(claims, "nonce") != nonce {
+		return fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+	if claimString(claims, "sub") == "" {
+		return fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok || time.Now().Add(-clockLeeway).After(time.Unix(int64(exp), 0)) {
+		return fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	return nil
+}
+
+func (p *OIDCProvider) redeemCode(ctx context.Context, tokenEndpoint, code string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request: unexpected status %s", resp.Status)
+	}
+
+	var body struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode token response: %w", err)
+	}
+	if body.IDToken == "" {
+		return "", fmt.Errorf("%w: token response has no id_token", ErrInvalidToken)
+	}
+	return body.IDToken, nil
+}
+
+func (p *OIDCProvider) discover(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	doc := p.discovery
+	p.mu.Unlock()
+	if doc != nil {
+		return doc, nil
+	}
+
+	wellKnown := strings.TrimRight(p.cfg.IssuerURL, "/") + "/.well-known/openid-configuration"
+	doc = &discoveryDocument{}
+	if err := p.getJSON(ctx, wellKnown, doc); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if doc.Issuer == "" || doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("oidc discovery: incomplete provider metadata")
+	}
+
+	p.mu.Lock()
+	p.discovery = doc
+	p.mu.Unlock()
+	return doc, nil
+}
+
+// key returns the signing key with the given ID, refreshing the JWKS when an
+// unknown key ID shows up (providers rotate keys).
+func (p *OIDCProvider) key(ctx context.Context, jwksURI, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	key, ok := p.keys[kid]
+	canRefresh := time.Since(p.keysFetchedAt) > jwksMinRefresh
+	p.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+	if !canRefresh {
+		return nil, fmt.Errorf("%w: unknown key id %q", ErrInvalidToken, kid)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.getJSON(ctx, jwksURI, &set); err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.rsaPublicKey()
+		if err != nil {
+			p.logger.Debug("skipping jwk", zap.String("kid", k.Kid), zap.Error(err))
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+	p.mu.Unlock()
+
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown key id %q", ErrInvalidToken, kid)
+}
+
+func (p *OIDCProvider) getJSON(ctx context.Context, u string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", u, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
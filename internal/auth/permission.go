@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"avito-intro/internal/entity"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Action is something a principal may be allowed to do.
+type Action string
+
+const (
+	ActionTeamCreate       Action = "team.create"
+	ActionTeamConfigure    Action = "team.configure"
+	ActionUserManage       Action = "user.manage"
+	ActionPRCreate         Action = "pr.create"
+	ActionPRMerge          Action = "pr.merge"
+	ActionPRClose          Action = "pr.close"
+	ActionPRReopen         Action = "pr.reopen"
+	ActionPRUpdate         Action = "pr.update"
+	ActionPRApprove        Action = "pr.approve"
+	ActionPRRequestChanges Action = "pr.request_changes"
+	ActionPRAutoMerge      Action = "pr.auto_merge"
+	ActionPRSetMilestone   Action = "pr.set_milestone"
+	ActionPRCheckChecklist Action = "pr.check_checklist"
+	ActionMilestoneCreate  Action = "milestone.create"
+	ActionMilestoneUpdate  Action = "milestone.update"
+	ActionMilestoneDelete  Action = "milestone.delete"
+	ActionPRReassign       Action = "pr.reassign"
+	ActionPRDecline        Action = "pr.decline"
+	ActionPROverride       Action = "pr.override_approval"
+	ActionStatsView        Action = "stats.view"
+	ActionRoleView         Action = "role.view"
+	ActionRoleManageMember Action = "role.manage_member"
+	ActionRoleManage       Action = "role.manage"
+	ActionOrgManage        Action = "org.manage"
+	ActionTokenManage      Action = "token.manage"
+	ActionAuditView        Action = "audit.view"
+	ActionAdminOperate     Action = "admin.operate"
+)
+
+var ErrInvalidPermissions = errors.New("invalid permissions")
+
+// Permissions maps each action to the roles allowed to perform it. An
+// action without roles needs none; admins may perform every action.
+type Permissions map[Action][]string
+
+// DefaultPermissions keeps pull request, milestone and team workflows open and
+// reserves role, organization, token and audit management and the admin
+// endpoints for leads and admins.
+func DefaultPermissions() Permissions {
+	lead := []string{string(entity.RoleLead)}
+	admin := []string{string(entity.RoleAdmin)}
+	return Permissions{
+		ActionTeamCreate:       nil,
+		ActionTeamConfigure:    nil,
+		ActionUserManage:       nil,
+		ActionPRCreate:         nil,
+		ActionPRMerge:          nil,
+		ActionPRClose:          nil,
+		ActionPRReopen:         nil,
+		ActionPRUpdate:         nil,
+		ActionPRApprove:        nil,
+		ActionPRRequestChanges: nil,
+		ActionPRAutoMerge:      nil,
+		ActionPRSetMilestone:   nil,
+		ActionPRCheckChecklist: nil,
+		ActionMilestoneCreate:  nil,
+		ActionMilestoneUpdate:  nil,
+		ActionMilestoneDelete:  nil,
+		ActionPRReassign:       nil,
+		ActionPRDecline:        nil,
+		ActionPROverride:       lead,
+		ActionStatsView:        nil,
+		ActionRoleView:         nil,
+		ActionRoleManageMember: lead,
+		ActionRoleManage:       admin,
+		ActionOrgManage:        admin,
+		ActionTokenManage:      admin,
+		ActionAuditView:        admin,
+		ActionAdminOperate:     admin,
+	}
+}
+
+// LoadPermissions reads a YAML mapping of action to roles, e.g.
+// `pr.merge: [lead]`, on top of the defaults. Unknown actions and roles are
+// rejected so that a typo cannot silently open or close an endpoint.
+func LoadPermissions(path string) (Permissions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read permissions file: %w", err)
+	}
+
+	var overrides map[Action][]string
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPermissions, err)
+	}
+
+	perms := DefaultPermissions()
+	for action, roles := range overrides {
+		if _, ok := perms[action]; !ok {
+			return nil, fmt.Errorf("%w: unknown action %q", ErrInvalidPermissions, action)
+		}
+		for _, role := range roles {
+			if !entity.Role(role).Valid() {
+				return nil, fmt.Errorf("%w: unknown role %q for action %s", ErrInvalidPermissions, role, action)
+			}
+		}
+		perms[action] = roles
+	}
+	return perms, nil
+}
+
+// Authorizer evaluates the permission matrix; it is the only place that
+// decides which roles may perform an action.
+type Authorizer struct {
+	perms Permissions
+}
+
+func NewAuthorizer(perms Permissions) *Authorizer {
+	return &Authorizer{perms: perms}
+}
+
+// Restricted reports whether the action needs any role at all.
+func (a *Authorizer) Restricted(action Action) bool {
+	return len(a.perms[action]) > 0
+}
+
+// Roles returns the roles allowed to perform the action besides admin.
+func (a *Authorizer) Roles(action Action) []string {
+	return slices.Clone(a.perms[action])
+}
+
+// CanGrant reports whether a holder of roles may hand role on to someone
+// else, e.g. to an API token: admins may grant any role, others only their
+// own.
+func (a *Authorizer) CanGrant(roles []string, role string) bool {
+	return slices.Contains(roles, string(entity.RoleAdmin)) || slices.Contains(roles, role)
+}
+
+func (a *Authorizer) Allows(roles []string, action Action) bool {
+	if !a.Restricted(action) || slices.Contains(roles, string(entity.RoleAdmin)) {
+		return true
+	}
+	return slices.ContainsFunc(a.perms[action], func(role string) bool {
+		return slices.Contains(roles, role)
+	})
+}
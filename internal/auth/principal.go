@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MethodSession = "session"
+)
+
+type Principal struct {
+	Subject string
+	Email   string
+	Name    string
+	Roles   []string
+	// OrgID binds the principal to one organization; empty for users
+	// logged in through OIDC, who may act in any organization.
+	OrgID string
+	// UserID links the principal to a user of the service, whose granted
+	// roles then apply too; uuid.Nil when there is no such user.
+	UserID uuid.UUID
+	Method string
+}
+
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
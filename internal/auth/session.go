@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+type Session struct {
+	Token     string
+	Principal Principal
+	ExpiresAt time.Time
+}
+
+// SessionStore keeps issued session tokens in memory. Sessions do not survive
+// a restart, which only forces users to log in again.
+type SessionStore struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	sessions map[string]Session
+}
+
+func NewSessionStore(ttl time.Duration) *SessionStore {
+	return &SessionStore{
+		ttl:      ttl,
+		sessions: make(map[string]Session),
+	}
+}
+
+func (s *SessionStore) Create(p Principal) (Session, error) {
+	token, err := randomToken(32)
+	if err != nil {
+		return Session{}, err
+	}
+
+	session := Session{
+		Token:     token,
+		Principal: p,
+		ExpiresAt: time.Now().Add(s.ttl),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictExpired()
+	s.sessions[token] = session
+	return session, nil
+}
+
+func (s *SessionStore) Lookup(token string) (Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[token]
+	if !ok {
+		return Session{}, ErrInvalidSession
+	}
+	if time.Now().After(session.ExpiresAt) {
+		delete(s.sessions, token)
+		return Session{}, ErrInvalidSession
+	}
+	return session, nil
+}
+
+func (s *SessionStore) Revoke(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, token)
+}
+
+func (s *SessionStore) evictExpired() {
+	now := time.Now()
+	for token, session := range s.sessions {
+		if now.After(session.ExpiresAt) {
+			delete(s.sessions, token)
+		}
+	}
+}
+
+func randomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,239 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+type AdminController struct {
+	prUC         usecase.PullRequestUsecase
+	statsUC      usecase.StatsUsecase
+	quotaUC      usecase.QuotaUsecase
+	githubSyncUC usecase.TeamSyncUsecase
+	consistency  usecase.ConsistencyUsecase
+	idle         usecase.IdleUsecase
+	logger       *zap.Logger
+}
+
+func NewAdminController(
+	prUC usecase.PullRequestUsecase,
+	statsUC usecase.StatsUsecase,
+	quotaUC usecase.QuotaUsecase,
+	githubSyncUC usecase.TeamSyncUsecase,
+	consistency usecase.ConsistencyUsecase,
+	idle usecase.IdleUsecase,
+	logger *zap.Logger,
+) *AdminController {
+	return &AdminController{
+		prUC:         prUC,
+		statsUC:      statsUC,
+		quotaUC:      quotaUC,
+		githubSyncUC: githubSyncUC,
+		consistency:  consistency,
+		idle:         idle,
+		logger:       logger,
+	}
+}
+
+func (c *AdminController) BackfillReviewers(w http.ResponseWriter, r *http.Request) {
+	prs, err := c.prUC.BackfillReviewers(r.Context())
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to backfill reviewers", err)
+		return
+	}
+
+	prDTOs := make([]PullRequestDTO, len(prs))
+	for i, pr := range prs {
+		prDTOs[i] = PullRequestToDTO(pr)
+	}
+
+	response := struct {
+		Updated []PullRequestDTO `json:"updated"`
+	}{
+		Updated: prDTOs,
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+// Rebalance evens out the open review load of a team. With dry_run=true it
+// only reports the moves it would make.
+func (c *AdminController) Rebalance(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeFieldError(w, "team_name", "is required")
+		return
+	}
+
+	var dryRun bool
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeFieldError(w, "dry_run", "must be a boolean")
+			return
+		}
+		dryRun = parsed
+	}
+
+	result, err := c.prUC.RebalanceReviews(r.Context(), teamName, dryRun)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to rebalance reviews", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, RebalanceResultToDTO(result))
+}
+
+func (c *AdminController) GetStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := c.statsUC.GetStorageStats(r.Context())
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get storage stats", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, StorageStatsToDTO(stats))
+}
+
+func (c *AdminController) GetReviewStats(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+
+	stats, err := c.statsUC.GetReviewStats(r.Context(), teamName)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get review stats", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, ReviewStatsToDTO(stats))
+}
+
+func (c *AdminController) GetQuotas(w http.ResponseWriter, r *http.Request) {
+	usage, err := c.quotaUC.GetUsage(r.Context())
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get quota usage", err)
+		return
+	}
+
+	dtos := make([]QuotaUsageDTO, len(usage))
+	for i, u := range usage {
+		dtos[i] = QuotaUsageToDTO(u)
+	}
+
+	response := struct {
+		Quotas []QuotaUsageDTO `json:"quotas"`
+	}{
+		Quotas: dtos,
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+// CheckConsistency reports stored data of the request's organization that
+// violates cross-entity invariants. It only reads.
+func (c *AdminController) CheckConsistency(w http.ResponseWriter, r *http.Request) {
+	report, err := c.consistency.Check(r.Context())
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to check consistency", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, ConsistencyReportToDTO(report))
+}
+
+// Repair fixes anomalies of the kinds given as repeated kind query
+// parameters, or of every repairable kind. With dry_run=true it only
+// reports what it would change.
+func (c *AdminController) Repair(w http.ResponseWriter, r *http.Request) {
+	var dryRun bool
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeFieldError(w, "dry_run", "must be a boolean")
+			return
+		}
+		dryRun = parsed
+	}
+
+	var kinds []entity.AnomalyKind
+	for _, kind := range r.URL.Query()["kind"] {
+		kinds = append(kinds, entity.AnomalyKind(kind))
+	}
+
+	result, err := c.consistency.Repair(r.Context(), kinds, dryRun)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to repair inconsistencies", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, RepairResultToDTO(result))
+}
+
+// CheckIdleUsers applies the idle user policy now instead of waiting for the
+// worker. With dry_run=true it only reports what it would do.
+func (c *AdminController) CheckIdleUsers(w http.ResponseWriter, r *http.Request) {
+	if c.idle == nil {
+		c.sendError(w, http.StatusServiceUnavailable, ErrorCodeNotConfigured, "idle user policy is not configured")
+		return
+	}
+
+	var dryRun bool
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeFieldError(w, "dry_run", "must be a boolean")
+			return
+		}
+		dryRun = parsed
+	}
+
+	report, err := c.idle.Check(r.Context(), dryRun)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to check idle users", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, IdleReportToDTO(report))
+}
+
+func (c *AdminController) SyncGitHub(w http.ResponseWriter, r *http.Request) {
+	if c.githubSyncUC == nil {
+		c.sendError(w, http.StatusServiceUnavailable, ErrorCodeNotConfigured, "github sync is not configured")
+		return
+	}
+
+	result, err := c.githubSyncUC.Sync(r.Context())
+	if err != nil {
+		if errors.Is(err, usecase.ErrSyncInProgress) {
+			writeUsecaseError(w, r, c.logger, "failed to sync github org", err)
+			return
+		}
+		logging.From(r.Context(), c.logger).Error("failed to sync github org", zap.Error(err))
+		c.sendError(w, http.StatusBadGateway, ErrorCodeInvalidInput, "github sync failed")
+		return
+	}
+
+	response := struct {
+		Sync SyncResultDTO `json:"sync"`
+	}{
+		Sync: SyncResultToDTO(result),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *AdminController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *AdminController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
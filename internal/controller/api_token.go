@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"avito-intro/internal/auth"
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/tenant"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type APITokenController struct {
+	tokens  *auth.APITokenStore
+	authz   *auth.Authorizer
+	auditUC usecase.AuditUsecase
+	logger  *zap.Logger
+}
+
+func NewAPITokenController(tokens *auth.APITokenStore, authz *auth.Authorizer, auditUC usecase.AuditUsecase, logger *zap.Logger) *APITokenController {
+	return &APITokenController{
+		tokens:  tokens,
+		authz:   authz,
+		auditUC: auditUC,
+		logger:  logger,
+	}
+}
+
+type issueAPITokenRequest struct {
+	Name   string   `json:"name" required:"true"`
+	Roles  []string `json:"roles"`
+	UserID string   `json:"user_id"`
+	TTL    string   `json:"ttl"`
+}
+
+type revokeAPITokenRequest struct {
+	TokenID string `json:"token_id" required:"true"`
+}
+
+// IssueToken creates a token for the organization of the request. Callers
+// authenticated with an API token cannot grant roles they do not have.
+func (c *APITokenController) IssueToken(w http.ResponseWriter, r *http.Request) {
+	var req issueAPITokenRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		parsed, err := time.ParseDuration(req.TTL)
+		if err != nil || parsed <= 0 {
+			writeFieldError(w, "ttl", "must be a positive duration like 720h")
+			return
+		}
+		ttl = parsed
+	}
+
+	var userID uuid.UUID
+	if req.UserID != "" {
+		parsed, err := uuid.Parse(req.UserID)
+		if err != nil {
+			writeFieldError(w, "user_id", "must be a UUID")
+			return
+		}
+		userID = parsed
+	}
+
+	var createdBy string
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		createdBy = principal.Subject
+		if principal.Method == auth.MethodAPIToken {
+			for _, role := range req.Roles {
+				if !c.authz.CanGrant(principal.Roles, role) {
+					c.sendError(w, http.StatusForbidden, ErrorCodeForbidden, "cannot grant role "+role)
+					return
+				}
+			}
+		}
+	}
+
+	orgID := tenant.OrganizationFromContext(r.Context())
+	token, secret, err := c.tokens.Issue(r.Context(), orgID, req.Name, req.Roles, userID, ttl, createdBy)
+	if err != nil {
+		logging.From(r.Context(), c.logger).Error("failed to issue API token", zap.Error(err))
+		c.sendError(w, http.StatusInternalServerError, ErrorCodeInternal, "internal server error")
+		return
+	}
+
+	logging.From(r.Context(), c.logger).Info("API token issued",
+		zap.String("token_id", token.ID.String()),
+		zap.String("org_id", orgID),
+		zap.Strings("roles", token.Roles),
+	)
+	c.auditUC.Record(r.Context(), entity.AuditAPITokenIssued, entity.AuditAPIToken, token.ID.String(), map[string]string{
+		"name":  token.Name,
+		"roles": strings.Join(token.Roles, ","),
+	})
+
+	c.sendJSON(w, http.StatusCreated, map[string]interface{}{
+		"token":  APITokenToDTO(token),
+		"secret": secret,
+	})
+}
+
+func (c *APITokenController) ListTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := c.tokens.List(r.Context(), tenant.OrganizationFromContext(r.Context()))
+	if err != nil {
+		logging.From(r.Context(), c.logger).Error("failed to list API tokens", zap.Error(err))
+		c.sendError(w, http.StatusInternalServerError, ErrorCodeInternal, "internal server error")
+		return
+	}
+
+	dtos := make([]APITokenDTO, len(tokens))
+	for i, token := range tokens {
+		dtos[i] = APITokenToDTO(token)
+	}
+	c.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"tokens": dtos,
+	})
+}
+
+func (c *APITokenController) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	var req revokeAPITokenRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	tokenID, err := uuid.Parse(req.TokenID)
+	if err != nil {
+		writeFieldError(w, "token_id", "must be a UUID")
+		return
+	}
+
+	orgID := tenant.OrganizationFromContext(r.Context())
+	if err := c.tokens.Revoke(r.Context(), orgID, tokenID); err != nil {
+		if errors.Is(err, auth.ErrAPITokenNotFound) {
+			c.sendError(w, http.StatusNotFound, ErrorCodeNotFound, "token not found")
+			return
+		}
+		logging.From(r.Context(), c.logger).Error("failed to revoke API token", zap.Error(err))
+		c.sendError(w, http.StatusInternalServerError, ErrorCodeInternal, "internal server error")
+		return
+	}
+
+	logging.From(r.Context(), c.logger).Info("API token revoked",
+		zap.String("token_id", tokenID.String()),
+		zap.String("org_id", orgID),
+	)
+	c.auditUC.Record(r.Context(), entity.AuditAPITokenRevoked, entity.AuditAPIToken, tokenID.String(), nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *APITokenController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *APITokenController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+type AuditController struct {
+	auditUC usecase.AuditUsecase
+	logger  *zap.Logger
+}
+
+func NewAuditController(auditUC usecase.AuditUsecase, logger *zap.Logger) *AuditController {
+	return &AuditController{
+		auditUC: auditUC,
+		logger:  logger,
+	}
+}
+
+// ListEntries returns audit entries newest first. All filters are optional
+// and combined with AND; from is inclusive and to is exclusive.
+func (c *AuditController) ListEntries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := entity.AuditFilter{
+		Actor:      query.Get("actor"),
+		Action:     query.Get("action"),
+		EntityType: entity.AuditEntityType(query.Get("entity_type")),
+		EntityID:   query.Get("entity_id"),
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(query, "from"); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if filter.To, err = parseTimeParam(query, "to"); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	page, pageSize, err := parsePageParams(query)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	filter.Offset = (page - 1) * pageSize
+	filter.Limit = pageSize
+
+	entries, total, err := c.auditUC.ListEntries(r.Context(), filter)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to list audit entries", err)
+		return
+	}
+
+	dtos := make([]AuditEntryDTO, len(entries))
+	for i, entry := range entries {
+		dtos[i] = AuditEntryToDTO(entry)
+	}
+
+	response := struct {
+		Entries  []AuditEntryDTO `json:"entries"`
+		Page     int             `json:"page"`
+		PageSize int             `json:"page_size"`
+		Total    int             `json:"total"`
+	}{
+		Entries:  dtos,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *AuditController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *AuditController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
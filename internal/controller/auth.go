@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"avito-intro/internal/auth"
+	"avito-intro/internal/logging"
+
+	"go.uber.org/zap"
+)
+
+const stateCookieName = "pr_reviewer_oidc_state"
+
+type AuthController struct {
+	provider *auth.OIDCProvider
+	sessions *auth.SessionStore
+	logger   *zap.Logger
+}
+
+func NewAuthController(provider *auth.OIDCProvider, sessions *auth.SessionStore, logger *zap.Logger) *AuthController {
+	return &AuthController{
+		provider: provider,
+		sessions: sessions,
+		logger:   logger,
+	}
+}
+
+func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
+	authURL, state, err := c.provider.AuthCodeURL(r.Context())
+	if err != nil {
+		logging.From(r.Context(), c.logger).Error("failed to start oidc login", zap.Error(err))
+		c.sendError(w, http.StatusBadGateway, ErrorCodeUnauthorized, "identity provider unavailable")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookieName,
+		Value:    state,
+		Path:     "/auth",
+		MaxAge:   int((10 * time.Minute).Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+func (c *AuthController) Callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if errCode := query.Get("error"); errCode != "" {
+		c.sendError(w, http.StatusUnauthorized, ErrorCodeUnauthorized, "login rejected by identity provider: "+errCode)
+		return
+	}
+
+	state := query.Get("state")
+	stateCookie, err := r.Cookie(stateCookieName)
+	if err != nil || state == "" || stateCookie.Value != state {
+		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "login state mismatch")
+		return
+	}
+
+	principal, err := c.provider.Exchange(r.Context(), state, query.Get("code"))
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidState) || errors.Is(err, auth.ErrInvalidToken) {
+			logging.From(r.Context(), c.logger).Warn("oidc login rejected", zap.Error(err))
+			c.sendError(w, http.StatusUnauthorized, ErrorCodeUnauthorized, "login failed")
+			return
+		}
+		logging.From(r.Context(), c.logger).Error("failed to complete oidc login", zap.Error(err))
+		c.sendError(w, http.StatusBadGateway, ErrorCodeUnauthorized, "identity provider unavailable")
+		return
+	}
+
+	session, err := c.sessions.Create(principal)
+	if err != nil {
+		logging.From(r.Context(), c.logger).Error("failed to create session", zap.Error(err))
+		c.sendError(w, http.StatusInternalServerError, ErrorCodeInternal, "internal server error")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: stateCookieName, Path: "/auth", MaxAge: -1})
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    session.Token,
+		Path:     "/",
+		Expires:  session.ExpiresAt,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	response := struct {
+		Token     string `json:"token"`
+		ExpiresAt string `json:"expires_at"`
+		Subject   string `json:"subject"`
+	}{
+		Token:     session.Token,
+		ExpiresAt: session.ExpiresAt.Format(time.RFC3339),
+		Subject:   principal.Subject,
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *AuthController) Logout(w http.ResponseWriter, r *http.Request) {
+	if token := requestToken(r); token != "" {
+		c.sessions.Revoke(token)
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Path: "/", MaxAge: -1})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *AuthController) Me(w http.ResponseWriter, r *http.Request) {
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		c.sendError(w, http.StatusUnauthorized, ErrorCodeUnauthorized, "authentication required")
+		return
+	}
+
+	response := struct {
+		Subject string   `json:"subject"`
+		Email   string   `json:"email,omitempty"`
+		Name    string   `json:"name,omitempty"`
+		Roles   []string `json:"roles"`
+		Method  string   `json:"method"`
+	}{
+		Subject: principal.Subject,
+		Email:   principal.Email,
+		Name:    principal.Name,
+		Roles:   principal.Roles,
+		Method:  principal.Method,
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *AuthController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *AuthController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
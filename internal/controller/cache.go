@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// CacheHeaders sets Cache-Control on every response the handler did not set
+// it on itself: successful GET and HEAD reads get policy (and Vary on the
+// organization header, since the same URL serves every tenant), everything
+// else — writes and errors — is marked no-store so no intermediary keeps it.
+// An empty policy leaves successful reads without the header.
+func CacheHeaders(policy string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		read := r.Method == http.MethodGet || r.Method == http.MethodHead
+		next.ServeHTTP(&cacheWriter{ResponseWriter: w, read: read, policy: policy}, r)
+	})
+}
+
+type cacheWriter struct {
+	http.ResponseWriter
+	read        bool
+	policy      string
+	wroteHeader bool
+}
+
+func (w *cacheWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.setCacheControl(status)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *cacheWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *cacheWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *cacheWriter) setCacheControl(status int) {
+	header := w.Header()
+	if header.Get("Cache-Control") != "" {
+		return
+	}
+	if !w.read || (status != http.StatusOK && status != http.StatusNotModified) {
+		header.Set("Cache-Control", "no-store")
+		return
+	}
+	if w.policy != "" {
+		header.Set("Cache-Control", w.policy)
+		header.Add("Vary", organizationHeader)
+	}
+}
+
+// HandleHEAD answers HEAD requests with exactly the headers the matching GET
+// would produce, including Content-Length, which net/http cannot compute on
+// its own once the body is discarded. The mux already routes HEAD to GET
+// patterns; handlers never see the difference.
+func HandleHEAD(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		hw := &headWriter{ResponseWriter: w}
+		next.ServeHTTP(hw, r)
+
+		status := hw.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if w.Header().Get("Content-Length") == "" && bodyAllowed(status) {
+			w.Header().Set("Content-Length", strconv.Itoa(hw.size))
+		}
+		w.WriteHeader(status)
+	})
+}
+
+// headWriter holds the status back until the handler returns and counts the
+// body instead of sending it.
+type headWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *headWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *headWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.size += len(b)
+	return len(b), nil
+}
+
+func (w *headWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
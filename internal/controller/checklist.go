@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ChecklistController struct {
+	checklistUC  usecase.ChecklistUsecase
+	authRequired bool
+	logger       *zap.Logger
+}
+
+func NewChecklistController(checklistUC usecase.ChecklistUsecase, authRequired bool, logger *zap.Logger) *ChecklistController {
+	return &ChecklistController{
+		checklistUC:  checklistUC,
+		authRequired: authRequired,
+		logger:       logger,
+	}
+}
+
+func (c *ChecklistController) SetTemplate(w http.ResponseWriter, r *http.Request) {
+	var req ChecklistTemplateDTO
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	items := make([]string, 0, len(req.Items))
+	for i, item := range req.Items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			writeFieldError(w, fmt.Sprintf("items[%d]", i), "must not be empty")
+			return
+		}
+		items = append(items, item)
+	}
+
+	template, err := c.checklistUC.SetTemplate(r.Context(), entity.ChecklistTemplate{
+		TeamName:         req.TeamName,
+		Items:            items,
+		RequiredForMerge: req.RequiredForMerge,
+	})
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to set checklist template", err)
+		return
+	}
+
+	response := struct {
+		Checklist ChecklistTemplateDTO `json:"checklist"`
+	}{
+		Checklist: ChecklistTemplateToDTO(template),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *ChecklistController) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeFieldError(w, "team_name", "is required")
+		return
+	}
+
+	template, err := c.checklistUC.GetTemplate(r.Context(), teamName)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get checklist template", err)
+		return
+	}
+
+	response := struct {
+		Checklist ChecklistTemplateDTO `json:"checklist"`
+	}{
+		Checklist: ChecklistTemplateToDTO(template),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+// checkItemRequest names the acting user in user_id only when the request
+// is not authenticated; see actingUser.
+type checkItemRequest struct {
+	PullRequestID string `json:"pull_request_id" required:"true"`
+	ItemID        int    `json:"item_id" required:"true"`
+	UserID        string `json:"user_id"`
+	Checked       *bool  `json:"checked"`
+}
+
+func (c *ChecklistController) CheckItem(w http.ResponseWriter, r *http.Request) {
+	var req checkItemRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prID, err := uuid.Parse(req.PullRequestID)
+	if err != nil {
+		writeFieldError(w, "pull_request_id", "must be a UUID")
+		return
+	}
+
+	userID, ok := actingUser(w, r, req.UserID, c.authRequired)
+	if !ok {
+		return
+	}
+
+	checked := true
+	if req.Checked != nil {
+		checked = *req.Checked
+	}
+
+	pr, err := c.checklistUC.CheckItem(r.Context(), prID, req.ItemID, userID, checked)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to update checklist item", err)
+		return
+	}
+
+	response := struct {
+		PR PullRequestDTO `json:"pr"`
+	}{
+		PR: PullRequestToDTO(pr),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *ChecklistController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *ChecklistController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"time"
 
+	"avito-intro/internal/auth"
 	"avito-intro/internal/entity"
 
 	"github.com/google/uuid"
@@ -10,18 +11,21 @@ import (
 
 func UserToDTO(user entity.User) UserDTO {
 	return UserDTO{
-		UserID:   user.UserID.String(),
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		UserID:       user.UserID.String(),
+		Username:     user.Username,
+		TeamName:     user.TeamName,
+		IsActive:     user.IsActive,
+		SnoozedUntil: formatTimePtr(user.SnoozedUntil),
+		DeletedAt:    formatTimePtr(user.DeletedAt),
 	}
 }
 
 func TeamMemberToDTO(user entity.User) TeamMemberDTO {
 	return TeamMemberDTO{
-		UserID:   user.UserID.String(),
-		Username: user.Username,
-		IsActive: user.IsActive,
+		UserID:    user.UserID.String(),
+		Username:  user.Username,
+		IsActive:  user.IsActive,
+		DeletedAt: formatTimePtr(user.DeletedAt),
 	}
 }
 
@@ -37,10 +41,32 @@ func TeamToDTO(team entity.Team, members []entity.User) TeamDTO {
 	}
 }
 
+func TeamSummaryToDTO(summary entity.TeamSummary) TeamSummaryDTO {
+	return TeamSummaryDTO{
+		TeamName:         summary.TeamName,
+		MembersCount:     summary.Members,
+		OpenPullRequests: summary.OpenPullRequests,
+	}
+}
+
 func PullRequestToDTO(pr entity.PullRequest) PullRequestDTO {
 	reviewerIDs := make([]string, len(pr.AssignedReviewers))
+	reviewers := make([]ReviewerDTO, len(pr.AssignedReviewers))
 	for i, id := range pr.AssignedReviewers {
 		reviewerIDs[i] = id.String()
+		reviewers[i] = ReviewerDTO{
+			UserID: id.String(),
+			Slot:   string(pr.SlotOf(id)),
+		}
+		if at, ok := pr.FirstResponses[id]; ok {
+			reviewers[i].FirstResponseAt = formatTimePtr(&at)
+		}
+		for _, approval := range pr.Approvals {
+			if approval.UserID == id {
+				reviewers[i].Approved = true
+				reviewers[i].ApprovedAt = formatTimePtr(&approval.ApprovedAt)
+			}
+		}
 	}
 
 	return PullRequestDTO{
@@ -49,8 +75,110 @@ func PullRequestToDTO(pr entity.PullRequest) PullRequestDTO {
 		AuthorID:          pr.AuthorID.String(),
 		Status:            string(pr.Status),
 		AssignedReviewers: reviewerIDs,
+		Reviewers:         reviewers,
 		CreatedAt:         formatTimePtr(&pr.CreatedAt),
 		MergedAt:          formatTimePtr(pr.MergedAt),
+		ClosedAt:          formatTimePtr(pr.ClosedAt),
+		MilestoneID:       formatUUIDPtr(pr.MilestoneID),
+		Checklist:         checklistToDTO(pr.Checklist),
+		Override:          overrideToDTO(pr.Override),
+		ChangesRequested:  changeRequestToDTO(pr.ChangesRequested),
+		Declines:          declinesToDTO(pr.Declines),
+		AutoMerge:         pr.AutoMerge,
+		Iteration:         pr.ReviewIterations(),
+		ExternalID:        pr.ExternalID,
+		ChangedFiles:      pr.ChangedFiles,
+	}
+}
+
+func overrideToDTO(override *entity.ApprovalOverride) *OverrideDTO {
+	if override == nil {
+		return nil
+	}
+	return &OverrideDTO{
+		UserID:       override.UserID.String(),
+		Reason:       override.Reason,
+		OverriddenAt: override.OverriddenAt.Format(time.RFC3339),
+	}
+}
+
+func declinesToDTO(declines []entity.ReviewerDecline) []DeclineDTO {
+	if len(declines) == 0 {
+		return nil
+	}
+	dtos := make([]DeclineDTO, len(declines))
+	for i, decline := range declines {
+		dtos[i] = DeclineDTO{
+			UserID:     decline.UserID.String(),
+			ReplacedBy: decline.ReplacedBy.String(),
+			Reason:     decline.Reason,
+			DeclinedAt: decline.DeclinedAt.Format(time.RFC3339),
+		}
+	}
+	return dtos
+}
+
+func changeRequestToDTO(request *entity.ChangeRequest) *ChangeRequestDTO {
+	if request == nil {
+		return nil
+	}
+	return &ChangeRequestDTO{
+		UserID:      request.UserID.String(),
+		RequestedAt: request.RequestedAt.Format(time.RFC3339),
+	}
+}
+
+func checklistToDTO(checklist entity.Checklist) *ChecklistDTO {
+	if len(checklist.Items) == 0 {
+		return nil
+	}
+
+	items := make([]ChecklistItemDTO, len(checklist.Items))
+	for i, item := range checklist.Items {
+		items[i] = ChecklistItemDTO{
+			ItemID:    item.ItemID,
+			Text:      item.Text,
+			Checked:   item.Checked,
+			CheckedBy: formatUUIDPtr(item.CheckedBy),
+			CheckedAt: formatTimePtr(item.CheckedAt),
+		}
+	}
+
+	return &ChecklistDTO{
+		RequiredForMerge: checklist.RequiredForMerge,
+		Items:            items,
+	}
+}
+
+func ChecklistTemplateToDTO(template entity.ChecklistTemplate) ChecklistTemplateDTO {
+	items := template.Items
+	if items == nil {
+		items = []string{}
+	}
+	return ChecklistTemplateDTO{
+		TeamName:         template.TeamName,
+		Items:            items,
+		RequiredForMerge: template.RequiredForMerge,
+	}
+}
+
+func MilestoneToDTO(milestone entity.Milestone) MilestoneDTO {
+	return MilestoneDTO{
+		MilestoneID: milestone.MilestoneID.String(),
+		Title:       milestone.Title,
+		Description: milestone.Description,
+		DueDate:     formatTimePtr(milestone.DueDate),
+		CreatedAt:   formatTimePtr(&milestone.CreatedAt),
+	}
+}
+
+func MilestoneStatsToDTO(stats entity.MilestoneStats) MilestoneStatsDTO {
+	return MilestoneStatsDTO{
+		MilestoneID: stats.MilestoneID.String(),
+		Total:       stats.Total,
+		Open:        stats.Open,
+		Merged:      stats.Merged,
+		Closed:      stats.Closed,
 	}
 }
 
@@ -63,6 +191,216 @@ func PullRequestToShortDTO(pr entity.PullRequest) PullRequestShortDTO {
 	}
 }
 
+func StorageStatsToDTO(stats entity.StorageStats) StorageStatsDTO {
+	prCounts := map[string]int{
+		string(entity.StatusOpen):             0,
+		string(entity.StatusMerged):           0,
+		string(entity.StatusClosed):           0,
+		string(entity.StatusChangesRequested): 0,
+	}
+	for status, count := range stats.PullRequests {
+		prCounts[string(status)] = count
+	}
+
+	indexes := make([]IndexStatsDTO, len(stats.Indexes))
+	for i, idx := range stats.Indexes {
+		indexes[i] = IndexStatsDTO{
+			Name:           idx.Name,
+			Entries:        idx.Entries,
+			EstimatedBytes: idx.EstimatedBytes,
+		}
+	}
+
+	return StorageStatsDTO{
+		Backend:        stats.Backend,
+		Users:          stats.Users,
+		ActiveUsers:    stats.ActiveUsers,
+		Teams:          stats.Teams,
+		PullRequests:   prCounts,
+		EstimatedBytes: stats.EstimatedBytes,
+		Indexes:        indexes,
+	}
+}
+
+func ReviewStatsToDTO(stats entity.ReviewStats) ReviewStatsDTO {
+	reviewers := make([]ReviewerResponseDTO, len(stats.Reviewers))
+	for i, r := range stats.Reviewers {
+		reviewers[i] = ReviewerResponseDTO{
+			UserID:                  r.UserID.String(),
+			TeamName:                r.TeamName,
+			FirstResponses:          r.Responses,
+			AvgFirstResponseSeconds: r.Avg().Seconds(),
+		}
+	}
+
+	teams := make([]TeamResponseDTO, len(stats.Teams))
+	for i, t := range stats.Teams {
+		teams[i] = TeamResponseDTO{
+			TeamName:                t.TeamName,
+			FirstResponses:          t.Responses,
+			AvgFirstResponseSeconds: t.Avg().Seconds(),
+		}
+	}
+
+	return ReviewStatsDTO{
+		TeamName:                stats.TeamName,
+		PullRequests:            stats.PullRequests,
+		AvgIterations:           stats.AvgIterations(),
+		MaxIterations:           stats.MaxIterations,
+		MultiIterationPRs:       stats.MultiIterationPRs,
+		OverriddenPRs:           stats.OverriddenPRs,
+		FirstResponses:          stats.FirstResponse.Responses,
+		AvgFirstResponseSeconds: stats.FirstResponse.Avg().Seconds(),
+		Reviewers:               reviewers,
+		Teams:                   teams,
+	}
+}
+
+func ConsistencyReportToDTO(report entity.ConsistencyReport) ConsistencyReportDTO {
+	return ConsistencyReportDTO{
+		OrgID:        report.OrgID,
+		CheckedAt:    report.CheckedAt.Format(time.RFC3339),
+		Teams:        report.Teams,
+		Users:        report.Users,
+		PullRequests: report.PullRequests,
+		Anomalies:    AnomaliesToDTO(report.Anomalies),
+	}
+}
+
+func IdleReportToDTO(report entity.IdleReport) IdleReportDTO {
+	users := make([]IdleUserDTO, len(report.Users))
+	for i, user := range report.Users {
+		users[i] = IdleUserDTO{
+			UserID:       user.UserID.String(),
+			TeamName:     user.TeamName,
+			LastActiveAt: formatTimePtr(user.LastActiveAt),
+			Action:       string(user.Action),
+		}
+	}
+	return IdleReportDTO{
+		OrgID:     report.OrgID,
+		CheckedAt: report.CheckedAt.Format(time.RFC3339),
+		DryRun:    report.DryRun,
+		Users:     users,
+	}
+}
+
+func AnomaliesToDTO(anomalies []entity.Anomaly) []AnomalyDTO {
+	dtos := make([]AnomalyDTO, len(anomalies))
+	for i, anomaly := range anomalies {
+		dtos[i] = AnomalyToDTO(anomaly)
+	}
+	return dtos
+}
+
+func AnomalyToDTO(anomaly entity.Anomaly) AnomalyDTO {
+	dto := AnomalyDTO{
+		Kind:     string(anomaly.Kind),
+		TeamName: anomaly.TeamName,
+		UserID:   anomaly.UserID.String(),
+		Detail:   anomaly.Detail,
+	}
+	if anomaly.PullRequestID != uuid.Nil {
+		dto.PullRequestID = anomaly.PullRequestID.String()
+	}
+	return dto
+}
+
+func RepairResultToDTO(result entity.RepairResult) RepairResultDTO {
+	kinds := make([]string, len(result.Kinds))
+	for i, kind := range result.Kinds {
+		kinds[i] = string(kind)
+	}
+
+	repaired := make([]RepairActionDTO, len(result.Repaired))
+	for i, action := range result.Repaired {
+		repaired[i] = RepairActionDTO{AnomalyDTO: AnomalyToDTO(action.Anomaly)}
+		if action.ReplacementID != uuid.Nil {
+			repaired[i].ReplacementID = action.ReplacementID.String()
+		}
+	}
+
+	return RepairResultDTO{
+		OrgID:      result.OrgID,
+		DryRun:     result.DryRun,
+		Kinds:      kinds,
+		Repaired:   repaired,
+		Unresolved: AnomaliesToDTO(result.Unresolved),
+	}
+}
+
+func SyncResultToDTO(result entity.SyncResult) SyncResultDTO {
+	return SyncResultDTO{
+		Source:       result.Source,
+		TeamsCreated: result.TeamsCreated,
+		TeamsUpdated: result.TeamsUpdated,
+		Members:      result.Members,
+		StartedAt:    result.StartedAt.Format(time.RFC3339),
+		FinishedAt:   result.FinishedAt.Format(time.RFC3339),
+	}
+}
+
+func BackupResultToDTO(result entity.BackupResult) BackupResultDTO {
+	return BackupResultDTO{
+		OrgID:        result.OrgID,
+		Location:     result.Location,
+		CreatedAt:    result.CreatedAt.Format(time.RFC3339),
+		Teams:        result.Teams,
+		Users:        result.Users,
+		PullRequests: result.PullRequests,
+	}
+}
+
+func RebalanceResultToDTO(result entity.RebalanceResult) RebalanceResultDTO {
+	loads := func(load map[uuid.UUID]int) map[string]int {
+		out := make(map[string]int, len(load))
+		for userID, n := range load {
+			out[userID.String()] = n
+		}
+		return out
+	}
+
+	return RebalanceResultDTO{
+		TeamName:   result.TeamName,
+		DryRun:     result.DryRun,
+		Moves:      ReviewerMovesToDTO(result.Moves),
+		LoadBefore: loads(result.LoadBefore),
+		LoadAfter:  loads(result.LoadAfter),
+	}
+}
+
+func ReviewerMovesToDTO(moves []entity.ReviewerMove) []ReviewerMoveDTO {
+	dtos := make([]ReviewerMoveDTO, len(moves))
+	for i, move := range moves {
+		dtos[i] = ReviewerMoveDTO{
+			PullRequestID: move.PullRequestID.String(),
+			FromUserID:    move.FromUserID.String(),
+			ToUserID:      move.ToUserID.String(),
+		}
+	}
+	return dtos
+}
+
+func ReviewTransferToDTO(transfer entity.ReviewTransfer) ReviewTransferDTO {
+	skipped := make([]SkippedTransferDTO, len(transfer.Skipped))
+	for i, skip := range transfer.Skipped {
+		skipped[i] = SkippedTransferDTO{
+			PullRequestID: skip.PullRequestID.String(),
+			Reason:        skip.Reason,
+		}
+	}
+
+	dto := ReviewTransferDTO{
+		FromUserID: transfer.FromUserID.String(),
+		Moves:      ReviewerMovesToDTO(transfer.Moves),
+		Skipped:    skipped,
+	}
+	if transfer.ToUserID != uuid.Nil {
+		dto.ToUserID = transfer.ToUserID.String()
+	}
+	return dto
+}
+
 func TeamMemberDTOToEntity(dto TeamMemberDTO, teamName string) (entity.User, error) {
 	userID, err := uuid.Parse(dto.UserID)
 	if err != nil {
@@ -84,3 +422,116 @@ func formatTimePtr(t *time.Time) *string {
 	s := t.Format(time.RFC3339)
 	return &s
 }
+
+func formatUUIDPtr(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func OrganizationToDTO(org entity.Organization) OrganizationDTO {
+	return OrganizationDTO{
+		OrgID:     org.OrgID,
+		Name:      org.Name,
+		CreatedAt: org.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func APITokenToDTO(token auth.APIToken) APITokenDTO {
+	roles := token.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	var userID *string
+	if token.UserID != uuid.Nil {
+		userID = formatUUIDPtr(&token.UserID)
+	}
+	return APITokenDTO{
+		TokenID:   token.ID.String(),
+		OrgID:     token.OrgID,
+		Name:      token.Name,
+		Roles:     roles,
+		UserID:    userID,
+		CreatedBy: token.CreatedBy,
+		CreatedAt: token.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: formatTimePtr(token.ExpiresAt),
+	}
+}
+
+func QuotaUsageToDTO(usage entity.QuotaUsage) QuotaUsageDTO {
+	return QuotaUsageDTO{
+		Resource: string(usage.Resource),
+		TeamName: usage.TeamName,
+		Used:     usage.Used,
+		Limit:    usage.Limit,
+	}
+}
+
+func MergePolicyToDTO(policy entity.MergePolicy) MergePolicyDTO {
+	return MergePolicyDTO{
+		TeamName:          policy.TeamName,
+		MinApprovals:      policy.MinApprovals,
+		ChecklistComplete: policy.ChecklistComplete,
+		NotOverdue:        policy.NotOverdue,
+	}
+}
+
+func TeamSettingsToDTO(settings entity.TeamSettings) TeamSettingsDTO {
+	dto := TeamSettingsDTO{
+		TeamName:           settings.TeamName,
+		RequiredReviewers:  settings.RequiredReviewers,
+		OptionalReviewers:  settings.OptionalReviewers,
+		AssignmentStrategy: settings.AssignmentStrategy,
+		MergeApprovals:     settings.MergeApprovals,
+		Timezone:           settings.Timezone,
+		NamePattern:        settings.NamePattern,
+	}
+	if settings.SLA != nil {
+		sla := settings.SLA.String()
+		dto.SLA = &sla
+	}
+	return dto
+}
+
+func GlobalSettingsToDTO(settings entity.GlobalSettings) GlobalSettingsDTO {
+	dto := GlobalSettingsDTO{
+		RequiredReviewers: settings.RequiredReviewers,
+		OptionalReviewers: settings.OptionalReviewers,
+	}
+	if settings.SLA != nil {
+		sla := settings.SLA.String()
+		dto.SLA = &sla
+	}
+	if len(settings.Features) > 0 {
+		dto.Features = make(map[string]bool, len(settings.Features))
+		for feature, enabled := range settings.Features {
+			dto.Features[string(feature)] = enabled
+		}
+	}
+	return dto
+}
+
+func AuditEntryToDTO(entry entity.AuditEntry) AuditEntryDTO {
+	return AuditEntryDTO{
+		EntryID:    entry.ID.String(),
+		OccurredAt: entry.OccurredAt.Format(time.RFC3339),
+		Actor:      entry.Actor,
+		Action:     entry.Action,
+		EntityType: string(entry.EntityType),
+		EntityID:   entry.EntityID,
+		Details:    entry.Details,
+	}
+}
+
+func StorageEventToDTO(event entity.StorageEvent) StorageEventDTO {
+	return StorageEventDTO{
+		Seq:        event.Seq,
+		OccurredAt: event.OccurredAt.Format(time.RFC3339Nano),
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Operation:  string(event.Operation),
+		Payload:    event.Payload,
+	}
+}
@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"cmp"
+	_ "embed"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/tenant"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	//go:embed web/dashboard.html
+	dashboardHTML string
+	//go:embed web/style.css
+	pageCSS string
+
+	dashboardTemplate = template.Must(template.New("dashboard").Parse(dashboardHTML))
+)
+
+const dashboardPath = "/admin"
+
+// DashboardController renders a server-side admin page on top of the same
+// usecases as the JSON API. Reassignment is a plain form post; the session
+// cookie is SameSite=Lax, so cross-site forms do not carry credentials.
+type DashboardController struct {
+	userUC usecase.UserUsecase
+	prUC   usecase.PullRequestUsecase
+	sla    time.Duration
+	logger *zap.Logger
+}
+
+func NewDashboardController(userUC usecase.UserUsecase, prUC usecase.PullRequestUsecase, sla time.Duration, logger *zap.Logger) *DashboardController {
+	return &DashboardController{
+		userUC: userUC,
+		prUC:   prUC,
+		sla:    sla,
+		logger: logger,
+	}
+}
+
+type dashboardPage struct {
+	OrgID       string
+	SLA         time.Duration
+	Notice      string
+	Error       string
+	ReassignURL string
+	Style       template.CSS
+	Teams       []dashboardTeam
+	Overdue     []dashboardPR
+}
+
+type dashboardTeam struct {
+	Name    string
+	Members []dashboardMember
+}
+
+type dashboardMember struct {
+	UserID      string
+	Username    string
+	IsActive    bool
+	OpenReviews int
+}
+
+type dashboardPR struct {
+	ID        string
+	Name      string
+	Author    string
+	CreatedAt string
+	Reviewers []dashboardMember
+}
+
+func (c *DashboardController) Show(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	users, _, err := c.userUC.ListUsers(ctx, entity.UserFilter{})
+	if err != nil {
+		c.renderError(w, r, "failed to list users", err)
+		return
+	}
+
+	userIDs := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		userIDs[i] = user.UserID
+	}
+	openReviews, err := c.prUC.GetOpenReviewCounts(ctx, userIDs)
+	if err != nil {
+		c.renderError(w, r, "failed to get open review counts", err)
+		return
+	}
+
+	// A zero threshold applies the SLA of each PR author's team.
+	overdue, err := c.prUC.GetOverduePRs(ctx, 0)
+	if err != nil {
+		c.renderError(w, r, "failed to get overdue PRs", err)
+		return
+	}
+
+	members := make(map[uuid.UUID]dashboardMember, len(users))
+	teams := make(map[string]*dashboardTeam)
+	for _, user := range users {
+		member := dashboardMember{
+			UserID:      user.UserID.String(),
+			Username:    user.Username,
+			IsActive:    user.IsActive,
+			OpenReviews: openReviews[user.UserID],
+		}
+		members[user.UserID] = member
+
+		team := teams[user.TeamName]
+		if team == nil {
+			team = &dashboardTeam{Name: user.TeamName}
+			teams[user.TeamName] = team
+		}
+		team.Members = append(team.Members, member)
+	}
+
+	page := dashboardPage{
+		OrgID:       tenant.OrganizationFromContext(ctx),
+		SLA:         c.sla,
+		Notice:      r.URL.Query().Get("notice"),
+		Error:       r.URL.Query().Get("error"),
+		ReassignURL: c.pageURL(r, dashboardPath+"/reassign", nil),
+		Style:       template.CSS(pageCSS),
+	}
+	for _, team := range teams {
+		page.Teams = append(page.Teams, *team)
+	}
+	slices.SortFunc(page.Teams, func(a, b dashboardTeam) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	for _, pr := range overdue {
+		item := dashboardPR{
+			ID:        pr.PullRequestID.String(),
+			Name:      pr.PullRequestName,
+			Author:    c.username(members, pr.AuthorID),
+			CreatedAt: pr.CreatedAt.Format(time.RFC3339),
+		}
+		for _, id := range pr.AssignedReviewers {
+			item.Reviewers = append(item.Reviewers, dashboardMember{
+				UserID:   id.String(),
+				Username: c.username(members, id),
+			})
+		}
+		page.Overdue = append(page.Overdue, item)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := dashboardTemplate.Execute(w, page); err != nil {
+		logging.From(ctx, c.logger).Error("failed to render dashboard", zap.Error(err))
+	}
+}
+
+// Reassign handles the dashboard's reassign button and redirects back to
+// the dashboard with the outcome.
+func (c *DashboardController) Reassign(w http.ResponseWriter, r *http.Request) {
+	prID, err := uuid.Parse(r.FormValue("pull_request_id"))
+	if err != nil {
+		c.redirect(w, r, "error", "invalid pull_request_id")
+		return
+	}
+	oldReviewerID, err := uuid.Parse(r.FormValue("old_user_id"))
+	if err != nil {
+		c.redirect(w, r, "error", "invalid old_user_id")
+		return
+	}
+
+	_, newReviewerID, err := c.prUC.ReassignReviewer(r.Context(), prID, oldReviewerID)
+	var ucErr *usecase.Error
+	switch {
+	case err == nil:
+		c.redirect(w, r, "notice", "reviewer "+oldReviewerID.String()+" replaced by "+newReviewerID.String())
+	case errors.As(err, &ucErr):
+		c.redirect(w, r, "error", ucErr.Message)
+	default:
+		logging.From(r.Context(), c.logger).Error("failed to reassign reviewer", zap.Error(err))
+		c.redirect(w, r, "error", "internal server error")
+	}
+}
+
+func (c *DashboardController) username(members map[uuid.UUID]dashboardMember, id uuid.UUID) string {
+	if member, ok := members[id]; ok {
+		return member.Username
+	}
+	return id.String()
+}
+
+// pageURL keeps the org_id query parameter, so the dashboard stays in the
+// organization it was opened for.
+func (c *DashboardController) pageURL(r *http.Request, path string, query url.Values) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	if orgID := r.URL.Query().Get("org_id"); orgID != "" {
+		query.Set("org_id", orgID)
+	}
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+func (c *DashboardController) redirect(w http.ResponseWriter, r *http.Request, kind, message string) {
+	http.Redirect(w, r, c.pageURL(r, dashboardPath, url.Values{kind: {message}}), http.StatusSeeOther)
+}
+
+func (c *DashboardController) renderError(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	writeUsecaseError(w, r, c.logger, msg, err)
+}
@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// maxRequestBodySize bounds JSON request bodies; team imports have their
+// own, larger limit.
+const maxRequestBodySize = 1 << 20
+
+// bodyError is a request decodeJSON or one of the query parsers rejected.
+// field is the path of the body field or the name of the query parameter
+// at fault, such as members[1].user_id, and empty when the body as a whole
+// is unusable.
+type bodyError struct {
+	code    ErrorCode
+	field   string
+	reason  string
+	message string
+}
+
+func (e *bodyError) Error() string {
+	return e.message
+}
+
+func malformedBody(message string) *bodyError {
+	return &bodyError{code: ErrorCodeMalformedJSON, message: message}
+}
+
+func invalidField(field, reason string) *bodyError {
+	return &bodyError{code: ErrorCodeValidationFailed, field: field, reason: reason, message: field + " " + reason}
+}
+
+// writeDecodeError reports an error returned by decodeJSON or a query
+// parser, naming the field at fault when there is one.
+func writeDecodeError(w http.ResponseWriter, err error) {
+	var bodyErr *bodyError
+	if !errors.As(err, &bodyErr) {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+		return
+	}
+	var fields []FieldErrorDTO
+	if bodyErr.field != "" {
+		fields = []FieldErrorDTO{{Field: bodyErr.field, Reason: bodyErr.reason}}
+	}
+	writeErrorDetails(w, http.StatusBadRequest, bodyErr.code, bodyErr.message, nil, fields)
+}
+
+// decodeJSON strictly decodes the request body into dst: the body must be a
+// single JSON value, may not carry fields dst does not declare, and must set
+// every field tagged `required:"true"` — to a non-null value and, for
+// strings, to a non-empty one. Required fields of nested structs and of
+// struct slices are checked too, so a missing members[1].user_id is reported
+// as such. The returned error is meant for the client; report it with
+// writeDecodeError.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return &bodyError{code: ErrorCodeInvalidInput, message: fmt.Sprintf("request body must not exceed %d bytes", tooLarge.Limit)}
+		}
+		return &bodyError{code: ErrorCodeInvalidInput, message: "failed to read request body"}
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return malformedBody("request body is empty")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return malformedBody("request body must contain a single JSON object")
+	}
+
+	return checkRequired(body, reflect.TypeOf(dst).Elem(), "")
+}
+
+func decodeError(err error) *bodyError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return malformedBody(fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset))
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return malformedBody("malformed JSON: unexpected end of body")
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return malformedBody(fmt.Sprintf("request body must be a JSON %s", jsonKind(typeErr.Type)))
+		}
+		return invalidField(typeErr.Field, "must be a "+jsonKind(typeErr.Type))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		name := strings.TrimPrefix(err.Error(), "json: unknown field ")
+		return &bodyError{
+			code:    ErrorCodeValidationFailed,
+			field:   strings.Trim(name, `"`),
+			reason:  "is not a known field",
+			message: "unknown field " + name,
+		}
+	default:
+		return malformedBody("invalid request body")
+	}
+}
+
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// checkRequired walks the raw JSON alongside t. It runs after a successful
+// strict decode, so the raw value is known to match the shape of t.
+func checkRequired(raw json.RawMessage, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+			return nil
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := jsonFieldName(field)
+			if name == "" {
+				continue
+			}
+			value, present := lookupField(fields, name)
+			if field.Tag.Get("required") == "true" && !isSet(value, present) {
+				return invalidField(path+name, "is required")
+			}
+			if present {
+				if err := checkRequired(value, field.Type, path+name+"."); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Slice:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil
+		}
+		prefix := strings.TrimSuffix(path, ".")
+		for i, item := range items {
+			if err := checkRequired(item, t.Elem(), fmt.Sprintf("%s[%d].", prefix, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookupField matches keys the way encoding/json does: exactly, and failing
+// that, case-insensitively.
+func lookupField(fields map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+	if value, ok := fields[name]; ok {
+		return value, true
+	}
+	for key, value := range fields {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func isSet(value json.RawMessage, present bool) bool {
+	if !present {
+		return false
+	}
+	switch string(bytes.TrimSpace(value)) {
+	case "null", `""`:
+		return false
+	}
+	return true
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return field.Name
+}
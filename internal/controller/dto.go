@@ -1,31 +1,85 @@
 package controller
 
+import "encoding/json"
+
 type TeamMemberDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID    string  `json:"user_id" required:"true"`
+	Username  string  `json:"username" required:"true"`
+	IsActive  bool    `json:"is_active"`
+	DeletedAt *string `json:"deleted_at,omitempty"`
 }
 
 type TeamDTO struct {
-	TeamName string          `json:"team_name"`
+	TeamName string          `json:"team_name" required:"true"`
 	Members  []TeamMemberDTO `json:"members"`
 }
 
+type TeamSummaryDTO struct {
+	TeamName         string `json:"team_name"`
+	MembersCount     int    `json:"members_count"`
+	OpenPullRequests int    `json:"open_pull_requests"`
+}
+
 type UserDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID       string  `json:"user_id"`
+	Username     string  `json:"username"`
+	TeamName     string  `json:"team_name"`
+	IsActive     bool    `json:"is_active"`
+	SnoozedUntil *string `json:"snoozed_until,omitempty"`
+	DeletedAt    *string `json:"deleted_at,omitempty"`
+}
+
+type UserWithLoadDTO struct {
+	UserDTO
+	OpenReviews int `json:"open_reviews"`
 }
 
 type PullRequestDTO struct {
-	PullRequestID     string   `json:"pull_request_id"`
-	PullRequestName   string   `json:"pull_request_name"`
-	AuthorID          string   `json:"author_id"`
-	Status            string   `json:"status"`
-	AssignedReviewers []string `json:"assigned_reviewers"`
-	CreatedAt         *string  `json:"createdAt,omitempty"`
-	MergedAt          *string  `json:"mergedAt,omitempty"`
+	PullRequestID     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+	AuthorID          string            `json:"author_id"`
+	Status            string            `json:"status"`
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+	Reviewers         []ReviewerDTO     `json:"reviewers"`
+	CreatedAt         *string           `json:"createdAt,omitempty"`
+	MergedAt          *string           `json:"mergedAt,omitempty"`
+	ClosedAt          *string           `json:"closedAt,omitempty"`
+	MilestoneID       *string           `json:"milestone_id,omitempty"`
+	Checklist         *ChecklistDTO     `json:"checklist,omitempty"`
+	Override          *OverrideDTO      `json:"override,omitempty"`
+	ChangesRequested  *ChangeRequestDTO `json:"changes_requested,omitempty"`
+	Declines          []DeclineDTO      `json:"declines,omitempty"`
+	AutoMerge         bool              `json:"auto_merge"`
+	Iteration         int               `json:"iteration"`
+	ExternalID        string            `json:"external_id,omitempty"`
+	ChangedFiles      []string          `json:"changed_files,omitempty"`
+}
+
+type OverrideDTO struct {
+	UserID       string `json:"user_id"`
+	Reason       string `json:"reason"`
+	OverriddenAt string `json:"overridden_at"`
+}
+
+type ChangeRequestDTO struct {
+	UserID      string `json:"user_id"`
+	RequestedAt string `json:"requested_at"`
+}
+
+type DeclineDTO struct {
+	UserID     string `json:"user_id"`
+	ReplacedBy string `json:"replaced_by"`
+	Reason     string `json:"reason"`
+	DeclinedAt string `json:"declined_at"`
+}
+
+type ReviewerDTO struct {
+	UserID     string  `json:"user_id"`
+	Slot       string  `json:"slot"`
+	Approved   bool    `json:"approved"`
+	ApprovedAt *string `json:"approved_at,omitempty"`
+
+	FirstResponseAt *string `json:"first_response_at,omitempty"`
 }
 
 type PullRequestShortDTO struct {
@@ -35,21 +89,343 @@ type PullRequestShortDTO struct {
 	Status          string `json:"status"`
 }
 
+type TeamImportRowDTO struct {
+	Row      int    `json:"row"`
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+type TeamImportTeamDTO struct {
+	TeamName     string `json:"team_name"`
+	Status       string `json:"status"`
+	MembersCount int    `json:"members_count"`
+	Error        string `json:"error,omitempty"`
+}
+
+type SyncResultDTO struct {
+	Source       string `json:"source"`
+	TeamsCreated int    `json:"teams_created"`
+	TeamsUpdated int    `json:"teams_updated"`
+	Members      int    `json:"members"`
+	StartedAt    string `json:"started_at"`
+	FinishedAt   string `json:"finished_at"`
+}
+
+type BackupResultDTO struct {
+	OrgID        string `json:"org_id"`
+	Location     string `json:"location"`
+	CreatedAt    string `json:"created_at"`
+	Teams        int    `json:"teams"`
+	Users        int    `json:"users"`
+	PullRequests int    `json:"pull_requests"`
+}
+
+type ConsistencyReportDTO struct {
+	OrgID        string       `json:"org_id"`
+	CheckedAt    string       `json:"checked_at"`
+	Teams        int          `json:"teams"`
+	Users        int          `json:"users"`
+	PullRequests int          `json:"pull_requests"`
+	Anomalies    []AnomalyDTO `json:"anomalies"`
+}
+
+type AnomalyDTO struct {
+	Kind          string `json:"kind"`
+	TeamName      string `json:"team_name,omitempty"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	UserID        string `json:"user_id"`
+	Detail        string `json:"detail"`
+}
+
+type RepairResultDTO struct {
+	OrgID      string            `json:"org_id"`
+	DryRun     bool              `json:"dry_run"`
+	Kinds      []string          `json:"kinds"`
+	Repaired   []RepairActionDTO `json:"repaired"`
+	Unresolved []AnomalyDTO      `json:"unresolved"`
+}
+
+type IdleReportDTO struct {
+	OrgID     string        `json:"org_id"`
+	CheckedAt string        `json:"checked_at"`
+	DryRun    bool          `json:"dry_run"`
+	Users     []IdleUserDTO `json:"users"`
+}
+
+type IdleUserDTO struct {
+	UserID       string  `json:"user_id"`
+	TeamName     string  `json:"team_name"`
+	LastActiveAt *string `json:"last_active_at"`
+	Action       string  `json:"action"`
+}
+
+type RepairActionDTO struct {
+	AnomalyDTO
+	ReplacementID string `json:"replacement_id,omitempty"`
+}
+
+type RebalanceResultDTO struct {
+	TeamName   string            `json:"team_name"`
+	DryRun     bool              `json:"dry_run"`
+	Moves      []ReviewerMoveDTO `json:"moves"`
+	LoadBefore map[string]int    `json:"load_before"`
+	LoadAfter  map[string]int    `json:"load_after"`
+}
+
+type ReviewTransferDTO struct {
+	FromUserID string               `json:"from_user_id"`
+	ToUserID   string               `json:"to_user_id,omitempty"`
+	Moves      []ReviewerMoveDTO    `json:"moves"`
+	Skipped    []SkippedTransferDTO `json:"skipped"`
+}
+
+type SkippedTransferDTO struct {
+	PullRequestID string `json:"pull_request_id"`
+	Reason        string `json:"reason"`
+}
+
+type ReviewerMoveDTO struct {
+	PullRequestID string `json:"pull_request_id"`
+	FromUserID    string `json:"from_user_id"`
+	ToUserID      string `json:"to_user_id"`
+}
+
+type StorageStatsDTO struct {
+	Backend        string          `json:"backend"`
+	Users          int             `json:"users"`
+	ActiveUsers    int             `json:"active_users"`
+	Teams          int             `json:"teams"`
+	PullRequests   map[string]int  `json:"pull_requests"`
+	EstimatedBytes int64           `json:"estimated_bytes"`
+	Indexes        []IndexStatsDTO `json:"indexes"`
+}
+
+type ReviewStatsDTO struct {
+	TeamName          string  `json:"team_name,omitempty"`
+	PullRequests      int     `json:"pull_requests"`
+	AvgIterations     float64 `json:"avg_iterations"`
+	MaxIterations     int     `json:"max_iterations"`
+	MultiIterationPRs int     `json:"multi_iteration_prs"`
+	OverriddenPRs     int     `json:"overridden_prs"`
+
+	FirstResponses          int                   `json:"first_responses"`
+	AvgFirstResponseSeconds float64               `json:"avg_first_response_seconds"`
+	Reviewers               []ReviewerResponseDTO `json:"reviewers"`
+	Teams                   []TeamResponseDTO     `json:"teams"`
+}
+
+type ReviewerResponseDTO struct {
+	UserID                  string  `json:"user_id"`
+	TeamName                string  `json:"team_name"`
+	FirstResponses          int     `json:"first_responses"`
+	AvgFirstResponseSeconds float64 `json:"avg_first_response_seconds"`
+}
+
+type TeamResponseDTO struct {
+	TeamName                string  `json:"team_name"`
+	FirstResponses          int     `json:"first_responses"`
+	AvgFirstResponseSeconds float64 `json:"avg_first_response_seconds"`
+}
+
+type IndexStatsDTO struct {
+	Name           string `json:"name"`
+	Entries        int    `json:"entries"`
+	EstimatedBytes int64  `json:"estimated_bytes"`
+}
+
+type MilestoneDTO struct {
+	MilestoneID string  `json:"milestone_id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	DueDate     *string `json:"due_date,omitempty"`
+	CreatedAt   *string `json:"created_at,omitempty"`
+}
+
+type MilestoneStatsDTO struct {
+	MilestoneID string `json:"milestone_id"`
+	Total       int    `json:"total"`
+	Open        int    `json:"open"`
+	Merged      int    `json:"merged"`
+	Closed      int    `json:"closed"`
+}
+
+type ChecklistTemplateDTO struct {
+	TeamName         string   `json:"team_name" required:"true"`
+	Items            []string `json:"items"`
+	RequiredForMerge bool     `json:"required_for_merge"`
+}
+
+type MergePolicyDTO struct {
+	TeamName          string `json:"team_name" required:"true"`
+	MinApprovals      int    `json:"min_approvals"`
+	ChecklistComplete bool   `json:"checklist_complete"`
+	NotOverdue        bool   `json:"not_overdue"`
+}
+
+// TeamSettingsDTO carries a team's overrides, where an omitted field means
+// the service-wide default, or the effective settings with every field set.
+type TeamSettingsDTO struct {
+	TeamName           string  `json:"team_name" required:"true"`
+	RequiredReviewers  *int    `json:"required_reviewers,omitempty"`
+	OptionalReviewers  *int    `json:"optional_reviewers,omitempty"`
+	SLA                *string `json:"sla,omitempty"`
+	AssignmentStrategy string  `json:"assignment_strategy,omitempty"`
+	MergeApprovals     string  `json:"merge_approvals,omitempty"`
+	Timezone           string  `json:"timezone,omitempty"`
+	NamePattern        string  `json:"pull_request_name_pattern,omitempty"`
+}
+
+// GlobalSettingsDTO carries the instance-wide overrides, where an omitted
+// field keeps the configured default, or the effective settings with every
+// field set.
+type GlobalSettingsDTO struct {
+	RequiredReviewers *int            `json:"required_reviewers,omitempty"`
+	OptionalReviewers *int            `json:"optional_reviewers,omitempty"`
+	SLA               *string         `json:"sla,omitempty"`
+	Features          map[string]bool `json:"features,omitempty"`
+}
+
+type OrganizationDTO struct {
+	OrgID     string `json:"org_id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+}
+
+type APITokenDTO struct {
+	TokenID   string   `json:"token_id"`
+	OrgID     string   `json:"org_id"`
+	Name      string   `json:"name"`
+	Roles     []string `json:"roles"`
+	UserID    *string  `json:"user_id,omitempty"`
+	CreatedBy string   `json:"created_by,omitempty"`
+	CreatedAt string   `json:"created_at"`
+	ExpiresAt *string  `json:"expires_at,omitempty"`
+}
+
+type AuditEntryDTO struct {
+	EntryID    string            `json:"entry_id"`
+	OccurredAt string            `json:"occurred_at"`
+	Actor      string            `json:"actor"`
+	Action     string            `json:"action"`
+	EntityType string            `json:"entity_type"`
+	EntityID   string            `json:"entity_id"`
+	Details    map[string]string `json:"details,omitempty"`
+}
+
+type StorageEventDTO struct {
+	Seq        int64           `json:"seq"`
+	OccurredAt string          `json:"occurred_at"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Operation  string          `json:"operation"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+}
+
+type QuotaUsageDTO struct {
+	Resource string `json:"resource"`
+	TeamName string `json:"team_name,omitempty"`
+	Used     int    `json:"used"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+type ChecklistDTO struct {
+	RequiredForMerge bool               `json:"required_for_merge"`
+	Items            []ChecklistItemDTO `json:"items"`
+}
+
+type ChecklistItemDTO struct {
+	ItemID    int     `json:"item_id"`
+	Text      string  `json:"text"`
+	Checked   bool    `json:"checked"`
+	CheckedBy *string `json:"checked_by,omitempty"`
+	CheckedAt *string `json:"checked_at,omitempty"`
+}
+
+type RuntimeInfoDTO struct {
+	StartedAt     string            `json:"started_at"`
+	Uptime        string            `json:"uptime"`
+	UptimeSeconds int64             `json:"uptime_seconds"`
+	GoVersion     string            `json:"go_version"`
+	Goroutines    int               `json:"goroutines"`
+	NumCPU        int               `json:"num_cpu"`
+	GOMAXPROCS    int               `json:"gomaxprocs"`
+	Memory        RuntimeMemoryDTO  `json:"memory"`
+	GC            RuntimeGCDTO      `json:"gc"`
+	Config        map[string]string `json:"config"`
+}
+
+type RuntimeMemoryDTO struct {
+	HeapAllocBytes uint64 `json:"heap_alloc_bytes"`
+	HeapInuseBytes uint64 `json:"heap_inuse_bytes"`
+	SysBytes       uint64 `json:"sys_bytes"`
+	HeapObjects    uint64 `json:"heap_objects"`
+}
+
+type RuntimeGCDTO struct {
+	NumGC        uint32  `json:"num_gc"`
+	LastGC       string  `json:"last_gc,omitempty"`
+	LastPauseMs  float64 `json:"last_pause_ms"`
+	PauseTotalMs float64 `json:"pause_total_ms"`
+	NextGCBytes  uint64  `json:"next_gc_bytes"`
+}
+
 type ErrorCode string
 
 const (
-	ErrorCodeTeamExists   ErrorCode = "TEAM_EXISTS"
-	ErrorCodePRExists     ErrorCode = "PR_EXISTS"
-	ErrorCodePRMerged     ErrorCode = "PR_MERGED"
-	ErrorCodeNotAssigned  ErrorCode = "NOT_ASSIGNED"
-	ErrorCodeNoCandidate  ErrorCode = "NO_CANDIDATE"
-	ErrorCodeNotFound     ErrorCode = "NOT_FOUND"
-	ErrorCodeInvalidInput ErrorCode = "INVALID_INPUT"
+	ErrorCodeTeamExists    ErrorCode = "TEAM_EXISTS"
+	ErrorCodePRExists      ErrorCode = "PR_EXISTS"
+	ErrorCodePRMerged      ErrorCode = "PR_MERGED"
+	ErrorCodePRClosed      ErrorCode = "PR_CLOSED"
+	ErrorCodeNotAssigned   ErrorCode = "NOT_ASSIGNED"
+	ErrorCodeNoCandidate   ErrorCode = "NO_CANDIDATE"
+	ErrorCodeNotFound      ErrorCode = "NOT_FOUND"
+	ErrorCodeInvalidInput  ErrorCode = "INVALID_INPUT"
+	ErrorCodeNotConfigured ErrorCode = "NOT_CONFIGURED"
+	ErrorCodeInProgress    ErrorCode = "IN_PROGRESS"
+	ErrorCodeUnauthorized  ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden     ErrorCode = "FORBIDDEN"
+	ErrorCodeQuotaExceeded ErrorCode = "QUOTA_EXCEEDED"
+
+	ErrorCodeInvalidReviewer ErrorCode = "INVALID_REVIEWER"
+	ErrorCodeMilestoneExists ErrorCode = "MILESTONE_EXISTS"
+	ErrorCodeInvalidOwner    ErrorCode = "INVALID_OWNER"
+	ErrorCodeOrgExists       ErrorCode = "ORG_EXISTS"
+	ErrorCodeOrgNotFound     ErrorCode = "ORG_NOT_FOUND"
+
+	ErrorCodeChecklistIncomplete ErrorCode = "CHECKLIST_INCOMPLETE"
+	ErrorCodeMergePolicy         ErrorCode = "MERGE_POLICY_VIOLATION"
+	ErrorCodeApprovalsPending    ErrorCode = "APPROVALS_PENDING"
+	ErrorCodeChangesRequested    ErrorCode = "CHANGES_REQUESTED"
+	ErrorCodeConcurrentUpdate    ErrorCode = "CONCURRENT_UPDATE"
+	ErrorCodeFeatureDisabled     ErrorCode = "FEATURE_DISABLED"
+	ErrorCodeInvalidPRName       ErrorCode = "INVALID_PR_NAME"
+
+	ErrorCodeMalformedJSON    ErrorCode = "MALFORMED_JSON"
+	ErrorCodeValidationFailed ErrorCode = "VALIDATION_FAILED"
+	ErrorCodeMethodNotAllowed ErrorCode = "METHOD_NOT_ALLOWED"
+
+	ErrorCodeInternal ErrorCode = "INTERNAL"
+	ErrorCodeTimeout  ErrorCode = "TIMEOUT"
 )
 
+// ErrorResponse is the body of every JSON error. Fields lists the request
+// fields at fault, if any are known; RequestID matches the X-Request-ID
+// header, for quoting in bug reports.
 type ErrorResponse struct {
 	Error struct {
-		Code    ErrorCode `json:"code"`
-		Message string    `json:"message"`
+		Code      ErrorCode         `json:"code"`
+		Message   string            `json:"message"`
+		Details   map[string]string `json:"details,omitempty"`
+		Fields    []FieldErrorDTO   `json:"fields,omitempty"`
+		RequestID string            `json:"request_id,omitempty"`
 	} `json:"error"`
 }
+
+// FieldErrorDTO names an invalid request field by its path, such as
+// members[1].user_id, and says what is wrong with it.
+type FieldErrorDTO struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
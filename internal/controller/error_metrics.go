@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// ErrorMetrics counts rejected requests by route and error code, so a spike
+// of NO_CANDIDATE on /pullRequest/create can be told apart from a spike of
+// NOT_ASSIGNED on /pullRequest/reassign. Counters live in process and are
+// exposed on /metrics; when a recorder is set they are pushed to it as well.
+type ErrorMetrics struct {
+	recorder MetricsRecorder
+
+	mu     sync.Mutex
+	counts map[errorMetricKey]int64
+}
+
+type errorMetricKey struct {
+	Route string
+	Code  ErrorCode
+}
+
+// ErrorCount is one counter of ErrorMetrics.
+type ErrorCount struct {
+	Route string
+	Code  ErrorCode
+	Count int64
+}
+
+// NewErrorMetrics creates the counters; recorder may be nil.
+func NewErrorMetrics(recorder MetricsRecorder) *ErrorMetrics {
+	return &ErrorMetrics{
+		recorder: recorder,
+		counts:   make(map[errorMetricKey]int64),
+	}
+}
+
+// Instrument counts every response with a 4xx or 5xx status. The code is
+// the one written by writeError; responses written some other way are
+// counted under an empty code.
+func (m *ErrorMetrics) Instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cw := &errorCodeWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(cw, r)
+
+		if cw.status < http.StatusBadRequest {
+			return
+		}
+		key := errorMetricKey{Route: routeLabel(r), Code: cw.code}
+
+		m.mu.Lock()
+		m.counts[key]++
+		m.mu.Unlock()
+
+		if m.recorder != nil {
+			m.recorder.Count("http.errors", 1, "route:"+key.Route, "code:"+string(key.Code))
+		}
+	})
+}
+
+// Counts returns the counters ordered by route and code.
+func (m *ErrorMetrics) Counts() []ErrorCount {
+	m.mu.Lock()
+	counts := make([]ErrorCount, 0, len(m.counts))
+	for key, n := range m.counts {
+		counts = append(counts, ErrorCount{Route: key.Route, Code: key.Code, Count: n})
+	}
+	m.mu.Unlock()
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Route != counts[j].Route {
+			return counts[i].Route < counts[j].Route
+		}
+		return counts[i].Code < counts[j].Code
+	})
+	return counts
+}
+
+// errorCodeWriter remembers the status and the error code of a response.
+// writeError finds it through the Unwrap chain, like htmlErrorWriter.
+type errorCodeWriter struct {
+	http.ResponseWriter
+	status int
+	code   ErrorCode
+}
+
+func (w *errorCodeWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *errorCodeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func noteErrorCode(w http.ResponseWriter, code ErrorCode) {
+	for {
+		if cw, ok := w.(*errorCodeWriter); ok {
+			cw.code = code
+			return
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return
+		}
+		w = unwrapper.Unwrap()
+	}
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"avito-intro/internal/logging"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+var (
+	//go:embed web/error.html
+	errorHTML string
+
+	errorTemplate = template.Must(template.New("error").Parse(errorHTML))
+)
+
+// usecaseStatuses is the one place usecase error codes are mapped to HTTP
+// statuses.
+var usecaseStatuses = map[usecase.ErrorCode]int{
+	usecase.CodeInvalidInput:        http.StatusBadRequest,
+	usecase.CodeNotFound:            http.StatusNotFound,
+	usecase.CodeTeamExists:          http.StatusBadRequest,
+	usecase.CodePRExists:            http.StatusConflict,
+	usecase.CodeMilestoneExists:     http.StatusConflict,
+	usecase.CodeOrgExists:           http.StatusConflict,
+	usecase.CodePRMerged:            http.StatusConflict,
+	usecase.CodePRClosed:            http.StatusConflict,
+	usecase.CodeChangesRequested:    http.StatusConflict,
+	usecase.CodeNotAssigned:         http.StatusConflict,
+	usecase.CodeNoCandidate:         http.StatusConflict,
+	usecase.CodeInvalidReviewer:     http.StatusUnprocessableEntity,
+	usecase.CodeInvalidOwner:        http.StatusUnprocessableEntity,
+	usecase.CodeForbidden:           http.StatusForbidden,
+	usecase.CodeQuotaExceeded:       http.StatusForbidden,
+	usecase.CodeInProgress:          http.StatusConflict,
+	usecase.CodeChecklistIncomplete: http.StatusConflict,
+	usecase.CodeMergePolicy:         http.StatusConflict,
+	usecase.CodeApprovalsPending:    http.StatusConflict,
+	usecase.CodeConcurrentUpdate:    http.StatusConflict,
+	usecase.CodeFeatureDisabled:     http.StatusForbidden,
+	usecase.CodeInvalidPRName:       http.StatusUnprocessableEntity,
+}
+
+// writeUsecaseError writes the response for an error returned by a usecase.
+// Typed usecase errors keep their code, message and details; a storage
+// timeout becomes a 504. Anything else is logged with msg and reported as
+// a bare 500 so internals do not leak to clients.
+func writeUsecaseError(w http.ResponseWriter, r *http.Request, logger *zap.Logger, msg string, err error) {
+	var ucErr *usecase.Error
+	if errors.As(err, &ucErr) {
+		status, ok := usecaseStatuses[ucErr.Code]
+		if !ok {
+			logging.From(r.Context(), logger).Error("unmapped usecase error code", zap.String("code", string(ucErr.Code)))
+			status = http.StatusInternalServerError
+		}
+		var fields []FieldErrorDTO
+		if field := ucErr.Details["field"]; field != "" {
+			fields = []FieldErrorDTO{{Field: field, Reason: ucErr.Message}}
+		}
+		writeErrorDetails(w, status, ErrorCode(ucErr.Code), ucErr.Message, ucErr.Details, fields)
+		return
+	}
+
+	logging.From(r.Context(), logger).Error(msg, zap.Error(err))
+	if errors.Is(err, context.DeadlineExceeded) {
+		writeError(w, http.StatusGatewayTimeout, ErrorCodeTimeout, "storage did not respond in time")
+		return
+	}
+	writeError(w, http.StatusInternalServerError, ErrorCodeInternal, "internal server error")
+}
+
+// writeError is the single place error responses are written: API clients
+// get the JSON ErrorResponse, browsers (see NegotiateErrors) a readable
+// HTML page with the same code and message.
+func writeError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeErrorDetails(w, status, code, message, nil, nil)
+}
+
+// writeFieldError rejects a request because of one invalid field; the
+// message is the field path followed by reason.
+func writeFieldError(w http.ResponseWriter, field, reason string) {
+	writeErrorDetails(w, http.StatusBadRequest, ErrorCodeValidationFailed, field+" "+reason, nil,
+		[]FieldErrorDTO{{Field: field, Reason: reason}})
+}
+
+func writeErrorDetails(w http.ResponseWriter, status int, code ErrorCode, message string, details map[string]string, fields []FieldErrorDTO) {
+	noteErrorCode(w, code)
+	if prefersHTML(w) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		errorTemplate.Execute(w, struct {
+			Status     int
+			StatusText string
+			Code       ErrorCode
+			Message    string
+			Style      template.CSS
+		}{
+			Status:     status,
+			StatusText: http.StatusText(status),
+			Code:       code,
+			Message:    message,
+			Style:      template.CSS(pageCSS),
+		})
+		return
+	}
+
+	resp := ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	resp.Error.Details = details
+	resp.Error.Fields = fields
+	resp.Error.RequestID = w.Header().Get(requestIDHeader)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// htmlErrorWriter marks a response whose client asked for HTML. Handlers
+// and middlewares may wrap it further, so writeError looks for it through
+// the Unwrap chain.
+type htmlErrorWriter struct {
+	http.ResponseWriter
+}
+
+func (w *htmlErrorWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// NegotiateErrors makes error responses to browsers HTML. It must wrap every
+// handler that can fail, so it goes outermost.
+func NegotiateErrors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if acceptsHTML(r.Header.Get("Accept")) {
+			w = &htmlErrorWriter{ResponseWriter: w}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func prefersHTML(w http.ResponseWriter) bool {
+	for {
+		if _, ok := w.(*htmlErrorWriter); ok {
+			return true
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return false
+		}
+		w = unwrapper.Unwrap()
+	}
+}
+
+// acceptsHTML reports whether the Accept header explicitly ranks text/html
+// above application/json. Wildcards do not count, so curl and API clients
+// sending */* keep getting JSON.
+func acceptsHTML(accept string) bool {
+	var htmlQ, jsonQ float64
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+				q = parsed
+			}
+		}
+		switch mediaType {
+		case "text/html":
+			htmlQ = max(htmlQ, q)
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return htmlQ > 0 && htmlQ > jsonQ
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// sendCachedJSON writes a 200 response with a strong ETag derived from the
+// encoded body and answers 304 Not Modified when the client's If-None-Match
+// already names it. Entities carry no version counter, so the representation
+// itself is the version: any change to the team, its members or a PR changes
+// the hash.
+func sendCachedJSON(w http.ResponseWriter, r *http.Request, data interface{}) {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(data); err != nil {
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, "internal server error")
+		return
+	}
+
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+// etagMatches implements the weak comparison If-None-Match calls for: a
+// W/ prefix is ignored and "*" matches any current representation.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
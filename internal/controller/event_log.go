@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+type EventLogController struct {
+	eventLogUC usecase.EventLogUsecase
+	logger     *zap.Logger
+}
+
+func NewEventLogController(eventLogUC usecase.EventLogUsecase, logger *zap.Logger) *EventLogController {
+	return &EventLogController{
+		eventLogUC: eventLogUC,
+		logger:     logger,
+	}
+}
+
+// ListEvents returns storage events oldest first. after is a cursor: pass
+// the next_after of the previous response to get the following page.
+func (c *EventLogController) ListEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := entity.StorageEventFilter{
+		EntityType: query.Get("entity_type"),
+		EntityID:   query.Get("entity_id"),
+		Limit:      defaultPageSize,
+	}
+
+	if raw := query.Get("after"); raw != "" {
+		after, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || after < 0 {
+			writeFieldError(w, "after", "must be a non-negative integer")
+			return
+		}
+		filter.AfterSeq = after
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			writeFieldError(w, "limit", fmt.Sprintf("must be between 1 and %d", maxPageSize))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	events, err := c.eventLogUC.ListEvents(r.Context(), filter)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to list storage events", err)
+		return
+	}
+
+	dtos := make([]StorageEventDTO, len(events))
+	for i, event := range events {
+		dtos[i] = StorageEventToDTO(event)
+	}
+
+	nextAfter := filter.AfterSeq
+	if len(events) > 0 {
+		nextAfter = events[len(events)-1].Seq
+	}
+
+	response := struct {
+		Events    []StorageEventDTO `json:"events"`
+		NextAfter int64             `json:"next_after"`
+	}{
+		Events:    dtos,
+		NextAfter: nextAfter,
+	}
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *EventLogController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *EventLogController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+type GlobalSettingsController struct {
+	globalSettingsUC usecase.GlobalSettingsUsecase
+	logger           *zap.Logger
+}
+
+func NewGlobalSettingsController(globalSettingsUC usecase.GlobalSettingsUsecase, logger *zap.Logger) *GlobalSettingsController {
+	return &GlobalSettingsController{
+		globalSettingsUC: globalSettingsUC,
+		logger:           logger,
+	}
+}
+
+func (c *GlobalSettingsController) SetSettings(w http.ResponseWriter, r *http.Request) {
+	var req GlobalSettingsDTO
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	settings := entity.GlobalSettings{
+		RequiredReviewers: req.RequiredReviewers,
+		OptionalReviewers: req.OptionalReviewers,
+	}
+	if req.SLA != nil {
+		sla, err := time.ParseDuration(*req.SLA)
+		if err != nil {
+			writeFieldError(w, "sla", "must be a duration like 24h")
+			return
+		}
+		settings.SLA = &sla
+	}
+	if len(req.Features) > 0 {
+		settings.Features = make(map[entity.Feature]bool, len(req.Features))
+		for feature, enabled := range req.Features {
+			settings.Features[entity.Feature(feature)] = enabled
+		}
+	}
+
+	overrides, effective, err := c.globalSettingsUC.SetSettings(r.Context(), settings)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to set global settings", err)
+		return
+	}
+
+	c.sendSettings(w, overrides, effective)
+}
+
+func (c *GlobalSettingsController) GetSettings(w http.ResponseWriter, r *http.Request) {
+	overrides, effective, err := c.globalSettingsUC.GetSettings(r.Context())
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get global settings", err)
+		return
+	}
+
+	c.sendSettings(w, overrides, effective)
+}
+
+func (c *GlobalSettingsController) sendSettings(w http.ResponseWriter, overrides, effective entity.GlobalSettings) {
+	response := struct {
+		Overrides GlobalSettingsDTO `json:"overrides"`
+		Effective GlobalSettingsDTO `json:"effective"`
+	}{
+		Overrides: GlobalSettingsToDTO(overrides),
+		Effective: GlobalSettingsToDTO(effective),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *GlobalSettingsController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *GlobalSettingsController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+	"avito-intro/internal/worker"
+
+	"go.uber.org/zap"
+)
+
+const readinessTimeout = 2 * time.Second
+
+type HealthController struct {
+	storage repository.Storage
+	workers *worker.Registry
+	logger  *zap.Logger
+}
+
+func NewHealthController(storage repository.Storage, workers *worker.Registry, logger *zap.Logger) *HealthController {
+	return &HealthController{
+		storage: storage,
+		workers: workers,
+		logger:  logger,
+	}
+}
+
+type readinessDTO struct {
+	Status   string             `json:"status"`
+	Error    string             `json:"error,omitempty"`
+	Failover *failoverHealthDTO `json:"failover,omitempty"`
+	Workers  []workerHealthDTO  `json:"workers,omitempty"`
+}
+
+type workerHealthDTO struct {
+	Name          string `json:"name"`
+	Critical      bool   `json:"critical"`
+	State         string `json:"state"`
+	StartedAt     string `json:"started_at,omitempty"`
+	LastRunAt     string `json:"last_run_at,omitempty"`
+	LastSuccessAt string `json:"last_success_at,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+	Runs          int    `json:"runs"`
+	Failures      int    `json:"failures"`
+}
+
+type failoverHealthDTO struct {
+	PrimaryHealthy bool   `json:"primary_healthy"`
+	LastError      string `json:"last_error,omitempty"`
+	Since          string `json:"since"`
+	Fallbacks      int    `json:"fallbacks"`
+}
+
+// Readyz reports whether the service can take traffic: the storage must
+// answer a ping within readinessTimeout. A failover storage whose primary is
+// down still serves reads, so it is reported as degraded rather than unready.
+// Background workers are listed too; a crashed critical worker makes the
+// service unready, since the work it owns would silently stop.
+func (c *HealthController) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	if err := c.storage.Ping(ctx); err != nil {
+		logging.From(r.Context(), c.logger).Warn("storage ping failed", zap.Error(err))
+		c.sendJSON(w, http.StatusServiceUnavailable, readinessDTO{
+			Status: "unavailable",
+			Error:  err.Error(),
+		})
+		return
+	}
+
+	resp := readinessDTO{Status: "ok", Workers: workersToDTO(c.workers.Statuses())}
+
+	if failover, ok := c.storage.(interface {
+		Health() repository.FailoverHealth
+	}); ok {
+		health := failover.Health()
+		resp.Failover = &failoverHealthDTO{
+			PrimaryHealthy: health.PrimaryHealthy,
+			LastError:      health.LastError,
+			Since:          health.Since.Format(time.RFC3339),
+			Fallbacks:      health.Fallbacks,
+		}
+		if !health.PrimaryHealthy {
+			resp.Status = "degraded"
+		}
+	}
+
+	if crashed := c.workers.Crashed(); len(crashed) > 0 {
+		logging.From(r.Context(), c.logger).Warn("critical workers crashed", zap.Strings("workers", crashed))
+		resp.Status = "unavailable"
+		resp.Error = "critical workers crashed: " + strings.Join(crashed, ", ")
+		c.sendJSON(w, http.StatusServiceUnavailable, resp)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, resp)
+}
+
+func workersToDTO(statuses []worker.Status) []workerHealthDTO {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	dtos := make([]workerHealthDTO, len(statuses))
+	for i, s := range statuses {
+		dtos[i] = workerHealthDTO{
+			Name:          s.Name,
+			Critical:      s.Critical,
+			State:         string(s.State),
+			StartedAt:     formatTime(s.StartedAt),
+			LastRunAt:     formatTime(s.LastRunAt),
+			LastSuccessAt: formatTime(s.LastSuccessAt),
+			LastError:     s.LastError,
+			Runs:          s.Runs,
+			Failures:      s.Failures,
+		}
+	}
+	return dtos
+}
+
+func (c *HealthController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
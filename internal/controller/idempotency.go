@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"avito-intro/internal/auth"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/tenant"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLen     = 255
+)
+
+var _ usecase.IdempotencyStore = (*Idempotency)(nil)
+
+// Idempotency lets clients retry writes safely: a request carrying an
+// Idempotency-Key runs once, and later requests with the same key get the
+// stored response instead of running again. Keys are scoped by organization,
+// caller and route. Responses are kept in memory until the retention worker
+// purges them, so they do not survive a restart and are not shared between
+// instances.
+type Idempotency struct {
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+	logger  *zap.Logger
+}
+
+// idempotencyEntry is a request seen under a key, in progress until done.
+// Entries are replaced when the request finishes, never changed in place,
+// so they can be read without the lock.
+type idempotencyEntry struct {
+	fingerprint [sha256.Size]byte
+	done        bool
+	status      int
+	contentType string
+	body        []byte
+	orgID       string
+	storedAt    time.Time
+}
+
+func NewIdempotency(logger *zap.Logger) *Idempotency {
+	return &Idempotency{
+		entries: make(map[string]*idempotencyEntry),
+		logger:  logger,
+	}
+}
+
+// Wrap makes next idempotent. Requests without the header run as usual. A
+// key reused with a different body is rejected, and so is a retry arriving
+// while the first request still runs. Server errors are not stored, so a
+// request that failed with one may be retried under the same key.
+func (m *Idempotency) Wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput,
+				fmt.Sprintf("%s must not exceed %d characters", idempotencyKeyHeader, maxIdempotencyKeyLen))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, fmt.Sprintf("request body must not exceed %d bytes", tooLarge.Limit))
+				return
+			}
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := idempotencyScope(r, key)
+		fingerprint := sha256.Sum256(body)
+
+		m.mu.Lock()
+		entry, seen := m.entries[scope]
+		if !seen {
+			m.entries[scope] = &idempotencyEntry{fingerprint: fingerprint}
+		}
+		m.mu.Unlock()
+
+		if seen {
+			switch {
+			case entry.fingerprint != fingerprint:
+				writeError(w, http.StatusUnprocessableEntity, ErrorCodeInvalidInput,
+					idempotencyKeyHeader+" was already used with a different request")
+			case !entry.done:
+				writeError(w, http.StatusConflict, ErrorCodeInProgress,
+					"a request with this "+idempotencyKeyHeader+" is still in progress")
+			default:
+				logging.From(r.Context(), m.logger).Debug("replaying idempotent response", zap.String("idempotency_key", key))
+				entry.replay(w)
+			}
+			return
+		}
+
+		rec := &recordingWriter{ResponseWriter: w}
+		completed := false
+		orgID := tenant.OrganizationFromContext(r.Context())
+		defer func() { m.finish(scope, orgID, fingerprint, rec, completed) }()
+		next(rec, r)
+		completed = true
+	}
+}
+
+// finish stores the response of a completed request, or forgets the key
+// when the request failed on the server side or panicked, so that it can
+// be retried.
+func (m *Idempotency) finish(scope, orgID string, fingerprint [sha256.Size]byte, rec *recordingWriter, completed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := rec.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if !completed || status >= http.StatusInternalServerError {
+		delete(m.entries, scope)
+		return
+	}
+	m.entries[scope] = &idempotencyEntry{
+		fingerprint: fingerprint,
+		done:        true,
+		status:      status,
+		contentType: rec.Header().Get("Content-Type"),
+		body:        rec.body.Bytes(),
+		orgID:       orgID,
+		storedAt:    time.Now(),
+	}
+}
+
+// Purge forgets the responses stored before olderThan. Requests still in
+// progress are kept.
+func (m *Idempotency) Purge(olderThan time.Time) map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	purged := make(map[string]int)
+	for scope, entry := range m.entries {
+		if entry.done && entry.storedAt.Before(olderThan) {
+			delete(m.entries, scope)
+			purged[entry.orgID]++
+		}
+	}
+	return purged
+}
+
+func (e *idempotencyEntry) replay(w http.ResponseWriter) {
+	if e.contentType != "" {
+		w.Header().Set("Content-Type", e.contentType)
+	}
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(e.status)
+	w.Write(e.body)
+}
+
+// idempotencyScope keeps keys of different organizations, callers and
+// routes apart, so clients cannot see each other's responses by guessing
+// keys.
+func idempotencyScope(r *http.Request, key string) string {
+	var caller string
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		caller = principal.Subject
+	}
+	return strings.Join([]string{
+		tenant.OrganizationFromContext(r.Context()),
+		caller,
+		r.Pattern,
+		key,
+	}, "\x00")
+}
+
+// recordingWriter passes the response through and keeps a copy of it.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+type MergePolicyController struct {
+	mergePolicyUC usecase.MergePolicyUsecase
+	logger        *zap.Logger
+}
+
+func NewMergePolicyController(mergePolicyUC usecase.MergePolicyUsecase, logger *zap.Logger) *MergePolicyController {
+	return &MergePolicyController{
+		mergePolicyUC: mergePolicyUC,
+		logger:        logger,
+	}
+}
+
+func (c *MergePolicyController) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	var req MergePolicyDTO
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	policy, err := c.mergePolicyUC.SetPolicy(r.Context(), entity.MergePolicy{
+		TeamName:          req.TeamName,
+		MinApprovals:      req.MinApprovals,
+		ChecklistComplete: req.ChecklistComplete,
+		NotOverdue:        req.NotOverdue,
+	})
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to set merge policy", err)
+		return
+	}
+
+	response := struct {
+		Policy MergePolicyDTO `json:"merge_policy"`
+	}{
+		Policy: MergePolicyToDTO(policy),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *MergePolicyController) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeFieldError(w, "team_name", "is required")
+		return
+	}
+
+	policy, err := c.mergePolicyUC.GetPolicy(r.Context(), teamName)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get merge policy", err)
+		return
+	}
+
+	response := struct {
+		Policy MergePolicyDTO `json:"merge_policy"`
+	}{
+		Policy: MergePolicyToDTO(policy),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *MergePolicyController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *MergePolicyController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+
+	"avito-intro/internal/logging"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+// MetricsController renders review metrics in the Prometheus text format.
+// Review values are computed from the repository on every scrape; error
+// counters come from ErrorMetrics.
+type MetricsController struct {
+	statsUC usecase.StatsUsecase
+	errors  *ErrorMetrics
+	logger  *zap.Logger
+}
+
+func NewMetricsController(statsUC usecase.StatsUsecase, errors *ErrorMetrics, logger *zap.Logger) *MetricsController {
+	return &MetricsController{
+		statsUC: statsUC,
+		errors:  errors,
+		logger:  logger,
+	}
+}
+
+func (c *MetricsController) Metrics(w http.ResponseWriter, r *http.Request) {
+	stats, err := c.statsUC.GetReviewStats(r.Context(), "")
+	if err != nil {
+		logging.From(r.Context(), c.logger).Error("failed to collect metrics", zap.Error(err))
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	out := bufio.NewWriter(w)
+	defer out.Flush()
+
+	fmt.Fprintln(out, "# HELP pr_reviewer_first_response_seconds Time from PR creation to the reviewer's first action.")
+	fmt.Fprintln(out, "# TYPE pr_reviewer_first_response_seconds summary")
+	for _, r := range stats.Reviewers {
+		labels := fmt.Sprintf("user_id=%q,team=%q", r.UserID.String(), r.TeamName)
+		fmt.Fprintf(out, "pr_reviewer_first_response_seconds_sum{%s} %g\n", labels, r.Total.Seconds())
+		fmt.Fprintf(out, "pr_reviewer_first_response_seconds_count{%s} %d\n", labels, r.Responses)
+	}
+
+	fmt.Fprintln(out, "# HELP pr_team_first_response_seconds Time from PR creation to the first action of a team's reviewers.")
+	fmt.Fprintln(out, "# TYPE pr_team_first_response_seconds summary")
+	for _, t := range stats.Teams {
+		labels := fmt.Sprintf("team=%q", t.TeamName)
+		fmt.Fprintf(out, "pr_team_first_response_seconds_sum{%s} %g\n", labels, t.Total.Seconds())
+		fmt.Fprintf(out, "pr_team_first_response_seconds_count{%s} %d\n", labels, t.Responses)
+	}
+
+	fmt.Fprintln(out, "# HELP pr_reviewer_http_errors_total Rejected requests by route and error code.")
+	fmt.Fprintln(out, "# TYPE pr_reviewer_http_errors_total counter")
+	for _, e := range c.errors.Counts() {
+		fmt.Fprintf(out, "pr_reviewer_http_errors_total{route=%q,code=%q} %d\n", e.Route, string(e.Code), e.Count)
+	}
+}
@@ -0,0 +1,288 @@
+package controller
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"avito-intro/internal/auth"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/tenant"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	sessionCookieName = "pr_reviewer_session"
+	requestIDHeader   = "X-Request-ID"
+	maxRequestIDLen   = 128
+)
+
+type AuthMiddleware struct {
+	sessions *auth.SessionStore
+	tokens   *auth.APITokenStore
+	authz    *auth.Authorizer
+	roleUC   usecase.RoleUsecase
+	logger   *zap.Logger
+}
+
+func NewAuthMiddleware(sessions *auth.SessionStore, tokens *auth.APITokenStore, authz *auth.Authorizer, roleUC usecase.RoleUsecase, logger *zap.Logger) *AuthMiddleware {
+	return &AuthMiddleware{
+		sessions: sessions,
+		tokens:   tokens,
+		authz:    authz,
+		roleUC:   roleUC,
+		logger:   logger,
+	}
+}
+
+// Require rejects requests without a valid session or API token and stores
+// the authenticated principal in the request context. Requests made with an
+// API token always run in the token's organization. Roles granted to the
+// user linked to the principal are added to the principal's own roles.
+func (m *AuthMiddleware) Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := requestToken(r)
+		if token == "" {
+			m.sendError(w, http.StatusUnauthorized, ErrorCodeUnauthorized, "authentication required")
+			return
+		}
+
+		principal, err := m.authenticate(r.Context(), token)
+		if errors.Is(err, auth.ErrInvalidAPIToken) || errors.Is(err, auth.ErrInvalidSession) {
+			m.sendError(w, http.StatusUnauthorized, ErrorCodeUnauthorized, err.Error())
+			return
+		}
+		if err != nil {
+			logging.From(r.Context(), m.logger).Error("failed to authenticate", zap.Error(err))
+			m.sendError(w, http.StatusInternalServerError, ErrorCodeInternal, "internal server error")
+			return
+		}
+
+		ctx := logging.WithActor(r.Context(), principal.Subject)
+		if principal.OrgID != "" {
+			requested := requestedOrganization(r)
+			if requested != "" && requested != principal.OrgID {
+				m.sendError(w, http.StatusForbidden, ErrorCodeForbidden, "token is not valid for organization "+requested)
+				return
+			}
+			ctx = tenant.WithOrganization(ctx, principal.OrgID)
+			if requested == "" {
+				ctx = logging.WithFields(ctx, zap.String("org_id", principal.OrgID))
+			}
+		}
+
+		if principal.UserID != uuid.Nil {
+			granted, err := m.roleUC.GetUserRoles(ctx, principal.UserID)
+			if err != nil && !errors.Is(err, usecase.ErrNotFound) {
+				logging.From(ctx, m.logger).Error("failed to get user roles", zap.Error(err))
+				m.sendError(w, http.StatusInternalServerError, ErrorCodeInternal, "internal server error")
+				return
+			}
+			for _, role := range granted {
+				if !principal.HasRole(string(role)) {
+					principal.Roles = append(principal.Roles, string(role))
+				}
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(ctx, principal)))
+	})
+}
+
+// RequirePermission rejects authenticated principals the permission matrix
+// does not allow to perform action. Anonymous requests are let through:
+// routes that need a principal are wrapped in Require, which runs first.
+func (m *AuthMiddleware) RequirePermission(action auth.Action, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.PrincipalFromContext(r.Context())
+		if ok && !m.authz.Allows(principal.Roles, action) {
+			m.sendError(w, http.StatusForbidden, ErrorCodeForbidden,
+				fmt.Sprintf("%s requires one of roles %v", action, m.authz.Roles(action)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Optional authenticates requests that carry a token, the same way Require
+// does, and lets anonymous requests through unchanged.
+func (m *AuthMiddleware) Optional(next http.Handler) http.Handler {
+	require := m.Require(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestToken(r) == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		require.ServeHTTP(w, r)
+	})
+}
+
+func (m *AuthMiddleware) authenticate(ctx context.Context, token string) (auth.Principal, error) {
+	apiToken, err := m.tokens.Lookup(ctx, token)
+	if err == nil {
+		return apiToken.Principal(), nil
+	}
+	if auth.IsAPIToken(token) || !errors.Is(err, auth.ErrInvalidAPIToken) {
+		return auth.Principal{}, err
+	}
+
+	session, err := m.sessions.Lookup(token)
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	return session.Principal, nil
+}
+
+func requestToken(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// actingUser resolves the user a request acts as. An authenticated
+// principal acts as its linked user, and a user_id in the body may only
+// repeat it. The body is taken at its word for anonymous requests, which
+// are let through only while authentication is not required.
+func actingUser(w http.ResponseWriter, r *http.Request, bodyUserID string, authRequired bool) (uuid.UUID, bool) {
+	var bodyID uuid.UUID
+	if bodyUserID != "" {
+		id, err := uuid.Parse(bodyUserID)
+		if err != nil {
+			writeFieldError(w, "user_id", "must be a UUID")
+			return uuid.Nil, false
+		}
+		bodyID = id
+	}
+
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		if authRequired {
+			writeError(w, http.StatusUnauthorized, ErrorCodeUnauthorized, "authentication required")
+			return uuid.Nil, false
+		}
+		if bodyID == uuid.Nil {
+			writeFieldError(w, "user_id", "is required")
+			return uuid.Nil, false
+		}
+		return bodyID, true
+	}
+
+	if principal.UserID == uuid.Nil {
+		writeError(w, http.StatusForbidden, ErrorCodeForbidden, "the authenticated principal is not linked to a user")
+		return uuid.Nil, false
+	}
+	if bodyID != uuid.Nil && bodyID != principal.UserID {
+		writeError(w, http.StatusForbidden, ErrorCodeForbidden, "user_id must be the authenticated user")
+		return uuid.Nil, false
+	}
+	return principal.UserID, true
+}
+
+func (m *AuthMiddleware) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
+
+// RequestContext tags the request context with a request ID (taken from
+// X-Request-ID or generated) and the W3C traceparent trace ID, so every log
+// line written while serving the request can be correlated.
+func RequestContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLen {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+
+		ctx := logging.WithRequestID(r.Context(), requestID)
+		if traceID, ok := traceIDFromTraceparent(r.Header.Get("traceparent")); ok {
+			ctx = logging.WithTraceID(ctx, traceID)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// traceIDFromTraceparent extracts the trace ID from a header of the form
+// version-traceid-parentid-flags.
+func traceIDFromTraceparent(header string) (string, bool) {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || strings.Trim(parts[1], "0") == "" {
+		return "", false
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// MetricsRecorder is the push-based metrics sink used by MetricsMiddleware.
+type MetricsRecorder interface {
+	Count(name string, value int64, tags ...string)
+	Timing(name string, d time.Duration, tags ...string)
+}
+
+type MetricsMiddleware struct {
+	recorder MetricsRecorder
+}
+
+func NewMetricsMiddleware(recorder MetricsRecorder) *MetricsMiddleware {
+	return &MetricsMiddleware{recorder: recorder}
+}
+
+// Instrument counts requests and records their latency, tagged by method,
+// matched route and response status.
+func (m *MetricsMiddleware) Instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(sw, r)
+
+		tags := []string{
+			"method:" + r.Method,
+			"route:" + routeLabel(r),
+			"status:" + strconv.Itoa(sw.status),
+		}
+
+		m.recorder.Count("http.requests", 1, tags...)
+		m.recorder.Timing("http.request.duration", time.Since(start), tags...)
+	})
+}
+
+// routeLabel is the path of the route pattern that matched r, without the
+// method, so metrics do not explode on path parameters.
+func routeLabel(r *http.Request) string {
+	if r.Pattern == "" {
+		return "unmatched"
+	}
+	if _, path, found := strings.Cut(r.Pattern, " "); found {
+		return path
+	}
+	return r.Pattern
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,274 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type MilestoneController struct {
+	milestoneUC usecase.MilestoneUsecase
+	logger      *zap.Logger
+}
+
+func NewMilestoneController(milestoneUC usecase.MilestoneUsecase, logger *zap.Logger) *MilestoneController {
+	return &MilestoneController{
+		milestoneUC: milestoneUC,
+		logger:      logger,
+	}
+}
+
+type milestoneRequest struct {
+	MilestoneID string  `json:"milestone_id"`
+	Title       string  `json:"title" required:"true"`
+	Description string  `json:"description"`
+	DueDate     *string `json:"due_date"`
+}
+
+func (req milestoneRequest) toEntity(requireID bool) (entity.Milestone, error) {
+	var milestone entity.Milestone
+
+	if req.MilestoneID != "" || requireID {
+		id, err := uuid.Parse(req.MilestoneID)
+		if err != nil {
+			return entity.Milestone{}, invalidField("milestone_id", "must be a UUID")
+		}
+		milestone.MilestoneID = id
+	}
+
+	milestone.Title = strings.TrimSpace(req.Title)
+	if milestone.Title == "" {
+		return entity.Milestone{}, invalidField("title", "is required")
+	}
+	milestone.Description = req.Description
+
+	if req.DueDate != nil && *req.DueDate != "" {
+		dueDate, err := time.Parse(time.RFC3339, *req.DueDate)
+		if err != nil {
+			return entity.Milestone{}, invalidField("due_date", "must be an RFC 3339 timestamp")
+		}
+		milestone.DueDate = &dueDate
+	}
+
+	return milestone, nil
+}
+
+func (c *MilestoneController) CreateMilestone(w http.ResponseWriter, r *http.Request) {
+	var req milestoneRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	milestone, err := req.toEntity(false)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	created, err := c.milestoneUC.CreateMilestone(r.Context(), milestone)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to create milestone", err)
+		return
+	}
+
+	response := struct {
+		Milestone MilestoneDTO `json:"milestone"`
+	}{
+		Milestone: MilestoneToDTO(created),
+	}
+
+	c.sendJSON(w, http.StatusCreated, response)
+}
+
+func (c *MilestoneController) GetMilestone(w http.ResponseWriter, r *http.Request) {
+	milestoneID, ok := c.milestoneIDFromQuery(w, r)
+	if !ok {
+		return
+	}
+
+	milestone, err := c.milestoneUC.GetMilestone(r.Context(), milestoneID)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get milestone", err)
+		return
+	}
+
+	response := struct {
+		Milestone MilestoneDTO `json:"milestone"`
+	}{
+		Milestone: MilestoneToDTO(milestone),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *MilestoneController) ListMilestones(w http.ResponseWriter, r *http.Request) {
+	milestones, err := c.milestoneUC.ListMilestones(r.Context())
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to list milestones", err)
+		return
+	}
+
+	milestoneDTOs := make([]MilestoneDTO, len(milestones))
+	for i, milestone := range milestones {
+		milestoneDTOs[i] = MilestoneToDTO(milestone)
+	}
+
+	response := struct {
+		Milestones []MilestoneDTO `json:"milestones"`
+	}{
+		Milestones: milestoneDTOs,
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *MilestoneController) UpdateMilestone(w http.ResponseWriter, r *http.Request) {
+	var req milestoneRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	milestone, err := req.toEntity(true)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	updated, err := c.milestoneUC.UpdateMilestone(r.Context(), milestone)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to update milestone", err)
+		return
+	}
+
+	response := struct {
+		Milestone MilestoneDTO `json:"milestone"`
+	}{
+		Milestone: MilestoneToDTO(updated),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+type milestoneIDRequest struct {
+	MilestoneID string `json:"milestone_id" required:"true"`
+}
+
+type setPRMilestoneRequest struct {
+	PullRequestID string  `json:"pull_request_id" required:"true"`
+	MilestoneID   *string `json:"milestone_id"`
+}
+
+func (c *MilestoneController) DeleteMilestone(w http.ResponseWriter, r *http.Request) {
+	var req milestoneIDRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	milestoneID, err := uuid.Parse(req.MilestoneID)
+	if err != nil {
+		writeFieldError(w, "milestone_id", "must be a UUID")
+		return
+	}
+
+	if err := c.milestoneUC.DeleteMilestone(r.Context(), milestoneID); err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to delete milestone", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *MilestoneController) GetMilestoneStats(w http.ResponseWriter, r *http.Request) {
+	milestoneID, ok := c.milestoneIDFromQuery(w, r)
+	if !ok {
+		return
+	}
+
+	stats, err := c.milestoneUC.GetMilestoneStats(r.Context(), milestoneID)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get milestone stats", err)
+		return
+	}
+
+	response := struct {
+		Stats MilestoneStatsDTO `json:"stats"`
+	}{
+		Stats: MilestoneStatsToDTO(stats),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *MilestoneController) SetPRMilestone(w http.ResponseWriter, r *http.Request) {
+	var req setPRMilestoneRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prID, err := uuid.Parse(req.PullRequestID)
+	if err != nil {
+		writeFieldError(w, "pull_request_id", "must be a UUID")
+		return
+	}
+
+	var milestoneID *uuid.UUID
+	if req.MilestoneID != nil && *req.MilestoneID != "" {
+		id, err := uuid.Parse(*req.MilestoneID)
+		if err != nil {
+			writeFieldError(w, "milestone_id", "must be a UUID")
+			return
+		}
+		milestoneID = &id
+	}
+
+	pr, err := c.milestoneUC.SetPRMilestone(r.Context(), prID, milestoneID)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to set PR milestone", err)
+		return
+	}
+
+	response := struct {
+		PR PullRequestDTO `json:"pr"`
+	}{
+		PR: PullRequestToDTO(pr),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *MilestoneController) milestoneIDFromQuery(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	raw := r.URL.Query().Get("milestone_id")
+	if raw == "" {
+		writeFieldError(w, "milestone_id", "is required")
+		return uuid.Nil, false
+	}
+
+	milestoneID, err := uuid.Parse(raw)
+	if err != nil {
+		writeFieldError(w, "milestone_id", "must be a UUID")
+		return uuid.Nil, false
+	}
+
+	return milestoneID, true
+}
+
+func (c *MilestoneController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *MilestoneController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/tenant"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+const organizationHeader = "X-Organization-ID"
+
+// orgExemptPrefixes are served without an organization even when one is
+// required: probes, metrics, login, organization management itself and the
+// static frontend.
+var orgExemptPrefixes = []string{"/readyz", "/metrics", "/auth/", "/org/", "/ui"}
+
+type OrganizationController struct {
+	orgUC    usecase.OrganizationUsecase
+	required bool
+	logger   *zap.Logger
+}
+
+func NewOrganizationController(orgUC usecase.OrganizationUsecase, required bool, logger *zap.Logger) *OrganizationController {
+	return &OrganizationController{
+		orgUC:    orgUC,
+		required: required,
+		logger:   logger,
+	}
+}
+
+type createOrganizationRequest struct {
+	OrgID string `json:"org_id" required:"true"`
+	Name  string `json:"name"`
+}
+
+func (c *OrganizationController) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req createOrganizationRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	org, err := c.orgUC.CreateOrganization(r.Context(), entity.Organization{OrgID: req.OrgID, Name: req.Name})
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to create organization", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusCreated, map[string]interface{}{
+		"organization": OrganizationToDTO(org),
+	})
+}
+
+func (c *OrganizationController) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	orgs, err := c.orgUC.ListOrganizations(r.Context())
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to list organizations", err)
+		return
+	}
+
+	dtos := make([]OrganizationDTO, len(orgs))
+	for i, org := range orgs {
+		dtos[i] = OrganizationToDTO(org)
+	}
+	c.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"organizations": dtos,
+	})
+}
+
+// Scope resolves the organization of the request from the X-Organization-ID
+// header (or the org_id query parameter) and stores it in the request
+// context, so the repository only sees that organization's data. Without
+// one the request is served from the default organization, unless
+// organizations are required.
+func (c *OrganizationController) Scope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orgID := requestedOrganization(r)
+
+		if orgID == "" {
+			if c.required && !orgExempt(r.URL.Path) {
+				c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, organizationHeader+" header is required")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if _, err := c.orgUC.GetOrganization(r.Context(), orgID); err != nil {
+			if errors.Is(err, usecase.ErrNotFound) {
+				c.sendError(w, http.StatusNotFound, ErrorCodeOrgNotFound, "organization not found")
+				return
+			}
+			writeUsecaseError(w, r, c.logger, "failed to resolve organization", err)
+			return
+		}
+
+		ctx := tenant.WithOrganization(r.Context(), orgID)
+		ctx = logging.WithFields(ctx, zap.String("org_id", orgID))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func requestedOrganization(r *http.Request) string {
+	if orgID := r.Header.Get(organizationHeader); orgID != "" {
+		return orgID
+	}
+	return r.URL.Query().Get("org_id")
+}
+
+func orgExempt(path string) bool {
+	for _, prefix := range orgExemptPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *OrganizationController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *OrganizationController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
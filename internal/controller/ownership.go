@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type OwnershipController struct {
+	ownershipUC usecase.OwnershipUsecase
+	logger      *zap.Logger
+}
+
+func NewOwnershipController(ownershipUC usecase.OwnershipUsecase, logger *zap.Logger) *OwnershipController {
+	return &OwnershipController{
+		ownershipUC: ownershipUC,
+		logger:      logger,
+	}
+}
+
+type teamOwnersDTO struct {
+	TeamName string   `json:"team_name" required:"true"`
+	Owners   []string `json:"owners" required:"true"`
+}
+
+func (c *OwnershipController) SetTeamOwners(w http.ResponseWriter, r *http.Request) {
+	var req teamOwnersDTO
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	owners := make([]uuid.UUID, len(req.Owners))
+	for i, raw := range req.Owners {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			writeFieldError(w, fmt.Sprintf("owners[%d]", i), "must be a UUID")
+			return
+		}
+		owners[i] = id
+	}
+
+	saved, err := c.ownershipUC.SetTeamOwners(r.Context(), req.TeamName, owners)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to set team owners", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, teamOwnersResponse(req.TeamName, saved))
+}
+
+func (c *OwnershipController) GetTeamOwners(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeFieldError(w, "team_name", "is required")
+		return
+	}
+
+	owners, err := c.ownershipUC.GetTeamOwners(r.Context(), teamName)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get team owners", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, teamOwnersResponse(teamName, owners))
+}
+
+func teamOwnersResponse(teamName string, owners []uuid.UUID) teamOwnersDTO {
+	ids := make([]string, len(owners))
+	for i, id := range owners {
+		ids[i] = id.String()
+	}
+	return teamOwnersDTO{
+		TeamName: teamName,
+		Owners:   ids,
+	}
+}
+
+type componentOwnerDTO struct {
+	Path   string   `json:"path" required:"true"`
+	Owners []string `json:"owners" required:"true"`
+}
+
+type componentOwnersDTO struct {
+	TeamName   string              `json:"team_name" required:"true"`
+	Components []componentOwnerDTO `json:"components" required:"true"`
+}
+
+func (c *OwnershipController) SetComponentOwners(w http.ResponseWriter, r *http.Request) {
+	var req componentOwnersDTO
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	components := make([]entity.ComponentOwner, len(req.Components))
+	for i, component := range req.Components {
+		owners := make([]uuid.UUID, len(component.Owners))
+		for j, raw := range component.Owners {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				writeFieldError(w, fmt.Sprintf("components[%d].owners[%d]", i, j), "must be a UUID")
+				return
+			}
+			owners[j] = id
+		}
+		components[i] = entity.ComponentOwner{Path: component.Path, Owners: owners}
+	}
+
+	saved, err := c.ownershipUC.SetComponentOwners(r.Context(), req.TeamName, components)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to set component owners", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, componentOwnersResponse(req.TeamName, saved))
+}
+
+func (c *OwnershipController) GetComponentOwners(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeFieldError(w, "team_name", "is required")
+		return
+	}
+
+	components, err := c.ownershipUC.GetComponentOwners(r.Context(), teamName)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get component owners", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, componentOwnersResponse(teamName, components))
+}
+
+func componentOwnersResponse(teamName string, components []entity.ComponentOwner) componentOwnersDTO {
+	dtos := make([]componentOwnerDTO, len(components))
+	for i, component := range components {
+		dtos[i] = componentOwnerDTO{
+			Path:   component.Path,
+			Owners: teamOwnersResponse(teamName, component.Owners).Owners,
+		}
+	}
+	return componentOwnersDTO{
+		TeamName:   teamName,
+		Components: dtos,
+	}
+}
+
+func (c *OwnershipController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *OwnershipController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
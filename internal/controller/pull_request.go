@@ -2,10 +2,13 @@ package controller
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
-	"avito-intro/internal/repository"
+	"avito-intro/internal/entity"
 	"avito-intro/internal/usecase"
 
 	"github.com/google/uuid"
@@ -13,53 +16,103 @@ import (
 )
 
 type PullRequestController struct {
-	prUC   usecase.PullRequestUsecase
-	logger *zap.Logger
+	prUC usecase.PullRequestUsecase
+	// authRequired makes actions taken on behalf of a user need that user
+	// to be authenticated; otherwise the body names them.
+	authRequired bool
+	logger       *zap.Logger
 }
 
-func NewPullRequestController(prUC usecase.PullRequestUsecase, logger *zap.Logger) *PullRequestController {
+func NewPullRequestController(prUC usecase.PullRequestUsecase, authRequired bool, logger *zap.Logger) *PullRequestController {
 	return &PullRequestController{
-		prUC:   prUC,
-		logger: logger,
+		prUC:         prUC,
+		authRequired: authRequired,
+		logger:       logger,
 	}
 }
 
-func (c *PullRequestController) CreatePR(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
-	}
+type createPRRequest struct {
+	PullRequestID   string   `json:"pull_request_id" required:"true"`
+	PullRequestName string   `json:"pull_request_name" required:"true"`
+	AuthorID        string   `json:"author_id" required:"true"`
+	ExternalID      string   `json:"external_id"`
+	Reviewers       []string `json:"reviewers"`
+}
+
+type pullRequestIDRequest struct {
+	PullRequestID string `json:"pull_request_id" required:"true"`
+}
+
+// approvePRRequest names the acting user in user_id only when the request
+// is not authenticated; see actingUser.
+type approvePRRequest struct {
+	PullRequestID string `json:"pull_request_id" required:"true"`
+	UserID        string `json:"user_id"`
+}
+
+// overrideApprovalRequest names the lead in user_id only when the request
+// is not authenticated; see actingUser.
+type overrideApprovalRequest struct {
+	PullRequestID string `json:"pull_request_id" required:"true"`
+	UserID        string `json:"user_id"`
+	Reason        string `json:"reason" required:"true"`
+}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid request body")
+// updatePRRequest holds the fields to change; omitted ones are kept.
+type updatePRRequest struct {
+	PullRequestID   string  `json:"pull_request_id" required:"true"`
+	PullRequestName *string `json:"pull_request_name"`
+}
+
+type setAutoMergeRequest struct {
+	PullRequestID string `json:"pull_request_id" required:"true"`
+	AutoMerge     *bool  `json:"auto_merge" required:"true"`
+}
+
+type reassignReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" required:"true"`
+	OldUserID     string `json:"old_user_id" required:"true"`
+}
+
+// declineReviewRequest names the reviewer in user_id only when the request
+// is not authenticated; see actingUser.
+type declineReviewRequest struct {
+	PullRequestID string `json:"pull_request_id" required:"true"`
+	UserID        string `json:"user_id"`
+	Reason        string `json:"reason" required:"true"`
+}
+
+func (c *PullRequestController) CreatePR(w http.ResponseWriter, r *http.Request) {
+	var req createPRRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
 		return
 	}
 
 	prID, err := uuid.Parse(req.PullRequestID)
 	if err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid pull_request_id format")
+		writeFieldError(w, "pull_request_id", "must be a UUID")
 		return
 	}
 
 	authorID, err := uuid.Parse(req.AuthorID)
 	if err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid author_id format")
+		writeFieldError(w, "author_id", "must be a UUID")
 		return
 	}
 
-	pr, err := c.prUC.CreatePR(r.Context(), prID, req.PullRequestName, authorID)
-	if err != nil {
-		if errors.Is(err, repository.ErrAlreadyExists) {
-			c.sendError(w, http.StatusConflict, ErrorCodePRExists, "PR id already exists")
-			return
-		}
-		if errors.Is(err, repository.ErrNotFound) {
-			c.sendError(w, http.StatusNotFound, ErrorCodeNotFound, "author or team not found")
+	reviewers := make([]uuid.UUID, len(req.Reviewers))
+	for i, id := range req.Reviewers {
+		reviewers[i], err = uuid.Parse(id)
+		if err != nil {
+			writeFieldError(w, fmt.Sprintf("reviewers[%d]", i), "must be a UUID")
 			return
 		}
-		c.logger.Error("failed to create PR", zap.Error(err))
-		c.sendError(w, http.StatusInternalServerError, ErrorCodeInvalidInput, "internal server error")
+	}
+
+	pr, created, err := c.prUC.CreatePR(r.Context(), prID, req.PullRequestName, authorID, req.ExternalID, reviewers)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to create PR", err)
 		return
 	}
 
@@ -69,33 +122,57 @@ func (c *PullRequestController) CreatePR(w http.ResponseWriter, r *http.Request)
 		PR: PullRequestToDTO(pr),
 	}
 
-	c.sendJSON(w, http.StatusCreated, response)
+	status := http.StatusCreated
+	if !created {
+		status = http.StatusOK
+	}
+	c.sendJSON(w, status, response)
 }
 
 func (c *PullRequestController) MergePR(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		PullRequestID string `json:"pull_request_id"`
+	var req pullRequestIDRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prID, err := uuid.Parse(req.PullRequestID)
+	if err != nil {
+		writeFieldError(w, "pull_request_id", "must be a UUID")
+		return
+	}
+
+	pr, err := c.prUC.MergePR(r.Context(), prID)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to merge PR", err)
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid request body")
+	response := struct {
+		PR PullRequestDTO `json:"pr"`
+	}{
+		PR: PullRequestToDTO(pr),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *PullRequestController) ClosePR(w http.ResponseWriter, r *http.Request) {
+	var req pullRequestIDRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
 		return
 	}
 
 	prID, err := uuid.Parse(req.PullRequestID)
 	if err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid pull_request_id format")
+		writeFieldError(w, "pull_request_id", "must be a UUID")
 		return
 	}
 
-	pr, err := c.prUC.MergePR(r.Context(), prID)
+	pr, err := c.prUC.ClosePR(r.Context(), prID)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			c.sendError(w, http.StatusNotFound, ErrorCodeNotFound, "PR not found")
-			return
-		}
-		c.logger.Error("failed to merge PR", zap.Error(err))
-		c.sendError(w, http.StatusInternalServerError, ErrorCodeInvalidInput, "internal server error")
+		writeUsecaseError(w, r, c.logger, "failed to close PR", err)
 		return
 	}
 
@@ -108,61 +185,415 @@ func (c *PullRequestController) MergePR(w http.ResponseWriter, r *http.Request)
 	c.sendJSON(w, http.StatusOK, response)
 }
 
-func (c *PullRequestController) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		PullRequestID string `json:"pull_request_id"`
-		OldUserID     string `json:"old_user_id"`
+func (c *PullRequestController) ApprovePR(w http.ResponseWriter, r *http.Request) {
+	var req approvePRRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prID, err := uuid.Parse(req.PullRequestID)
+	if err != nil {
+		writeFieldError(w, "pull_request_id", "must be a UUID")
+		return
+	}
+
+	reviewerID, ok := actingUser(w, r, req.UserID, c.authRequired)
+	if !ok {
+		return
+	}
+
+	pr, err := c.prUC.ApprovePR(r.Context(), prID, reviewerID)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to approve PR", err)
+		return
+	}
+
+	response := struct {
+		PR PullRequestDTO `json:"pr"`
+	}{
+		PR: PullRequestToDTO(pr),
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid request body")
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *PullRequestController) RequestChanges(w http.ResponseWriter, r *http.Request) {
+	var req approvePRRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prID, err := uuid.Parse(req.PullRequestID)
+	if err != nil {
+		writeFieldError(w, "pull_request_id", "must be a UUID")
+		return
+	}
+
+	reviewerID, ok := actingUser(w, r, req.UserID, c.authRequired)
+	if !ok {
+		return
+	}
+
+	pr, err := c.prUC.RequestChanges(r.Context(), prID, reviewerID)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to request changes on PR", err)
+		return
+	}
+
+	response := struct {
+		PR PullRequestDTO `json:"pr"`
+	}{
+		PR: PullRequestToDTO(pr),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *PullRequestController) ReopenPR(w http.ResponseWriter, r *http.Request) {
+	var req approvePRRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prID, err := uuid.Parse(req.PullRequestID)
+	if err != nil {
+		writeFieldError(w, "pull_request_id", "must be a UUID")
+		return
+	}
+
+	authorID, ok := actingUser(w, r, req.UserID, c.authRequired)
+	if !ok {
+		return
+	}
+
+	pr, err := c.prUC.ReopenPR(r.Context(), prID, authorID)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to reopen PR", err)
+		return
+	}
+
+	response := struct {
+		PR PullRequestDTO `json:"pr"`
+	}{
+		PR: PullRequestToDTO(pr),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *PullRequestController) OverrideApproval(w http.ResponseWriter, r *http.Request) {
+	var req overrideApprovalRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prID, err := uuid.Parse(req.PullRequestID)
+	if err != nil {
+		writeFieldError(w, "pull_request_id", "must be a UUID")
+		return
+	}
+
+	leadID, ok := actingUser(w, r, req.UserID, c.authRequired)
+	if !ok {
+		return
+	}
+
+	pr, err := c.prUC.OverrideApproval(r.Context(), prID, leadID, req.Reason)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to override PR approval", err)
+		return
+	}
+
+	response := struct {
+		PR PullRequestDTO `json:"pr"`
+	}{
+		PR: PullRequestToDTO(pr),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *PullRequestController) UpdatePR(w http.ResponseWriter, r *http.Request) {
+	var req updatePRRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
 		return
 	}
 
 	prID, err := uuid.Parse(req.PullRequestID)
 	if err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid pull_request_id format")
+		writeFieldError(w, "pull_request_id", "must be a UUID")
+		return
+	}
+
+	pr, err := c.prUC.UpdatePR(r.Context(), prID, entity.PullRequestUpdate{Name: req.PullRequestName})
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to update PR", err)
+		return
+	}
+
+	response := struct {
+		PR PullRequestDTO `json:"pr"`
+	}{
+		PR: PullRequestToDTO(pr),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *PullRequestController) SetAutoMerge(w http.ResponseWriter, r *http.Request) {
+	var req setAutoMergeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prID, err := uuid.Parse(req.PullRequestID)
+	if err != nil {
+		writeFieldError(w, "pull_request_id", "must be a UUID")
+		return
+	}
+
+	pr, err := c.prUC.SetAutoMerge(r.Context(), prID, *req.AutoMerge)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to set PR auto-merge", err)
+		return
+	}
+
+	response := struct {
+		PR PullRequestDTO `json:"pr"`
+	}{
+		PR: PullRequestToDTO(pr),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *PullRequestController) DeclineReview(w http.ResponseWriter, r *http.Request) {
+	var req declineReviewRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prID, err := uuid.Parse(req.PullRequestID)
+	if err != nil {
+		writeFieldError(w, "pull_request_id", "must be a UUID")
+		return
+	}
+
+	reviewerID, ok := actingUser(w, r, req.UserID, c.authRequired)
+	if !ok {
+		return
+	}
+
+	pr, newReviewerID, err := c.prUC.DeclineReview(r.Context(), prID, reviewerID, req.Reason)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to decline review", err)
+		return
+	}
+
+	response := struct {
+		PR         PullRequestDTO `json:"pr"`
+		ReplacedBy string         `json:"replaced_by"`
+	}{
+		PR:         PullRequestToDTO(pr),
+		ReplacedBy: newReviewerID.String(),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *PullRequestController) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
+	var req reassignReviewerRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prID, err := uuid.Parse(req.PullRequestID)
+	if err != nil {
+		writeFieldError(w, "pull_request_id", "must be a UUID")
 		return
 	}
 
 	oldReviewerID, err := uuid.Parse(req.OldUserID)
 	if err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid old_user_id format")
+		writeFieldError(w, "old_user_id", "must be a UUID")
 		return
 	}
 
 	pr, newReviewerID, err := c.prUC.ReassignReviewer(r.Context(), prID, oldReviewerID)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			c.sendError(w, http.StatusNotFound, ErrorCodeNotFound, "PR or user not found")
+		writeUsecaseError(w, r, c.logger, "failed to reassign reviewer", err)
+		return
+	}
+
+	response := struct {
+		PR         PullRequestDTO `json:"pr"`
+		ReplacedBy string         `json:"replaced_by"`
+	}{
+		PR:         PullRequestToDTO(pr),
+		ReplacedBy: newReviewerID.String(),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *PullRequestController) GetOverduePRs(w http.ResponseWriter, r *http.Request) {
+	var olderThan time.Duration
+	if olderThanStr := r.URL.Query().Get("older_than"); olderThanStr != "" {
+		d, err := time.ParseDuration(olderThanStr)
+		if err != nil || d <= 0 {
+			writeFieldError(w, "older_than", "must be a positive duration like 48h")
+			return
+		}
+		olderThan = d
+	}
+
+	prs, err := c.prUC.GetOverduePRs(r.Context(), olderThan)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get overdue PRs", err)
+		return
+	}
+
+	prDTOs := make([]PullRequestDTO, len(prs))
+	for i, pr := range prs {
+		prDTOs[i] = PullRequestToDTO(pr)
+	}
+
+	response := struct {
+		PullRequests []PullRequestDTO `json:"pull_requests"`
+	}{
+		PullRequests: prDTOs,
+	}
+
+	sendCachedJSON(w, r, response)
+}
+
+func (c *PullRequestController) GetTeamPRs(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeFieldError(w, "team_name", "is required")
+		return
+	}
+
+	var filter entity.PullRequestFilter
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		status := entity.PullRequestStatus(strings.ToUpper(statusStr))
+		if !status.IsValid() {
+			writeFieldError(w, "status", "must be OPEN, MERGED, CLOSED or CHANGES_REQUESTED")
 			return
 		}
-		if errors.Is(err, usecase.ErrPRMerged) {
-			c.sendError(w, http.StatusConflict, ErrorCodePRMerged, "cannot reassign on merged PR")
+		filter.Status = &status
+	}
+	filter.NameQuery = strings.TrimSpace(r.URL.Query().Get("q"))
+	if err := parseSortParams(r.URL.Query(), &filter.SortBy, &filter.Order); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prs, err := c.prUC.GetTeamPRs(r.Context(), teamName, filter)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get team PRs", err)
+		return
+	}
+
+	prDTOs := make([]PullRequestDTO, len(prs))
+	for i, pr := range prs {
+		prDTOs[i] = PullRequestToDTO(pr)
+	}
+
+	response := struct {
+		TeamName     string           `json:"team_name"`
+		PullRequests []PullRequestDTO `json:"pull_requests"`
+	}{
+		TeamName:     teamName,
+		PullRequests: prDTOs,
+	}
+
+	sendCachedJSON(w, r, response)
+}
+
+// ListPRs returns PRs of every team page by page. cursor continues a
+// listing: pass the next_cursor of the previous response, which is absent
+// on the last page, with the same filters and sort.
+func (c *PullRequestController) ListPRs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := entity.PullRequestListFilter{
+		TeamName: query.Get("team_name"),
+		Limit:    defaultPageSize,
+	}
+	if statusStr := query.Get("status"); statusStr != "" {
+		status := entity.PullRequestStatus(strings.ToUpper(statusStr))
+		if !status.IsValid() {
+			writeFieldError(w, "status", "must be OPEN, MERGED, CLOSED or CHANGES_REQUESTED")
 			return
 		}
-		if errors.Is(err, usecase.ErrNotAssigned) {
-			c.sendError(w, http.StatusConflict, ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+		filter.Status = &status
+	}
+	if authorIDStr := query.Get("author_id"); authorIDStr != "" {
+		authorID, err := uuid.Parse(authorIDStr)
+		if err != nil {
+			writeFieldError(w, "author_id", "must be a UUID")
 			return
 		}
-		if errors.Is(err, usecase.ErrNoCandidate) {
-			c.sendError(w, http.StatusConflict, ErrorCodeNoCandidate, "no active replacement candidate in team")
+		filter.AuthorID = &authorID
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseTimeParam(query, "created_from"); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if filter.CreatedTo, err = parseTimeParam(query, "created_to"); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if err := parseSortParams(query, &filter.SortBy, &filter.Order); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if filter.After, err = parseCursorParam(query, "cursor"); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			writeFieldError(w, "limit", fmt.Sprintf("must be between 1 and %d", maxPageSize))
 			return
 		}
-		c.logger.Error("failed to reassign reviewer", zap.Error(err))
-		c.sendError(w, http.StatusInternalServerError, ErrorCodeInvalidInput, "internal server error")
+		filter.Limit = limit
+	}
+
+	page, err := c.prUC.ListPRs(r.Context(), filter)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to list PRs", err)
 		return
 	}
 
+	prDTOs := make([]PullRequestDTO, len(page.PullRequests))
+	for i, pr := range page.PullRequests {
+		prDTOs[i] = PullRequestToDTO(pr)
+	}
+
 	response := struct {
-		PR         PullRequestDTO `json:"pr"`
-		ReplacedBy string         `json:"replaced_by"`
+		PullRequests []PullRequestDTO `json:"pull_requests"`
+		NextCursor   string           `json:"next_cursor,omitempty"`
 	}{
-		PR:         PullRequestToDTO(pr),
-		ReplacedBy: newReviewerID.String(),
+		PullRequests: prDTOs,
+	}
+	if page.Next != nil {
+		response.NextCursor = encodeCursor(*page.Next)
 	}
 
-	c.sendJSON(w, http.StatusOK, response)
+	sendCachedJSON(w, r, response)
 }
 
 func (c *PullRequestController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -172,8 +603,5 @@ func (c *PullRequestController) sendJSON(w http.ResponseWriter, status int, data
 }
 
 func (c *PullRequestController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
-	resp := ErrorResponse{}
-	resp.Error.Code = code
-	resp.Error.Message = message
-	c.sendJSON(w, status, resp)
+	writeError(w, status, code, message)
 }
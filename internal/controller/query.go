@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+func parsePageParams(query url.Values) (page, pageSize int, err error) {
+	page, pageSize = 1, defaultPageSize
+
+	if raw := query.Get("page"); raw != "" {
+		page, err = strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return 0, 0, invalidField("page", "must be a positive integer")
+		}
+	}
+
+	if raw := query.Get("page_size"); raw != "" {
+		pageSize, err = strconv.Atoi(raw)
+		if err != nil || pageSize < 1 || pageSize > maxPageSize {
+			return 0, 0, invalidField("page_size", fmt.Sprintf("must be between 1 and %d", maxPageSize))
+		}
+	}
+
+	return page, pageSize, nil
+}
+
+// parseTimeParam reads an optional RFC3339 timestamp; nil means the
+// parameter is absent.
+func parseTimeParam(query url.Values, name string) (*time.Time, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, invalidField(name, "must be an RFC 3339 timestamp")
+	}
+	return &t, nil
+}
+
+func parseSortParams(query url.Values, sortBy *entity.PullRequestSortField, order *entity.SortOrder) error {
+	if raw := strings.ToLower(query.Get("sort")); raw != "" {
+		*sortBy = entity.PullRequestSortField(raw)
+		if !sortBy.IsValid() {
+			return invalidField("sort", "must be created_at, merged_at or name")
+		}
+	}
+
+	if raw := strings.ToLower(query.Get("order")); raw != "" {
+		*order = entity.SortOrder(raw)
+		if !order.IsValid() {
+			return invalidField("order", "must be asc or desc")
+		}
+	}
+
+	return nil
+}
+
+// pullRequestCursor is the wire form of entity.PullRequestCursor. Clients
+// get it as opaque unpadded base64url JSON and pass it back unchanged.
+type pullRequestCursor struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	MergedAt  *time.Time `json:"merged_at,omitempty"`
+}
+
+func encodeCursor(cursor entity.PullRequestCursor) string {
+	data, _ := json.Marshal(pullRequestCursor{
+		ID:        cursor.PullRequestID,
+		Name:      cursor.PullRequestName,
+		CreatedAt: cursor.CreatedAt,
+		MergedAt:  cursor.MergedAt,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// parseCursorParam reads an optional cursor made by encodeCursor; nil
+// means the parameter is absent.
+func parseCursorParam(query url.Values, name string) (*entity.PullRequestCursor, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, invalidField(name, "is malformed")
+	}
+	var cursor pullRequestCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, invalidField(name, "is malformed")
+	}
+	return &entity.PullRequestCursor{
+		PullRequestID:   cursor.ID,
+		PullRequestName: cursor.Name,
+		CreatedAt:       cursor.CreatedAt,
+		MergedAt:        cursor.MergedAt,
+	}, nil
+}
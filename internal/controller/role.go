@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type RoleController struct {
+	roleUC usecase.RoleUsecase
+	logger *zap.Logger
+}
+
+func NewRoleController(roleUC usecase.RoleUsecase, logger *zap.Logger) *RoleController {
+	return &RoleController{
+		roleUC: roleUC,
+		logger: logger,
+	}
+}
+
+type userRoleRequest struct {
+	UserID string `json:"user_id" required:"true"`
+	Role   string `json:"role" required:"true"`
+}
+
+type userRolesDTO struct {
+	UserID string   `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
+
+func (c *RoleController) GrantRole(w http.ResponseWriter, r *http.Request) {
+	c.changeRole(w, r, c.roleUC.GrantRole)
+}
+
+func (c *RoleController) RevokeRole(w http.ResponseWriter, r *http.Request) {
+	c.changeRole(w, r, c.roleUC.RevokeRole)
+}
+
+func (c *RoleController) GetUserRoles(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		writeFieldError(w, "user_id", "must be a UUID")
+		return
+	}
+
+	roles, err := c.roleUC.GetUserRoles(r.Context(), userID)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to manage user roles", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, userRolesResponse(userID, roles))
+}
+
+func (c *RoleController) changeRole(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, userID uuid.UUID, role entity.Role) ([]entity.Role, error)) {
+	var req userRoleRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		writeFieldError(w, "user_id", "must be a UUID")
+		return
+	}
+
+	roles, err := change(r.Context(), userID, entity.Role(req.Role))
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to manage user roles", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, userRolesResponse(userID, roles))
+}
+
+func userRolesResponse(userID uuid.UUID, roles []entity.Role) userRolesDTO {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+	return userRolesDTO{
+		UserID: userID.String(),
+		Roles:  names,
+	}
+}
+
+func (c *RoleController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *RoleController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
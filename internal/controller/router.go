@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RouteErrors answers requests mux has no route for with the standard
+// ErrorResponse instead of net/http's plain-text pages: unknown paths get
+// 404 NOT_FOUND, known paths requested with another method 405
+// METHOD_NOT_ALLOWED along with the Allow header the mux computed.
+func RouteErrors(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := mux.Handler(r); pattern != "" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		// Only the mux knows whether another method would have matched;
+		// let its fallback run and keep just the status and Allow header.
+		fallback := &routeErrorWriter{header: make(http.Header)}
+		mux.ServeHTTP(fallback, r)
+
+		if fallback.status == http.StatusMethodNotAllowed {
+			allow := fallback.header.Get("Allow")
+			w.Header().Set("Allow", allow)
+			writeError(w, http.StatusMethodNotAllowed, ErrorCodeMethodNotAllowed,
+				fmt.Sprintf("method %s is not allowed for %s, use %s", r.Method, r.URL.Path, strings.ReplaceAll(allow, ", ", " or ")))
+			return
+		}
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, fmt.Sprintf("no endpoint at %s", r.URL.Path))
+	})
+}
+
+type routeErrorWriter struct {
+	header http.Header
+	status int
+}
+
+func (w *routeErrorWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *routeErrorWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *routeErrorWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return len(b), nil
+}
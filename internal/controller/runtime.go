@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RuntimeController exposes process internals for live debugging when
+// attaching pprof is not an option.
+type RuntimeController struct {
+	config    map[string]string
+	startedAt time.Time
+	logger    *zap.Logger
+}
+
+// NewRuntimeController takes the configuration summary with secrets
+// already redacted; it is served as is.
+func NewRuntimeController(config map[string]string, startedAt time.Time, logger *zap.Logger) *RuntimeController {
+	return &RuntimeController{
+		config:    config,
+		startedAt: startedAt,
+		logger:    logger,
+	}
+}
+
+func (c *RuntimeController) GetRuntime(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	uptime := time.Since(c.startedAt)
+	response := RuntimeInfoDTO{
+		StartedAt:     c.startedAt.Format(time.RFC3339),
+		Uptime:        uptime.Round(time.Second).String(),
+		UptimeSeconds: int64(uptime.Seconds()),
+		GoVersion:     runtime.Version(),
+		Goroutines:    runtime.NumGoroutine(),
+		NumCPU:        runtime.NumCPU(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		Memory: RuntimeMemoryDTO{
+			HeapAllocBytes: mem.HeapAlloc,
+			HeapInuseBytes: mem.HeapInuse,
+			SysBytes:       mem.Sys,
+			HeapObjects:    mem.HeapObjects,
+		},
+		GC: RuntimeGCDTO{
+			NumGC:        mem.NumGC,
+			PauseTotalMs: float64(mem.PauseTotalNs) / float64(time.Millisecond),
+			NextGCBytes:  mem.NextGC,
+		},
+		Config: c.config,
+	}
+	if mem.NumGC > 0 {
+		lastGC := time.Unix(0, int64(mem.LastGC))
+		response.GC.LastGC = lastGC.Format(time.RFC3339)
+		response.GC.LastPauseMs = float64(mem.PauseNs[(mem.NumGC+255)%256]) / float64(time.Millisecond)
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *RuntimeController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,445 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	scimContentType = "application/scim+json"
+
+	scimSchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimSchemaEnterprise   = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	scimSchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimSchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	scimDefaultCount = 100
+)
+
+var scimUserNameFilter = regexp.MustCompile(`^userName\s+eq\s+"([^"]*)"$`)
+
+type ScimEnterpriseDTO struct {
+	Department string `json:"department,omitempty"`
+}
+
+type ScimMetaDTO struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+type ScimUserDTO struct {
+	Schemas    []string           `json:"schemas"`
+	ID         string             `json:"id,omitempty"`
+	UserName   string             `json:"userName"`
+	Active     *bool              `json:"active,omitempty"`
+	Enterprise *ScimEnterpriseDTO `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta       *ScimMetaDTO       `json:"meta,omitempty"`
+}
+
+type scimListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []ScimUserDTO `json:"Resources"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string `json:"schemas"`
+	Operations []struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value"`
+	} `json:"Operations"`
+}
+
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// ScimController implements the subset of SCIM 2.0 (RFC 7644) needed by
+// identity providers to provision and deprovision users. A user's team is
+// carried in the enterprise extension "department" attribute.
+type ScimController struct {
+	userUC usecase.UserUsecase
+	token  string
+	logger *zap.Logger
+}
+
+func NewScimController(userUC usecase.UserUsecase, token string, logger *zap.Logger) *ScimController {
+	return &ScimController{
+		userUC: userUC,
+		token:  token,
+		logger: logger,
+	}
+}
+
+func (c *ScimController) ListUsers(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	var filter entity.UserFilter
+	if raw := strings.TrimSpace(r.URL.Query().Get("filter")); raw != "" {
+		m := scimUserNameFilter.FindStringSubmatch(raw)
+		if m == nil {
+			c.sendError(w, http.StatusBadRequest, "invalidFilter", "only 'userName eq \"...\"' filters are supported")
+			return
+		}
+		filter.Username = m[1]
+	}
+
+	startIndex, err := scimPositiveInt(r.URL.Query().Get("startIndex"), 1)
+	if err != nil {
+		c.sendError(w, http.StatusBadRequest, "invalidValue", "invalid startIndex")
+		return
+	}
+	count, err := scimPositiveInt(r.URL.Query().Get("count"), scimDefaultCount)
+	if err != nil {
+		c.sendError(w, http.StatusBadRequest, "invalidValue", "invalid count")
+		return
+	}
+
+	filter.Offset = startIndex - 1
+	filter.Limit = count
+
+	users, total, err := c.userUC.ListUsers(r.Context(), filter)
+	if err != nil {
+		logging.From(r.Context(), c.logger).Error("failed to list users", zap.Error(err))
+		c.sendError(w, http.StatusInternalServerError, "", "internal server error")
+		return
+	}
+
+	resources := make([]ScimUserDTO, len(users))
+	for i, user := range users {
+		resources[i] = userToScim(user)
+	}
+
+	c.sendJSON(w, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (c *ScimController) GetUser(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	user, ok := c.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, userToScim(user))
+}
+
+func (c *ScimController) CreateUser(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	var req ScimUserDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, http.StatusBadRequest, "invalidSyntax", "invalid request body")
+		return
+	}
+	if req.UserName == "" {
+		c.sendError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+		return
+	}
+
+	existing, _, err := c.userUC.ListUsers(r.Context(), entity.UserFilter{Username: req.UserName})
+	if err != nil {
+		logging.From(r.Context(), c.logger).Error("failed to list users", zap.Error(err))
+		c.sendError(w, http.StatusInternalServerError, "", "internal server error")
+		return
+	}
+	if len(existing) > 0 {
+		c.sendError(w, http.StatusConflict, "uniqueness", "userName already exists")
+		return
+	}
+
+	user := scimToUser(uuid.New(), req)
+	created, _, err := c.userUC.UpsertUser(r.Context(), user)
+	if err != nil {
+		if errors.Is(err, usecase.ErrQuotaExceeded) {
+			c.sendError(w, http.StatusForbidden, "", err.Error())
+			return
+		}
+		logging.From(r.Context(), c.logger).Error("failed to provision user", zap.Error(err))
+		c.sendError(w, http.StatusInternalServerError, "", "internal server error")
+		return
+	}
+
+	c.sendJSON(w, http.StatusCreated, userToScim(created))
+}
+
+func (c *ScimController) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	current, ok := c.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req ScimUserDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, http.StatusBadRequest, "invalidSyntax", "invalid request body")
+		return
+	}
+	if req.UserName == "" {
+		c.sendError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+		return
+	}
+
+	c.saveUser(w, r, scimToUser(current.UserID, req))
+}
+
+func (c *ScimController) PatchUser(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	user, ok := c.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req scimPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.sendError(w, http.StatusBadRequest, "invalidSyntax", "invalid request body")
+		return
+	}
+
+	for _, op := range req.Operations {
+		if err := applyScimPatch(&user, op.Op, op.Path, op.Value); err != nil {
+			c.sendError(w, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+	}
+
+	c.saveUser(w, r, user)
+}
+
+// DeleteUser deprovisions the user by deactivating it: historical PRs keep
+// referencing the account.
+func (c *ScimController) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r) {
+		return
+	}
+
+	user, ok := c.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := c.userUC.SetIsActive(r.Context(), user.UserID, false); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			c.sendError(w, http.StatusNotFound, "", "user not found")
+			return
+		}
+		logging.From(r.Context(), c.logger).Error("failed to deprovision user", zap.Error(err))
+		c.sendError(w, http.StatusInternalServerError, "", "internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *ScimController) saveUser(w http.ResponseWriter, r *http.Request, user entity.User) {
+	saved, _, err := c.userUC.UpsertUser(r.Context(), user)
+	if err != nil {
+		if errors.Is(err, usecase.ErrQuotaExceeded) {
+			c.sendError(w, http.StatusForbidden, "", err.Error())
+			return
+		}
+		logging.From(r.Context(), c.logger).Error("failed to update provisioned user", zap.Error(err))
+		c.sendError(w, http.StatusInternalServerError, "", "internal server error")
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, userToScim(saved))
+}
+
+func (c *ScimController) loadUser(w http.ResponseWriter, r *http.Request) (entity.User, bool) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		c.sendError(w, http.StatusNotFound, "", "user not found")
+		return entity.User{}, false
+	}
+
+	user, err := c.userUC.GetUser(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			c.sendError(w, http.StatusNotFound, "", "user not found")
+			return entity.User{}, false
+		}
+		logging.From(r.Context(), c.logger).Error("failed to get user", zap.Error(err))
+		c.sendError(w, http.StatusInternalServerError, "", "internal server error")
+		return entity.User{}, false
+	}
+
+	return user, true
+}
+
+func (c *ScimController) authorize(w http.ResponseWriter, r *http.Request) bool {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if found && subtle.ConstantTimeCompare([]byte(token), []byte(c.token)) == 1 {
+		return true
+	}
+
+	c.sendError(w, http.StatusUnauthorized, "", "invalid or missing bearer token")
+	return false
+}
+
+func applyScimPatch(user *entity.User, op, path string, value json.RawMessage) error {
+	switch strings.ToLower(op) {
+	case "replace", "add":
+	default:
+		return fmt.Errorf("unsupported patch op %q", op)
+	}
+
+	// Without a path the value is a partial resource.
+	if path == "" {
+		var partial struct {
+			UserName   *string            `json:"userName"`
+			Active     json.RawMessage    `json:"active"`
+			Enterprise *ScimEnterpriseDTO `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"`
+		}
+		if err := json.Unmarshal(value, &partial); err != nil {
+			return errors.New("invalid patch value")
+		}
+		if partial.UserName != nil {
+			user.Username = *partial.UserName
+		}
+		if partial.Active != nil {
+			active, err := scimBool(partial.Active)
+			if err != nil {
+				return err
+			}
+			user.IsActive = active
+		}
+		if partial.Enterprise != nil {
+			user.TeamName = partial.Enterprise.Department
+		}
+		return nil
+	}
+
+	switch path {
+	case "active":
+		active, err := scimBool(value)
+		if err != nil {
+			return err
+		}
+		user.IsActive = active
+	case "userName":
+		if err := json.Unmarshal(value, &user.Username); err != nil {
+			return errors.New("invalid userName value")
+		}
+	case scimSchemaEnterprise + ":department":
+		if err := json.Unmarshal(value, &user.TeamName); err != nil {
+			return errors.New("invalid department value")
+		}
+	default:
+		return fmt.Errorf("unsupported patch path %q", path)
+	}
+	return nil
+}
+
+// scimBool accepts both JSON booleans and the "True"/"False" strings some
+// identity providers send.
+func scimBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if parsed, err := strconv.ParseBool(s); err == nil {
+			return parsed, nil
+		}
+	}
+	return false, errors.New("invalid active value")
+}
+
+func scimPositiveInt(raw string, defaultValue int) (int, error) {
+	if raw == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, errors.New("must be a positive integer")
+	}
+	return n, nil
+}
+
+func userToScim(user entity.User) ScimUserDTO {
+	active := user.IsActive
+	dto := ScimUserDTO{
+		Schemas:  []string{scimSchemaUser, scimSchemaEnterprise},
+		ID:       user.UserID.String(),
+		UserName: user.Username,
+		Active:   &active,
+		Meta: &ScimMetaDTO{
+			ResourceType: "User",
+			Location:     "/scim/v2/Users/" + user.UserID.String(),
+		},
+	}
+	if user.TeamName != "" {
+		dto.Enterprise = &ScimEnterpriseDTO{Department: user.TeamName}
+	}
+	return dto
+}
+
+func scimToUser(userID uuid.UUID, dto ScimUserDTO) entity.User {
+	user := entity.User{
+		UserID:   userID,
+		Username: dto.UserName,
+		IsActive: true,
+	}
+	if dto.Active != nil {
+		user.IsActive = *dto.Active
+	}
+	if dto.Enterprise != nil {
+		user.TeamName = dto.Enterprise.Department
+	}
+	return user
+}
+
+func (c *ScimController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *ScimController) sendError(w http.ResponseWriter, status int, scimType, detail string) {
+	c.sendJSON(w, status, scimError{
+		Schemas:  []string{scimSchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const spaIndex = "index.html"
+
+// SPAHandler serves a single-page application: existing files are served
+// as is and every other path falls back to index.html, so client-side routes
+// survive a reload. Mount it with http.StripPrefix.
+type SPAHandler struct {
+	files fs.FS
+	fs    http.Handler
+}
+
+func NewSPAHandler(files fs.FS) (*SPAHandler, error) {
+	if _, err := fs.Stat(files, spaIndex); err != nil {
+		return nil, err
+	}
+	return &SPAHandler{
+		files: files,
+		fs:    http.FileServerFS(files),
+	}, nil
+}
+
+func (h *SPAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" || name == spaIndex {
+		h.serveIndex(w, r)
+		return
+	}
+
+	info, err := fs.Stat(h.files, name)
+	if err != nil || info.IsDir() {
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		// Paths that look like files are real misses, not client routes.
+		if path.Ext(name) != "" {
+			http.NotFound(w, r)
+			return
+		}
+		h.serveIndex(w, r)
+		return
+	}
+
+	h.fs.ServeHTTP(w, r)
+}
+
+// serveIndex is never cached so that a new deployment is picked up on the
+// next navigation; hashed assets can still be cached by the browser.
+func (h *SPAHandler) serveIndex(w http.ResponseWriter, r *http.Request) {
+	f, err := h.files.Open(spaIndex)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, spaIndex, info.ModTime(), content)
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+// StateSnapshotDTO is the body of POST /admin/export and POST
+// /admin/import. Entities are encoded as the storage keeps them (the
+// encoding of the redis driver), so a dump keeps fields the API does not
+// expose, like approvals and PR versions.
+type StateSnapshotDTO struct {
+	Version      int                  `json:"version"`
+	ExportedAt   time.Time            `json:"exported_at"`
+	Teams        []entity.Team        `json:"teams"`
+	Users        []entity.User        `json:"users"`
+	PullRequests []entity.PullRequest `json:"pull_requests"`
+}
+
+type StateController struct {
+	stateUC  usecase.StateUsecase
+	backupUC usecase.BackupUsecase
+	logger   *zap.Logger
+}
+
+func NewStateController(stateUC usecase.StateUsecase, backupUC usecase.BackupUsecase, logger *zap.Logger) *StateController {
+	return &StateController{
+		stateUC:  stateUC,
+		backupUC: backupUC,
+		logger:   logger,
+	}
+}
+
+func (c *StateController) Export(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := c.stateUC.Export(r.Context())
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to export state", err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="pr-reviewer-%s.json"`, snapshot.ExportedAt.UTC().Format("20060102T150405Z")))
+	c.sendJSON(w, http.StatusOK, StateSnapshotDTO{
+		Version:      snapshot.Version,
+		ExportedAt:   snapshot.ExportedAt,
+		Teams:        snapshot.Teams,
+		Users:        snapshot.Users,
+		PullRequests: snapshot.PullRequests,
+	})
+}
+
+// Import accepts an export either as the JSON body or as the "file" field
+// of a multipart form, up to the team import size limit.
+func (c *StateController) Import(w http.ResponseWriter, r *http.Request) {
+	body, err := importBody(w, r)
+	if err != nil {
+		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+		return
+	}
+	defer body.Close()
+
+	var req StateSnapshotDTO
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeDecodeError(w, decodeError(err))
+		return
+	}
+
+	result, err := c.stateUC.Import(r.Context(), entity.StateSnapshot{
+		Version:      req.Version,
+		ExportedAt:   req.ExportedAt,
+		Teams:        req.Teams,
+		Users:        req.Users,
+		PullRequests: req.PullRequests,
+	})
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to import state", err)
+		return
+	}
+
+	response := struct {
+		Created map[string]int `json:"created"`
+		Updated map[string]int `json:"updated"`
+	}{
+		Created: result.Created,
+		Updated: result.Updated,
+	}
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+// Backup stores a backup of every organization right away, as the backup
+// worker does on its schedule.
+func (c *StateController) Backup(w http.ResponseWriter, r *http.Request) {
+	if c.backupUC == nil {
+		c.sendError(w, http.StatusServiceUnavailable, ErrorCodeNotConfigured, "backups are not configured")
+		return
+	}
+
+	results, err := c.backupUC.Backup(r.Context())
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to back up state", err)
+		return
+	}
+
+	backups := make([]BackupResultDTO, len(results))
+	for i, result := range results {
+		backups[i] = BackupResultToDTO(result)
+	}
+
+	response := struct {
+		Backups []BackupResultDTO `json:"backups"`
+	}{
+		Backups: backups,
+	}
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *StateController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *StateController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
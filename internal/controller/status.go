@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"cmp"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	//go:embed web/status.html
+	statusHTML string
+
+	statusTemplate = template.Must(template.New("status").Parse(statusHTML))
+)
+
+const statusRefreshInterval = time.Minute
+
+// StatusController renders a read-only, unauthenticated page per team for
+// wallboards. It reloads itself every minute.
+type StatusController struct {
+	teamUC usecase.TeamUsecase
+	prUC   usecase.PullRequestUsecase
+	sla    time.Duration
+	logger *zap.Logger
+}
+
+func NewStatusController(teamUC usecase.TeamUsecase, prUC usecase.PullRequestUsecase, sla time.Duration, logger *zap.Logger) *StatusController {
+	return &StatusController{
+		teamUC: teamUC,
+		prUC:   prUC,
+		sla:    sla,
+		logger: logger,
+	}
+}
+
+type statusPage struct {
+	TeamName       string
+	SLA            time.Duration
+	GeneratedAt    string
+	RefreshSeconds int
+	Breaches       int
+	Style          template.CSS
+	OpenPRs        []statusPR
+}
+
+type statusPR struct {
+	Name     string
+	Author   string
+	OpenFor  time.Duration
+	Age      string
+	Breached bool
+	Pending  []string
+}
+
+func (c *StatusController) TeamStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	teamName := r.PathValue("team")
+
+	_, members, err := c.teamUC.GetTeam(ctx, teamName)
+	if err != nil {
+		c.renderError(w, r, "failed to get team", err)
+		return
+	}
+
+	open := entity.StatusOpen
+	prs, err := c.prUC.GetTeamPRs(ctx, teamName, entity.PullRequestFilter{Status: &open})
+	if err != nil {
+		c.renderError(w, r, "failed to get team PRs", err)
+		return
+	}
+
+	// A zero threshold applies the SLA of each PR author's team.
+	overdue, err := c.prUC.GetOverduePRs(ctx, 0)
+	if err != nil {
+		c.renderError(w, r, "failed to get overdue PRs", err)
+		return
+	}
+	breached := make(map[uuid.UUID]bool, len(overdue))
+	for _, pr := range overdue {
+		breached[pr.PullRequestID] = true
+	}
+
+	names := make(map[uuid.UUID]string, len(members))
+	for _, member := range members {
+		names[member.UserID] = member.Username
+	}
+	name := func(id uuid.UUID) string {
+		if username, ok := names[id]; ok {
+			return username
+		}
+		return id.String()
+	}
+
+	now := time.Now()
+	page := statusPage{
+		TeamName:       teamName,
+		SLA:            c.sla,
+		GeneratedAt:    now.Format(time.RFC3339),
+		RefreshSeconds: int(statusRefreshInterval.Seconds()),
+		Style:          template.CSS(pageCSS),
+	}
+	for _, pr := range prs {
+		item := statusPR{
+			Name:     pr.PullRequestName,
+			Author:   name(pr.AuthorID),
+			OpenFor:  now.Sub(pr.CreatedAt),
+			Breached: breached[pr.PullRequestID],
+		}
+		for _, id := range pr.AssignedReviewers {
+			if !pr.IsApprovedBy(id) {
+				item.Pending = append(item.Pending, name(id))
+			}
+		}
+		item.Age = formatAge(item.OpenFor)
+		if item.Breached {
+			page.Breaches++
+		}
+		page.OpenPRs = append(page.OpenPRs, item)
+	}
+	slices.SortStableFunc(page.OpenPRs, func(a, b statusPR) int {
+		return cmp.Compare(b.OpenFor, a.OpenFor)
+	})
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := statusTemplate.Execute(w, page); err != nil {
+		logging.From(ctx, c.logger).Error("failed to render status page", zap.Error(err))
+	}
+}
+
+// formatAge renders a duration the way a wallboard reader wants it: days
+// and hours for old PRs, hours and minutes for fresh ones.
+func formatAge(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+	return fmt.Sprintf("%dh %dm", hours, int(d%time.Hour/time.Minute))
+}
+
+func (c *StatusController) renderError(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	writeUsecaseError(w, r, c.logger, msg, err)
+}
@@ -2,11 +2,10 @@ package controller
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
 
 	"avito-intro/internal/entity"
-	"avito-intro/internal/repository"
 	"avito-intro/internal/usecase"
 
 	"github.com/google/uuid"
@@ -27,8 +26,8 @@ func NewTeamController(teamUC usecase.TeamUsecase, logger *zap.Logger) *TeamCont
 
 func (c *TeamController) AddTeam(w http.ResponseWriter, r *http.Request) {
 	var req TeamDTO
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid request body")
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
 		return
 	}
 
@@ -37,7 +36,7 @@ func (c *TeamController) AddTeam(w http.ResponseWriter, r *http.Request) {
 	for i, m := range req.Members {
 		user, err := TeamMemberDTOToEntity(m, req.TeamName)
 		if err != nil {
-			c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid user_id format")
+			writeFieldError(w, fmt.Sprintf("members[%d].user_id", i), "must be a UUID")
 			return
 		}
 		members[i] = user
@@ -51,19 +50,13 @@ func (c *TeamController) AddTeam(w http.ResponseWriter, r *http.Request) {
 
 	createdTeam, err := c.teamUC.AddTeam(r.Context(), team, members)
 	if err != nil {
-		if errors.Is(err, repository.ErrAlreadyExists) {
-			c.sendError(w, http.StatusBadRequest, ErrorCodeTeamExists, "team_name already exists")
-			return
-		}
-		c.logger.Error("failed to add team", zap.Error(err))
-		c.sendError(w, http.StatusInternalServerError, ErrorCodeInvalidInput, "internal server error")
+		writeUsecaseError(w, r, c.logger, "failed to add team", err)
 		return
 	}
 
 	_, retrievedMembers, err := c.teamUC.GetTeam(r.Context(), createdTeam.TeamName)
 	if err != nil {
-		c.logger.Error("failed to get team", zap.Error(err))
-		c.sendError(w, http.StatusInternalServerError, ErrorCodeInvalidInput, "internal server error")
+		writeUsecaseError(w, r, c.logger, "failed to get team", err)
 		return
 	}
 
@@ -76,26 +69,103 @@ func (c *TeamController) AddTeam(w http.ResponseWriter, r *http.Request) {
 	c.sendJSON(w, http.StatusCreated, response)
 }
 
+func (c *TeamController) ListTeams(w http.ResponseWriter, r *http.Request) {
+	page, pageSize, err := parsePageParams(r.URL.Query())
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	summaries, total, err := c.teamUC.ListTeams(r.Context(), entity.TeamListFilter{
+		Offset: (page - 1) * pageSize,
+		Limit:  pageSize,
+	})
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to list teams", err)
+		return
+	}
+
+	teamDTOs := make([]TeamSummaryDTO, len(summaries))
+	for i, summary := range summaries {
+		teamDTOs[i] = TeamSummaryToDTO(summary)
+	}
+
+	response := struct {
+		Teams    []TeamSummaryDTO `json:"teams"`
+		Page     int              `json:"page"`
+		PageSize int              `json:"page_size"`
+		Total    int              `json:"total"`
+	}{
+		Teams:    teamDTOs,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+// updateTeamRequest lists members to add to the team or, with replace, its
+// complete new member list.
+type updateTeamRequest struct {
+	TeamName string          `json:"team_name" required:"true"`
+	Members  []TeamMemberDTO `json:"members" required:"true"`
+	Replace  bool            `json:"replace"`
+}
+
+func (c *TeamController) UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	var req updateTeamRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	members := make([]entity.User, len(req.Members))
+	for i, m := range req.Members {
+		user, err := TeamMemberDTOToEntity(m, req.TeamName)
+		if err != nil {
+			writeFieldError(w, fmt.Sprintf("members[%d].user_id", i), "must be a UUID")
+			return
+		}
+		members[i] = user
+	}
+
+	updatedTeam, err := c.teamUC.UpdateTeam(r.Context(), req.TeamName, members, req.Replace)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to update team", err)
+		return
+	}
+
+	_, retrievedMembers, err := c.teamUC.GetTeam(r.Context(), updatedTeam.TeamName)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get team", err)
+		return
+	}
+
+	response := struct {
+		Team TeamDTO `json:"team"`
+	}{
+		Team: TeamToDTO(updatedTeam, retrievedMembers),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
 func (c *TeamController) GetTeam(w http.ResponseWriter, r *http.Request) {
 	teamName := r.URL.Query().Get("team_name")
 	if teamName == "" {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "team_name query parameter is required")
+		writeFieldError(w, "team_name", "is required")
 		return
 	}
 
 	team, members, err := c.teamUC.GetTeam(r.Context(), teamName)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			c.sendError(w, http.StatusNotFound, ErrorCodeNotFound, "team not found")
-			return
-		}
-		c.logger.Error("failed to get team", zap.Error(err))
-		c.sendError(w, http.StatusInternalServerError, ErrorCodeInvalidInput, "internal server error")
+		writeUsecaseError(w, r, c.logger, "failed to get team", err)
 		return
 	}
 
 	response := TeamToDTO(team, members)
-	c.sendJSON(w, http.StatusOK, response)
+	sendCachedJSON(w, r, response)
 }
 
 func (c *TeamController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -105,8 +175,5 @@ func (c *TeamController) sendJSON(w http.ResponseWriter, status int, data interf
 }
 
 func (c *TeamController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
-	resp := ErrorResponse{}
-	resp.Error.Code = code
-	resp.Error.Message = message
-	c.sendJSON(w, status, resp)
+	writeError(w, status, code, message)
 }
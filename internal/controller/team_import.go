@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	maxImportSize = 10 << 20
+
+	importStatusImported = "imported"
+	importStatusFailed   = "failed"
+	importStatusCreated  = "created"
+	importStatusUpdated  = "updated"
+)
+
+type importRow struct {
+	index int
+	user  entity.User
+}
+
+func (c *TeamController) ImportTeams(w http.ResponseWriter, r *http.Request) {
+	body, err := importBody(w, r)
+	if err != nil {
+		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+		return
+	}
+	defer body.Close()
+
+	records, err := readImportCSV(body)
+	if err != nil {
+		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, fmt.Sprintf("invalid CSV: %v", err))
+		return
+	}
+
+	rowResults := make([]TeamImportRowDTO, 0, len(records))
+	rowsByTeam := make(map[string][]importRow)
+	var teamOrder []string
+
+	for i, record := range records {
+		rowNum := i + 1
+		if i == 0 && isImportHeader(record) {
+			continue
+		}
+
+		user, err := parseImportRecord(record)
+		if err != nil {
+			rowResults = append(rowResults, TeamImportRowDTO{
+				Row:      rowNum,
+				TeamName: field(record, 0),
+				UserID:   field(record, 2),
+				Status:   importStatusFailed,
+				Error:    err.Error(),
+			})
+			continue
+		}
+
+		if _, seen := rowsByTeam[user.TeamName]; !seen {
+			teamOrder = append(teamOrder, user.TeamName)
+		}
+		rowsByTeam[user.TeamName] = append(rowsByTeam[user.TeamName], importRow{index: len(rowResults), user: user})
+		rowResults = append(rowResults, TeamImportRowDTO{
+			Row:      rowNum,
+			TeamName: user.TeamName,
+			UserID:   user.UserID.String(),
+			Status:   importStatusImported,
+		})
+	}
+
+	teamResults := make([]TeamImportTeamDTO, 0, len(teamOrder))
+	for _, teamName := range teamOrder {
+		rows := rowsByTeam[teamName]
+		members := make([]entity.User, len(rows))
+		for i, row := range rows {
+			members[i] = row.user
+		}
+
+		team, created, err := c.teamUC.UpsertTeam(r.Context(), teamName, members)
+		if err != nil {
+			message := "failed to import team"
+			var ucErr *usecase.Error
+			if errors.As(err, &ucErr) {
+				message = ucErr.Message
+			} else {
+				logging.From(r.Context(), c.logger).Error("failed to import team", zap.String("team_name", teamName), zap.Error(err))
+			}
+			teamResults = append(teamResults, TeamImportTeamDTO{
+				TeamName: teamName,
+				Status:   importStatusFailed,
+				Error:    message,
+			})
+			for _, row := range rows {
+				rowResults[row.index].Status = importStatusFailed
+				rowResults[row.index].Error = message
+			}
+			continue
+		}
+
+		status := importStatusUpdated
+		if created {
+			status = importStatusCreated
+		}
+		teamResults = append(teamResults, TeamImportTeamDTO{
+			TeamName:     teamName,
+			Status:       status,
+			MembersCount: len(team.Members),
+		})
+	}
+
+	response := struct {
+		Teams []TeamImportTeamDTO `json:"teams"`
+		Rows  []TeamImportRowDTO  `json:"rows"`
+	}{
+		Teams: teamResults,
+		Rows:  rowResults,
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+// importBody accepts either a multipart upload with a "file" field or a raw
+// text/csv request body.
+func importBody(w http.ResponseWriter, r *http.Request) (io.ReadCloser, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.Body, nil
+	}
+
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		return nil, errors.New("invalid multipart body")
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, errors.New("multipart field \"file\" is required")
+	}
+	return file, nil
+}
+
+func readImportCSV(body io.Reader) ([][]string, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no rows")
+	}
+	return records, nil
+}
+
+func isImportHeader(record []string) bool {
+	return strings.EqualFold(strings.TrimSpace(field(record, 0)), "team") &&
+		strings.EqualFold(strings.TrimSpace(field(record, 2)), "user_id")
+}
+
+func parseImportRecord(record []string) (entity.User, error) {
+	if len(record) < 3 || len(record) > 4 {
+		return entity.User{}, errors.New("expected columns: team, username, user_id, active")
+	}
+
+	teamName := strings.TrimSpace(record[0])
+	if teamName == "" {
+		return entity.User{}, errors.New("team is required")
+	}
+
+	username := strings.TrimSpace(record[1])
+	if username == "" {
+		return entity.User{}, errors.New("username is required")
+	}
+
+	userID, err := uuid.Parse(strings.TrimSpace(record[2]))
+	if err != nil {
+		return entity.User{}, errors.New("invalid user_id format")
+	}
+
+	isActive := true
+	if active := strings.TrimSpace(field(record, 3)); active != "" {
+		isActive, err = strconv.ParseBool(active)
+		if err != nil {
+			return entity.User{}, errors.New("invalid active value")
+		}
+	}
+
+	return entity.User{
+		UserID:   userID,
+		Username: username,
+		TeamName: teamName,
+		IsActive: isActive,
+	}, nil
+}
+
+func field(record []string, i int) string {
+	if i < len(record) {
+		return record[i]
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"go.uber.org/zap"
+)
+
+type TeamSettingsController struct {
+	teamSettingsUC usecase.TeamSettingsUsecase
+	logger         *zap.Logger
+}
+
+func NewTeamSettingsController(teamSettingsUC usecase.TeamSettingsUsecase, logger *zap.Logger) *TeamSettingsController {
+	return &TeamSettingsController{
+		teamSettingsUC: teamSettingsUC,
+		logger:         logger,
+	}
+}
+
+func (c *TeamSettingsController) SetSettings(w http.ResponseWriter, r *http.Request) {
+	var req TeamSettingsDTO
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	settings := entity.TeamSettings{
+		TeamName:           req.TeamName,
+		RequiredReviewers:  req.RequiredReviewers,
+		OptionalReviewers:  req.OptionalReviewers,
+		AssignmentStrategy: req.AssignmentStrategy,
+		MergeApprovals:     req.MergeApprovals,
+		Timezone:           req.Timezone,
+		NamePattern:        req.NamePattern,
+	}
+	if req.SLA != nil {
+		sla, err := time.ParseDuration(*req.SLA)
+		if err != nil {
+			writeFieldError(w, "sla", "must be a duration like 24h")
+			return
+		}
+		settings.SLA = &sla
+	}
+
+	overrides, effective, err := c.teamSettingsUC.SetSettings(r.Context(), settings)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to set team settings", err)
+		return
+	}
+
+	c.sendSettings(w, overrides, effective)
+}
+
+func (c *TeamSettingsController) GetSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeFieldError(w, "team_name", "is required")
+		return
+	}
+
+	overrides, effective, err := c.teamSettingsUC.GetSettings(r.Context(), teamName)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get team settings", err)
+		return
+	}
+
+	c.sendSettings(w, overrides, effective)
+}
+
+func (c *TeamSettingsController) sendSettings(w http.ResponseWriter, overrides, effective entity.TeamSettings) {
+	response := struct {
+		Overrides TeamSettingsDTO `json:"overrides"`
+		Effective TeamSettingsDTO `json:"effective"`
+	}{
+		Overrides: TeamSettingsToDTO(overrides),
+		Effective: TeamSettingsToDTO(effective),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *TeamSettingsController) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (c *TeamSettingsController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	writeError(w, status, code, message)
+}
@@ -2,16 +2,26 @@ package controller
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
-	"avito-intro/internal/repository"
+	"avito-intro/internal/entity"
 	"avito-intro/internal/usecase"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+const maxBatchUserIDs = 100
+
+// reviewStatusAll is the getReview status that lists reviews of every
+// status instead of only the open ones.
+const reviewStatusAll = "ALL"
+
 type UserController struct {
 	userUC usecase.UserUsecase
 	prUC   usecase.PullRequestUsecase
@@ -26,31 +36,165 @@ func NewUserController(userUC usecase.UserUsecase, prUC usecase.PullRequestUseca
 	}
 }
 
-func (c *UserController) SetIsActive(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		UserID   string `json:"user_id"`
-		IsActive bool   `json:"is_active"`
-	}
+type setIsActiveRequest struct {
+	UserID   string `json:"user_id" required:"true"`
+	IsActive bool   `json:"is_active" required:"true"`
+}
+
+type setIsActiveBulkRequest struct {
+	UserIDs  []string `json:"user_ids" required:"true"`
+	IsActive bool     `json:"is_active" required:"true"`
+}
+
+type snoozeRequest struct {
+	UserID string  `json:"user_id" required:"true"`
+	Until  *string `json:"until"`
+}
+
+type transferReviewsRequest struct {
+	FromUserID string `json:"from_user_id" required:"true"`
+	ToUserID   string `json:"to_user_id"`
+}
+
+type usersByIDsRequest struct {
+	UserIDs []string `json:"user_ids" required:"true"`
+}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid request body")
+func (c *UserController) SetIsActive(w http.ResponseWriter, r *http.Request) {
+	var req setIsActiveRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
 		return
 	}
 
 	userID, err := uuid.Parse(req.UserID)
 	if err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid user_id format")
+		writeFieldError(w, "user_id", "must be a UUID")
 		return
 	}
 
 	user, err := c.userUC.SetIsActive(r.Context(), userID, req.IsActive)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			c.sendError(w, http.StatusNotFound, ErrorCodeNotFound, "user not found")
+		writeUsecaseError(w, r, c.logger, "failed to set user active status", err)
+		return
+	}
+
+	response := struct {
+		User UserDTO `json:"user"`
+	}{
+		User: UserToDTO(user),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+// SetIsActiveBulk sets is_active for every user in user_ids at once. Either
+// all users are updated or, when some are missing, none is; the response
+// tells for each user whether the flag changed.
+func (c *UserController) SetIsActiveBulk(w http.ResponseWriter, r *http.Request) {
+	var req setIsActiveBulkRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	if len(req.UserIDs) > maxBatchUserIDs {
+		writeFieldError(w, "user_ids", fmt.Sprintf("must have at most %d items", maxBatchUserIDs))
+		return
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(req.UserIDs))
+	for i, raw := range req.UserIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			writeFieldError(w, fmt.Sprintf("user_ids[%d]", i), "must be a UUID")
 			return
 		}
-		c.logger.Error("failed to set user active status", zap.Error(err))
-		c.sendError(w, http.StatusInternalServerError, ErrorCodeInvalidInput, "internal server error")
+		if !slices.Contains(userIDs, id) {
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	changes, err := c.userUC.SetIsActiveBulk(r.Context(), userIDs, req.IsActive)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to set active status of users", err)
+		return
+	}
+
+	type result struct {
+		UserID  string  `json:"user_id"`
+		Changed bool    `json:"changed"`
+		User    UserDTO `json:"user"`
+	}
+	results := make([]result, len(changes))
+	for i, change := range changes {
+		results[i] = result{
+			UserID:  change.User.UserID.String(),
+			Changed: change.Changed,
+			User:    UserToDTO(change.User),
+		}
+	}
+
+	response := struct {
+		Results []result `json:"results"`
+	}{
+		Results: results,
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+// DeleteUser soft-deletes the user in the user_id query parameter.
+func (c *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		writeFieldError(w, "user_id", "must be a UUID")
+		return
+	}
+
+	user, err := c.userUC.DeleteUser(r.Context(), userID)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to delete user", err)
+		return
+	}
+
+	response := struct {
+		User UserDTO `json:"user"`
+	}{
+		User: UserToDTO(user),
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+// Snooze pauses review assignment for a user until the RFC 3339 time in
+// until; a null or missing until ends the snooze.
+func (c *UserController) Snooze(w http.ResponseWriter, r *http.Request) {
+	var req snoozeRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		writeFieldError(w, "user_id", "must be a UUID")
+		return
+	}
+
+	var until *time.Time
+	if req.Until != nil {
+		parsed, err := time.Parse(time.RFC3339, *req.Until)
+		if err != nil {
+			writeFieldError(w, "until", "must be an RFC 3339 timestamp")
+			return
+		}
+		until = &parsed
+	}
+
+	user, err := c.userUC.Snooze(r.Context(), userID, until)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to snooze user", err)
 		return
 	}
 
@@ -63,23 +207,144 @@ func (c *UserController) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	c.sendJSON(w, http.StatusOK, response)
 }
 
+// TransferReviews moves every open review of from_user_id to to_user_id or,
+// when to_user_id is omitted, to replacements picked by the assignment
+// strategy.
+func (c *UserController) TransferReviews(w http.ResponseWriter, r *http.Request) {
+	var req transferReviewsRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	fromID, err := uuid.Parse(req.FromUserID)
+	if err != nil {
+		writeFieldError(w, "from_user_id", "must be a UUID")
+		return
+	}
+
+	var toID uuid.UUID
+	if req.ToUserID != "" {
+		toID, err = uuid.Parse(req.ToUserID)
+		if err != nil {
+			writeFieldError(w, "to_user_id", "must be a UUID")
+			return
+		}
+	}
+
+	transfer, err := c.prUC.TransferReviews(r.Context(), fromID, toID)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to transfer reviews", err)
+		return
+	}
+
+	c.sendJSON(w, http.StatusOK, ReviewTransferToDTO(transfer))
+}
+
+// GetReview returns the user's open reviews page by page; status picks
+// another status or ALL, and cursor continues a listing like in ListPRs.
 func (c *UserController) GetReview(w http.ResponseWriter, r *http.Request) {
 	userIDStr := r.URL.Query().Get("user_id")
 	if userIDStr == "" {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "user_id query parameter is required")
+		writeFieldError(w, "user_id", "is required")
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		c.sendError(w, http.StatusBadRequest, ErrorCodeInvalidInput, "invalid user_id format")
+		writeFieldError(w, "user_id", "must be a UUID")
 		return
 	}
 
-	prs, err := c.prUC.GetUserReviews(r.Context(), userID)
+	query := r.URL.Query()
+	openStatus := entity.StatusOpen
+	filter := entity.PullRequestListFilter{
+		Status: &openStatus,
+		Limit:  defaultPageSize,
+	}
+	if statusStr := query.Get("status"); strings.EqualFold(statusStr, reviewStatusAll) {
+		filter.Status = nil
+	} else if statusStr != "" {
+		status := entity.PullRequestStatus(strings.ToUpper(statusStr))
+		if !status.IsValid() {
+			writeFieldError(w, "status", "must be OPEN, MERGED, CLOSED, CHANGES_REQUESTED or ALL")
+			return
+		}
+		filter.Status = &status
+	}
+	if err := parseSortParams(query, &filter.SortBy, &filter.Order); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if filter.After, err = parseCursorParam(query, "cursor"); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			writeFieldError(w, "limit", fmt.Sprintf("must be between 1 and %d", maxPageSize))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	page, err := c.prUC.GetUserReviews(r.Context(), userID, filter)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get user reviews", err)
+		return
+	}
+
+	prDTOs := make([]PullRequestShortDTO, len(page.PullRequests))
+	for i, pr := range page.PullRequests {
+		prDTOs[i] = PullRequestToShortDTO(pr)
+	}
+
+	response := struct {
+		UserID       string                `json:"user_id"`
+		PullRequests []PullRequestShortDTO `json:"pull_requests"`
+		NextCursor   string                `json:"next_cursor,omitempty"`
+	}{
+		UserID:       userIDStr,
+		PullRequests: prDTOs,
+	}
+	if page.Next != nil {
+		response.NextCursor = encodeCursor(*page.Next)
+	}
+
+	sendCachedJSON(w, r, response)
+}
+
+func (c *UserController) GetAuthored(w http.ResponseWriter, r *http.Request) {
+	userIDStr := r.URL.Query().Get("user_id")
+	if userIDStr == "" {
+		writeFieldError(w, "user_id", "is required")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		c.logger.Error("failed to get user reviews", zap.Error(err))
-		c.sendError(w, http.StatusInternalServerError, ErrorCodeInvalidInput, "internal server error")
+		writeFieldError(w, "user_id", "must be a UUID")
+		return
+	}
+
+	var filter entity.PullRequestFilter
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		status := entity.PullRequestStatus(strings.ToUpper(statusStr))
+		if !status.IsValid() {
+			writeFieldError(w, "status", "must be OPEN, MERGED, CLOSED or CHANGES_REQUESTED")
+			return
+		}
+		filter.Status = &status
+	}
+	if err := parseSortParams(r.URL.Query(), &filter.SortBy, &filter.Order); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	prs, err := c.prUC.GetUserAuthored(r.Context(), userID, filter)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get user authored PRs", err)
 		return
 	}
 
@@ -96,6 +361,129 @@ func (c *UserController) GetReview(w http.ResponseWriter, r *http.Request) {
 		PullRequests: prDTOs,
 	}
 
+	sendCachedJSON(w, r, response)
+}
+
+func (c *UserController) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var filter entity.UserFilter
+	if teamName := query.Get("team_name"); teamName != "" {
+		filter.TeamName = &teamName
+	}
+	if raw := query.Get("is_active"); raw != "" {
+		isActive, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeFieldError(w, "is_active", "must be a boolean")
+			return
+		}
+		filter.IsActive = &isActive
+	}
+	if raw := query.Get("include_deleted"); raw != "" {
+		includeDeleted, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeFieldError(w, "include_deleted", "must be a boolean")
+			return
+		}
+		filter.IncludeDeleted = includeDeleted
+	}
+
+	page, pageSize, err := parsePageParams(query)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	filter.Offset = (page - 1) * pageSize
+	filter.Limit = pageSize
+
+	users, total, err := c.userUC.ListUsers(r.Context(), filter)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to list users", err)
+		return
+	}
+
+	userIDs := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		userIDs[i] = user.UserID
+	}
+
+	openReviews, err := c.prUC.GetOpenReviewCounts(r.Context(), userIDs)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get open review counts", err)
+		return
+	}
+
+	userDTOs := make([]UserWithLoadDTO, len(users))
+	for i, user := range users {
+		userDTOs[i] = UserWithLoadDTO{
+			UserDTO:     UserToDTO(user),
+			OpenReviews: openReviews[user.UserID],
+		}
+	}
+
+	response := struct {
+		Users    []UserWithLoadDTO `json:"users"`
+		Page     int               `json:"page"`
+		PageSize int               `json:"page_size"`
+		Total    int               `json:"total"`
+	}{
+		Users:    userDTOs,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}
+
+	c.sendJSON(w, http.StatusOK, response)
+}
+
+func (c *UserController) GetUsersByIDs(w http.ResponseWriter, r *http.Request) {
+	var req usersByIDsRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	if len(req.UserIDs) > maxBatchUserIDs {
+		writeFieldError(w, "user_ids", fmt.Sprintf("must have at most %d items", maxBatchUserIDs))
+		return
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(req.UserIDs))
+	for i, raw := range req.UserIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			writeFieldError(w, fmt.Sprintf("user_ids[%d]", i), "must be a UUID")
+			return
+		}
+		if !slices.Contains(userIDs, id) {
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	users, missing, err := c.userUC.GetUsersByIDs(r.Context(), userIDs)
+	if err != nil {
+		writeUsecaseError(w, r, c.logger, "failed to get users by IDs", err)
+		return
+	}
+
+	userDTOs := make([]UserDTO, len(users))
+	for i, user := range users {
+		userDTOs[i] = UserToDTO(user)
+	}
+
+	missingIDs := make([]string, len(missing))
+	for i, id := range missing {
+		missingIDs[i] = id.String()
+	}
+
+	response := struct {
+		Users   []UserDTO `json:"users"`
+		Missing []string  `json:"missing"`
+	}{
+		Users:   userDTOs,
+		Missing: missingIDs,
+	}
+
 	c.sendJSON(w, http.StatusOK, response)
 }
 
@@ -106,8 +494,5 @@ func (c *UserController) sendJSON(w http.ResponseWriter, status int, data interf
 }
 
 func (c *UserController) sendError(w http.ResponseWriter, status int, code ErrorCode, message string) {
-	resp := ErrorResponse{}
-	resp.Error.Code = code
-	resp.Error.Message = message
-	c.sendJSON(w, status, resp)
+	writeError(w, status, code, message)
 }
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIToken is an issued API token as stored: the token's claims and the
+// SHA-256 of its secret, which is what a request is looked up by. The
+// secret itself is never stored.
+type APIToken struct {
+	ID         uuid.UUID
+	SecretHash string
+	OrgID      string
+	Name       string
+	Roles      []string
+	UserID     uuid.UUID
+	CreatedBy  string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+}
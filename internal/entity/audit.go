@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEntityType string
+
+const (
+	AuditPullRequest  AuditEntityType = "pull_request"
+	AuditUser         AuditEntityType = "user"
+	AuditTeam         AuditEntityType = "team"
+	AuditAPIToken     AuditEntityType = "api_token"
+	AuditOrganization AuditEntityType = "organization"
+	AuditSettings     AuditEntityType = "settings"
+)
+
+// Audit actions besides the PR event types, which are recorded as is.
+const (
+	AuditRoleGranted           = "role.granted"
+	AuditRoleRevoked           = "role.revoked"
+	AuditUserActivated         = "user.activated"
+	AuditUserDeactivated       = "user.deactivated"
+	AuditUserSnoozed           = "user.snoozed"
+	AuditUserUnsnoozed         = "user.unsnoozed"
+	AuditUserIdleWarning       = "user.idle_warning"
+	AuditUserDeleted           = "user.deleted"
+	AuditTeamCreated           = "team.created"
+	AuditTeamUpdated           = "team.updated"
+	AuditTeamSettingsUpdated   = "team.settings_updated"
+	AuditGlobalSettingsUpdated = "settings.updated"
+	AuditAPITokenIssued        = "api_token.issued"
+	AuditAPITokenRevoked       = "api_token.revoked"
+	AuditOrganizationCreated   = "organization.created"
+	AuditRetentionPurged       = "retention.purged"
+	AuditConsistencyRepaired   = "consistency.repaired"
+	AuditStateImported         = "state.imported"
+	AuditBackupCreated         = "backup.created"
+)
+
+// AuditEntry records who did what to which entity. Actor is the
+// authenticated subject, "anonymous" for unauthenticated requests and
+// "system" for background work.
+type AuditEntry struct {
+	ID         uuid.UUID
+	OccurredAt time.Time
+	Actor      string
+	Action     string
+	EntityType AuditEntityType
+	EntityID   string
+	Details    map[string]string
+}
+
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	EntityType AuditEntityType
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	Offset     int
+	Limit      int
+}
+
+func (f AuditFilter) Matches(e *AuditEntry) bool {
+	if f.Actor != "" && e.Actor != f.Actor {
+		return false
+	}
+	if f.Action != "" && e.Action != f.Action {
+		return false
+	}
+	if f.EntityType != "" && e.EntityType != f.EntityType {
+		return false
+	}
+	if f.EntityID != "" && e.EntityID != f.EntityID {
+		return false
+	}
+	if f.From != nil && e.OccurredAt.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !e.OccurredAt.Before(*f.To) {
+		return false
+	}
+	return true
+}
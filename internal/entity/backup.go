@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// BackupResult reports the backup of one organization: where the snapshot
+// was stored and how many entities it holds.
+type BackupResult struct {
+	OrgID        string
+	Location     string
+	CreatedAt    time.Time
+	Teams        int
+	Users        int
+	PullRequests int
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChecklistTemplate struct {
+	TeamName         string
+	Items            []string
+	RequiredForMerge bool
+}
+
+type ChecklistItem struct {
+	ItemID    int
+	Text      string
+	Checked   bool
+	CheckedBy *uuid.UUID
+	CheckedAt *time.Time
+}
+
+// Checklist is a snapshot of the author's team template taken when the PR is
+// created, so later template edits do not affect PRs already in review.
+type Checklist struct {
+	Items            []ChecklistItem
+	RequiredForMerge bool
+}
+
+func NewChecklist(template ChecklistTemplate) Checklist {
+	items := make([]ChecklistItem, len(template.Items))
+	for i, text := range template.Items {
+		items[i] = ChecklistItem{ItemID: i + 1, Text: text}
+	}
+	return Checklist{
+		Items:            items,
+		RequiredForMerge: template.RequiredForMerge,
+	}
+}
+
+func (c Checklist) Complete() bool {
+	for _, item := range c.Items {
+		if !item.Checked {
+			return false
+		}
+	}
+	return true
+}
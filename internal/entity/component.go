@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ComponentOwner maps a directory or file of the repository, such as
+// "services/billing", to the team members responsible for it.
+type ComponentOwner struct {
+	Path   string
+	Owners []uuid.UUID
+}
+
+// Covers reports whether file lies at or below c.Path.
+func (c ComponentOwner) Covers(file string) bool {
+	return file == c.Path || strings.HasPrefix(file, c.Path+"/")
+}
+
+// TouchedComponents returns the components that own at least one of files.
+// A file belongs to the most specific component covering it only, so an
+// owner of "services" is not asked to review "services/billing" changes
+// when that has owners of its own.
+func TouchedComponents(components []ComponentOwner, files []string) []ComponentOwner {
+	touched := make(map[int]bool)
+	for _, file := range files {
+		best := -1
+		for i, component := range components {
+			if component.Covers(file) && (best < 0 || len(component.Path) > len(components[best].Path)) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			touched[best] = true
+		}
+	}
+
+	result := make([]ComponentOwner, 0, len(touched))
+	for i, component := range components {
+		if touched[i] {
+			result = append(result, component)
+		}
+	}
+	return result
+}
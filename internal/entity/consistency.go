@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnomalyKind names a cross-entity invariant that stored data violates.
+type AnomalyKind string
+
+const (
+	// AnomalyReviewerMissing: an open PR is assigned to a user that does
+	// not exist.
+	AnomalyReviewerMissing AnomalyKind = "reviewer_missing"
+	// AnomalyReviewerNotInTeam: an open PR is assigned to a user who is no
+	// longer a member of the author's team.
+	AnomalyReviewerNotInTeam AnomalyKind = "reviewer_not_in_team"
+	// AnomalyAuthorMissing: a PR was authored by a user that does not exist.
+	AnomalyAuthorMissing AnomalyKind = "author_missing"
+	// AnomalyMemberMissing: a team member list references a user that does
+	// not exist.
+	AnomalyMemberMissing AnomalyKind = "member_missing"
+	// AnomalyMemberTeamMismatch: a team member list references a user whose
+	// team is a different one.
+	AnomalyMemberTeamMismatch AnomalyKind = "member_team_mismatch"
+)
+
+func AnomalyKinds() []AnomalyKind {
+	return []AnomalyKind{
+		AnomalyReviewerMissing,
+		AnomalyReviewerNotInTeam,
+		AnomalyAuthorMissing,
+		AnomalyMemberMissing,
+		AnomalyMemberTeamMismatch,
+	}
+}
+
+// RepairableAnomalyKinds lists the kinds POST /admin/repair can fix. A PR
+// whose author is missing has nobody to take it over, so author_missing is
+// only reported.
+func RepairableAnomalyKinds() []AnomalyKind {
+	return []AnomalyKind{
+		AnomalyReviewerMissing,
+		AnomalyReviewerNotInTeam,
+		AnomalyMemberMissing,
+		AnomalyMemberTeamMismatch,
+	}
+}
+
+// Anomaly is one violation found by a consistency check. PullRequestID is
+// uuid.Nil for anomalies that do not concern a PR.
+type Anomaly struct {
+	Kind          AnomalyKind
+	TeamName      string
+	PullRequestID uuid.UUID
+	UserID        uuid.UUID
+	Detail        string
+}
+
+// ConsistencyReport is the result of checking one organization.
+type ConsistencyReport struct {
+	OrgID        string
+	CheckedAt    time.Time
+	Teams        int
+	Users        int
+	PullRequests int
+	Anomalies    []Anomaly
+}
+
+// RepairAction is one change made, or with a dry run planned, to resolve an
+// anomaly. ReplacementID is the reviewer who took over a stale assignment
+// and uuid.Nil when a member was dropped from a team list.
+type RepairAction struct {
+	Anomaly       Anomaly
+	ReplacementID uuid.UUID
+}
+
+// RepairResult lists the anomalies of the selected kinds that were repaired
+// and the ones left as they are, whose Detail says why.
+type RepairResult struct {
+	OrgID      string
+	DryRun     bool
+	Kinds      []AnomalyKind
+	Repaired   []RepairAction
+	Unresolved []Anomaly
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventPRCreated          EventType = "pr.created"
+	EventPRApproved         EventType = "pr.approved"
+	EventReviewerReassigned EventType = "pr.reviewer_reassigned"
+	EventPRMerged           EventType = "pr.merged"
+	EventPRAutoMerged       EventType = "pr.auto_merged"
+	EventPRClosed           EventType = "pr.closed"
+	EventChangesRequested   EventType = "pr.changes_requested"
+	EventPRReopened         EventType = "pr.reopened"
+	EventApprovalOverridden EventType = "pr.approval_overridden"
+	EventPRUpdated          EventType = "pr.updated"
+	EventAutoMergeChanged   EventType = "pr.auto_merge_changed"
+)
+
+// PullRequestEvent describes a change in a PR's lifecycle. UserID is the
+// user the event is about (approver, new reviewer) and is uuid.Nil when
+// there is none. PreviousUserID is the replaced reviewer on reassignment.
+type PullRequestEvent struct {
+	Type           EventType
+	PullRequest    PullRequest
+	UserID         uuid.UUID
+	PreviousUserID uuid.UUID
+	OccurredAt     time.Time
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+type StorageOperation string
+
+const (
+	StorageCreate StorageOperation = "create"
+	StorageUpdate StorageOperation = "update"
+	StorageDelete StorageOperation = "delete"
+	// StorageArchive moves an entity out of the working set, see
+	// PullRequestRepository.ArchivePullRequest.
+	StorageArchive StorageOperation = "archive"
+)
+
+// StorageEvent is one write recorded in the event log: the entity it
+// changed, how, and the entity's state after the write as JSON (empty when
+// the write removed the entity from storage or the working set). Seq is assigned by the log and orders events for replay.
+type StorageEvent struct {
+	Seq        int64
+	OccurredAt time.Time
+	EntityType string
+	EntityID   string
+	Operation  StorageOperation
+	Payload    []byte
+}
+
+// StorageEventFilter selects events with Seq above AfterSeq, oldest first;
+// zero Limit returns all of them.
+type StorageEventFilter struct {
+	EntityType string
+	EntityID   string
+	AfterSeq   int64
+	Limit      int
+}
+
+func (f StorageEventFilter) Matches(e *StorageEvent) bool {
+	if e.Seq <= f.AfterSeq {
+		return false
+	}
+	if f.EntityType != "" && e.EntityType != f.EntityType {
+		return false
+	}
+	if f.EntityID != "" && e.EntityID != f.EntityID {
+		return false
+	}
+	return true
+}
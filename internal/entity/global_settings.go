@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Feature names an optional feature admins can switch off at runtime.
+type Feature string
+
+const (
+	// FeatureAutoMerge lets PRs opt into being merged once approved.
+	FeatureAutoMerge Feature = "auto_merge"
+	// FeatureSnooze lets users pause review assignments.
+	FeatureSnooze Feature = "snooze"
+)
+
+func Features() []Feature {
+	return []Feature{FeatureAutoMerge, FeatureSnooze}
+}
+
+// GlobalSettings overrides the review defaults the service was started with
+// for the whole instance; team settings still take precedence. Nil pointers
+// keep the configured default, and a feature missing from Features is on.
+type GlobalSettings struct {
+	RequiredReviewers *int
+	OptionalReviewers *int
+	SLA               *time.Duration
+	Features          map[Feature]bool
+}
+
+func (s GlobalSettings) FeatureEnabled(feature Feature) bool {
+	enabled, ok := s.Features[feature]
+	return !ok || enabled
+}
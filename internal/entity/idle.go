@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdlePolicy flags active users who have not authored or approved a PR for
+// IdleAfter. With Deactivate they are deactivated, but only once a warning
+// was recorded at least WarnBefore earlier. Exempt users are never touched.
+type IdlePolicy struct {
+	IdleAfter  time.Duration
+	WarnBefore time.Duration
+	Deactivate bool
+	Exempt     []uuid.UUID
+}
+
+type IdleAction string
+
+const (
+	// IdleFlagged users are idle but left active, because the policy only
+	// reports or because they have no activity on record to measure from.
+	IdleFlagged IdleAction = "flagged"
+	// IdleWarned users are close to, or past, deactivation and were told so.
+	IdleWarned      IdleAction = "warned"
+	IdleDeactivated IdleAction = "deactivated"
+)
+
+// IdleUser is a user the idle policy acted on. LastActiveAt is nil when the
+// user never authored or approved a PR.
+type IdleUser struct {
+	UserID       uuid.UUID
+	TeamName     string
+	LastActiveAt *time.Time
+	Action       IdleAction
+}
+
+// IdleReport is the result of applying the idle policy to one organization.
+type IdleReport struct {
+	OrgID     string
+	CheckedAt time.Time
+	DryRun    bool
+	Users     []IdleUser
+}
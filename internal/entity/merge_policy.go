@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type MergeRule string
+
+const (
+	RuleMinApprovals      MergeRule = "min_approvals"
+	RuleChecklistComplete MergeRule = "checklist_complete"
+	RuleNotOverdue        MergeRule = "not_overdue"
+)
+
+// MergePolicy holds the per-team rules MergePR checks on top of the global
+// review settings. The zero value allows every merge.
+type MergePolicy struct {
+	TeamName          string
+	MinApprovals      int
+	ChecklistComplete bool
+	NotOverdue        bool
+}
+
+type MergePolicyViolation struct {
+	Rule   MergeRule
+	Detail string
+}
+
+// Check returns the first rule the PR fails, or nil. A PR is overdue once it
+// has been open for longer than sla.
+// A lead override satisfies the approvals rule.
+func (p MergePolicy) Check(pr PullRequest, now time.Time, sla time.Duration) *MergePolicyViolation {
+	if len(pr.Approvals) < p.MinApprovals && !pr.IsOverridden() {
+		return &MergePolicyViolation{
+			Rule:   RuleMinApprovals,
+			Detail: fmt.Sprintf("%d of %d approvals", len(pr.Approvals), p.MinApprovals),
+		}
+	}
+
+	if p.ChecklistComplete && !pr.Checklist.Complete() {
+		return &MergePolicyViolation{
+			Rule:   RuleChecklistComplete,
+			Detail: "checklist has unchecked items",
+		}
+	}
+
+	if p.NotOverdue && sla > 0 && now.Sub(pr.CreatedAt) > sla {
+		return &MergePolicyViolation{
+			Rule:   RuleNotOverdue,
+			Detail: fmt.Sprintf("open for longer than %s", sla),
+		}
+	}
+
+	return nil
+}
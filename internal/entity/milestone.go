@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Milestone struct {
+	MilestoneID uuid.UUID
+	Title       string
+	Description string
+	DueDate     *time.Time
+	CreatedAt   time.Time
+}
+
+type MilestoneStats struct {
+	MilestoneID uuid.UUID
+	Total       int
+	Open        int
+	Merged      int
+	Closed      int
+}
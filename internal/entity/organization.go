@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// Organization is a tenant: teams, users and PRs of different organizations
+// are stored separately and never visible to each other.
+type Organization struct {
+	OrgID     string
+	Name      string
+	CreatedAt time.Time
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is a PR event waiting for delivery to the outbox sinks. It
+// is stored in the transaction of the change it describes and removed once
+// every sink accepted it; a failed delivery is retried at NextAttemptAt.
+// Sinks may see a message more than once and can tell repeats by ID.
+type OutboxMessage struct {
+	ID            uuid.UUID
+	Event         PullRequestEvent
+	CreatedAt     time.Time
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+// IsDue reports whether the message should be delivered at now.
+func (m *OutboxMessage) IsDue(now time.Time) bool {
+	return !m.NextAttemptAt.After(now)
+}
+
+// OutboxPolicy sets how the outbox dispatcher works through the queue:
+// at most BatchSize messages per organization and run, and retries backing
+// off from RetryBackoff, doubling per attempt up to MaxRetryBackoff.
+type OutboxPolicy struct {
+	BatchSize       int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+}
+
+// DispatchResult reports one outbox dispatcher run in an organization.
+type DispatchResult struct {
+	OrgID     string
+	Delivered int
+	Failed    int
+}
@@ -1,6 +1,11 @@
 package entity
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,8 +16,27 @@ type PullRequestStatus string
 const (
 	StatusOpen   PullRequestStatus = "OPEN"
 	StatusMerged PullRequestStatus = "MERGED"
+	// StatusClosed is a PR abandoned without merging. Its reviewers no
+	// longer count it as an open review.
+	StatusClosed PullRequestStatus = "CLOSED"
+	// StatusChangesRequested is a PR a reviewer sent back to its author. It
+	// waits for the author to reopen it and is not an open review meanwhile.
+	StatusChangesRequested PullRequestStatus = "CHANGES_REQUESTED"
 )
 
+func PullRequestStatuses() []PullRequestStatus {
+	return []PullRequestStatus{StatusOpen, StatusMerged, StatusClosed, StatusChangesRequested}
+}
+
+func (s PullRequestStatus) IsValid() bool {
+	switch s {
+	case StatusOpen, StatusMerged, StatusClosed, StatusChangesRequested:
+		return true
+	default:
+		return false
+	}
+}
+
 type PullRequest struct {
 	PullRequestID     uuid.UUID
 	PullRequestName   string
@@ -21,4 +45,292 @@ type PullRequest struct {
 	AssignedReviewers []uuid.UUID
 	CreatedAt         time.Time
 	MergedAt          *time.Time
+	ClosedAt          *time.Time
+	MilestoneID       *uuid.UUID
+	Checklist         Checklist
+	ReviewerSlots     map[uuid.UUID]ReviewerSlot
+	Approvals         []Approval
+	Override          *ApprovalOverride
+	ChangesRequested  *ChangeRequest
+	Declines          []ReviewerDecline
+	AutoMerge         bool
+	Iteration         int
+	FirstResponses    map[uuid.UUID]time.Time
+	ExternalID        string
+	ChangedFiles      []string
+	// Version is assigned by the repository and grows with every stored
+	// change; conditional updates compare it to detect concurrent writers.
+	Version int
+}
+
+// ReviewerSlot tells why a reviewer was assigned and whether their approval
+// gates the merge. Reviewers without an explicit slot (e.g. PRs created before
+// slots existed) are required.
+type ReviewerSlot string
+
+const (
+	SlotRequired ReviewerSlot = "REQUIRED"
+	SlotOptional ReviewerSlot = "OPTIONAL"
+	SlotOwner    ReviewerSlot = "OWNER"
+	SlotPeer     ReviewerSlot = "PEER"
+)
+
+func (s ReviewerSlot) Blocking() bool {
+	return s != SlotOptional
+}
+
+type Approval struct {
+	UserID     uuid.UUID
+	ApprovedAt time.Time
+}
+
+// ApprovalOverride is a team lead's approval on behalf of the reviewers. It
+// satisfies the approval gates of a merge but is never counted as a review.
+type ApprovalOverride struct {
+	UserID       uuid.UUID
+	Reason       string
+	OverriddenAt time.Time
+}
+
+// ReviewerDecline records a reviewer who stepped down from the PR, the
+// reason they gave and who took their place.
+type ReviewerDecline struct {
+	UserID     uuid.UUID
+	ReplacedBy uuid.UUID
+	Reason     string
+	DeclinedAt time.Time
+}
+
+// PullRequestUpdate lists the metadata to change on a PR; nil fields are
+// kept as they are.
+type PullRequestUpdate struct {
+	Name *string
+}
+
+// ChangeRequest is the reviewer's request for changes that sent the PR back
+// to its author.
+type ChangeRequest struct {
+	UserID      uuid.UUID
+	RequestedAt time.Time
+}
+
+func (pr *PullRequest) IsOverridden() bool {
+	return pr.Override != nil
+}
+
+func (pr *PullRequest) SlotOf(reviewerID uuid.UUID) ReviewerSlot {
+	if slot, ok := pr.ReviewerSlots[reviewerID]; ok {
+		return slot
+	}
+	return SlotRequired
+}
+
+func (pr *PullRequest) IsApprovedBy(userID uuid.UUID) bool {
+	return slices.ContainsFunc(pr.Approvals, func(a Approval) bool {
+		return a.UserID == userID
+	})
+}
+
+func (pr *PullRequest) PendingRequiredApprovals() []uuid.UUID {
+	var pending []uuid.UUID
+	for _, id := range pr.AssignedReviewers {
+		if pr.SlotOf(id).Blocking() && !pr.IsApprovedBy(id) {
+			pending = append(pending, id)
+		}
+	}
+	return pending
+}
+
+// PendingApprovals returns every assigned reviewer, blocking or not, that
+// has not approved yet.
+func (pr *PullRequest) PendingApprovals() []uuid.UUID {
+	var pending []uuid.UUID
+	for _, id := range pr.AssignedReviewers {
+		if !pr.IsApprovedBy(id) {
+			pending = append(pending, id)
+		}
+	}
+	return pending
+}
+
+// RecordResponse stores the time of a reviewer's first action on the PR;
+// later actions keep the original timestamp. The map is copied because PR
+// values share it with the stored entity.
+func (pr *PullRequest) RecordResponse(userID uuid.UUID, at time.Time) {
+	if _, ok := pr.FirstResponses[userID]; ok {
+		return
+	}
+	responses := maps.Clone(pr.FirstResponses)
+	if responses == nil {
+		responses = make(map[uuid.UUID]time.Time)
+	}
+	responses[userID] = at
+	pr.FirstResponses = responses
+}
+
+// ReviewIterations is the number of review rounds the PR went through. PRs
+// stored before iterations were tracked count as a single round.
+func (pr *PullRequest) ReviewIterations() int {
+	return max(pr.Iteration, 1)
+}
+
+// InvalidReviewer returns the first assigned reviewer that makes the
+// reviewer list invalid, either because they are assigned twice or because
+// they authored the PR, together with the reason.
+func (pr *PullRequest) InvalidReviewer() (uuid.UUID, string, bool) {
+	for i, id := range pr.AssignedReviewers {
+		switch {
+		case id == pr.AuthorID:
+			return id, "author cannot review own PR", true
+		case slices.Contains(pr.AssignedReviewers[:i], id):
+			return id, "duplicate reviewer", true
+		}
+	}
+	return uuid.Nil, "", false
+}
+
+// CheckInvariants reports every rule of a well-formed PR that pr breaks:
+// reviewers are unique and never the author, MergedAt and ClosedAt are set
+// exactly when the PR is merged or closed, and ChangesRequested exactly
+// when changes are requested on it. The usecases maintain these
+// rules themselves, so a violation points at a logic bug.
+func (pr *PullRequest) CheckInvariants() error {
+	var errs []error
+
+	if id, reason, ok := pr.InvalidReviewer(); ok {
+		errs = append(errs, fmt.Errorf("reviewer %s: %s", id, reason))
+	}
+
+	switch {
+	case pr.Status == StatusMerged && pr.MergedAt == nil:
+		errs = append(errs, errors.New("merged PR has no merge time"))
+	case pr.Status != StatusMerged && pr.MergedAt != nil:
+		errs = append(errs, fmt.Errorf("%s PR has a merge time", pr.Status))
+	}
+
+	switch {
+	case pr.Status == StatusClosed && pr.ClosedAt == nil:
+		errs = append(errs, errors.New("closed PR has no close time"))
+	case pr.Status != StatusClosed && pr.ClosedAt != nil:
+		errs = append(errs, fmt.Errorf("%s PR has a close time", pr.Status))
+	}
+
+	switch {
+	case pr.Status == StatusChangesRequested && pr.ChangesRequested == nil:
+		errs = append(errs, errors.New("PR with requested changes has no change request"))
+	case pr.Status != StatusChangesRequested && pr.ChangesRequested != nil:
+		errs = append(errs, fmt.Errorf("%s PR has a change request", pr.Status))
+	}
+
+	return errors.Join(errs...)
+}
+
+type PullRequestSortField string
+
+const (
+	SortByCreatedAt PullRequestSortField = "created_at"
+	SortByMergedAt  PullRequestSortField = "merged_at"
+	SortByName      PullRequestSortField = "name"
+)
+
+func (f PullRequestSortField) IsValid() bool {
+	switch f {
+	case SortByCreatedAt, SortByMergedAt, SortByName:
+		return true
+	default:
+		return false
+	}
+}
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+func (o SortOrder) IsValid() bool {
+	return o == SortAsc || o == SortDesc
+}
+
+type PullRequestFilter struct {
+	Status    *PullRequestStatus
+	NameQuery string
+	SortBy    PullRequestSortField
+	Order     SortOrder
+}
+
+func (f PullRequestFilter) Matches(pr *PullRequest) bool {
+	if f.Status != nil && pr.Status != *f.Status {
+		return false
+	}
+	if f.NameQuery != "" && !strings.Contains(strings.ToLower(pr.PullRequestName), strings.ToLower(f.NameQuery)) {
+		return false
+	}
+	return true
+}
+
+// PullRequestListFilter selects PRs for the PR list. Every set field must
+// match; ReviewerID matches PRs the user is assigned to review, TeamName
+// matches PRs authored by the team's members, CreatedFrom
+// is inclusive and CreatedTo exclusive. PRs come ordered by SortBy and
+// Order; After continues a listing past the PR it points at, and Limit
+// caps the number of PRs returned.
+type PullRequestListFilter struct {
+	Status      *PullRequestStatus
+	AuthorID    *uuid.UUID
+	ReviewerID  *uuid.UUID
+	TeamName    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	SortBy      PullRequestSortField
+	Order       SortOrder
+	After       *PullRequestCursor
+	Limit       int
+}
+
+// Matches checks every condition but TeamName, which needs the team.
+func (f PullRequestListFilter) Matches(pr *PullRequest) bool {
+	if f.Status != nil && pr.Status != *f.Status {
+		return false
+	}
+	if f.AuthorID != nil && pr.AuthorID != *f.AuthorID {
+		return false
+	}
+	if f.ReviewerID != nil && !slices.Contains(pr.AssignedReviewers, *f.ReviewerID) {
+		return false
+	}
+	if f.CreatedFrom != nil && pr.CreatedAt.Before(*f.CreatedFrom) {
+		return false
+	}
+	if f.CreatedTo != nil && !pr.CreatedAt.Before(*f.CreatedTo) {
+		return false
+	}
+	return true
+}
+
+// PullRequestCursor is the position of a PR in a listing: the fields every
+// sort order compares, with the ID breaking ties. It stays valid when the
+// PR it was taken from changes or goes away.
+type PullRequestCursor struct {
+	PullRequestID   uuid.UUID
+	PullRequestName string
+	CreatedAt       time.Time
+	MergedAt        *time.Time
+}
+
+func CursorOf(pr PullRequest) PullRequestCursor {
+	return PullRequestCursor{
+		PullRequestID:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		CreatedAt:       pr.CreatedAt,
+		MergedAt:        pr.MergedAt,
+	}
+}
+
+// PullRequestPage is one page of the PR list. Next continues the listing
+// and is nil on the last page.
+type PullRequestPage struct {
+	PullRequests []PullRequest
+	Next         *PullRequestCursor
 }
@@ -0,0 +1,46 @@
+package entity
+
+type QuotaResource string
+
+const (
+	QuotaTeams       QuotaResource = "teams"
+	QuotaUsers       QuotaResource = "users"
+	QuotaOpenPRs     QuotaResource = "open_prs"
+	QuotaTeamMembers QuotaResource = "team_members"
+	QuotaTeamOpenPRs QuotaResource = "team_open_prs"
+)
+
+// Quotas limits how much an organization, and each of its teams, may hold.
+// A zero limit means unlimited.
+type Quotas struct {
+	MaxTeams       int
+	MaxUsers       int
+	MaxOpenPRs     int
+	MaxTeamMembers int
+	MaxTeamOpenPRs int
+}
+
+func (q Quotas) Limit(resource QuotaResource) int {
+	switch resource {
+	case QuotaTeams:
+		return q.MaxTeams
+	case QuotaUsers:
+		return q.MaxUsers
+	case QuotaOpenPRs:
+		return q.MaxOpenPRs
+	case QuotaTeamMembers:
+		return q.MaxTeamMembers
+	case QuotaTeamOpenPRs:
+		return q.MaxTeamOpenPRs
+	}
+	return 0
+}
+
+// QuotaUsage reports current usage of one resource. TeamName is set for
+// per-team resources.
+type QuotaUsage struct {
+	Resource QuotaResource
+	TeamName string
+	Used     int
+	Limit    int
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "github.com/google/uuid"
+
+// ReviewerMove is one review assignment handed from a busier team member to
+// a less busy one during a rebalance.
+type ReviewerMove struct {
+	PullRequestID uuid.UUID
+	FromUserID    uuid.UUID
+	ToUserID      uuid.UUID
+}
+
+// RebalanceResult lists the moves of a rebalance together with the open
+// review count of every active team member before and after it.
+type RebalanceResult struct {
+	TeamName   string
+	DryRun     bool
+	Moves      []ReviewerMove
+	LoadBefore map[uuid.UUID]int
+	LoadAfter  map[uuid.UUID]int
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+type RetentionResource string
+
+const (
+	RetentionAuditEntries         RetentionResource = "audit_entries"
+	RetentionMergedPullRequests   RetentionResource = "merged_pull_requests"
+	RetentionArchivedPullRequests RetentionResource = "archived_pull_requests"
+	RetentionIdempotencyKeys      RetentionResource = "idempotency_keys"
+)
+
+// RetentionPolicy sets how long data is kept; a zero duration keeps it
+// forever. Merged PRs are archived once MergedPullRequests has passed
+// since their merge, or deleted with DeleteMergedPullRequests, and
+// archived PRs are deleted once ArchivedPullRequests has passed since
+// their merge. IdempotencyKeys is how long responses stored for retried
+// requests are kept.
+type RetentionPolicy struct {
+	AuditEntries             time.Duration
+	MergedPullRequests       time.Duration
+	DeleteMergedPullRequests bool
+	ArchivedPullRequests     time.Duration
+	IdempotencyKeys          time.Duration
+}
+
+// PurgeResult reports one cleanup of a resource in an organization.
+// Archived means the Deleted entries were archived instead.
+type PurgeResult struct {
+	OrgID    string
+	Resource RetentionResource
+	Before   time.Time
+	Deleted  int
+	Archived bool
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "slices"
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleLead   Role = "lead"
+	RoleMember Role = "member"
+)
+
+func Roles() []Role {
+	return []Role{RoleAdmin, RoleLead, RoleMember}
+}
+
+func (r Role) Valid() bool {
+	return slices.Contains(Roles(), r)
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// StateSnapshotVersion is the format version of StateSnapshot; import
+// rejects dumps of any other version.
+const StateSnapshotVersion = 1
+
+// StateSnapshot is the state of one organization as dumped by export and
+// restored by import.
+type StateSnapshot struct {
+	Version      int
+	ExportedAt   time.Time
+	Teams        []Team
+	Users        []User
+	PullRequests []PullRequest
+}
+
+// ImportResult counts the entities an import created and the existing ones
+// it overwrote.
+type ImportResult struct {
+	Created map[string]int
+	Updated map[string]int
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StorageStats struct {
+	Backend        string
+	Users          int
+	ActiveUsers    int
+	Teams          int
+	PullRequests   map[PullRequestStatus]int
+	EstimatedBytes int64
+	Indexes        []IndexStats
+}
+
+type IndexStats struct {
+	Name           string
+	Entries        int
+	EstimatedBytes int64
+}
+
+// ReviewStats aggregates review churn over a set of PRs. TeamName is empty
+// for service-wide stats.
+type ReviewStats struct {
+	TeamName          string
+	PullRequests      int
+	TotalIterations   int
+	MaxIterations     int
+	MultiIterationPRs int
+	OverriddenPRs     int
+	FirstResponse     ResponseStats
+	Reviewers         []ReviewerResponseStats
+	Teams             []TeamResponseStats
+}
+
+func (s ReviewStats) AvgIterations() float64 {
+	if s.PullRequests == 0 {
+		return 0
+	}
+	return float64(s.TotalIterations) / float64(s.PullRequests)
+}
+
+// ResponseStats measures the time from PR creation to a reviewer's first
+// action (approval or checklist update).
+type ResponseStats struct {
+	Responses int
+	Total     time.Duration
+}
+
+func (s *ResponseStats) Add(d time.Duration) {
+	s.Responses++
+	s.Total += d
+}
+
+func (s ResponseStats) Avg() time.Duration {
+	if s.Responses == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Responses)
+}
+
+type ReviewerResponseStats struct {
+	UserID   uuid.UUID
+	TeamName string
+	ResponseStats
+}
+
+type TeamResponseStats struct {
+	TeamName string
+	ResponseStats
+}
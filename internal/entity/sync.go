@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type SyncResult struct {
+	Source       string
+	TeamsCreated int
+	TeamsUpdated int
+	Members      int
+	StartedAt    time.Time
+	FinishedAt   time.Time
+}
@@ -6,3 +6,18 @@ type Team struct {
 	TeamName string
 	Members  []uuid.UUID
 }
+
+// TeamListFilter pages through the teams ordered by name. A zero Limit
+// returns every team past Offset.
+type TeamListFilter struct {
+	Offset int
+	Limit  int
+}
+
+// TeamSummary is a team with its size and the number of open PRs authored
+// by its members.
+type TeamSummary struct {
+	TeamName         string
+	Members          int
+	OpenPullRequests int
+}
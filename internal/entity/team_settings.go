@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"regexp"
+	"sync"
+	"time"
+)
+
+// TeamSettings overrides the service-wide review defaults for one team.
+// Nil pointers and empty strings inherit the default, so a team only pins
+// what it wants to differ and follows later changes of everything else.
+type TeamSettings struct {
+	TeamName           string
+	RequiredReviewers  *int
+	OptionalReviewers  *int
+	SLA                *time.Duration
+	AssignmentStrategy string
+	MergeApprovals     string
+	// Timezone is an IANA zone name such as "Europe/Moscow"; empty means
+	// UTC.
+	Timezone string
+	// NamePattern is a regular expression new PR names must match, such as
+	// `^[A-Z]+-[0-9]+: ` for a ticket prefix; empty allows any name.
+	NamePattern string
+}
+
+// namePatterns caches compiled NamePatterns by their source. Patterns are
+// set by admins, so there are few, and settings are loaded per request.
+var namePatterns sync.Map
+
+// NameRegexp returns NamePattern compiled, or nil when it is empty. Each
+// pattern is compiled once, when settings with it are first validated or
+// used.
+func (s TeamSettings) NameRegexp() (*regexp.Regexp, error) {
+	if s.NamePattern == "" {
+		return nil, nil
+	}
+	if re, ok := namePatterns.Load(s.NamePattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(s.NamePattern)
+	if err != nil {
+		return nil, err
+	}
+	namePatterns.Store(s.NamePattern, re)
+	return re, nil
+}
+
+// Location returns the team's time zone; an unknown name falls back to UTC.
+func (s TeamSettings) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
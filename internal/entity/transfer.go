@@ -0,0 +1,18 @@
+package entity
+
+import "github.com/google/uuid"
+
+// ReviewTransfer is the outcome of moving every open review of one user.
+// Moves lists the assignments handed over; Skipped the PRs that kept the
+// user as reviewer, with the reason.
+type ReviewTransfer struct {
+	FromUserID uuid.UUID
+	ToUserID   uuid.UUID
+	Moves      []ReviewerMove
+	Skipped    []SkippedTransfer
+}
+
+type SkippedTransfer struct {
+	PullRequestID uuid.UUID
+	Reason        string
+}
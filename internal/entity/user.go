@@ -1,10 +1,52 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type User struct {
 	UserID   uuid.UUID
 	Username string
 	TeamName string
 	IsActive bool
+	// SnoozedUntil pauses new review assignments without deactivating the
+	// user; the snooze lapses on its own once the time has passed.
+	SnoozedUntil *time.Time
+	// DeletedAt marks a soft-deleted user: kept so PRs they authored or
+	// reviewed still resolve, but never assigned again and hidden from
+	// user lists.
+	DeletedAt *time.Time
+}
+
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+func (u *User) IsSnoozed(now time.Time) bool {
+	return u.SnoozedUntil != nil && now.Before(*u.SnoozedUntil)
+}
+
+// IsAssignable reports whether the user may be given new reviews at now.
+func (u *User) IsAssignable(now time.Time) bool {
+	return u.IsActive && !u.IsDeleted() && !u.IsSnoozed(now)
+}
+
+// ActiveStatusChange is the outcome for one user of setting the active flag
+// of several users at once; Changed is false when the flag already had the
+// requested value.
+type ActiveStatusChange struct {
+	User    User
+	Changed bool
+}
+
+// UserFilter selects users; deleted users only match with IncludeDeleted.
+type UserFilter struct {
+	TeamName       *string
+	IsActive       *bool
+	Username       string
+	IncludeDeleted bool
+	Offset         int
+	Limit          int
 }
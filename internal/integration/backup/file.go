@@ -0,0 +1,59 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"avito-intro/internal/entity"
+)
+
+// FileStore writes backups as files under a local directory. A backup is
+// written to a temporary file first and renamed into place, so a crash never
+// leaves a truncated backup under its final name.
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) Name() string {
+	return "file"
+}
+
+func (s *FileStore) Save(ctx context.Context, name string, snapshot entity.StateSnapshot) (string, error) {
+	body, err := encode(snapshot)
+	if err != nil {
+		return "", fmt.Errorf("encode snapshot: %w", err)
+	}
+
+	path := filepath.Join(s.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".backup-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,28 @@
+package backup
+
+import (
+	"encoding/json"
+	"time"
+
+	"avito-intro/internal/entity"
+)
+
+// snapshot is the layout of POST /admin/export, so a backup can be restored
+// with POST /admin/import.
+type snapshot struct {
+	Version      int                  `json:"version"`
+	ExportedAt   time.Time            `json:"exported_at"`
+	Teams        []entity.Team        `json:"teams"`
+	Users        []entity.User        `json:"users"`
+	PullRequests []entity.PullRequest `json:"pull_requests"`
+}
+
+func encode(s entity.StateSnapshot) ([]byte, error) {
+	return json.Marshal(snapshot{
+		Version:      s.Version,
+		ExportedAt:   s.ExportedAt,
+		Teams:        s.Teams,
+		Users:        s.Users,
+		PullRequests: s.PullRequests,
+	})
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultAPIURL = "https://api.github.com"
+	perPage       = 100
+)
+
+type Team struct {
+	ID   int64  `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type Member struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+type PullRequestFile struct {
+	Filename string `json:"filename"`
+}
+
+type Client struct {
+	apiURL     string
+	token      string
+	httpClient *http.Client
+}
+
+func NewClient(apiURL, token string) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	return &Client{
+		apiURL:     strings.TrimRight(apiURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *Client) ListTeams(ctx context.Context, org string) ([]Team, error) {
+	var teams []Team
+	path := fmt.Sprintf("/orgs/%s/teams", url.PathEscape(org))
+	if err := c.listAll(ctx, path, func(page json.RawMessage) (int, error) {
+		var batch []Team
+		if err := json.Unmarshal(page, &batch); err != nil {
+			return 0, err
+		}
+		teams = append(teams, batch...)
+		return len(batch), nil
+	}); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (c *Client) ListTeamMembers(ctx context.Context, org, teamSlug string) ([]Member, error) {
+	var members []Member
+	path := fmt.Sprintf("/orgs/%s/teams/%s/members", url.PathEscape(org), url.PathEscape(teamSlug))
+	if err := c.listAll(ctx, path, func(page json.RawMessage) (int, error) {
+		var batch []Member
+		if err := json.Unmarshal(page, &batch); err != nil {
+			return 0, err
+		}
+		members = append(members, batch...)
+		return len(batch), nil
+	}); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (c *Client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]PullRequestFile, error) {
+	var files []PullRequestFile
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/files", url.PathEscape(owner), url.PathEscape(repo), number)
+	if err := c.listAll(ctx, path, func(page json.RawMessage) (int, error) {
+		var batch []PullRequestFile
+		if err := json.Unmarshal(page, &batch); err != nil {
+			return 0, err
+		}
+		files = append(files, batch...)
+		return len(batch), nil
+	}); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// ChangedFiles resolves an external PR reference of the form
+// "owner/repo#number" and returns the paths it touches.
+func (c *Client) ChangedFiles(ctx context.Context, externalID string) ([]string, error) {
+	owner, repo, number, err := ParsePullRequestRef(externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := c.ListPullRequestFiles(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Filename
+	}
+	return paths, nil
+}
+
+func ParsePullRequestRef(ref string) (owner, repo string, number int, err error) {
+	fullName, num, ok := strings.Cut(ref, "#")
+	if ok {
+		owner, repo, ok = strings.Cut(fullName, "/")
+	}
+	if ok {
+		number, err = strconv.Atoi(num)
+	}
+	if !ok || err != nil || owner == "" || repo == "" || strings.Contains(repo, "/") || number <= 0 {
+		return "", "", 0, fmt.Errorf("invalid pull request reference %q, want owner/repo#number", ref)
+	}
+	return owner, repo, number, nil
+}
+
+func (c *Client) listAll(ctx context.Context, path string, consume func(json.RawMessage) (int, error)) error {
+	for page := 1; ; page++ {
+		query := url.Values{
+			"per_page": {fmt.Sprint(perPage)},
+			"page":     {fmt.Sprint(page)},
+		}
+
+		body, err := c.get(ctx, path+"?"+query.Encode())
+		if err != nil {
+			return err
+		}
+
+		n, err := consume(body)
+		if err != nil {
+			return fmt.Errorf("decode %s: %w", path, err)
+		}
+		if n < perPage {
+			return nil
+		}
+	}
+}
+
+func (c *Client) get(ctx context.Context, pathAndQuery string) (json.RawMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+pathAndQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github api %s: unexpected status %s", pathAndQuery, resp.Status)
+	}
+
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,142 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const syncSource = "github"
+
+// userNamespace derives stable service user IDs from GitHub numeric user IDs.
+var userNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com"))
+
+var _ usecase.TeamSyncUsecase = (*Syncer)(nil)
+
+type Syncer struct {
+	client *Client
+	org    string
+	teamUC usecase.TeamUsecase
+	userUC usecase.UserUsecase
+	logger *zap.Logger
+
+	mu sync.Mutex
+}
+
+func NewSyncer(
+	client *Client,
+	org string,
+	teamUC usecase.TeamUsecase,
+	userUC usecase.UserUsecase,
+	logger *zap.Logger,
+) *Syncer {
+	return &Syncer{
+		client: client,
+		org:    org,
+		teamUC: teamUC,
+		userUC: userUC,
+		logger: logger,
+	}
+}
+
+func UserID(githubID int64) uuid.UUID {
+	return uuid.NewSHA1(userNamespace, []byte(fmt.Sprint(githubID)))
+}
+
+func (s *Syncer) Sync(ctx context.Context) (entity.SyncResult, error) {
+	if !s.mu.TryLock() {
+		return entity.SyncResult{}, usecase.ErrSyncInProgress.Withf("github sync already in progress")
+	}
+	defer s.mu.Unlock()
+
+	result := entity.SyncResult{Source: syncSource, StartedAt: time.Now()}
+	s.logger.Info("starting github org sync", zap.String("org", s.org))
+
+	teams, err := s.client.ListTeams(ctx, s.org)
+	if err != nil {
+		s.logger.Error("failed to list github teams", zap.Error(err))
+		return entity.SyncResult{}, err
+	}
+
+	for _, team := range teams {
+		members, err := s.client.ListTeamMembers(ctx, s.org, team.Slug)
+		if err != nil {
+			s.logger.Error("failed to list github team members",
+				zap.String("team", team.Slug),
+				zap.Error(err),
+			)
+			return entity.SyncResult{}, err
+		}
+
+		users, err := s.toUsers(ctx, team.Slug, members)
+		if err != nil {
+			return entity.SyncResult{}, err
+		}
+
+		_, created, err := s.teamUC.SetTeamMembers(ctx, team.Slug, users)
+		if err != nil {
+			return entity.SyncResult{}, fmt.Errorf("sync team %q: %w", team.Slug, err)
+		}
+
+		if created {
+			result.TeamsCreated++
+		} else {
+			result.TeamsUpdated++
+		}
+		result.Members += len(users)
+	}
+
+	result.FinishedAt = time.Now()
+	s.logger.Info("github org sync finished",
+		zap.String("org", s.org),
+		zap.Int("teams_created", result.TeamsCreated),
+		zap.Int("teams_updated", result.TeamsUpdated),
+		zap.Int("members", result.Members),
+		zap.Duration("took", result.FinishedAt.Sub(result.StartedAt)),
+	)
+
+	return result, nil
+}
+
+// SyncScheduled is the body of the periodic sync worker: a sync already
+// started manually is not a failure of the scheduled run.
+func (s *Syncer) SyncScheduled(ctx context.Context) error {
+	if _, err := s.Sync(ctx); err != nil && !errors.Is(err, usecase.ErrSyncInProgress) {
+		return err
+	}
+	return nil
+}
+
+// toUsers keeps the active flag of already known users so that a sync does
+// not undo manual deactivations.
+func (s *Syncer) toUsers(ctx context.Context, teamName string, members []Member) ([]entity.User, error) {
+	users := make([]entity.User, len(members))
+	for i, member := range members {
+		userID := UserID(member.ID)
+
+		isActive := true
+		existing, err := s.userUC.GetUser(ctx, userID)
+		switch {
+		case err == nil:
+			isActive = existing.IsActive
+		case !errors.Is(err, usecase.ErrNotFound):
+			return nil, err
+		}
+
+		users[i] = entity.User{
+			UserID:   userID,
+			Username: member.Login,
+			TeamName: teamName,
+			IsActive: isActive,
+		}
+	}
+	return users, nil
+}
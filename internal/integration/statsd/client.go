@@ -0,0 +1,61 @@
+package statsd
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// Client pushes metrics to a StatsD agent over UDP using the DogStatsD line
+// format, so tags are understood by Datadog agents and ignored by plain
+// StatsD servers that accept the extension. Sends are fire-and-forget.
+type Client struct {
+	conn   net.Conn
+	prefix string
+	tags   []string
+}
+
+func NewClient(addr, prefix string, tags []string) (*Client, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial statsd %s: %w", addr, err)
+	}
+
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix += "."
+	}
+
+	return &Client{
+		conn:   conn,
+		prefix: prefix,
+		tags:   tags,
+	}, nil
+}
+
+func (c *Client) Count(name string, value int64, tags ...string) {
+	c.send(name, fmt.Sprintf("%d|c", value), tags)
+}
+
+func (c *Client) Timing(name string, d time.Duration, tags ...string) {
+	c.send(name, fmt.Sprintf("%g|ms", float64(d)/float64(time.Millisecond)), tags)
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) send(name, value string, tags []string) {
+	var b strings.Builder
+	b.WriteString(c.prefix)
+	b.WriteString(name)
+	b.WriteByte(':')
+	b.WriteString(value)
+
+	if len(c.tags)+len(tags) > 0 {
+		b.WriteString("|#")
+		b.WriteString(strings.Join(append(c.tags[:len(c.tags):len(c.tags)], tags...), ","))
+	}
+
+	c.conn.Write([]byte(b.String()))
+}
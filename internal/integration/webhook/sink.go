@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// Sink is the outbox sink that POSTs every message as JSON to a URL. Any
+// response other than 2xx fails the delivery, so the message is retried.
+// With a secret the body is signed: X-Signature carries
+// "sha256=<hex HMAC-SHA256 of the body>".
+type Sink struct {
+	url        string
+	secret     string
+	httpClient *http.Client
+}
+
+func NewSink(url, secret string) *Sink {
+	return &Sink{
+		url:        url,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type message struct {
+	ID             string      `json:"id"`
+	Type           string      `json:"type"`
+	OccurredAt     time.Time   `json:"occurred_at"`
+	UserID         string      `json:"user_id,omitempty"`
+	PreviousUserID string      `json:"previous_user_id,omitempty"`
+	PullRequest    pullRequest `json:"pull_request"`
+}
+
+type pullRequest struct {
+	PullRequestID     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorID          string     `json:"author_id"`
+	Status            string     `json:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	CreatedAt         time.Time  `json:"created_at"`
+	MergedAt          *time.Time `json:"merged_at,omitempty"`
+	ClosedAt          *time.Time `json:"closed_at,omitempty"`
+}
+
+func (s *Sink) Name() string {
+	return "webhook"
+}
+
+func (s *Sink) Publish(ctx context.Context, msg entity.OutboxMessage) error {
+	body, err := json.Marshal(newMessage(msg))
+	if err != nil {
+		return fmt.Errorf("encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", msg.ID.String())
+	req.Header.Set("X-Event-Type", string(msg.Event.Type))
+	if s.secret != "" {
+		mac := hmac.New(sha256.New, []byte(s.secret))
+		mac.Write(body)
+		req.Header.Set("X-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+func newMessage(msg entity.OutboxMessage) message {
+	pr := msg.Event.PullRequest
+	reviewers := make([]string, len(pr.AssignedReviewers))
+	for i, id := range pr.AssignedReviewers {
+		reviewers[i] = id.String()
+	}
+	return message{
+		ID:             msg.ID.String(),
+		Type:           string(msg.Event.Type),
+		OccurredAt:     msg.Event.OccurredAt,
+		UserID:         optionalID(msg.Event.UserID),
+		PreviousUserID: optionalID(msg.Event.PreviousUserID),
+		PullRequest: pullRequest{
+			PullRequestID:     pr.PullRequestID.String(),
+			PullRequestName:   pr.PullRequestName,
+			AuthorID:          pr.AuthorID.String(),
+			Status:            string(pr.Status),
+			AssignedReviewers: reviewers,
+			CreatedAt:         pr.CreatedAt,
+			MergedAt:          pr.MergedAt,
+			ClosedAt:          pr.ClosedAt,
+		},
+	}
+}
+
+func optionalID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
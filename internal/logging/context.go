@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type fieldsKey struct{}
+
+type requestIDKey struct{}
+
+// WithFields attaches fields to ctx; loggers derived with From include them
+// on every line. Fields accumulate across nested calls.
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	existing, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	merged := append(existing[:len(existing):len(existing)], fields...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	return WithFields(ctx, zap.String("request_id", requestID))
+}
+
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return WithFields(ctx, zap.String("trace_id", traceID))
+}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return WithFields(ctx, zap.String("actor", actor))
+}
+
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// From returns logger enriched with the fields stored in ctx, or logger
+// itself when there are none.
+func From(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	fields, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	if len(fields) == 0 {
+		return logger
+	}
+	return logger.With(fields...)
+}
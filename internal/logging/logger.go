@@ -0,0 +1,38 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"avito-intro/config"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// New builds the service logger: JSON lines at cfg.Level to stderr or to a
+// rotating file, with per-message sampling so hot debug paths cannot flood
+// the output.
+func New(cfg config.LogConfig) (*zap.Logger, error) {
+	level, err := zapcore.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_LEVEL %q: %w", cfg.Level, err)
+	}
+
+	var out zapcore.WriteSyncer = zapcore.Lock(os.Stderr)
+	if cfg.File != "" {
+		file, err := NewRotatingFile(cfg.File, cfg.MaxSizeMB, cfg.MaxAge, cfg.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		out = file
+	}
+
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), out, level)
+	if cfg.SamplingInitial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, cfg.SamplingInitial, cfg.SamplingThereafter)
+	}
+
+	return zap.New(core, zap.AddCaller(), zap.AddStacktrace(zap.ErrorLevel)), nil
+}
@@ -0,0 +1,137 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFile is a size-based rotating log file in the spirit of
+// lumberjack: once the current file would exceed maxSize it is renamed to
+// name-<timestamp>.ext and a fresh file is opened. Backups beyond maxBackups
+// or older than maxAge are removed; zero disables the respective limit.
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func NewRotatingFile(path string, maxSizeMB int, maxAge time.Duration, maxBackups int) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+
+	f := &RotatingFile{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *RotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Sync()
+}
+
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Close()
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	prefix, ext := f.backupNameParts()
+	backup := prefix + time.Now().Format(backupTimeFormat) + ext
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.removeOldBackups()
+	return nil
+}
+
+func (f *RotatingFile) removeOldBackups() {
+	prefix, ext := f.backupNameParts()
+	backups, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return
+	}
+
+	// Timestamps sort lexically, newest last.
+	slices.Sort(backups)
+	cutoff := time.Now().Add(-f.maxAge)
+	for i, backup := range backups {
+		tooMany := f.maxBackups > 0 && i < len(backups)-f.maxBackups
+		tooOld := false
+		if f.maxAge > 0 {
+			if info, err := os.Stat(backup); err == nil {
+				tooOld = info.ModTime().Before(cutoff)
+			}
+		}
+		if tooMany || tooOld {
+			os.Remove(backup)
+		}
+	}
+}
+
+func (f *RotatingFile) backupNameParts() (prefix, ext string) {
+	ext = filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-", ext
+}
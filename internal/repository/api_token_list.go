@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"slices"
+
+	"avito-intro/internal/entity"
+)
+
+// listAPITokens picks the tokens of orgID out of tokens, oldest first.
+func listAPITokens(tokens []*entity.APIToken, orgID string) []*entity.APIToken {
+	listed := make([]*entity.APIToken, 0)
+	for _, token := range tokens {
+		if token.OrgID == orgID {
+			listed = append(listed, token)
+		}
+	}
+	slices.SortFunc(listed, func(a, b *entity.APIToken) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return listed
+}
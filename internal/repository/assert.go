@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"avito-intro/internal/entity"
+)
+
+var (
+	_ Storage    = (*AssertingRepository)(nil)
+	_ Transactor = (*AssertingRepository)(nil)
+	_ Locker     = (*AssertingRepository)(nil)
+)
+
+// AssertingRepository checks the invariants of every PR before it reaches
+// the wrapped storage and panics when one is broken. It is meant for
+// staging: a logic bug stops the request with a stack trace instead of
+// being persisted and surfacing much later. Every other call is passed
+// through unchanged.
+type AssertingRepository struct {
+	Storage
+}
+
+func NewAssertingRepository(next Storage) *AssertingRepository {
+	return &AssertingRepository{Storage: next}
+}
+
+func (r *AssertingRepository) CreatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	assertPullRequest("CreatePullRequest", pr)
+	return r.Storage.CreatePullRequest(ctx, pr)
+}
+
+func (r *AssertingRepository) UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	assertPullRequest("UpdatePullRequest", pr)
+	return r.Storage.UpdatePullRequest(ctx, pr)
+}
+
+func (r *AssertingRepository) UpdatePullRequestIf(ctx context.Context, pr *entity.PullRequest, expectedVersion int) error {
+	assertPullRequest("UpdatePullRequestIf", pr)
+	return r.Storage.UpdatePullRequestIf(ctx, pr, expectedVersion)
+}
+
+func (r *AssertingRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return InTx(ctx, r.Storage, fn)
+}
+
+func (r *AssertingRepository) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return WithLock(ctx, r.Storage, name, fn)
+}
+
+func assertPullRequest(op string, pr *entity.PullRequest) {
+	if err := pr.CheckInvariants(); err != nil {
+		panic(fmt.Sprintf("repository: %s of PR %s breaks invariants: %v", op, pr.PullRequestID, err))
+	}
+}
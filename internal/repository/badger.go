@@ -0,0 +1,796 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	_ Storage    = (*BadgerRepository)(nil)
+	_ Transactor = (*BadgerRepository)(nil)
+	_ Locker     = (*BadgerRepository)(nil)
+)
+
+// BadgerRepository keeps its state in an embedded Badger database, so it
+// survives restarts without an external service. Every entity is a JSON
+// value under prefix+"<kind>:<id>" and scans iterate over the kind's key
+// prefix. Every call runs in a Badger transaction; transactions that lost
+// a conflict with a concurrent writer are retried.
+type BadgerRepository struct {
+	db     *badger.DB
+	prefix string
+	logger *zap.Logger
+	locks  localLocks
+}
+
+func NewBadgerRepository(db *badger.DB, prefix string, logger *zap.Logger) *BadgerRepository {
+	return &BadgerRepository{
+		db:     db,
+		prefix: prefix,
+		logger: logger,
+	}
+}
+
+func (r *BadgerRepository) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.db.IsClosed() {
+		return errors.New("badger database is closed")
+	}
+	return nil
+}
+
+const (
+	badgerUsers        = "users"
+	badgerTeams        = "teams"
+	badgerPullRequests = "pull_requests"
+	badgerMilestones   = "milestones"
+	// Archived PRs are never scanned: only reads by ID look at them.
+	badgerArchivedPullRequests = "archived_pull_requests"
+)
+
+func (r *BadgerRepository) key(kind, id string) string {
+	return r.prefix + kind + ":" + id
+}
+
+// kindPrefix is the common prefix of the keys of every entity of kind.
+func (r *BadgerRepository) kindPrefix(kind string) string {
+	return r.prefix + kind + ":"
+}
+
+// badgerTx is the transaction of InTx, carried in its ctx.
+type badgerTx struct {
+	db  *badger.DB
+	txn *badger.Txn
+}
+
+type badgerTxKey struct{}
+
+// txn returns the transaction of the InTx ctx runs in, or nil. Repositories
+// of other organizations share the database, so they join it too.
+func (r *BadgerRepository) txn(ctx context.Context) *badger.Txn {
+	if tx, ok := ctx.Value(badgerTxKey{}).(*badgerTx); ok && tx.db == r.db {
+		return tx.txn
+	}
+	return nil
+}
+
+// InTx runs fn in one read-write transaction. When the commit loses a
+// conflict with another writer, fn is run again on a fresh transaction,
+// so it must not have effects outside the storage.
+func (r *BadgerRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.txn(ctx) != nil {
+		return fn(ctx)
+	}
+	err := retryConflicts(func() error {
+		return r.db.Update(func(txn *badger.Txn) error {
+			return fn(context.WithValue(ctx, badgerTxKey{}, &badgerTx{db: r.db, txn: txn}))
+		})
+	})
+	if err != nil {
+		logging.From(ctx, r.logger).Info("transaction rolled back", zap.Error(err))
+	}
+	return err
+}
+
+// WithLock holds a lock of this process: a Badger database is opened by
+// one process only.
+func (r *BadgerRepository) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return r.locks.withLock(ctx, name, fn)
+}
+
+// update runs fn in the transaction of InTx, or in a transaction of its
+// own that is retried on conflicts.
+func (r *BadgerRepository) update(ctx context.Context, fn func(txn *badger.Txn) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if txn := r.txn(ctx); txn != nil {
+		return fn(txn)
+	}
+	return retryConflicts(func() error {
+		return r.db.Update(fn)
+	})
+}
+
+// view runs fn in the transaction of InTx, so it sees its writes, or in a
+// read-only transaction.
+func (r *BadgerRepository) view(ctx context.Context, fn func(txn *badger.Txn) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if txn := r.txn(ctx); txn != nil {
+		return fn(txn)
+	}
+	return r.db.View(fn)
+}
+
+// retryConflicts runs fn until its transaction commits without a conflict,
+// at most maxWatchRetries times.
+func retryConflicts(fn func() error) error {
+	for range maxWatchRetries {
+		err := fn()
+		if !errors.Is(err, badger.ErrConflict) {
+			return err
+		}
+	}
+	return ErrConflict
+}
+
+func decodeItem(item *badger.Item, v any) error {
+	return item.Value(func(data []byte) error {
+		if err := json.Unmarshal(data, v); err != nil {
+			return fmt.Errorf("decode %s: %w", item.Key(), err)
+		}
+		return nil
+	})
+}
+
+// get decodes the value at key into v; a missing key is ErrNotFound.
+func (r *BadgerRepository) get(txn *badger.Txn, key string, v any) error {
+	item, err := txn.Get([]byte(key))
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("badger get %s: %w", key, err)
+	}
+	return decodeItem(item, v)
+}
+
+// load is get in a transaction of its own.
+func (r *BadgerRepository) load(ctx context.Context, key string, v any) error {
+	return r.view(ctx, func(txn *badger.Txn) error {
+		return r.get(txn, key, v)
+	})
+}
+
+// exists reports whether any of keys exists.
+func (r *BadgerRepository) exists(txn *badger.Txn, keys ...string) (bool, error) {
+	for _, key := range keys {
+		_, err := txn.Get([]byte(key))
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, badger.ErrKeyNotFound) {
+			return false, fmt.Errorf("badger get %s: %w", key, err)
+		}
+	}
+	return false, nil
+}
+
+// existsAny is exists in a transaction of its own.
+func (r *BadgerRepository) existsAny(ctx context.Context, keys ...string) (bool, error) {
+	var found bool
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		var err error
+		found, err = r.exists(txn, keys...)
+		return err
+	})
+	return found, err
+}
+
+// set stores v as JSON under key.
+func (r *BadgerRepository) set(txn *badger.Txn, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", key, err)
+	}
+	if err := txn.Set([]byte(key), data); err != nil {
+		return fmt.Errorf("badger set %s: %w", key, err)
+	}
+	return nil
+}
+
+// create stores v under kind:id unless the key exists.
+func (r *BadgerRepository) create(ctx context.Context, kind, id string, v any) error {
+	key := r.key(kind, id)
+	return r.update(ctx, func(txn *badger.Txn) error {
+		exists, err := r.exists(txn, key)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrAlreadyExists
+		}
+		return r.set(txn, key, v)
+	})
+}
+
+// replace overwrites the existing value of kind:id.
+func (r *BadgerRepository) replace(ctx context.Context, kind, id string, v any) error {
+	key := r.key(kind, id)
+	return r.update(ctx, func(txn *badger.Txn) error {
+		exists, err := r.exists(txn, key)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNotFound
+		}
+		return r.set(txn, key, v)
+	})
+}
+
+// each calls fn for every item whose key starts with prefix, in key order
+// or in reverse.
+func each(txn *badger.Txn, prefix string, reverse bool, fn func(item *badger.Item) error) error {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = []byte(prefix)
+	opts.Reverse = reverse
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	seek := []byte(prefix)
+	if reverse {
+		seek = append(seek, 0xff)
+	}
+	for it.Seek(seek); it.ValidForPrefix(opts.Prefix); it.Next() {
+		if err := fn(it.Item()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanKind decodes every entity of kind in key order.
+func scanKind[T any](txn *badger.Txn, r *BadgerRepository, kind string) ([]*T, error) {
+	var items []*T
+	err := each(txn, r.kindPrefix(kind), false, func(item *badger.Item) error {
+		v := new(T)
+		if err := decodeItem(item, v); err != nil {
+			return err
+		}
+		items = append(items, v)
+		return nil
+	})
+	return items, err
+}
+
+// loadKind is scanKind in a transaction of its own.
+func loadKind[T any](ctx context.Context, r *BadgerRepository, kind string) ([]*T, error) {
+	var items []*T
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		var err error
+		items, err = scanKind[T](txn, r, kind)
+		return err
+	})
+	return items, err
+}
+
+// UserRepository implementation
+
+func (r *BadgerRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	if err := r.create(ctx, badgerUsers, user.UserID.String(), user); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			logging.From(ctx, r.logger).Warn("user already exists", zap.String("user_id", user.UserID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("user created",
+		zap.String("user_id", user.UserID.String()),
+		zap.String("username", user.Username),
+		zap.String("team_name", user.TeamName),
+		zap.Bool("is_active", user.IsActive),
+	)
+	return nil
+}
+
+func (r *BadgerRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	if err := r.replace(ctx, badgerUsers, user.UserID.String(), user); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("user not found for update", zap.String("user_id", user.UserID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("user updated",
+		zap.String("user_id", user.UserID.String()),
+		zap.String("team_name", user.TeamName),
+		zap.Bool("is_active", user.IsActive),
+	)
+	return nil
+}
+
+func (r *BadgerRepository) DeleteUser(ctx context.Context, userID uuid.UUID, deletedAt time.Time) error {
+	key := r.key(badgerUsers, userID.String())
+	err := r.update(ctx, func(txn *badger.Txn) error {
+		var user entity.User
+		if err := r.get(txn, key, &user); err != nil {
+			return err
+		}
+		user.DeletedAt = &deletedAt
+		return r.set(txn, key, &user)
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("user not found for delete", zap.String("user_id", userID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("user deleted", zap.String("user_id", userID.String()))
+	return nil
+}
+
+func (r *BadgerRepository) GetUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	var user entity.User
+	if err := r.load(ctx, r.key(badgerUsers, userID.String()), &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *BadgerRepository) UserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return r.existsAny(ctx, r.key(badgerUsers, userID.String()))
+}
+
+func (r *BadgerRepository) GetUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
+	users, err := loadKind[entity.User](ctx, r, badgerUsers)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(users, func(user *entity.User) bool {
+		return user.TeamName != teamName
+	}), nil
+}
+
+func (r *BadgerRepository) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*entity.User, error) {
+	users := make([]*entity.User, 0, len(userIDs))
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		for _, id := range userIDs {
+			var user entity.User
+			if err := r.get(txn, r.key(badgerUsers, id.String()), &user); err != nil {
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				return err
+			}
+			users = append(users, &user)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *BadgerRepository) ListUsers(ctx context.Context, filter entity.UserFilter) ([]*entity.User, int, error) {
+	users, err := loadKind[entity.User](ctx, r, badgerUsers)
+	if err != nil {
+		return nil, 0, err
+	}
+	users, total := listUsers(users, filter)
+	return users, total, nil
+}
+
+// TeamRepository implementation
+
+func (r *BadgerRepository) CreateTeam(ctx context.Context, team *entity.Team) error {
+	if err := r.create(ctx, badgerTeams, team.TeamName, team); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			logging.From(ctx, r.logger).Warn("team already exists", zap.String("team_name", team.TeamName))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("team created",
+		zap.String("team_name", team.TeamName),
+		zap.Int("members_count", len(team.Members)),
+	)
+	return nil
+}
+
+func (r *BadgerRepository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	if err := r.replace(ctx, badgerTeams, team.TeamName, team); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("team not found for update", zap.String("team_name", team.TeamName))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("team updated",
+		zap.String("team_name", team.TeamName),
+		zap.Int("members_count", len(team.Members)),
+	)
+	return nil
+}
+
+func (r *BadgerRepository) GetTeam(ctx context.Context, teamName string) (*entity.Team, error) {
+	var team entity.Team
+	if err := r.load(ctx, r.key(badgerTeams, teamName), &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *BadgerRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	return r.existsAny(ctx, r.key(badgerTeams, teamName))
+}
+
+// ListTeams relies on the scan being in key order, which is name order.
+func (r *BadgerRepository) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	return loadKind[entity.Team](ctx, r, badgerTeams)
+}
+
+func (r *BadgerRepository) ListTeamSummaries(ctx context.Context, filter entity.TeamListFilter) ([]entity.TeamSummary, int, error) {
+	var (
+		teams []*entity.Team
+		prs   []*entity.PullRequest
+	)
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		var err error
+		if teams, err = scanKind[entity.Team](txn, r, badgerTeams); err != nil {
+			return err
+		}
+		prs, err = scanKind[entity.PullRequest](txn, r, badgerPullRequests)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	summaries, total := summarizeTeams(teams, prs, filter)
+	return summaries, total, nil
+}
+
+// PullRequestRepository implementation
+
+func (r *BadgerRepository) CreatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	if err := checkReviewers(ctx, r.logger, pr); err != nil {
+		return err
+	}
+
+	id := pr.PullRequestID.String()
+	err := r.update(ctx, func(txn *badger.Txn) error {
+		// Archived IDs stay taken.
+		exists, err := r.exists(txn, r.key(badgerPullRequests, id), r.key(badgerArchivedPullRequests, id))
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrAlreadyExists
+		}
+		stored := *pr
+		stored.Version = 1
+		if err := r.trackOpenReviews(txn, nil, &stored); err != nil {
+			return err
+		}
+		return r.set(txn, r.key(badgerPullRequests, id), &stored)
+	})
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			logging.From(ctx, r.logger).Warn("pull request already exists", zap.String("pr_id", id))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("pull request created",
+		zap.String("pr_id", id),
+		zap.String("pr_name", pr.PullRequestName),
+		zap.String("author_id", pr.AuthorID.String()),
+		zap.Int("reviewers_count", len(pr.AssignedReviewers)),
+	)
+	pr.Version = 1
+	return nil
+}
+
+func (r *BadgerRepository) GetPullRequest(ctx context.Context, prID uuid.UUID) (*entity.PullRequest, error) {
+	var pr entity.PullRequest
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		err := r.get(txn, r.key(badgerPullRequests, prID.String()), &pr)
+		if errors.Is(err, ErrNotFound) {
+			err = r.get(txn, r.key(badgerArchivedPullRequests, prID.String()), &pr)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+func (r *BadgerRepository) UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	return r.updatePullRequest(ctx, pr, nil)
+}
+
+func (r *BadgerRepository) UpdatePullRequestIf(ctx context.Context, pr *entity.PullRequest, expectedVersion int) error {
+	return r.updatePullRequest(ctx, pr, &expectedVersion)
+}
+
+// updatePullRequest stores pr with the next version, if the stored version
+// is expectedVersion when one is given.
+func (r *BadgerRepository) updatePullRequest(ctx context.Context, pr *entity.PullRequest, expectedVersion *int) error {
+	if err := checkReviewers(ctx, r.logger, pr); err != nil {
+		return err
+	}
+
+	key := r.key(badgerPullRequests, pr.PullRequestID.String())
+	var version int
+	err := r.update(ctx, func(txn *badger.Txn) error {
+		var current entity.PullRequest
+		if err := r.get(txn, key, &current); err != nil {
+			return err
+		}
+		if expectedVersion != nil && current.Version != *expectedVersion {
+			logging.From(ctx, r.logger).Info("pull request version conflict",
+				zap.String("pr_id", pr.PullRequestID.String()),
+				zap.Int("expected_version", *expectedVersion),
+				zap.Int("stored_version", current.Version),
+			)
+			return ErrConflict
+		}
+
+		stored := *pr
+		stored.Version = current.Version + 1
+		version = stored.Version
+		if err := r.trackOpenReviews(txn, &current, &stored); err != nil {
+			return err
+		}
+		return r.set(txn, key, &stored)
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("pull request not found for update", zap.String("pr_id", pr.PullRequestID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("pull request updated",
+		zap.String("pr_id", pr.PullRequestID.String()),
+		zap.String("status", string(pr.Status)),
+		zap.Int("version", version),
+	)
+	pr.Version = version
+	return nil
+}
+
+func (r *BadgerRepository) GetPullRequestsByReviewer(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	prs, err := loadKind[entity.PullRequest](ctx, r, badgerPullRequests)
+	if err != nil {
+		return nil, err
+	}
+	prs = slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+		return !slices.Contains(pr.AssignedReviewers, userID) || !filter.Matches(pr)
+	})
+	sortPullRequests(prs, filter.SortBy, filter.Order)
+	return prs, nil
+}
+
+func (r *BadgerRepository) GetPullRequestsByAuthor(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	prs, err := loadKind[entity.PullRequest](ctx, r, badgerPullRequests)
+	if err != nil {
+		return nil, err
+	}
+	prs = slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+		return pr.AuthorID != userID || !filter.Matches(pr)
+	})
+	sortPullRequests(prs, filter.SortBy, filter.Order)
+	return prs, nil
+}
+
+func (r *BadgerRepository) GetPullRequestsByStatus(ctx context.Context, status entity.PullRequestStatus) ([]*entity.PullRequest, error) {
+	prs, err := loadKind[entity.PullRequest](ctx, r, badgerPullRequests)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+		return pr.Status != status
+	}), nil
+}
+
+func (r *BadgerRepository) GetPullRequestsByTeam(ctx context.Context, teamName string, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	var (
+		team entity.Team
+		prs  []*entity.PullRequest
+	)
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		if err := r.get(txn, r.key(badgerTeams, teamName), &team); err != nil {
+			return err
+		}
+		var err error
+		prs, err = scanKind[entity.PullRequest](txn, r, badgerPullRequests)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prs = slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+		return !slices.Contains(team.Members, pr.AuthorID) || !filter.Matches(pr)
+	})
+	if prs == nil {
+		prs = make([]*entity.PullRequest, 0)
+	}
+	sortPullRequests(prs, filter.SortBy, filter.Order)
+	return prs, nil
+}
+
+func (r *BadgerRepository) ListPullRequests(ctx context.Context, filter entity.PullRequestListFilter) ([]*entity.PullRequest, error) {
+	var (
+		team entity.Team
+		prs  []*entity.PullRequest
+	)
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		if filter.TeamName != "" {
+			if err := r.get(txn, r.key(badgerTeams, filter.TeamName), &team); err != nil {
+				return err
+			}
+		}
+		var err error
+		prs, err = scanKind[entity.PullRequest](txn, r, badgerPullRequests)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.TeamName != "" {
+		prs = slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+			return !slices.Contains(team.Members, pr.AuthorID)
+		})
+	}
+	return listPullRequests(prs, filter), nil
+}
+
+func (r *BadgerRepository) GetPullRequestsByMilestone(ctx context.Context, milestoneID uuid.UUID) ([]*entity.PullRequest, error) {
+	prs, err := loadKind[entity.PullRequest](ctx, r, badgerPullRequests)
+	if err != nil {
+		return nil, err
+	}
+	prs = slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+		return pr.MilestoneID == nil || *pr.MilestoneID != milestoneID
+	})
+	sortPullRequests(prs, entity.SortByCreatedAt, entity.SortAsc)
+	return prs, nil
+}
+
+func (r *BadgerRepository) PRExists(ctx context.Context, prID uuid.UUID) (bool, error) {
+	return r.existsAny(ctx, r.key(badgerPullRequests, prID.String()), r.key(badgerArchivedPullRequests, prID.String()))
+}
+
+func (r *BadgerRepository) ArchivePullRequest(ctx context.Context, prID uuid.UUID) error {
+	id := prID.String()
+	key := r.key(badgerPullRequests, id)
+	err := r.update(ctx, func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("badger get %s: %w", key, err)
+		}
+		data, err := item.ValueCopy(nil)
+		if err != nil {
+			return fmt.Errorf("badger read %s: %w", key, err)
+		}
+		var pr entity.PullRequest
+		if err := json.Unmarshal(data, &pr); err != nil {
+			return fmt.Errorf("decode %s: %w", key, err)
+		}
+		if err := r.trackOpenReviews(txn, &pr, nil); err != nil {
+			return err
+		}
+		if err := txn.Set([]byte(r.key(badgerArchivedPullRequests, id)), data); err != nil {
+			return fmt.Errorf("badger archive pull request %s: %w", id, err)
+		}
+		return txn.Delete([]byte(key))
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("pull request not found for archive", zap.String("pr_id", id))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("pull request archived", zap.String("pr_id", id))
+	return nil
+}
+
+func (r *BadgerRepository) DeletePullRequest(ctx context.Context, prID uuid.UUID) error {
+	id := prID.String()
+	keys := []string{r.key(badgerPullRequests, id), r.key(badgerArchivedPullRequests, id)}
+	err := r.update(ctx, func(txn *badger.Txn) error {
+		exists, err := r.exists(txn, keys...)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNotFound
+		}
+		// An archived PR is not counted.
+		var pr entity.PullRequest
+		err = r.get(txn, keys[0], &pr)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if err == nil {
+			if err := r.trackOpenReviews(txn, &pr, nil); err != nil {
+				return err
+			}
+		}
+		for _, key := range keys {
+			if err := txn.Delete([]byte(key)); err != nil {
+				return fmt.Errorf("badger delete %s: %w", key, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("pull request not found for delete", zap.String("pr_id", id))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("pull request deleted", zap.String("pr_id", id))
+	return nil
+}
+
+func (r *BadgerRepository) PurgeArchivedPullRequests(ctx context.Context, olderThan time.Time) (int, error) {
+	var deleted int
+	err := r.update(ctx, func(txn *badger.Txn) error {
+		var keys [][]byte
+		err := each(txn, r.kindPrefix(badgerArchivedPullRequests), false, func(item *badger.Item) error {
+			var pr entity.PullRequest
+			if err := decodeItem(item, &pr); err != nil {
+				return err
+			}
+			if pr.MergedAt != nil && pr.MergedAt.Before(olderThan) {
+				keys = append(keys, item.KeyCopy(nil))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err := txn.Delete(key); err != nil {
+				return fmt.Errorf("badger delete %s: %w", key, err)
+			}
+		}
+		deleted = len(keys)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		logging.From(ctx, r.logger).Info("purged archived pull requests", zap.Int("deleted", deleted))
+	}
+	return deleted, nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"avito-intro/internal/entity"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+)
+
+const badgerAPITokens = "api_tokens"
+
+// APITokenRepository implementation. Tokens are keyed by the hash of their
+// secret, so deleting one by ID scans them; there are few.
+
+func (r *BadgerRepository) CreateAPIToken(ctx context.Context, token *entity.APIToken) error {
+	return r.create(ctx, badgerAPITokens, token.SecretHash, token)
+}
+
+func (r *BadgerRepository) GetAPIToken(ctx context.Context, secretHash string) (*entity.APIToken, error) {
+	var token entity.APIToken
+	if err := r.load(ctx, r.key(badgerAPITokens, secretHash), &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *BadgerRepository) ListAPITokens(ctx context.Context, orgID string) ([]*entity.APIToken, error) {
+	tokens, err := loadKind[entity.APIToken](ctx, r, badgerAPITokens)
+	if err != nil {
+		return nil, err
+	}
+	return listAPITokens(tokens, orgID), nil
+}
+
+func (r *BadgerRepository) DeleteAPIToken(ctx context.Context, orgID string, id uuid.UUID) error {
+	return r.update(ctx, func(txn *badger.Txn) error {
+		tokens, err := scanKind[entity.APIToken](txn, r, badgerAPITokens)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			if token.ID == id && token.OrgID == orgID {
+				return txn.Delete([]byte(r.key(badgerAPITokens, token.SecretHash)))
+			}
+		}
+		return ErrNotFound
+	})
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	badgerRoles    = "roles"
+	badgerAudit    = "audit"
+	badgerAuditSeq = "audit_seq"
+)
+
+// nextSeq increments the counter stored under name and returns its new
+// value. Concurrent increments conflict, so each number is handed out once.
+func (r *BadgerRepository) nextSeq(txn *badger.Txn, name string) (int64, error) {
+	var seq int64
+	if err := r.get(txn, r.prefix+name, &seq); err != nil && !errors.Is(err, ErrNotFound) {
+		return 0, err
+	}
+	seq++
+	if err := r.set(txn, r.prefix+name, seq); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// seqID formats seq zero-padded, so keys of a log sort in seq order.
+func seqID(seq int64) string {
+	return fmt.Sprintf("%020d", seq)
+}
+
+// RoleRepository implementation
+
+func (r *BadgerRepository) GrantRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	return r.changeRoles(ctx, userID, func(roles []entity.Role) []entity.Role {
+		if slices.Contains(roles, role) {
+			return roles
+		}
+		roles = append(roles, role)
+		slices.Sort(roles)
+		return roles
+	})
+}
+
+func (r *BadgerRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	return r.changeRoles(ctx, userID, func(roles []entity.Role) []entity.Role {
+		return slices.DeleteFunc(roles, func(granted entity.Role) bool {
+			return granted == role
+		})
+	})
+}
+
+// changeRoles stores the roles change returns for the user's current ones.
+func (r *BadgerRepository) changeRoles(ctx context.Context, userID uuid.UUID, change func(roles []entity.Role) []entity.Role) error {
+	key := r.key(badgerRoles, userID.String())
+	return r.update(ctx, func(txn *badger.Txn) error {
+		exists, err := r.exists(txn, r.key(badgerUsers, userID.String()))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			logging.From(ctx, r.logger).Warn("user not found for role change", zap.String("user_id", userID.String()))
+			return ErrNotFound
+		}
+
+		var roles []entity.Role
+		if err := r.get(txn, key, &roles); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		roles = change(roles)
+		if len(roles) == 0 {
+			return txn.Delete([]byte(key))
+		}
+		return r.set(txn, key, roles)
+	})
+}
+
+func (r *BadgerRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]entity.Role, error) {
+	var roles []entity.Role
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		exists, err := r.exists(txn, r.key(badgerUsers, userID.String()))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNotFound
+		}
+		if err := r.get(txn, r.key(badgerRoles, userID.String()), &roles); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// AuditRepository implementation
+
+// AppendAudit stores the entry under the next audit sequence number, so
+// keys are in append order and listing newest first is a reverse scan.
+func (r *BadgerRepository) AppendAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	return r.update(ctx, func(txn *badger.Txn) error {
+		seq, err := r.nextSeq(txn, badgerAuditSeq)
+		if err != nil {
+			return err
+		}
+		return r.set(txn, r.key(badgerAudit, seqID(seq)), entry)
+	})
+}
+
+// ListAudit returns matching entries newest first, along with the number of
+// matches before pagination.
+func (r *BadgerRepository) ListAudit(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEntry, int, error) {
+	matched := make([]*entity.AuditEntry, 0)
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		return each(txn, r.kindPrefix(badgerAudit), true, func(item *badger.Item) error {
+			var entry entity.AuditEntry
+			if err := decodeItem(item, &entry); err != nil {
+				return err
+			}
+			if filter.Matches(&entry) {
+				matched = append(matched, &entry)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(matched)
+	from := min(max(filter.Offset, 0), total)
+	to := total
+	if filter.Limit > 0 {
+		to = min(from+filter.Limit, total)
+	}
+	return matched[from:to], total, nil
+}
+
+func (r *BadgerRepository) DeleteAuditBefore(ctx context.Context, before time.Time) (int, error) {
+	var deleted int
+	err := r.update(ctx, func(txn *badger.Txn) error {
+		var keys [][]byte
+		err := each(txn, r.kindPrefix(badgerAudit), false, func(item *badger.Item) error {
+			var entry entity.AuditEntry
+			if err := decodeItem(item, &entry); err != nil {
+				return err
+			}
+			if entry.OccurredAt.Before(before) {
+				keys = append(keys, item.KeyCopy(nil))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err := txn.Delete(key); err != nil {
+				return fmt.Errorf("badger delete %s: %w", key, err)
+			}
+		}
+		deleted = len(keys)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"avito-intro/internal/entity"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+const (
+	badgerEvents   = "events"
+	badgerEventSeq = "events_seq"
+)
+
+// EventLogRepository implementation
+
+// AppendEvent takes the next Seq in the transaction of the write it logs,
+// so events are stored in the order their writes commit.
+func (r *BadgerRepository) AppendEvent(ctx context.Context, event *entity.StorageEvent) error {
+	var seq int64
+	err := r.update(ctx, func(txn *badger.Txn) error {
+		var err error
+		seq, err = r.nextSeq(txn, badgerEventSeq)
+		if err != nil {
+			return err
+		}
+		stored := *event
+		stored.Seq = seq
+		return r.set(txn, r.key(badgerEvents, seqID(seq)), &stored)
+	})
+	if err != nil {
+		return err
+	}
+	event.Seq = seq
+	return nil
+}
+
+func (r *BadgerRepository) ListEvents(ctx context.Context, filter entity.StorageEventFilter) ([]*entity.StorageEvent, error) {
+	events := make([]*entity.StorageEvent, 0)
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(r.kindPrefix(badgerEvents))
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		// Keys are in Seq order, so the scan can start right after the
+		// cursor.
+		from := []byte(r.key(badgerEvents, seqID(max(filter.AfterSeq, 0)+1)))
+		for it.Seek(from); it.ValidForPrefix(opts.Prefix); it.Next() {
+			if filter.Limit > 0 && len(events) == filter.Limit {
+				break
+			}
+			var event entity.StorageEvent
+			if err := decodeItem(it.Item(), &event); err != nil {
+				return err
+			}
+			if filter.Matches(&event) {
+				events = append(events, &event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MilestoneRepository implementation
+
+func (r *BadgerRepository) CreateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	if err := r.create(ctx, badgerMilestones, milestone.MilestoneID.String(), milestone); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			logging.From(ctx, r.logger).Warn("milestone already exists", zap.String("milestone_id", milestone.MilestoneID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("milestone created",
+		zap.String("milestone_id", milestone.MilestoneID.String()),
+		zap.String("title", milestone.Title),
+	)
+	return nil
+}
+
+func (r *BadgerRepository) GetMilestone(ctx context.Context, milestoneID uuid.UUID) (*entity.Milestone, error) {
+	var milestone entity.Milestone
+	if err := r.load(ctx, r.key(badgerMilestones, milestoneID.String()), &milestone); err != nil {
+		return nil, err
+	}
+	return &milestone, nil
+}
+
+func (r *BadgerRepository) ListMilestones(ctx context.Context) ([]*entity.Milestone, error) {
+	milestones, err := loadKind[entity.Milestone](ctx, r, badgerMilestones)
+	if err != nil {
+		return nil, err
+	}
+	if milestones == nil {
+		milestones = make([]*entity.Milestone, 0)
+	}
+	slices.SortFunc(milestones, func(a, b *entity.Milestone) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return milestones, nil
+}
+
+func (r *BadgerRepository) UpdateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	if err := r.replace(ctx, badgerMilestones, milestone.MilestoneID.String(), milestone); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("milestone not found for update", zap.String("milestone_id", milestone.MilestoneID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("milestone updated", zap.String("milestone_id", milestone.MilestoneID.String()))
+	return nil
+}
+
+// DeleteMilestone removes the milestone and detaches it from its pull
+// requests in one transaction.
+func (r *BadgerRepository) DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error {
+	milestoneKey := r.key(badgerMilestones, milestoneID.String())
+	var detached int
+	err := r.update(ctx, func(txn *badger.Txn) error {
+		exists, err := r.exists(txn, milestoneKey)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNotFound
+		}
+
+		prs, err := scanKind[entity.PullRequest](txn, r, badgerPullRequests)
+		if err != nil {
+			return err
+		}
+		for _, pr := range prs {
+			if pr.MilestoneID == nil || *pr.MilestoneID != milestoneID {
+				continue
+			}
+			pr.MilestoneID = nil
+			pr.Version++
+			if err := r.set(txn, r.key(badgerPullRequests, pr.PullRequestID.String()), pr); err != nil {
+				return err
+			}
+			detached++
+		}
+		return txn.Delete([]byte(milestoneKey))
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("milestone not found for delete", zap.String("milestone_id", milestoneID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("milestone deleted",
+		zap.String("milestone_id", milestoneID.String()),
+		zap.Int("detached_prs", detached),
+	)
+	return nil
+}
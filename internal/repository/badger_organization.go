@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"go.uber.org/zap"
+)
+
+const badgerOrganizations = "organizations"
+
+// OrganizationRepository implementation
+
+func (r *BadgerRepository) CreateOrganization(ctx context.Context, org *entity.Organization) error {
+	if err := r.create(ctx, badgerOrganizations, org.OrgID, org); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			logging.From(ctx, r.logger).Warn("organization already exists", zap.String("org_id", org.OrgID))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("organization created", zap.String("org_id", org.OrgID))
+	return nil
+}
+
+func (r *BadgerRepository) GetOrganization(ctx context.Context, orgID string) (*entity.Organization, error) {
+	var org entity.Organization
+	if err := r.load(ctx, r.key(badgerOrganizations, orgID), &org); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *BadgerRepository) ListOrganizations(ctx context.Context) ([]*entity.Organization, error) {
+	orgs, err := loadKind[entity.Organization](ctx, r, badgerOrganizations)
+	if err != nil {
+		return nil, err
+	}
+	return sortOrganizations(orgs), nil
+}
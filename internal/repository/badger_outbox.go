@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"avito-intro/internal/entity"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+)
+
+const badgerOutbox = "outbox"
+
+// OutboxRepository implementation
+
+func (r *BadgerRepository) AppendOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	return r.create(ctx, badgerOutbox, msg.ID.String(), msg)
+}
+
+func (r *BadgerRepository) ListOutbox(ctx context.Context, due time.Time, limit int) ([]*entity.OutboxMessage, error) {
+	messages, err := loadKind[entity.OutboxMessage](ctx, r, badgerOutbox)
+	if err != nil {
+		return nil, err
+	}
+	return listOutbox(messages, due, limit), nil
+}
+
+func (r *BadgerRepository) UpdateOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	return r.replace(ctx, badgerOutbox, msg.ID.String(), msg)
+}
+
+func (r *BadgerRepository) DeleteOutbox(ctx context.Context, id uuid.UUID) error {
+	key := r.key(badgerOutbox, id.String())
+	return r.update(ctx, func(txn *badger.Txn) error {
+		exists, err := r.exists(txn, key)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNotFound
+		}
+		return txn.Delete([]byte(key))
+	})
+}
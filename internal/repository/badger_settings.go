@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	badgerChecklists     = "checklist"
+	badgerTeamOwners     = "team_owners"
+	badgerComponents     = "components"
+	badgerMergePolicies  = "merge_policy"
+	badgerTeamSettings   = "team_settings"
+	badgerGlobalSettings = "global_settings"
+)
+
+// setTeamValue stores v as the kind record of an existing team.
+func (r *BadgerRepository) setTeamValue(ctx context.Context, kind, teamName string, v any) error {
+	return r.update(ctx, func(txn *badger.Txn) error {
+		exists, err := r.exists(txn, r.key(badgerTeams, teamName))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			logging.From(ctx, r.logger).Warn("team not found", zap.String("team_name", teamName), zap.String("kind", kind))
+			return ErrNotFound
+		}
+		return r.set(txn, r.key(kind, teamName), v)
+	})
+}
+
+// ChecklistRepository implementation
+
+func (r *BadgerRepository) SetChecklistTemplate(ctx context.Context, template *entity.ChecklistTemplate) error {
+	return r.setTeamValue(ctx, badgerChecklists, template.TeamName, template)
+}
+
+func (r *BadgerRepository) GetChecklistTemplate(ctx context.Context, teamName string) (*entity.ChecklistTemplate, error) {
+	var template entity.ChecklistTemplate
+	if err := r.load(ctx, r.key(badgerChecklists, teamName), &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// OwnershipRepository implementation
+
+func (r *BadgerRepository) SetTeamOwners(ctx context.Context, teamName string, owners []uuid.UUID) error {
+	return r.setTeamValue(ctx, badgerTeamOwners, teamName, owners)
+}
+
+func (r *BadgerRepository) GetTeamOwners(ctx context.Context, teamName string) ([]uuid.UUID, error) {
+	var owners []uuid.UUID
+	if err := r.load(ctx, r.key(badgerTeamOwners, teamName), &owners); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return owners, nil
+}
+
+func (r *BadgerRepository) SetComponentOwners(ctx context.Context, teamName string, components []entity.ComponentOwner) error {
+	return r.setTeamValue(ctx, badgerComponents, teamName, components)
+}
+
+func (r *BadgerRepository) GetComponentOwners(ctx context.Context, teamName string) ([]entity.ComponentOwner, error) {
+	components := make([]entity.ComponentOwner, 0)
+	if err := r.load(ctx, r.key(badgerComponents, teamName), &components); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return components, nil
+}
+
+// MergePolicyRepository implementation
+
+func (r *BadgerRepository) SetMergePolicy(ctx context.Context, policy *entity.MergePolicy) error {
+	return r.setTeamValue(ctx, badgerMergePolicies, policy.TeamName, policy)
+}
+
+func (r *BadgerRepository) GetMergePolicy(ctx context.Context, teamName string) (*entity.MergePolicy, error) {
+	var policy entity.MergePolicy
+	if err := r.load(ctx, r.key(badgerMergePolicies, teamName), &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// TeamSettingsRepository implementation
+
+func (r *BadgerRepository) SetTeamSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	return r.setTeamValue(ctx, badgerTeamSettings, settings.TeamName, settings)
+}
+
+func (r *BadgerRepository) GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	var settings entity.TeamSettings
+	if err := r.load(ctx, r.key(badgerTeamSettings, teamName), &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// GlobalSettingsRepository implementation
+
+func (r *BadgerRepository) SetGlobalSettings(ctx context.Context, settings *entity.GlobalSettings) error {
+	err := r.update(ctx, func(txn *badger.Txn) error {
+		return r.set(txn, r.prefix+badgerGlobalSettings, settings)
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("global settings updated")
+	return nil
+}
+
+func (r *BadgerRepository) GetGlobalSettings(ctx context.Context) (*entity.GlobalSettings, error) {
+	var settings entity.GlobalSettings
+	if err := r.load(ctx, r.prefix+badgerGlobalSettings, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
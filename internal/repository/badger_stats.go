@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"avito-intro/internal/entity"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+// Stats counts entities by scanning them in one read transaction. Sizes
+// are Badger's estimates of the stored keys and values.
+func (r *BadgerRepository) Stats(ctx context.Context) (entity.StorageStats, error) {
+	stats := entity.StorageStats{
+		Backend:      "badger",
+		PullRequests: make(map[entity.PullRequestStatus]int),
+	}
+
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		for _, index := range []struct {
+			name  string
+			kind  string
+			count func(item *badger.Item) error
+		}{
+			{"users_by_id", badgerUsers, func(item *badger.Item) error {
+				var user entity.User
+				if err := decodeItem(item, &user); err != nil {
+					return err
+				}
+				stats.Users++
+				if user.IsActive && !user.IsDeleted() {
+					stats.ActiveUsers++
+				}
+				return nil
+			}},
+			{"teams_by_name", badgerTeams, func(*badger.Item) error {
+				stats.Teams++
+				return nil
+			}},
+			{"pull_requests_by_id", badgerPullRequests, func(item *badger.Item) error {
+				var pr entity.PullRequest
+				if err := decodeItem(item, &pr); err != nil {
+					return err
+				}
+				stats.PullRequests[pr.Status]++
+				return nil
+			}},
+			{"open_reviews_by_user", badgerOpenReviews, func(*badger.Item) error {
+				return nil
+			}},
+		} {
+			indexStats := entity.IndexStats{Name: index.name}
+			err := each(txn, r.kindPrefix(index.kind), false, func(item *badger.Item) error {
+				indexStats.Entries++
+				indexStats.EstimatedBytes += item.EstimatedSize()
+				return index.count(item)
+			})
+			if err != nil {
+				return err
+			}
+			stats.Indexes = append(stats.Indexes, indexStats)
+			stats.EstimatedBytes += indexStats.EstimatedBytes
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.StorageStats{}, err
+	}
+	return stats, nil
+}
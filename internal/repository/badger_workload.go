@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"avito-intro/internal/entity"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	// badgerOpenReviews holds the open review count of each reviewer that
+	// has any, under open_reviews:<user_id>.
+	badgerOpenReviews = "open_reviews"
+	// badgerOpenReviewsBuilt marks the counters as covering the PRs stored
+	// before they were introduced.
+	badgerOpenReviewsBuilt = "open_reviews_built"
+)
+
+// trackOpenReviews updates the counters in txn for the stored PR old being
+// replaced by updated.
+func (r *BadgerRepository) trackOpenReviews(txn *badger.Txn, old, updated *entity.PullRequest) error {
+	for id, n := range openReviewDelta(old, updated) {
+		key := r.key(badgerOpenReviews, id.String())
+		var count int
+		if err := r.get(txn, key, &count); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		count += n
+		if count == 0 {
+			if err := txn.Delete([]byte(key)); err != nil {
+				return fmt.Errorf("badger delete %s: %w", key, err)
+			}
+			continue
+		}
+		if err := r.set(txn, key, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *BadgerRepository) CountOpenReviews(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	if err := r.buildOpenReviews(ctx); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int, len(userIDs))
+	err := r.view(ctx, func(txn *badger.Txn) error {
+		for _, id := range userIDs {
+			var count int
+			if err := r.get(txn, r.key(badgerOpenReviews, id.String()), &count); err != nil && !errors.Is(err, ErrNotFound) {
+				return err
+			}
+			counts[id] = count
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// buildOpenReviews recounts the open reviews from the stored PRs unless
+// that was done before. The transaction reads every counter it keeps or
+// removes, so a concurrent PR write changing one of them conflicts with it
+// instead of being overwritten.
+func (r *BadgerRepository) buildOpenReviews(ctx context.Context) error {
+	marker := r.prefix + badgerOpenReviewsBuilt
+	built, err := r.existsAny(ctx, marker)
+	if err != nil || built {
+		return err
+	}
+
+	return r.update(ctx, func(txn *badger.Txn) error {
+		stale := make(map[string]bool)
+		err := each(txn, r.kindPrefix(badgerOpenReviews), false, func(item *badger.Item) error {
+			stale[string(item.KeyCopy(nil))] = true
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		prs, err := scanKind[entity.PullRequest](txn, r, badgerPullRequests)
+		if err != nil {
+			return err
+		}
+		for id, count := range countOpenReviews(prs) {
+			key := r.key(badgerOpenReviews, id.String())
+			if _, err := r.exists(txn, key); err != nil {
+				return err
+			}
+			if err := r.set(txn, key, count); err != nil {
+				return err
+			}
+			delete(stale, key)
+		}
+		for key := range stale {
+			if err := txn.Delete([]byte(key)); err != nil {
+				return fmt.Errorf("badger delete %s: %w", key, err)
+			}
+		}
+		return r.set(txn, marker, true)
+	})
+}
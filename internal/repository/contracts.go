@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"avito-intro/internal/entity"
 
@@ -11,22 +12,199 @@ import (
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *entity.User) error
 	UpdateUser(ctx context.Context, user *entity.User) error
+	// DeleteUser soft-deletes a user by setting DeletedAt; the user can
+	// still be read by ID.
+	DeleteUser(ctx context.Context, userID uuid.UUID, deletedAt time.Time) error
 	GetUser(ctx context.Context, userID uuid.UUID) (*entity.User, error)
 	UserExists(ctx context.Context, userID uuid.UUID) (bool, error)
 	GetUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error)
 	GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*entity.User, error)
+	ListUsers(ctx context.Context, filter entity.UserFilter) ([]*entity.User, int, error)
 }
 
 type TeamRepository interface {
 	CreateTeam(ctx context.Context, team *entity.Team) error
+	UpdateTeam(ctx context.Context, team *entity.Team) error
 	GetTeam(ctx context.Context, teamName string) (*entity.Team, error)
 	TeamExists(ctx context.Context, teamName string) (bool, error)
+	// ListTeams returns every team ordered by name.
+	ListTeams(ctx context.Context) ([]*entity.Team, error)
+	// ListTeamSummaries returns a page of the teams ordered by name with
+	// their counts, along with the total number of teams.
+	ListTeamSummaries(ctx context.Context, filter entity.TeamListFilter) ([]entity.TeamSummary, int, error)
 }
 
 type PullRequestRepository interface {
 	CreatePullRequest(ctx context.Context, pr *entity.PullRequest) error
 	GetPullRequest(ctx context.Context, prID uuid.UUID) (*entity.PullRequest, error)
 	UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error
-	GetPullRequestsByReviewer(ctx context.Context, userID uuid.UUID) ([]*entity.PullRequest, error)
+	// UpdatePullRequestIf stores pr only if the stored version still equals
+	// expectedVersion and fails with ErrConflict otherwise. On success
+	// pr.Version is set to the new version.
+	UpdatePullRequestIf(ctx context.Context, pr *entity.PullRequest, expectedVersion int) error
+	GetPullRequestsByReviewer(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error)
+	GetPullRequestsByAuthor(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error)
+	GetPullRequestsByStatus(ctx context.Context, status entity.PullRequestStatus) ([]*entity.PullRequest, error)
+	CountOpenReviews(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	GetPullRequestsByTeam(ctx context.Context, teamName string, filter entity.PullRequestFilter) ([]*entity.PullRequest, error)
+	// ListPullRequests returns a page of the PRs matching filter. A
+	// TeamName that does not exist is ErrNotFound.
+	ListPullRequests(ctx context.Context, filter entity.PullRequestListFilter) ([]*entity.PullRequest, error)
+	GetPullRequestsByMilestone(ctx context.Context, milestoneID uuid.UUID) ([]*entity.PullRequest, error)
 	PRExists(ctx context.Context, prID uuid.UUID) (bool, error)
+	// ArchivePullRequest moves a PR out of the working set: it no longer
+	// shows up in any listing, count or stats, but GetPullRequest and
+	// PRExists still find it. Archived PRs cannot be updated.
+	ArchivePullRequest(ctx context.Context, prID uuid.UUID) error
+	// DeletePullRequest removes a PR, archived or not, for good.
+	DeletePullRequest(ctx context.Context, prID uuid.UUID) error
+	// PurgeArchivedPullRequests deletes the archived PRs merged before
+	// olderThan for good and returns how many it deleted.
+	PurgeArchivedPullRequests(ctx context.Context, olderThan time.Time) (int, error)
+}
+
+type MilestoneRepository interface {
+	CreateMilestone(ctx context.Context, milestone *entity.Milestone) error
+	GetMilestone(ctx context.Context, milestoneID uuid.UUID) (*entity.Milestone, error)
+	ListMilestones(ctx context.Context) ([]*entity.Milestone, error)
+	UpdateMilestone(ctx context.Context, milestone *entity.Milestone) error
+	DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error
+}
+
+type ChecklistRepository interface {
+	SetChecklistTemplate(ctx context.Context, template *entity.ChecklistTemplate) error
+	GetChecklistTemplate(ctx context.Context, teamName string) (*entity.ChecklistTemplate, error)
+}
+
+type OwnershipRepository interface {
+	SetTeamOwners(ctx context.Context, teamName string, owners []uuid.UUID) error
+	GetTeamOwners(ctx context.Context, teamName string) ([]uuid.UUID, error)
+	SetComponentOwners(ctx context.Context, teamName string, components []entity.ComponentOwner) error
+	GetComponentOwners(ctx context.Context, teamName string) ([]entity.ComponentOwner, error)
+}
+
+type MergePolicyRepository interface {
+	SetMergePolicy(ctx context.Context, policy *entity.MergePolicy) error
+	GetMergePolicy(ctx context.Context, teamName string) (*entity.MergePolicy, error)
+}
+
+type TeamSettingsRepository interface {
+	SetTeamSettings(ctx context.Context, settings *entity.TeamSettings) error
+	GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error)
+}
+
+// GlobalSettingsRepository stores the one instance-wide settings record.
+// GetGlobalSettings returns ErrNotFound until it is first set.
+type GlobalSettingsRepository interface {
+	SetGlobalSettings(ctx context.Context, settings *entity.GlobalSettings) error
+	GetGlobalSettings(ctx context.Context) (*entity.GlobalSettings, error)
+}
+
+type RoleRepository interface {
+	GrantRole(ctx context.Context, userID uuid.UUID, role entity.Role) error
+	RevokeRole(ctx context.Context, userID uuid.UUID, role entity.Role) error
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]entity.Role, error)
+}
+
+type AuditRepository interface {
+	AppendAudit(ctx context.Context, entry *entity.AuditEntry) error
+	ListAudit(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEntry, int, error)
+	DeleteAuditBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// EventLogRepository is the append-only log of storage writes filled by
+// EventLoggingRepository. AppendEvent assigns the event's Seq; ListEvents
+// returns matching events in Seq order.
+type EventLogRepository interface {
+	AppendEvent(ctx context.Context, event *entity.StorageEvent) error
+	ListEvents(ctx context.Context, filter entity.StorageEventFilter) ([]*entity.StorageEvent, error)
+}
+
+// OutboxRepository queues PR events for the outbox dispatcher. Messages
+// are written in the transaction of the change they describe. ListOutbox
+// returns up to limit messages due at the given time, oldest first; zero
+// limit returns all of them.
+type OutboxRepository interface {
+	AppendOutbox(ctx context.Context, msg *entity.OutboxMessage) error
+	ListOutbox(ctx context.Context, due time.Time, limit int) ([]*entity.OutboxMessage, error)
+	UpdateOutbox(ctx context.Context, msg *entity.OutboxMessage) error
+	DeleteOutbox(ctx context.Context, id uuid.UUID) error
+}
+
+// OrganizationRepository stores the organizations. Backends keep them like
+// any other entity; TenantRepository keeps them in the storage of the
+// default organization, which it lists on top of them.
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, org *entity.Organization) error
+	GetOrganization(ctx context.Context, orgID string) (*entity.Organization, error)
+	ListOrganizations(ctx context.Context) ([]*entity.Organization, error)
+}
+
+// APITokenRepository stores issued API tokens by the hash of their secret.
+// Tokens of every organization are kept together, as a request is looked
+// up by its token before its organization is known.
+type APITokenRepository interface {
+	CreateAPIToken(ctx context.Context, token *entity.APIToken) error
+	GetAPIToken(ctx context.Context, secretHash string) (*entity.APIToken, error)
+	// ListAPITokens returns the tokens of orgID, oldest first.
+	ListAPITokens(ctx context.Context, orgID string) ([]*entity.APIToken, error)
+	// DeleteAPIToken deletes a token of orgID; tokens of other
+	// organizations are ErrNotFound.
+	DeleteAPIToken(ctx context.Context, orgID string, id uuid.UUID) error
+}
+
+type StatsRepository interface {
+	Stats(ctx context.Context) (entity.StorageStats, error)
+}
+
+// HealthChecker is implemented by every storage backend. Ping performs a
+// cheap round trip to the backend and must honour ctx cancellation.
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+}
+
+// Migrator is implemented by backends with a schema that must be brought up
+// to date before use. Migrate must be idempotent.
+type Migrator interface {
+	Migrate(ctx context.Context) error
+}
+
+// Transactor is implemented by backends that can group several calls into
+// one unit of work. InTx runs fn and commits its writes only if fn returns
+// nil; the calls must be made with the ctx fn receives. Nested calls join
+// the outer unit of work. Use the package-level InTx, which falls back to
+// running fn directly for backends without transactions.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Locker is implemented by backends that can serialize work under a name.
+// WithLock runs fn while holding the lock, waiting as long as another
+// holder has it, and gives up with ctx.Err() once ctx is done. Backends
+// shared by several instances lock across all of them. Use the
+// package-level WithLock, which falls back to running fn directly for
+// backends without locks.
+type Locker interface {
+	WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error
+}
+
+// Storage is the full set of repositories a storage backend provides.
+type Storage interface {
+	UserRepository
+	TeamRepository
+	PullRequestRepository
+	MilestoneRepository
+	ChecklistRepository
+	OwnershipRepository
+	MergePolicyRepository
+	TeamSettingsRepository
+	GlobalSettingsRepository
+	RoleRepository
+	AuditRepository
+	EventLogRepository
+	OutboxRepository
+	OrganizationRepository
+	APITokenRepository
+	StatsRepository
+	HealthChecker
 }
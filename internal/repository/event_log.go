@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// Entity types of the event log.
+const (
+	EventEntityUser              = "user"
+	EventEntityTeam              = "team"
+	EventEntityPullRequest       = "pull_request"
+	EventEntityMilestone         = "milestone"
+	EventEntityChecklistTemplate = "checklist_template"
+	EventEntityTeamOwners        = "team_owners"
+	EventEntityComponentOwners   = "component_owners"
+	EventEntityMergePolicy       = "merge_policy"
+	EventEntityTeamSettings      = "team_settings"
+	EventEntityGlobalSettings    = "global_settings"
+	EventEntityUserRole          = "user_role"
+)
+
+var (
+	_ Storage    = (*EventLoggingRepository)(nil)
+	_ Transactor = (*EventLoggingRepository)(nil)
+	_ Locker     = (*EventLoggingRepository)(nil)
+)
+
+// EventLoggingRepository appends every successful write of the wrapped
+// storage to its event log, with the entity's state after the write as
+// payload, so the log can be replayed from empty storage. A write and its
+// event run in one transaction when the backend supports them: a write
+// whose event cannot be stored is rolled back, and events of one entity
+// are in the order their writes were applied. Deleting a milestone also
+// detaches it from its PRs; that is implied by the delete event and not
+// logged per PR. Reads, audit and outbox writes are passed through
+// unchanged.
+type EventLoggingRepository struct {
+	Storage
+}
+
+func NewEventLoggingRepository(next Storage) *EventLoggingRepository {
+	return &EventLoggingRepository{Storage: next}
+}
+
+func (r *EventLoggingRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return InTx(ctx, r.Storage, fn)
+}
+
+func (r *EventLoggingRepository) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return WithLock(ctx, r.Storage, name, fn)
+}
+
+// logged runs write and appends its event. payload is encoded after write
+// returns, so fields the storage sets on write (like a PR's version) are
+// included.
+func (r *EventLoggingRepository) logged(ctx context.Context, entityType, entityID string, op entity.StorageOperation, payload any, write func(ctx context.Context) error) error {
+	return InTx(ctx, r.Storage, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+
+		event := &entity.StorageEvent{
+			OccurredAt: time.Now(),
+			EntityType: entityType,
+			EntityID:   entityID,
+			Operation:  op,
+		}
+		if payload != nil {
+			data, err := json.Marshal(payload)
+			if err != nil {
+				return fmt.Errorf("encode %s %s event: %w", entityType, entityID, err)
+			}
+			event.Payload = data
+		}
+		return r.Storage.AppendEvent(ctx, event)
+	})
+}
+
+func (r *EventLoggingRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	return r.logged(ctx, EventEntityUser, user.UserID.String(), entity.StorageCreate, user, func(ctx context.Context) error {
+		return r.Storage.CreateUser(ctx, user)
+	})
+}
+
+func (r *EventLoggingRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	return r.logged(ctx, EventEntityUser, user.UserID.String(), entity.StorageUpdate, user, func(ctx context.Context) error {
+		return r.Storage.UpdateUser(ctx, user)
+	})
+}
+
+// DeleteUser logs a delete whose payload is the user as stored after the
+// soft delete, so the log keeps DeletedAt.
+func (r *EventLoggingRepository) DeleteUser(ctx context.Context, userID uuid.UUID, deletedAt time.Time) error {
+	user := &entity.User{}
+	return r.logged(ctx, EventEntityUser, userID.String(), entity.StorageDelete, user, func(ctx context.Context) error {
+		if err := r.Storage.DeleteUser(ctx, userID, deletedAt); err != nil {
+			return err
+		}
+		stored, err := r.Storage.GetUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		*user = *stored
+		return nil
+	})
+}
+
+func (r *EventLoggingRepository) CreateTeam(ctx context.Context, team *entity.Team) error {
+	return r.logged(ctx, EventEntityTeam, team.TeamName, entity.StorageCreate, team, func(ctx context.Context) error {
+		return r.Storage.CreateTeam(ctx, team)
+	})
+}
+
+func (r *EventLoggingRepository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	return r.logged(ctx, EventEntityTeam, team.TeamName, entity.StorageUpdate, team, func(ctx context.Context) error {
+		return r.Storage.UpdateTeam(ctx, team)
+	})
+}
+
+func (r *EventLoggingRepository) CreatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	return r.logged(ctx, EventEntityPullRequest, pr.PullRequestID.String(), entity.StorageCreate, pr, func(ctx context.Context) error {
+		return r.Storage.CreatePullRequest(ctx, pr)
+	})
+}
+
+func (r *EventLoggingRepository) UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	return r.logged(ctx, EventEntityPullRequest, pr.PullRequestID.String(), entity.StorageUpdate, pr, func(ctx context.Context) error {
+		return r.Storage.UpdatePullRequest(ctx, pr)
+	})
+}
+
+func (r *EventLoggingRepository) UpdatePullRequestIf(ctx context.Context, pr *entity.PullRequest, expectedVersion int) error {
+	return r.logged(ctx, EventEntityPullRequest, pr.PullRequestID.String(), entity.StorageUpdate, pr, func(ctx context.Context) error {
+		return r.Storage.UpdatePullRequestIf(ctx, pr, expectedVersion)
+	})
+}
+
+func (r *EventLoggingRepository) ArchivePullRequest(ctx context.Context, prID uuid.UUID) error {
+	return r.logged(ctx, EventEntityPullRequest, prID.String(), entity.StorageArchive, nil, func(ctx context.Context) error {
+		return r.Storage.ArchivePullRequest(ctx, prID)
+	})
+}
+
+func (r *EventLoggingRepository) DeletePullRequest(ctx context.Context, prID uuid.UUID) error {
+	return r.logged(ctx, EventEntityPullRequest, prID.String(), entity.StorageDelete, nil, func(ctx context.Context) error {
+		return r.Storage.DeletePullRequest(ctx, prID)
+	})
+}
+
+func (r *EventLoggingRepository) CreateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	return r.logged(ctx, EventEntityMilestone, milestone.MilestoneID.String(), entity.StorageCreate, milestone, func(ctx context.Context) error {
+		return r.Storage.CreateMilestone(ctx, milestone)
+	})
+}
+
+func (r *EventLoggingRepository) UpdateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	return r.logged(ctx, EventEntityMilestone, milestone.MilestoneID.String(), entity.StorageUpdate, milestone, func(ctx context.Context) error {
+		return r.Storage.UpdateMilestone(ctx, milestone)
+	})
+}
+
+func (r *EventLoggingRepository) DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error {
+	return r.logged(ctx, EventEntityMilestone, milestoneID.String(), entity.StorageDelete, nil, func(ctx context.Context) error {
+		return r.Storage.DeleteMilestone(ctx, milestoneID)
+	})
+}
+
+func (r *EventLoggingRepository) SetChecklistTemplate(ctx context.Context, template *entity.ChecklistTemplate) error {
+	return r.logged(ctx, EventEntityChecklistTemplate, template.TeamName, entity.StorageUpdate, template, func(ctx context.Context) error {
+		return r.Storage.SetChecklistTemplate(ctx, template)
+	})
+}
+
+func (r *EventLoggingRepository) SetTeamOwners(ctx context.Context, teamName string, owners []uuid.UUID) error {
+	return r.logged(ctx, EventEntityTeamOwners, teamName, entity.StorageUpdate, owners, func(ctx context.Context) error {
+		return r.Storage.SetTeamOwners(ctx, teamName, owners)
+	})
+}
+
+func (r *EventLoggingRepository) SetComponentOwners(ctx context.Context, teamName string, components []entity.ComponentOwner) error {
+	return r.logged(ctx, EventEntityComponentOwners, teamName, entity.StorageUpdate, components, func(ctx context.Context) error {
+		return r.Storage.SetComponentOwners(ctx, teamName, components)
+	})
+}
+
+func (r *EventLoggingRepository) SetMergePolicy(ctx context.Context, policy *entity.MergePolicy) error {
+	return r.logged(ctx, EventEntityMergePolicy, policy.TeamName, entity.StorageUpdate, policy, func(ctx context.Context) error {
+		return r.Storage.SetMergePolicy(ctx, policy)
+	})
+}
+
+func (r *EventLoggingRepository) SetTeamSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	return r.logged(ctx, EventEntityTeamSettings, settings.TeamName, entity.StorageUpdate, settings, func(ctx context.Context) error {
+		return r.Storage.SetTeamSettings(ctx, settings)
+	})
+}
+
+func (r *EventLoggingRepository) SetGlobalSettings(ctx context.Context, settings *entity.GlobalSettings) error {
+	return r.logged(ctx, EventEntityGlobalSettings, "", entity.StorageUpdate, settings, func(ctx context.Context) error {
+		return r.Storage.SetGlobalSettings(ctx, settings)
+	})
+}
+
+func (r *EventLoggingRepository) GrantRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	return r.logged(ctx, EventEntityUserRole, userID.String()+"/"+string(role), entity.StorageCreate, nil, func(ctx context.Context) error {
+		return r.Storage.GrantRole(ctx, userID, role)
+	})
+}
+
+func (r *EventLoggingRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	return r.logged(ctx, EventEntityUserRole, userID.String()+"/"+string(role), entity.StorageDelete, nil, func(ctx context.Context) error {
+		return r.Storage.RevokeRole(ctx, userID, role)
+	})
+}
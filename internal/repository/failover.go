@@ -0,0 +1,610 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	_ Storage    = (*FailoverRepository)(nil)
+	_ Transactor = (*FailoverRepository)(nil)
+	_ Locker     = (*FailoverRepository)(nil)
+)
+
+// FailoverRepository sends every call to the primary backend. Writes that
+// succeed there are mirrored to the secondary on a best-effort basis, so the
+// secondary works as a warm cache. When the primary fails with an
+// infrastructure error (anything other than ErrNotFound/ErrAlreadyExists),
+// reads are served from the secondary while writes keep failing, to avoid
+// the two backends diverging. A call whose ctx is done says nothing about the
+// primary: its error goes back to the caller without a fallback.
+type FailoverRepository struct {
+	primary   Storage
+	secondary Storage
+	logger    *zap.Logger
+
+	mu     sync.Mutex
+	health FailoverHealth
+}
+
+type FailoverHealth struct {
+	PrimaryHealthy bool
+	LastError      string
+	Since          time.Time
+	Fallbacks      int
+}
+
+func NewFailoverRepository(primary, secondary Storage, logger *zap.Logger) *FailoverRepository {
+	return &FailoverRepository{
+		primary:   primary,
+		secondary: secondary,
+		logger:    logger,
+		health: FailoverHealth{
+			PrimaryHealthy: true,
+			Since:          time.Now(),
+		},
+	}
+}
+
+func (f *FailoverRepository) Health() FailoverHealth {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.health
+}
+
+// Ping succeeds while either backend answers; a failed primary ping is
+// recorded in Health so readiness can report the degradation.
+func (f *FailoverRepository) Ping(ctx context.Context) error {
+	if err := f.primary.Ping(ctx); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		f.markUnhealthy(ctx, "Ping", err)
+		return f.secondary.Ping(ctx)
+	}
+	f.markHealthy(ctx)
+	return nil
+}
+
+func failoverRead[T any](ctx context.Context, f *FailoverRepository, op string, read func(Storage) (T, error)) (T, error) {
+	value, err := read(f.primary)
+	if err == nil || isDomainError(err) {
+		f.markHealthy(ctx)
+		return value, err
+	}
+	if ctx.Err() != nil {
+		return value, err
+	}
+
+	f.markUnhealthy(ctx, op, err)
+	f.mu.Lock()
+	f.health.Fallbacks++
+	f.mu.Unlock()
+
+	return read(f.secondary)
+}
+
+// InTx runs fn in a transaction of the primary. Writes are mirrored to the
+// secondary as they happen and stay there if the transaction rolls back;
+// the secondary is a best-effort copy either way.
+func (f *FailoverRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return InTx(ctx, f.primary, fn)
+}
+
+// WithLock takes the lock of the primary. While the primary is down it
+// fails like writes do: work that needs the lock is writing anyway.
+func (f *FailoverRepository) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return WithLock(ctx, f.primary, name, fn)
+}
+
+func (f *FailoverRepository) write(ctx context.Context, op string, write func(ctx context.Context, s Storage) error) error {
+	if err := write(ctx, f.primary); err != nil {
+		if !isDomainError(err) && ctx.Err() == nil {
+			f.markUnhealthy(ctx, op, err)
+		}
+		return err
+	}
+	f.markHealthy(ctx)
+
+	// The primary has the write: mirror it even if the caller has gone
+	// away meanwhile, or the secondary would silently fall behind.
+	if err := write(context.WithoutCancel(ctx), f.secondary); err != nil && !isDomainError(err) {
+		logging.From(ctx, f.logger).Warn("failed to mirror write to secondary storage",
+			zap.String("op", op),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+func (f *FailoverRepository) markHealthy(ctx context.Context) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.health.PrimaryHealthy {
+		return
+	}
+	logging.From(ctx, f.logger).Info("primary storage recovered",
+		zap.Duration("outage", time.Since(f.health.Since)),
+	)
+	f.health = FailoverHealth{
+		PrimaryHealthy: true,
+		Since:          time.Now(),
+		Fallbacks:      f.health.Fallbacks,
+	}
+}
+
+func (f *FailoverRepository) markUnhealthy(ctx context.Context, op string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.health.PrimaryHealthy {
+		logging.From(ctx, f.logger).Error("primary storage failed, serving reads from secondary",
+			zap.String("op", op),
+			zap.Error(err),
+		)
+		f.health.PrimaryHealthy = false
+		f.health.Since = time.Now()
+	}
+	f.health.LastError = err.Error()
+}
+
+func isDomainError(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrAlreadyExists) || errors.Is(err, ErrConflict) || errors.Is(err, ErrInvalid)
+}
+
+func (f *FailoverRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	return f.write(ctx, "CreateUser", func(ctx context.Context, s Storage) error {
+		return s.CreateUser(ctx, user)
+	})
+}
+
+func (f *FailoverRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	return f.write(ctx, "UpdateUser", func(ctx context.Context, s Storage) error {
+		return s.UpdateUser(ctx, user)
+	})
+}
+
+func (f *FailoverRepository) DeleteUser(ctx context.Context, userID uuid.UUID, deletedAt time.Time) error {
+	return f.write(ctx, "DeleteUser", func(ctx context.Context, s Storage) error {
+		return s.DeleteUser(ctx, userID, deletedAt)
+	})
+}
+
+func (f *FailoverRepository) GetUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	return failoverRead(ctx, f, "GetUser", func(s Storage) (*entity.User, error) {
+		return s.GetUser(ctx, userID)
+	})
+}
+
+func (f *FailoverRepository) UserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return failoverRead(ctx, f, "UserExists", func(s Storage) (bool, error) {
+		return s.UserExists(ctx, userID)
+	})
+}
+
+func (f *FailoverRepository) GetUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
+	return failoverRead(ctx, f, "GetUsersByTeam", func(s Storage) ([]*entity.User, error) {
+		return s.GetUsersByTeam(ctx, teamName)
+	})
+}
+
+func (f *FailoverRepository) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*entity.User, error) {
+	return failoverRead(ctx, f, "GetUsersByIDs", func(s Storage) ([]*entity.User, error) {
+		return s.GetUsersByIDs(ctx, userIDs)
+	})
+}
+
+func (f *FailoverRepository) ListUsers(ctx context.Context, filter entity.UserFilter) ([]*entity.User, int, error) {
+	type page struct {
+		users []*entity.User
+		total int
+	}
+	p, err := failoverRead(ctx, f, "ListUsers", func(s Storage) (page, error) {
+		users, total, err := s.ListUsers(ctx, filter)
+		return page{users, total}, err
+	})
+	return p.users, p.total, err
+}
+
+func (f *FailoverRepository) CreateTeam(ctx context.Context, team *entity.Team) error {
+	return f.write(ctx, "CreateTeam", func(ctx context.Context, s Storage) error {
+		return s.CreateTeam(ctx, team)
+	})
+}
+
+func (f *FailoverRepository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	return f.write(ctx, "UpdateTeam", func(ctx context.Context, s Storage) error {
+		return s.UpdateTeam(ctx, team)
+	})
+}
+
+func (f *FailoverRepository) GetTeam(ctx context.Context, teamName string) (*entity.Team, error) {
+	return failoverRead(ctx, f, "GetTeam", func(s Storage) (*entity.Team, error) {
+		return s.GetTeam(ctx, teamName)
+	})
+}
+
+func (f *FailoverRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	return failoverRead(ctx, f, "TeamExists", func(s Storage) (bool, error) {
+		return s.TeamExists(ctx, teamName)
+	})
+}
+
+func (f *FailoverRepository) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	return failoverRead(ctx, f, "ListTeams", func(s Storage) ([]*entity.Team, error) {
+		return s.ListTeams(ctx)
+	})
+}
+
+func (f *FailoverRepository) ListTeamSummaries(ctx context.Context, filter entity.TeamListFilter) ([]entity.TeamSummary, int, error) {
+	type page struct {
+		summaries []entity.TeamSummary
+		total     int
+	}
+	p, err := failoverRead(ctx, f, "ListTeamSummaries", func(s Storage) (page, error) {
+		summaries, total, err := s.ListTeamSummaries(ctx, filter)
+		return page{summaries, total}, err
+	})
+	return p.summaries, p.total, err
+}
+
+func (f *FailoverRepository) CreatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	return f.write(ctx, "CreatePullRequest", func(ctx context.Context, s Storage) error {
+		return s.CreatePullRequest(ctx, pr)
+	})
+}
+
+func (f *FailoverRepository) GetPullRequest(ctx context.Context, prID uuid.UUID) (*entity.PullRequest, error) {
+	return failoverRead(ctx, f, "GetPullRequest", func(s Storage) (*entity.PullRequest, error) {
+		return s.GetPullRequest(ctx, prID)
+	})
+}
+
+func (f *FailoverRepository) UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	return f.write(ctx, "UpdatePullRequest", func(ctx context.Context, s Storage) error {
+		if s == f.secondary {
+			return s.UpdatePullRequest(ctx, mirrorOf(pr))
+		}
+		return s.UpdatePullRequest(ctx, pr)
+	})
+}
+
+// UpdatePullRequestIf checks the version on the primary only. The secondary
+// may lag behind after an outage, so it takes the accepted write as is.
+func (f *FailoverRepository) UpdatePullRequestIf(ctx context.Context, pr *entity.PullRequest, expectedVersion int) error {
+	return f.write(ctx, "UpdatePullRequestIf", func(ctx context.Context, s Storage) error {
+		if s == f.secondary {
+			return s.UpdatePullRequest(ctx, mirrorOf(pr))
+		}
+		return s.UpdatePullRequestIf(ctx, pr, expectedVersion)
+	})
+}
+
+// mirrorOf hands the secondary its own copy of pr, so the version it
+// assigns does not replace the primary's in the caller's entity.
+func mirrorOf(pr *entity.PullRequest) *entity.PullRequest {
+	mirror := *pr
+	return &mirror
+}
+
+func (f *FailoverRepository) GetPullRequestsByReviewer(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	return failoverRead(ctx, f, "GetPullRequestsByReviewer", func(s Storage) ([]*entity.PullRequest, error) {
+		return s.GetPullRequestsByReviewer(ctx, userID, filter)
+	})
+}
+
+func (f *FailoverRepository) GetPullRequestsByAuthor(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	return failoverRead(ctx, f, "GetPullRequestsByAuthor", func(s Storage) ([]*entity.PullRequest, error) {
+		return s.GetPullRequestsByAuthor(ctx, userID, filter)
+	})
+}
+
+func (f *FailoverRepository) GetPullRequestsByStatus(ctx context.Context, status entity.PullRequestStatus) ([]*entity.PullRequest, error) {
+	return failoverRead(ctx, f, "GetPullRequestsByStatus", func(s Storage) ([]*entity.PullRequest, error) {
+		return s.GetPullRequestsByStatus(ctx, status)
+	})
+}
+
+func (f *FailoverRepository) CountOpenReviews(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	return failoverRead(ctx, f, "CountOpenReviews", func(s Storage) (map[uuid.UUID]int, error) {
+		return s.CountOpenReviews(ctx, userIDs)
+	})
+}
+
+func (f *FailoverRepository) ListPullRequests(ctx context.Context, filter entity.PullRequestListFilter) ([]*entity.PullRequest, error) {
+	return failoverRead(ctx, f, "ListPullRequests", func(s Storage) ([]*entity.PullRequest, error) {
+		return s.ListPullRequests(ctx, filter)
+	})
+}
+
+func (f *FailoverRepository) GetPullRequestsByTeam(ctx context.Context, teamName string, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	return failoverRead(ctx, f, "GetPullRequestsByTeam", func(s Storage) ([]*entity.PullRequest, error) {
+		return s.GetPullRequestsByTeam(ctx, teamName, filter)
+	})
+}
+
+func (f *FailoverRepository) PRExists(ctx context.Context, prID uuid.UUID) (bool, error) {
+	return failoverRead(ctx, f, "PRExists", func(s Storage) (bool, error) {
+		return s.PRExists(ctx, prID)
+	})
+}
+
+func (f *FailoverRepository) ArchivePullRequest(ctx context.Context, prID uuid.UUID) error {
+	return f.write(ctx, "ArchivePullRequest", func(ctx context.Context, s Storage) error {
+		return s.ArchivePullRequest(ctx, prID)
+	})
+}
+
+func (f *FailoverRepository) DeletePullRequest(ctx context.Context, prID uuid.UUID) error {
+	return f.write(ctx, "DeletePullRequest", func(ctx context.Context, s Storage) error {
+		return s.DeletePullRequest(ctx, prID)
+	})
+}
+
+func (f *FailoverRepository) PurgeArchivedPullRequests(ctx context.Context, olderThan time.Time) (int, error) {
+	var deleted int
+	err := f.write(ctx, "PurgeArchivedPullRequests", func(ctx context.Context, s Storage) error {
+		n, err := s.PurgeArchivedPullRequests(ctx, olderThan)
+		if s == f.primary {
+			deleted = n
+		}
+		return err
+	})
+	return deleted, err
+}
+
+func (f *FailoverRepository) SetChecklistTemplate(ctx context.Context, template *entity.ChecklistTemplate) error {
+	return f.write(ctx, "SetChecklistTemplate", func(ctx context.Context, s Storage) error {
+		return s.SetChecklistTemplate(ctx, template)
+	})
+}
+
+func (f *FailoverRepository) GetChecklistTemplate(ctx context.Context, teamName string) (*entity.ChecklistTemplate, error) {
+	return failoverRead(ctx, f, "GetChecklistTemplate", func(s Storage) (*entity.ChecklistTemplate, error) {
+		return s.GetChecklistTemplate(ctx, teamName)
+	})
+}
+
+func (f *FailoverRepository) SetMergePolicy(ctx context.Context, policy *entity.MergePolicy) error {
+	return f.write(ctx, "SetMergePolicy", func(ctx context.Context, s Storage) error {
+		return s.SetMergePolicy(ctx, policy)
+	})
+}
+
+func (f *FailoverRepository) GetMergePolicy(ctx context.Context, teamName string) (*entity.MergePolicy, error) {
+	return failoverRead(ctx, f, "GetMergePolicy", func(s Storage) (*entity.MergePolicy, error) {
+		return s.GetMergePolicy(ctx, teamName)
+	})
+}
+
+func (f *FailoverRepository) SetTeamSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	return f.write(ctx, "SetTeamSettings", func(ctx context.Context, s Storage) error {
+		return s.SetTeamSettings(ctx, settings)
+	})
+}
+
+func (f *FailoverRepository) GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	return failoverRead(ctx, f, "GetTeamSettings", func(s Storage) (*entity.TeamSettings, error) {
+		return s.GetTeamSettings(ctx, teamName)
+	})
+}
+
+func (f *FailoverRepository) SetGlobalSettings(ctx context.Context, settings *entity.GlobalSettings) error {
+	return f.write(ctx, "SetGlobalSettings", func(ctx context.Context, s Storage) error {
+		return s.SetGlobalSettings(ctx, settings)
+	})
+}
+
+func (f *FailoverRepository) GetGlobalSettings(ctx context.Context) (*entity.GlobalSettings, error) {
+	return failoverRead(ctx, f, "GetGlobalSettings", func(s Storage) (*entity.GlobalSettings, error) {
+		return s.GetGlobalSettings(ctx)
+	})
+}
+
+func (f *FailoverRepository) CreateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	return f.write(ctx, "CreateMilestone", func(ctx context.Context, s Storage) error {
+		return s.CreateMilestone(ctx, milestone)
+	})
+}
+
+func (f *FailoverRepository) GetMilestone(ctx context.Context, milestoneID uuid.UUID) (*entity.Milestone, error) {
+	return failoverRead(ctx, f, "GetMilestone", func(s Storage) (*entity.Milestone, error) {
+		return s.GetMilestone(ctx, milestoneID)
+	})
+}
+
+func (f *FailoverRepository) ListMilestones(ctx context.Context) ([]*entity.Milestone, error) {
+	return failoverRead(ctx, f, "ListMilestones", func(s Storage) ([]*entity.Milestone, error) {
+		return s.ListMilestones(ctx)
+	})
+}
+
+func (f *FailoverRepository) UpdateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	return f.write(ctx, "UpdateMilestone", func(ctx context.Context, s Storage) error {
+		return s.UpdateMilestone(ctx, milestone)
+	})
+}
+
+func (f *FailoverRepository) DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error {
+	return f.write(ctx, "DeleteMilestone", func(ctx context.Context, s Storage) error {
+		return s.DeleteMilestone(ctx, milestoneID)
+	})
+}
+
+func (f *FailoverRepository) GetPullRequestsByMilestone(ctx context.Context, milestoneID uuid.UUID) ([]*entity.PullRequest, error) {
+	return failoverRead(ctx, f, "GetPullRequestsByMilestone", func(s Storage) ([]*entity.PullRequest, error) {
+		return s.GetPullRequestsByMilestone(ctx, milestoneID)
+	})
+}
+
+func (f *FailoverRepository) SetTeamOwners(ctx context.Context, teamName string, owners []uuid.UUID) error {
+	return f.write(ctx, "SetTeamOwners", func(ctx context.Context, s Storage) error {
+		return s.SetTeamOwners(ctx, teamName, owners)
+	})
+}
+
+func (f *FailoverRepository) GetTeamOwners(ctx context.Context, teamName string) ([]uuid.UUID, error) {
+	return failoverRead(ctx, f, "GetTeamOwners", func(s Storage) ([]uuid.UUID, error) {
+		return s.GetTeamOwners(ctx, teamName)
+	})
+}
+
+func (f *FailoverRepository) SetComponentOwners(ctx context.Context, teamName string, components []entity.ComponentOwner) error {
+	return f.write(ctx, "SetComponentOwners", func(ctx context.Context, s Storage) error {
+		return s.SetComponentOwners(ctx, teamName, components)
+	})
+}
+
+func (f *FailoverRepository) GetComponentOwners(ctx context.Context, teamName string) ([]entity.ComponentOwner, error) {
+	return failoverRead(ctx, f, "GetComponentOwners", func(s Storage) ([]entity.ComponentOwner, error) {
+		return s.GetComponentOwners(ctx, teamName)
+	})
+}
+
+func (f *FailoverRepository) GrantRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	return f.write(ctx, "GrantRole", func(ctx context.Context, s Storage) error {
+		return s.GrantRole(ctx, userID, role)
+	})
+}
+
+func (f *FailoverRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	return f.write(ctx, "RevokeRole", func(ctx context.Context, s Storage) error {
+		return s.RevokeRole(ctx, userID, role)
+	})
+}
+
+func (f *FailoverRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]entity.Role, error) {
+	return failoverRead(ctx, f, "GetUserRoles", func(s Storage) ([]entity.Role, error) {
+		return s.GetUserRoles(ctx, userID)
+	})
+}
+
+func (f *FailoverRepository) AppendAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	return f.write(ctx, "AppendAudit", func(ctx context.Context, s Storage) error {
+		return s.AppendAudit(ctx, entry)
+	})
+}
+
+func (f *FailoverRepository) ListAudit(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEntry, int, error) {
+	type page struct {
+		entries []*entity.AuditEntry
+		total   int
+	}
+	p, err := failoverRead(ctx, f, "ListAudit", func(s Storage) (page, error) {
+		entries, total, err := s.ListAudit(ctx, filter)
+		return page{entries, total}, err
+	})
+	return p.entries, p.total, err
+}
+
+func (f *FailoverRepository) AppendEvent(ctx context.Context, event *entity.StorageEvent) error {
+	return f.write(ctx, "AppendEvent", func(ctx context.Context, s Storage) error {
+		if s == f.primary {
+			return s.AppendEvent(ctx, event)
+		}
+		// The secondary numbers its own log; the caller keeps the
+		// primary's Seq.
+		mirrored := *event
+		return s.AppendEvent(ctx, &mirrored)
+	})
+}
+
+func (f *FailoverRepository) ListEvents(ctx context.Context, filter entity.StorageEventFilter) ([]*entity.StorageEvent, error) {
+	return failoverRead(ctx, f, "ListEvents", func(s Storage) ([]*entity.StorageEvent, error) {
+		return s.ListEvents(ctx, filter)
+	})
+}
+
+func (f *FailoverRepository) AppendOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	return f.write(ctx, "AppendOutbox", func(ctx context.Context, s Storage) error {
+		return s.AppendOutbox(ctx, msg)
+	})
+}
+
+func (f *FailoverRepository) ListOutbox(ctx context.Context, due time.Time, limit int) ([]*entity.OutboxMessage, error) {
+	return failoverRead(ctx, f, "ListOutbox", func(s Storage) ([]*entity.OutboxMessage, error) {
+		return s.ListOutbox(ctx, due, limit)
+	})
+}
+
+func (f *FailoverRepository) UpdateOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	return f.write(ctx, "UpdateOutbox", func(ctx context.Context, s Storage) error {
+		return s.UpdateOutbox(ctx, msg)
+	})
+}
+
+func (f *FailoverRepository) DeleteOutbox(ctx context.Context, id uuid.UUID) error {
+	return f.write(ctx, "DeleteOutbox", func(ctx context.Context, s Storage) error {
+		return s.DeleteOutbox(ctx, id)
+	})
+}
+
+func (f *FailoverRepository) CreateOrganization(ctx context.Context, org *entity.Organization) error {
+	return f.write(ctx, "CreateOrganization", func(ctx context.Context, s Storage) error {
+		return s.CreateOrganization(ctx, org)
+	})
+}
+
+func (f *FailoverRepository) GetOrganization(ctx context.Context, orgID string) (*entity.Organization, error) {
+	return failoverRead(ctx, f, "GetOrganization", func(s Storage) (*entity.Organization, error) {
+		return s.GetOrganization(ctx, orgID)
+	})
+}
+
+func (f *FailoverRepository) ListOrganizations(ctx context.Context) ([]*entity.Organization, error) {
+	return failoverRead(ctx, f, "ListOrganizations", func(s Storage) ([]*entity.Organization, error) {
+		return s.ListOrganizations(ctx)
+	})
+}
+
+func (f *FailoverRepository) CreateAPIToken(ctx context.Context, token *entity.APIToken) error {
+	return f.write(ctx, "CreateAPIToken", func(ctx context.Context, s Storage) error {
+		return s.CreateAPIToken(ctx, token)
+	})
+}
+
+func (f *FailoverRepository) GetAPIToken(ctx context.Context, secretHash string) (*entity.APIToken, error) {
+	return failoverRead(ctx, f, "GetAPIToken", func(s Storage) (*entity.APIToken, error) {
+		return s.GetAPIToken(ctx, secretHash)
+	})
+}
+
+func (f *FailoverRepository) ListAPITokens(ctx context.Context, orgID string) ([]*entity.APIToken, error) {
+	return failoverRead(ctx, f, "ListAPITokens", func(s Storage) ([]*entity.APIToken, error) {
+		return s.ListAPITokens(ctx, orgID)
+	})
+}
+
+func (f *FailoverRepository) DeleteAPIToken(ctx context.Context, orgID string, id uuid.UUID) error {
+	return f.write(ctx, "DeleteAPIToken", func(ctx context.Context, s Storage) error {
+		return s.DeleteAPIToken(ctx, orgID, id)
+	})
+}
+
+func (f *FailoverRepository) DeleteAuditBefore(ctx context.Context, before time.Time) (int, error) {
+	var deleted int
+	err := f.write(ctx, "DeleteAuditBefore", func(ctx context.Context, s Storage) error {
+		n, err := s.DeleteAuditBefore(ctx, before)
+		if s == f.primary {
+			deleted = n
+		}
+		return err
+	})
+	return deleted, err
+}
+
+func (f *FailoverRepository) Stats(ctx context.Context) (entity.StorageStats, error) {
+	return failoverRead(ctx, f, "Stats", func(s Storage) (entity.StorageStats, error) {
+		return s.Stats(ctx)
+	})
+}
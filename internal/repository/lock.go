@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+// WithLock runs fn holding the named lock of s when the backend supports
+// locks (see Locker) and directly otherwise.
+func WithLock(ctx context.Context, s Storage, name string, fn func(ctx context.Context) error) error {
+	if locker, ok := s.(Locker); ok {
+		return locker.WithLock(ctx, name, fn)
+	}
+	return fn(ctx)
+}
+
+// localLocks are named locks within one process, for backends that a single
+// process owns. The zero value is ready to use.
+type localLocks struct {
+	mu    sync.Mutex
+	locks map[string]*localLock
+}
+
+// localLock is held while its channel is full; waiters counts the callers
+// holding or waiting for it, so an unused lock can be dropped.
+type localLock struct {
+	held    chan struct{}
+	waiters int
+}
+
+func (l *localLocks) withLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	lock := l.acquire(name)
+	defer l.release(name, lock)
+
+	select {
+	case lock.held <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-lock.held }()
+
+	return fn(ctx)
+}
+
+func (l *localLocks) acquire(name string) *localLock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.locks == nil {
+		l.locks = make(map[string]*localLock)
+	}
+	lock, ok := l.locks[name]
+	if !ok {
+		lock = &localLock{held: make(chan struct{}, 1)}
+		l.locks[name] = lock
+	}
+	lock.waiters++
+	return lock
+}
+
+func (l *localLocks) release(name string, lock *localLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock.waiters--
+	if lock.waiters == 0 {
+		delete(l.locks, name)
+	}
+}
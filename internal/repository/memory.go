@@ -3,9 +3,15 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -14,12 +20,34 @@ import (
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
+	// ErrConflict means a conditional update lost the race: the entity
+	// was changed since the caller read it.
+	ErrConflict = errors.New("modified concurrently")
+	// ErrInvalid means the write was refused because it would store a
+	// corrupted entity, e.g. a PR assigned to its author.
+	ErrInvalid = errors.New("invalid entity")
 )
 
 var (
-	_ UserRepository        = (*MemoryRepository)(nil)
-	_ TeamRepository        = (*MemoryRepository)(nil)
-	_ PullRequestRepository = (*MemoryRepository)(nil)
+	_ UserRepository           = (*MemoryRepository)(nil)
+	_ TeamRepository           = (*MemoryRepository)(nil)
+	_ PullRequestRepository    = (*MemoryRepository)(nil)
+	_ MilestoneRepository      = (*MemoryRepository)(nil)
+	_ ChecklistRepository      = (*MemoryRepository)(nil)
+	_ OwnershipRepository      = (*MemoryRepository)(nil)
+	_ MergePolicyRepository    = (*MemoryRepository)(nil)
+	_ TeamSettingsRepository   = (*MemoryRepository)(nil)
+	_ GlobalSettingsRepository = (*MemoryRepository)(nil)
+	_ RoleRepository           = (*MemoryRepository)(nil)
+	_ AuditRepository          = (*MemoryRepository)(nil)
+	_ EventLogRepository       = (*MemoryRepository)(nil)
+	_ OutboxRepository         = (*MemoryRepository)(nil)
+	_ OrganizationRepository   = (*MemoryRepository)(nil)
+	_ APITokenRepository       = (*MemoryRepository)(nil)
+	_ StatsRepository          = (*MemoryRepository)(nil)
+	_ Storage                  = (*MemoryRepository)(nil)
+	_ Transactor               = (*MemoryRepository)(nil)
+	_ Locker                   = (*MemoryRepository)(nil)
 )
 
 type MemoryRepository struct {
@@ -27,94 +55,212 @@ type MemoryRepository struct {
 	users        map[uuid.UUID]*entity.User
 	teams        map[string]*entity.Team
 	pullRequests map[uuid.UUID]*entity.PullRequest
-	logger       *zap.Logger
+	// archived holds PRs moved out of pullRequests by ArchivePullRequest:
+	// only reads by ID look at it.
+	archived map[uuid.UUID]*entity.PullRequest
+	// openReviews counts the open PRs in pullRequests each reviewer is
+	// assigned to; reviewers without any are left out.
+	openReviews   map[uuid.UUID]int
+	milestones    map[uuid.UUID]*entity.Milestone
+	checklists    map[string]*entity.ChecklistTemplate
+	teamOwners    map[string][]uuid.UUID
+	components    map[string][]entity.ComponentOwner
+	mergePolicies map[string]*entity.MergePolicy
+	teamSettings  map[string]*entity.TeamSettings
+	// globalSettings is nil until first set.
+	globalSettings *entity.GlobalSettings
+	userRoles      map[uuid.UUID][]entity.Role
+	audit          []*entity.AuditEntry
+	events         []*entity.StorageEvent
+	outbox         map[uuid.UUID]*entity.OutboxMessage
+	organizations  map[string]*entity.Organization
+	// apiTokens is keyed by the hash of the token's secret.
+	apiTokens map[string]*entity.APIToken
+	locks     localLocks
+	logger    *zap.Logger
 }
 
 func NewMemoryRepository(logger *zap.Logger) *MemoryRepository {
 	return &MemoryRepository{
-		users:        make(map[uuid.UUID]*entity.User),
-		teams:        make(map[string]*entity.Team),
-		pullRequests: make(map[uuid.UUID]*entity.PullRequest),
-		logger:       logger,
+		users:         make(map[uuid.UUID]*entity.User),
+		teams:         make(map[string]*entity.Team),
+		pullRequests:  make(map[uuid.UUID]*entity.PullRequest),
+		archived:      make(map[uuid.UUID]*entity.PullRequest),
+		openReviews:   make(map[uuid.UUID]int),
+		milestones:    make(map[uuid.UUID]*entity.Milestone),
+		checklists:    make(map[string]*entity.ChecklistTemplate),
+		teamOwners:    make(map[string][]uuid.UUID),
+		components:    make(map[string][]entity.ComponentOwner),
+		mergePolicies: make(map[string]*entity.MergePolicy),
+		teamSettings:  make(map[string]*entity.TeamSettings),
+		userRoles:     make(map[uuid.UUID][]entity.Role),
+		outbox:        make(map[uuid.UUID]*entity.OutboxMessage),
+		organizations: make(map[string]*entity.Organization),
+		apiTokens:     make(map[string]*entity.APIToken),
+		logger:        logger,
+	}
+}
+
+// Ping always succeeds unless ctx is done: the data lives in process memory.
+func (r *MemoryRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// lock takes the write lock unless ctx is done. ctx is checked again once
+// the lock is held: a call that queued behind a long write and was
+// cancelled meanwhile returns ctx.Err() instead of doing work nobody waits
+// for. Inside InTx the transaction already holds the lock, so calls with
+// its ctx neither take nor release it. Holders release the lock with
+// r.unlock(ctx).
+func (r *MemoryRepository) lock(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.inTx(ctx) {
+		return nil
+	}
+	r.mu.Lock()
+	if err := ctx.Err(); err != nil {
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (r *MemoryRepository) unlock(ctx context.Context) {
+	if !r.inTx(ctx) {
+		r.mu.Unlock()
+	}
+}
+
+// rlock is lock for readers; holders release it with r.runlock(ctx).
+func (r *MemoryRepository) rlock(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.inTx(ctx) {
+		return nil
+	}
+	r.mu.RLock()
+	if err := ctx.Err(); err != nil {
+		r.mu.RUnlock()
+		return err
+	}
+	return nil
+}
+
+func (r *MemoryRepository) runlock(ctx context.Context) {
+	if !r.inTx(ctx) {
+		r.mu.RUnlock()
 	}
 }
 
 // UserRepository implementation
 
 func (r *MemoryRepository) CreateUser(ctx context.Context, user *entity.User) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
 
 	if _, exists := r.users[user.UserID]; exists {
-		r.logger.Warn("user already exists", zap.String("user_id", user.UserID.String()))
+		logging.From(ctx, r.logger).Warn("user already exists", zap.String("user_id", user.UserID.String()))
 		return ErrAlreadyExists
 	}
 
-	r.logger.Info("creating user",
+	logging.From(ctx, r.logger).Info("creating user",
 		zap.String("user_id", user.UserID.String()),
 		zap.String("username", user.Username),
 		zap.String("team_name", user.TeamName),
 		zap.Bool("is_active", user.IsActive),
 	)
 
-	r.users[user.UserID] = user
+	r.users[user.UserID] = cloneUser(user)
 	return nil
 }
 
 func (r *MemoryRepository) UpdateUser(ctx context.Context, user *entity.User) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
 
 	if _, exists := r.users[user.UserID]; !exists {
-		r.logger.Warn("user not found for update", zap.String("user_id", user.UserID.String()))
+		logging.From(ctx, r.logger).Warn("user not found for update", zap.String("user_id", user.UserID.String()))
 		return ErrNotFound
 	}
 
-	r.logger.Info("updating user",
+	logging.From(ctx, r.logger).Info("updating user",
 		zap.String("user_id", user.UserID.String()),
 		zap.String("username", user.Username),
 		zap.String("team_name", user.TeamName),
 		zap.Bool("is_active", user.IsActive),
 	)
 
-	r.users[user.UserID] = user
+	r.users[user.UserID] = cloneUser(user)
+	return nil
+}
+
+func (r *MemoryRepository) DeleteUser(ctx context.Context, userID uuid.UUID, deletedAt time.Time) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	user, exists := r.users[userID]
+	if !exists {
+		logging.From(ctx, r.logger).Warn("user not found for delete", zap.String("user_id", userID.String()))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("deleting user", zap.String("user_id", userID.String()))
+
+	deleted := cloneUser(user)
+	deleted.DeletedAt = &deletedAt
+	r.users[userID] = deleted
 	return nil
 }
 
 func (r *MemoryRepository) GetUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
 
 	user, exists := r.users[userID]
 	if !exists {
-		r.logger.Warn("user not found", zap.String("user_id", userID.String()))
+		logging.From(ctx, r.logger).Warn("user not found", zap.String("user_id", userID.String()))
 		return nil, ErrNotFound
 	}
 
-	r.logger.Debug("user retrieved", zap.String("user_id", userID.String()))
-	return user, nil
+	logging.From(ctx, r.logger).Debug("user retrieved", zap.String("user_id", userID.String()))
+	return cloneUser(user), nil
 }
 
 func (r *MemoryRepository) UserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	if err := r.rlock(ctx); err != nil {
+		return false, err
+	}
+	defer r.runlock(ctx)
 
 	_, exists := r.users[userID]
 	return exists, nil
 }
 
 func (r *MemoryRepository) GetUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
 
 	var users []*entity.User
 	for _, user := range r.users {
 		if user.TeamName == teamName {
-			users = append(users, user)
+			users = append(users, cloneUser(user))
 		}
 	}
 
-	r.logger.Debug("users retrieved by team",
+	logging.From(ctx, r.logger).Debug("users retrieved by team",
 		zap.String("team_name", teamName),
 		zap.Int("count", len(users)),
 	)
@@ -122,144 +268,550 @@ func (r *MemoryRepository) GetUsersByTeam(ctx context.Context, teamName string)
 }
 
 func (r *MemoryRepository) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*entity.User, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
 
 	users := make([]*entity.User, 0, len(userIDs))
 	for _, id := range userIDs {
 		if user, exists := r.users[id]; exists {
-			users = append(users, user)
+			users = append(users, cloneUser(user))
 		}
 	}
 
-	r.logger.Debug("users retrieved by IDs",
+	logging.From(ctx, r.logger).Debug("users retrieved by IDs",
 		zap.Int("requested", len(userIDs)),
 		zap.Int("found", len(users)),
 	)
 	return users, nil
 }
 
+func (r *MemoryRepository) ListUsers(ctx context.Context, filter entity.UserFilter) ([]*entity.User, int, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, 0, err
+	}
+	defer r.runlock(ctx)
+
+	users, total := listUsers(slices.Collect(maps.Values(r.users)), filter)
+
+	logging.From(ctx, r.logger).Debug("users listed", zap.Int("count", len(users)), zap.Int("total", total))
+	return cloneAll(users, cloneUser), total, nil
+}
+
+// listUsers applies filter to users: matching users ordered by username,
+// paginated, along with the number of matches before pagination.
+func listUsers(users []*entity.User, filter entity.UserFilter) ([]*entity.User, int) {
+	matched := make([]*entity.User, 0)
+	for _, user := range users {
+		if user.IsDeleted() && !filter.IncludeDeleted {
+			continue
+		}
+		if filter.TeamName != nil && user.TeamName != *filter.TeamName {
+			continue
+		}
+		if filter.IsActive != nil && user.IsActive != *filter.IsActive {
+			continue
+		}
+		if filter.Username != "" && user.Username != filter.Username {
+			continue
+		}
+		matched = append(matched, user)
+	}
+
+	slices.SortFunc(matched, func(a, b *entity.User) int {
+		if c := strings.Compare(a.Username, b.Username); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UserID.String(), b.UserID.String())
+	})
+
+	total := len(matched)
+	from := min(max(filter.Offset, 0), total)
+	to := total
+	if filter.Limit > 0 {
+		to = min(from+filter.Limit, total)
+	}
+	return matched[from:to], total
+}
+
 // TeamRepository implementation
 
 func (r *MemoryRepository) CreateTeam(ctx context.Context, team *entity.Team) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
 
 	if _, exists := r.teams[team.TeamName]; exists {
-		r.logger.Warn("team already exists", zap.String("team_name", team.TeamName))
+		logging.From(ctx, r.logger).Warn("team already exists", zap.String("team_name", team.TeamName))
 		return ErrAlreadyExists
 	}
 
-	r.logger.Info("creating team",
+	logging.From(ctx, r.logger).Info("creating team",
 		zap.String("team_name", team.TeamName),
 		zap.Int("members_count", len(team.Members)),
 	)
 
-	r.teams[team.TeamName] = team
+	r.teams[team.TeamName] = cloneTeam(team)
+	return nil
+}
+
+func (r *MemoryRepository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.teams[team.TeamName]; !exists {
+		logging.From(ctx, r.logger).Warn("team not found for update", zap.String("team_name", team.TeamName))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("updating team",
+		zap.String("team_name", team.TeamName),
+		zap.Int("members_count", len(team.Members)),
+	)
+
+	r.teams[team.TeamName] = cloneTeam(team)
 	return nil
 }
 
 func (r *MemoryRepository) GetTeam(ctx context.Context, teamName string) (*entity.Team, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
 
 	team, exists := r.teams[teamName]
 	if !exists {
-		r.logger.Warn("team not found", zap.String("team_name", teamName))
+		logging.From(ctx, r.logger).Warn("team not found", zap.String("team_name", teamName))
 		return nil, ErrNotFound
 	}
 
-	r.logger.Debug("team retrieved", zap.String("team_name", teamName))
-	return team, nil
+	logging.From(ctx, r.logger).Debug("team retrieved", zap.String("team_name", teamName))
+	return cloneTeam(team), nil
 }
 
 func (r *MemoryRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	if err := r.rlock(ctx); err != nil {
+		return false, err
+	}
+	defer r.runlock(ctx)
 
 	_, exists := r.teams[teamName]
 	return exists, nil
 }
 
+func (r *MemoryRepository) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	teams := cloneAll(slices.Collect(maps.Values(r.teams)), cloneTeam)
+	slices.SortFunc(teams, func(a, b *entity.Team) int {
+		return strings.Compare(a.TeamName, b.TeamName)
+	})
+	return teams, nil
+}
+
+func (r *MemoryRepository) ListTeamSummaries(ctx context.Context, filter entity.TeamListFilter) ([]entity.TeamSummary, int, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, 0, err
+	}
+	defer r.runlock(ctx)
+
+	summaries, total := summarizeTeams(slices.Collect(maps.Values(r.teams)), slices.Collect(maps.Values(r.pullRequests)), filter)
+	return summaries, total, nil
+}
+
 // PullRequestRepository implementation
 
 func (r *MemoryRepository) CreatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if err := checkReviewers(ctx, r.logger, pr); err != nil {
+		return err
+	}
 
-	if _, exists := r.pullRequests[pr.PullRequestID]; exists {
-		r.logger.Warn("pull request already exists", zap.String("pr_id", pr.PullRequestID.String()))
+	if r.prExists(pr.PullRequestID) {
+		logging.From(ctx, r.logger).Warn("pull request already exists", zap.String("pr_id", pr.PullRequestID.String()))
 		return ErrAlreadyExists
 	}
 
-	r.logger.Info("creating pull request",
+	logging.From(ctx, r.logger).Info("creating pull request",
 		zap.String("pr_id", pr.PullRequestID.String()),
 		zap.String("pr_name", pr.PullRequestName),
 		zap.String("author_id", pr.AuthorID.String()),
 		zap.Int("reviewers_count", len(pr.AssignedReviewers)),
 	)
 
-	r.pullRequests[pr.PullRequestID] = pr
+	pr.Version = 1
+	r.storePullRequest(pr)
 	return nil
 }
 
+// checkReviewers refuses a PR whose reviewer list repeats a user or
+// includes the author instead of storing it.
+func checkReviewers(ctx context.Context, logger *zap.Logger, pr *entity.PullRequest) error {
+	id, reason, invalid := pr.InvalidReviewer()
+	if !invalid {
+		return nil
+	}
+
+	logging.From(ctx, logger).Error("refusing to store pull request with invalid reviewers",
+		zap.String("pr_id", pr.PullRequestID.String()),
+		zap.String("reviewer_id", id.String()),
+		zap.String("reason", reason),
+	)
+	return fmt.Errorf("%w: reviewer %s: %s", ErrInvalid, id, reason)
+}
+
 func (r *MemoryRepository) GetPullRequest(ctx context.Context, prID uuid.UUID) (*entity.PullRequest, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
 
 	pr, exists := r.pullRequests[prID]
 	if !exists {
-		r.logger.Warn("pull request not found", zap.String("pr_id", prID.String()))
+		pr, exists = r.archived[prID]
+	}
+	if !exists {
+		logging.From(ctx, r.logger).Warn("pull request not found", zap.String("pr_id", prID.String()))
 		return nil, ErrNotFound
 	}
 
-	r.logger.Debug("pull request retrieved", zap.String("pr_id", prID.String()))
-	return pr, nil
+	logging.From(ctx, r.logger).Debug("pull request retrieved", zap.String("pr_id", prID.String()))
+	return clonePullRequest(pr), nil
 }
 
 func (r *MemoryRepository) UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
 
-	if _, exists := r.pullRequests[pr.PullRequestID]; !exists {
-		r.logger.Warn("pull request not found for update", zap.String("pr_id", pr.PullRequestID.String()))
+	if err := checkReviewers(ctx, r.logger, pr); err != nil {
+		return err
+	}
+
+	stored, exists := r.pullRequests[pr.PullRequestID]
+	if !exists {
+		logging.From(ctx, r.logger).Warn("pull request not found for update", zap.String("pr_id", pr.PullRequestID.String()))
 		return ErrNotFound
 	}
 
-	r.logger.Info("updating pull request",
+	logging.From(ctx, r.logger).Info("updating pull request",
 		zap.String("pr_id", pr.PullRequestID.String()),
 		zap.String("status", string(pr.Status)),
 	)
 
-	r.pullRequests[pr.PullRequestID] = pr
+	pr.Version = stored.Version + 1
+	r.storePullRequest(pr)
 	return nil
 }
 
-func (r *MemoryRepository) GetPullRequestsByReviewer(ctx context.Context, userID uuid.UUID) ([]*entity.PullRequest, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+func (r *MemoryRepository) UpdatePullRequestIf(ctx context.Context, pr *entity.PullRequest, expectedVersion int) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if err := checkReviewers(ctx, r.logger, pr); err != nil {
+		return err
+	}
+
+	stored, exists := r.pullRequests[pr.PullRequestID]
+	if !exists {
+		logging.From(ctx, r.logger).Warn("pull request not found for update", zap.String("pr_id", pr.PullRequestID.String()))
+		return ErrNotFound
+	}
+	if stored.Version != expectedVersion {
+		logging.From(ctx, r.logger).Info("pull request version conflict",
+			zap.String("pr_id", pr.PullRequestID.String()),
+			zap.Int("expected_version", expectedVersion),
+			zap.Int("stored_version", stored.Version),
+		)
+		return ErrConflict
+	}
+
+	logging.From(ctx, r.logger).Info("updating pull request",
+		zap.String("pr_id", pr.PullRequestID.String()),
+		zap.String("status", string(pr.Status)),
+		zap.Int("version", expectedVersion+1),
+	)
+
+	pr.Version = expectedVersion + 1
+	r.storePullRequest(pr)
+	return nil
+}
+
+func (r *MemoryRepository) storePullRequest(pr *entity.PullRequest) {
+	r.trackOpenReviews(r.pullRequests[pr.PullRequestID], pr)
+	r.pullRequests[pr.PullRequestID] = clonePullRequest(pr)
+}
+
+// trackOpenReviews updates openReviews for old being replaced by updated.
+func (r *MemoryRepository) trackOpenReviews(old, updated *entity.PullRequest) {
+	for id, n := range openReviewDelta(old, updated) {
+		r.openReviews[id] += n
+		if r.openReviews[id] == 0 {
+			delete(r.openReviews, id)
+		}
+	}
+}
+
+func (r *MemoryRepository) GetPullRequestsByReviewer(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
 
 	var prs []*entity.PullRequest
 	for _, pr := range r.pullRequests {
-		for _, reviewerID := range pr.AssignedReviewers {
-			if reviewerID == userID {
-				prs = append(prs, pr)
-				break
-			}
+		if !slices.Contains(pr.AssignedReviewers, userID) || !filter.Matches(pr) {
+			continue
 		}
+		prs = append(prs, clonePullRequest(pr))
 	}
 
-	r.logger.Debug("pull requests retrieved by reviewer",
+	sortPullRequests(prs, filter.SortBy, filter.Order)
+
+	logging.From(ctx, r.logger).Debug("pull requests retrieved by reviewer",
 		zap.String("user_id", userID.String()),
 		zap.Int("count", len(prs)),
 	)
 	return prs, nil
 }
 
+func (r *MemoryRepository) GetPullRequestsByAuthor(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	var prs []*entity.PullRequest
+	for _, pr := range r.pullRequests {
+		if pr.AuthorID != userID || !filter.Matches(pr) {
+			continue
+		}
+		prs = append(prs, clonePullRequest(pr))
+	}
+
+	sortPullRequests(prs, filter.SortBy, filter.Order)
+
+	logging.From(ctx, r.logger).Debug("pull requests retrieved by author",
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(prs)),
+	)
+	return prs, nil
+}
+
+func (r *MemoryRepository) GetPullRequestsByStatus(ctx context.Context, status entity.PullRequestStatus) ([]*entity.PullRequest, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	var prs []*entity.PullRequest
+	for _, pr := range r.pullRequests {
+		if pr.Status == status {
+			prs = append(prs, clonePullRequest(pr))
+		}
+	}
+
+	logging.From(ctx, r.logger).Debug("pull requests retrieved by status",
+		zap.String("status", string(status)),
+		zap.Int("count", len(prs)),
+	)
+	return prs, nil
+}
+
+func (r *MemoryRepository) CountOpenReviews(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	return selectCounts(r.openReviews, userIDs), nil
+}
+
+func (r *MemoryRepository) GetPullRequestsByTeam(ctx context.Context, teamName string, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	team, exists := r.teams[teamName]
+	if !exists {
+		logging.From(ctx, r.logger).Warn("team not found", zap.String("team_name", teamName))
+		return nil, ErrNotFound
+	}
+
+	members := make(map[uuid.UUID]struct{}, len(team.Members))
+	for _, id := range team.Members {
+		members[id] = struct{}{}
+	}
+
+	prs := make([]*entity.PullRequest, 0)
+	for _, pr := range r.pullRequests {
+		if _, ok := members[pr.AuthorID]; !ok {
+			continue
+		}
+		if !filter.Matches(pr) {
+			continue
+		}
+		prs = append(prs, clonePullRequest(pr))
+	}
+
+	sortPullRequests(prs, filter.SortBy, filter.Order)
+
+	logging.From(ctx, r.logger).Debug("pull requests retrieved by team",
+		zap.String("team_name", teamName),
+		zap.Int("count", len(prs)),
+	)
+	return prs, nil
+}
+
+func (r *MemoryRepository) ListPullRequests(ctx context.Context, filter entity.PullRequestListFilter) ([]*entity.PullRequest, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	prs := make([]*entity.PullRequest, 0, len(r.pullRequests))
+	for _, pr := range r.pullRequests {
+		prs = append(prs, pr)
+	}
+	if filter.TeamName != "" {
+		team, exists := r.teams[filter.TeamName]
+		if !exists {
+			logging.From(ctx, r.logger).Warn("team not found", zap.String("team_name", filter.TeamName))
+			return nil, ErrNotFound
+		}
+		prs = slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+			return !slices.Contains(team.Members, pr.AuthorID)
+		})
+	}
+
+	listed := cloneAll(listPullRequests(prs, filter), clonePullRequest)
+	logging.From(ctx, r.logger).Debug("pull requests listed", zap.Int("count", len(listed)))
+	return listed, nil
+}
+
 func (r *MemoryRepository) PRExists(ctx context.Context, prID uuid.UUID) (bool, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	if err := r.rlock(ctx); err != nil {
+		return false, err
+	}
+	defer r.runlock(ctx)
 
+	return r.prExists(prID), nil
+}
+
+func (r *MemoryRepository) prExists(prID uuid.UUID) bool {
 	_, exists := r.pullRequests[prID]
-	return exists, nil
+	if !exists {
+		_, exists = r.archived[prID]
+	}
+	return exists
+}
+
+func (r *MemoryRepository) ArchivePullRequest(ctx context.Context, prID uuid.UUID) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	pr, exists := r.pullRequests[prID]
+	if !exists {
+		logging.From(ctx, r.logger).Warn("pull request not found for archive", zap.String("pr_id", prID.String()))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("archiving pull request", zap.String("pr_id", prID.String()))
+
+	r.trackOpenReviews(pr, nil)
+	delete(r.pullRequests, prID)
+	r.archived[prID] = pr
+	return nil
+}
+
+func (r *MemoryRepository) DeletePullRequest(ctx context.Context, prID uuid.UUID) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if !r.prExists(prID) {
+		logging.From(ctx, r.logger).Warn("pull request not found for delete", zap.String("pr_id", prID.String()))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("deleting pull request", zap.String("pr_id", prID.String()))
+
+	r.trackOpenReviews(r.pullRequests[prID], nil)
+	delete(r.pullRequests, prID)
+	delete(r.archived, prID)
+	return nil
+}
+
+func (r *MemoryRepository) PurgeArchivedPullRequests(ctx context.Context, olderThan time.Time) (int, error) {
+	if err := r.lock(ctx); err != nil {
+		return 0, err
+	}
+	defer r.unlock(ctx)
+
+	var deleted int
+	for id, pr := range r.archived {
+		if pr.MergedAt != nil && pr.MergedAt.Before(olderThan) {
+			delete(r.archived, id)
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		logging.From(ctx, r.logger).Info("purged archived pull requests", zap.Int("deleted", deleted))
+	}
+	return deleted, nil
+}
+
+func sortPullRequests(prs []*entity.PullRequest, sortBy entity.PullRequestSortField, order entity.SortOrder) {
+	slices.SortStableFunc(prs, func(a, b *entity.PullRequest) int {
+		return comparePullRequests(a, b, sortBy, order)
+	})
+}
+
+// comparePullRequests orders PRs by sortBy, created_at by default, and
+// then by ID, so no two PRs compare equal.
+func comparePullRequests(a, b *entity.PullRequest, sortBy entity.PullRequestSortField, order entity.SortOrder) int {
+	var cmp int
+	switch sortBy {
+	case entity.SortByName:
+		cmp = strings.Compare(a.PullRequestName, b.PullRequestName)
+	case entity.SortByMergedAt:
+		// Unmerged PRs always go last regardless of order
+		switch {
+		case a.MergedAt == nil && b.MergedAt == nil:
+			cmp = 0
+		case a.MergedAt == nil:
+			return 1
+		case b.MergedAt == nil:
+			return -1
+		default:
+			cmp = a.MergedAt.Compare(*b.MergedAt)
+		}
+	default:
+		cmp = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if cmp == 0 {
+		cmp = strings.Compare(a.PullRequestID.String(), b.PullRequestID.String())
+	}
+	if order == entity.SortDesc {
+		cmp = -cmp
+	}
+	return cmp
 }
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// APITokenRepository implementation
+
+func (r *MemoryRepository) CreateAPIToken(ctx context.Context, token *entity.APIToken) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.apiTokens[token.SecretHash]; exists {
+		return ErrAlreadyExists
+	}
+	r.apiTokens[token.SecretHash] = cloneAPIToken(token)
+	return nil
+}
+
+func (r *MemoryRepository) GetAPIToken(ctx context.Context, secretHash string) (*entity.APIToken, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	token, exists := r.apiTokens[secretHash]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return cloneAPIToken(token), nil
+}
+
+func (r *MemoryRepository) ListAPITokens(ctx context.Context, orgID string) ([]*entity.APIToken, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	return cloneAll(listAPITokens(slices.Collect(maps.Values(r.apiTokens)), orgID), cloneAPIToken), nil
+}
+
+func (r *MemoryRepository) DeleteAPIToken(ctx context.Context, orgID string, id uuid.UUID) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	for hash, token := range r.apiTokens {
+		if token.ID == id && token.OrgID == orgID {
+			delete(r.apiTokens, hash)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"avito-intro/internal/entity"
+)
+
+// AuditRepository implementation
+
+func (r *MemoryRepository) AppendAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	r.audit = append(r.audit, cloneAuditEntry(entry))
+	return nil
+}
+
+// ListAudit returns matching entries newest first, along with the number of
+// matches before pagination.
+func (r *MemoryRepository) ListAudit(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEntry, int, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, 0, err
+	}
+	defer r.runlock(ctx)
+
+	matched := make([]*entity.AuditEntry, 0)
+	for i := len(r.audit) - 1; i >= 0; i-- {
+		if filter.Matches(r.audit[i]) {
+			matched = append(matched, r.audit[i])
+		}
+	}
+
+	total := len(matched)
+	from := min(max(filter.Offset, 0), total)
+	to := total
+	if filter.Limit > 0 {
+		to = min(from+filter.Limit, total)
+	}
+
+	return cloneAll(matched[from:to], cloneAuditEntry), total, nil
+}
+
+func (r *MemoryRepository) DeleteAuditBefore(ctx context.Context, before time.Time) (int, error) {
+	if err := r.lock(ctx); err != nil {
+		return 0, err
+	}
+	defer r.unlock(ctx)
+
+	kept := r.audit[:0]
+	for _, entry := range r.audit {
+		if !entry.OccurredAt.Before(before) {
+			kept = append(kept, entry)
+		}
+	}
+	deleted := len(r.audit) - len(kept)
+	clear(r.audit[len(kept):])
+	r.audit = kept
+	return deleted, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"go.uber.org/zap"
+)
+
+// ChecklistRepository implementation
+
+func (r *MemoryRepository) SetChecklistTemplate(ctx context.Context, template *entity.ChecklistTemplate) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.teams[template.TeamName]; !exists {
+		logging.From(ctx, r.logger).Warn("team not found for checklist template", zap.String("team_name", template.TeamName))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("setting checklist template",
+		zap.String("team_name", template.TeamName),
+		zap.Int("items", len(template.Items)),
+		zap.Bool("required_for_merge", template.RequiredForMerge),
+	)
+
+	r.checklists[template.TeamName] = cloneChecklistTemplate(template)
+	return nil
+}
+
+func (r *MemoryRepository) GetChecklistTemplate(ctx context.Context, teamName string) (*entity.ChecklistTemplate, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	template, exists := r.checklists[teamName]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	return cloneChecklistTemplate(template), nil
+}
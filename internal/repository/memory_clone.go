@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"maps"
+	"slices"
+
+	"avito-intro/internal/entity"
+)
+
+// The memory repository stores its own copies of the entities it is given
+// and hands out copies of what it stores: callers are free to change
+// either, and stored entities are only ever replaced, never changed in
+// place, so no caller sees or causes a change outside the lock.
+
+func cloneAll[T any](items []*T, clone func(*T) *T) []*T {
+	if items == nil {
+		return nil
+	}
+	cloned := make([]*T, len(items))
+	for i, item := range items {
+		cloned[i] = clone(item)
+	}
+	return cloned
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneUser(user *entity.User) *entity.User {
+	cloned := *user
+	cloned.SnoozedUntil = clonePtr(user.SnoozedUntil)
+	cloned.DeletedAt = clonePtr(user.DeletedAt)
+	return &cloned
+}
+
+func cloneTeam(team *entity.Team) *entity.Team {
+	return &entity.Team{TeamName: team.TeamName, Members: slices.Clone(team.Members)}
+}
+
+func clonePullRequest(pr *entity.PullRequest) *entity.PullRequest {
+	cloned := *pr
+	cloned.AssignedReviewers = slices.Clone(pr.AssignedReviewers)
+	cloned.MergedAt = clonePtr(pr.MergedAt)
+	cloned.ClosedAt = clonePtr(pr.ClosedAt)
+	cloned.MilestoneID = clonePtr(pr.MilestoneID)
+	cloned.Checklist.Items = cloneChecklistItems(pr.Checklist.Items)
+	cloned.ReviewerSlots = maps.Clone(pr.ReviewerSlots)
+	cloned.Approvals = slices.Clone(pr.Approvals)
+	cloned.Override = clonePtr(pr.Override)
+	cloned.ChangesRequested = clonePtr(pr.ChangesRequested)
+	cloned.Declines = slices.Clone(pr.Declines)
+	cloned.FirstResponses = maps.Clone(pr.FirstResponses)
+	cloned.ChangedFiles = slices.Clone(pr.ChangedFiles)
+	return &cloned
+}
+
+func cloneChecklistItems(items []entity.ChecklistItem) []entity.ChecklistItem {
+	if items == nil {
+		return nil
+	}
+	cloned := make([]entity.ChecklistItem, len(items))
+	for i, item := range items {
+		cloned[i] = item
+		cloned[i].CheckedBy = clonePtr(item.CheckedBy)
+		cloned[i].CheckedAt = clonePtr(item.CheckedAt)
+	}
+	return cloned
+}
+
+func cloneMilestone(milestone *entity.Milestone) *entity.Milestone {
+	cloned := *milestone
+	cloned.DueDate = clonePtr(milestone.DueDate)
+	return &cloned
+}
+
+func cloneChecklistTemplate(template *entity.ChecklistTemplate) *entity.ChecklistTemplate {
+	cloned := *template
+	cloned.Items = slices.Clone(template.Items)
+	return &cloned
+}
+
+func cloneComponents(components []entity.ComponentOwner) []entity.ComponentOwner {
+	cloned := make([]entity.ComponentOwner, len(components))
+	for i, component := range components {
+		cloned[i] = entity.ComponentOwner{Path: component.Path, Owners: slices.Clone(component.Owners)}
+	}
+	return cloned
+}
+
+func cloneTeamSettings(settings *entity.TeamSettings) *entity.TeamSettings {
+	cloned := *settings
+	cloned.RequiredReviewers = clonePtr(settings.RequiredReviewers)
+	cloned.OptionalReviewers = clonePtr(settings.OptionalReviewers)
+	cloned.SLA = clonePtr(settings.SLA)
+	return &cloned
+}
+
+func cloneGlobalSettings(settings *entity.GlobalSettings) *entity.GlobalSettings {
+	cloned := *settings
+	cloned.RequiredReviewers = clonePtr(settings.RequiredReviewers)
+	cloned.OptionalReviewers = clonePtr(settings.OptionalReviewers)
+	cloned.SLA = clonePtr(settings.SLA)
+	cloned.Features = maps.Clone(settings.Features)
+	return &cloned
+}
+
+func cloneAuditEntry(entry *entity.AuditEntry) *entity.AuditEntry {
+	cloned := *entry
+	cloned.Details = maps.Clone(entry.Details)
+	return &cloned
+}
+
+func cloneStorageEvent(event *entity.StorageEvent) *entity.StorageEvent {
+	cloned := *event
+	cloned.Payload = slices.Clone(event.Payload)
+	return &cloned
+}
+
+func cloneOutboxMessage(msg *entity.OutboxMessage) *entity.OutboxMessage {
+	cloned := *msg
+	cloned.Event.PullRequest = *clonePullRequest(&msg.Event.PullRequest)
+	return &cloned
+}
+
+func cloneAPIToken(token *entity.APIToken) *entity.APIToken {
+	cloned := *token
+	cloned.Roles = slices.Clone(token.Roles)
+	cloned.ExpiresAt = clonePtr(token.ExpiresAt)
+	return &cloned
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"avito-intro/internal/entity"
+)
+
+// EventLogRepository implementation
+
+func (r *MemoryRepository) AppendEvent(ctx context.Context, event *entity.StorageEvent) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	stored := cloneStorageEvent(event)
+	stored.Seq = int64(len(r.events)) + 1
+	r.events = append(r.events, stored)
+	event.Seq = stored.Seq
+	return nil
+}
+
+func (r *MemoryRepository) ListEvents(ctx context.Context, filter entity.StorageEventFilter) ([]*entity.StorageEvent, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	// Seq is the position in the log, so the scan can start right after
+	// the cursor.
+	from := min(max(filter.AfterSeq, 0), int64(len(r.events)))
+	events := make([]*entity.StorageEvent, 0)
+	for _, event := range r.events[from:] {
+		if filter.Limit > 0 && len(events) == filter.Limit {
+			break
+		}
+		if !filter.Matches(event) {
+			continue
+		}
+		events = append(events, cloneStorageEvent(event))
+	}
+	return events, nil
+}
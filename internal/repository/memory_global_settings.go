@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+)
+
+// GlobalSettingsRepository implementation
+
+func (r *MemoryRepository) SetGlobalSettings(ctx context.Context, settings *entity.GlobalSettings) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	logging.From(ctx, r.logger).Info("setting global settings")
+
+	r.globalSettings = cloneGlobalSettings(settings)
+	return nil
+}
+
+func (r *MemoryRepository) GetGlobalSettings(ctx context.Context) (*entity.GlobalSettings, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	if r.globalSettings == nil {
+		return nil, ErrNotFound
+	}
+
+	return cloneGlobalSettings(r.globalSettings), nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"go.uber.org/zap"
+)
+
+// MergePolicyRepository implementation
+
+func (r *MemoryRepository) SetMergePolicy(ctx context.Context, policy *entity.MergePolicy) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.teams[policy.TeamName]; !exists {
+		logging.From(ctx, r.logger).Warn("team not found for merge policy", zap.String("team_name", policy.TeamName))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("setting merge policy",
+		zap.String("team_name", policy.TeamName),
+		zap.Int("min_approvals", policy.MinApprovals),
+		zap.Bool("checklist_complete", policy.ChecklistComplete),
+		zap.Bool("not_overdue", policy.NotOverdue),
+	)
+
+	r.mergePolicies[policy.TeamName] = clonePtr(policy)
+	return nil
+}
+
+func (r *MemoryRepository) GetMergePolicy(ctx context.Context, teamName string) (*entity.MergePolicy, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	policy, exists := r.mergePolicies[teamName]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	return clonePtr(policy), nil
+}
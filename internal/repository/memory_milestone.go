@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"slices"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MilestoneRepository implementation
+
+func (r *MemoryRepository) CreateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.milestones[milestone.MilestoneID]; exists {
+		logging.From(ctx, r.logger).Warn("milestone already exists", zap.String("milestone_id", milestone.MilestoneID.String()))
+		return ErrAlreadyExists
+	}
+
+	logging.From(ctx, r.logger).Info("creating milestone",
+		zap.String("milestone_id", milestone.MilestoneID.String()),
+		zap.String("title", milestone.Title),
+	)
+
+	r.milestones[milestone.MilestoneID] = cloneMilestone(milestone)
+	return nil
+}
+
+func (r *MemoryRepository) GetMilestone(ctx context.Context, milestoneID uuid.UUID) (*entity.Milestone, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	milestone, exists := r.milestones[milestoneID]
+	if !exists {
+		logging.From(ctx, r.logger).Warn("milestone not found", zap.String("milestone_id", milestoneID.String()))
+		return nil, ErrNotFound
+	}
+
+	return cloneMilestone(milestone), nil
+}
+
+func (r *MemoryRepository) ListMilestones(ctx context.Context) ([]*entity.Milestone, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	milestones := make([]*entity.Milestone, 0, len(r.milestones))
+	for _, milestone := range r.milestones {
+		milestones = append(milestones, cloneMilestone(milestone))
+	}
+
+	slices.SortFunc(milestones, func(a, b *entity.Milestone) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return milestones, nil
+}
+
+func (r *MemoryRepository) UpdateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.milestones[milestone.MilestoneID]; !exists {
+		logging.From(ctx, r.logger).Warn("milestone not found for update", zap.String("milestone_id", milestone.MilestoneID.String()))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("updating milestone", zap.String("milestone_id", milestone.MilestoneID.String()))
+
+	r.milestones[milestone.MilestoneID] = cloneMilestone(milestone)
+	return nil
+}
+
+// DeleteMilestone removes the milestone and detaches it from every pull
+// request in the same critical section.
+func (r *MemoryRepository) DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.milestones[milestoneID]; !exists {
+		logging.From(ctx, r.logger).Warn("milestone not found for delete", zap.String("milestone_id", milestoneID.String()))
+		return ErrNotFound
+	}
+
+	detached := 0
+	for id, pr := range r.pullRequests {
+		if pr.MilestoneID == nil || *pr.MilestoneID != milestoneID {
+			continue
+		}
+		updated := clonePullRequest(pr)
+		updated.MilestoneID = nil
+		updated.Version++
+		r.pullRequests[id] = updated
+		detached++
+	}
+
+	delete(r.milestones, milestoneID)
+
+	logging.From(ctx, r.logger).Info("milestone deleted",
+		zap.String("milestone_id", milestoneID.String()),
+		zap.Int("detached_prs", detached),
+	)
+	return nil
+}
+
+func (r *MemoryRepository) GetPullRequestsByMilestone(ctx context.Context, milestoneID uuid.UUID) ([]*entity.PullRequest, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	var prs []*entity.PullRequest
+	for _, pr := range r.pullRequests {
+		if pr.MilestoneID != nil && *pr.MilestoneID == milestoneID {
+			prs = append(prs, clonePullRequest(pr))
+		}
+	}
+
+	sortPullRequests(prs, entity.SortByCreatedAt, entity.SortAsc)
+
+	logging.From(ctx, r.logger).Debug("pull requests retrieved by milestone",
+		zap.String("milestone_id", milestoneID.String()),
+		zap.Int("count", len(prs)),
+	)
+	return prs, nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"go.uber.org/zap"
+)
+
+// OrganizationRepository implementation
+
+func (r *MemoryRepository) CreateOrganization(ctx context.Context, org *entity.Organization) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.organizations[org.OrgID]; exists {
+		logging.From(ctx, r.logger).Warn("organization already exists", zap.String("org_id", org.OrgID))
+		return ErrAlreadyExists
+	}
+
+	logging.From(ctx, r.logger).Info("creating organization", zap.String("org_id", org.OrgID))
+	r.organizations[org.OrgID] = clonePtr(org)
+	return nil
+}
+
+func (r *MemoryRepository) GetOrganization(ctx context.Context, orgID string) (*entity.Organization, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	org, exists := r.organizations[orgID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return clonePtr(org), nil
+}
+
+func (r *MemoryRepository) ListOrganizations(ctx context.Context) ([]*entity.Organization, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	orgs := make([]*entity.Organization, 0, len(r.organizations))
+	for _, org := range r.organizations {
+		orgs = append(orgs, clonePtr(org))
+	}
+	return sortOrganizations(orgs), nil
+}
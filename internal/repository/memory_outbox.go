@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// OutboxRepository implementation
+
+func (r *MemoryRepository) AppendOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.outbox[msg.ID]; exists {
+		return ErrAlreadyExists
+	}
+	r.outbox[msg.ID] = cloneOutboxMessage(msg)
+	return nil
+}
+
+func (r *MemoryRepository) ListOutbox(ctx context.Context, due time.Time, limit int) ([]*entity.OutboxMessage, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	return cloneAll(listOutbox(slices.Collect(maps.Values(r.outbox)), due, limit), cloneOutboxMessage), nil
+}
+
+func (r *MemoryRepository) UpdateOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.outbox[msg.ID]; !exists {
+		return ErrNotFound
+	}
+	r.outbox[msg.ID] = cloneOutboxMessage(msg)
+	return nil
+}
+
+func (r *MemoryRepository) DeleteOutbox(ctx context.Context, id uuid.UUID) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.outbox[id]; !exists {
+		return ErrNotFound
+	}
+	delete(r.outbox, id)
+	return nil
+}
+
+// listOutbox returns up to limit of messages due at the given time, oldest
+// first.
+func listOutbox(messages []*entity.OutboxMessage, due time.Time, limit int) []*entity.OutboxMessage {
+	messages = slices.DeleteFunc(messages, func(msg *entity.OutboxMessage) bool {
+		return !msg.IsDue(due)
+	})
+	slices.SortFunc(messages, func(a, b *entity.OutboxMessage) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+	if limit > 0 && len(messages) > limit {
+		messages = messages[:limit]
+	}
+	if messages == nil {
+		messages = make([]*entity.OutboxMessage, 0)
+	}
+	return messages
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"slices"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// OwnershipRepository implementation
+
+func (r *MemoryRepository) SetTeamOwners(ctx context.Context, teamName string, owners []uuid.UUID) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.teams[teamName]; !exists {
+		logging.From(ctx, r.logger).Warn("team not found for owners", zap.String("team_name", teamName))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("setting team owners",
+		zap.String("team_name", teamName),
+		zap.Int("owners", len(owners)),
+	)
+
+	r.teamOwners[teamName] = slices.Clone(owners)
+	return nil
+}
+
+func (r *MemoryRepository) GetTeamOwners(ctx context.Context, teamName string) ([]uuid.UUID, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	return slices.Clone(r.teamOwners[teamName]), nil
+}
+
+func (r *MemoryRepository) SetComponentOwners(ctx context.Context, teamName string, components []entity.ComponentOwner) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.teams[teamName]; !exists {
+		logging.From(ctx, r.logger).Warn("team not found for component owners", zap.String("team_name", teamName))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("setting component owners",
+		zap.String("team_name", teamName),
+		zap.Int("components", len(components)),
+	)
+
+	r.components[teamName] = cloneComponents(components)
+	return nil
+}
+
+func (r *MemoryRepository) GetComponentOwners(ctx context.Context, teamName string) ([]entity.ComponentOwner, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	return cloneComponents(r.components[teamName]), nil
+}
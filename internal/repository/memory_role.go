@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"slices"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RoleRepository implementation
+
+func (r *MemoryRepository) GrantRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.users[userID]; !exists {
+		logging.From(ctx, r.logger).Warn("user not found for role grant", zap.String("user_id", userID.String()))
+		return ErrNotFound
+	}
+
+	if !slices.Contains(r.userRoles[userID], role) {
+		roles := append(slices.Clone(r.userRoles[userID]), role)
+		slices.Sort(roles)
+		r.userRoles[userID] = roles
+	}
+	return nil
+}
+
+func (r *MemoryRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.users[userID]; !exists {
+		logging.From(ctx, r.logger).Warn("user not found for role revoke", zap.String("user_id", userID.String()))
+		return ErrNotFound
+	}
+
+	roles := slices.DeleteFunc(slices.Clone(r.userRoles[userID]), func(granted entity.Role) bool {
+		return granted == role
+	})
+	if len(roles) == 0 {
+		delete(r.userRoles, userID)
+	} else {
+		r.userRoles[userID] = roles
+	}
+	return nil
+}
+
+func (r *MemoryRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]entity.Role, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	if _, exists := r.users[userID]; !exists {
+		return nil, ErrNotFound
+	}
+	return slices.Clone(r.userRoles[userID]), nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"unsafe"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Rough per-entry overhead of a Go map bucket slot; good enough for trend
+// monitoring, not for exact accounting.
+const mapEntryOverhead = 48
+
+var (
+	userSize        = int64(unsafe.Sizeof(entity.User{}))
+	teamSize        = int64(unsafe.Sizeof(entity.Team{}))
+	pullRequestSize = int64(unsafe.Sizeof(entity.PullRequest{}))
+	uuidSize        = int64(unsafe.Sizeof(uuid.UUID{}))
+)
+
+func (r *MemoryRepository) Stats(ctx context.Context) (entity.StorageStats, error) {
+	if err := r.rlock(ctx); err != nil {
+		return entity.StorageStats{}, err
+	}
+	defer r.runlock(ctx)
+
+	stats := entity.StorageStats{
+		Backend:      "memory",
+		Users:        len(r.users),
+		Teams:        len(r.teams),
+		PullRequests: make(map[entity.PullRequestStatus]int),
+	}
+
+	var usersBytes int64
+	for _, user := range r.users {
+		if user.IsActive && !user.IsDeleted() {
+			stats.ActiveUsers++
+		}
+		usersBytes += userSize + int64(len(user.Username)+len(user.TeamName))
+	}
+
+	var teamsBytes int64
+	for name, team := range r.teams {
+		teamsBytes += teamSize + int64(len(name)) + int64(cap(team.Members))*uuidSize
+	}
+
+	var prsBytes int64
+	for _, pr := range r.pullRequests {
+		stats.PullRequests[pr.Status]++
+		prsBytes += pullRequestSize + int64(len(pr.PullRequestName)) + int64(cap(pr.AssignedReviewers))*uuidSize
+	}
+
+	stats.Indexes = []entity.IndexStats{
+		mapIndexStats("users_by_id", len(r.users), uuidSize),
+		mapIndexStats("teams_by_name", len(r.teams), int64(unsafe.Sizeof(""))),
+		mapIndexStats("pull_requests_by_id", len(r.pullRequests), uuidSize),
+		mapIndexStats("open_reviews_by_user", len(r.openReviews), uuidSize),
+	}
+
+	stats.EstimatedBytes = usersBytes + teamsBytes + prsBytes
+	for _, idx := range stats.Indexes {
+		stats.EstimatedBytes += idx.EstimatedBytes
+	}
+
+	logging.From(ctx, r.logger).Debug("storage stats computed",
+		zap.Int("users", stats.Users),
+		zap.Int("teams", stats.Teams),
+		zap.Int64("estimated_bytes", stats.EstimatedBytes),
+	)
+	return stats, nil
+}
+
+func mapIndexStats(name string, entries int, keySize int64) entity.IndexStats {
+	return entity.IndexStats{
+		Name:    name,
+		Entries: entries,
+		// Every index maps its key to a pointer into the store.
+		EstimatedBytes: int64(entries) * (keySize + int64(unsafe.Sizeof(uintptr(0))) + mapEntryOverhead),
+	}
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"go.uber.org/zap"
+)
+
+// TeamSettingsRepository implementation
+
+func (r *MemoryRepository) SetTeamSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.unlock(ctx)
+
+	if _, exists := r.teams[settings.TeamName]; !exists {
+		logging.From(ctx, r.logger).Warn("team not found for settings", zap.String("team_name", settings.TeamName))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("setting team settings", zap.String("team_name", settings.TeamName))
+
+	r.teamSettings[settings.TeamName] = cloneTeamSettings(settings)
+	return nil
+}
+
+func (r *MemoryRepository) GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	if err := r.rlock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.runlock(ctx)
+
+	settings, exists := r.teamSettings[teamName]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	return cloneTeamSettings(settings), nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func newTestPullRequest(t *testing.T, repo *MemoryRepository) *entity.PullRequest {
+	t.Helper()
+
+	pr := &entity.PullRequest{
+		PullRequestID:     uuid.New(),
+		PullRequestName:   "test PR",
+		AuthorID:          uuid.New(),
+		Status:            entity.StatusOpen,
+		AssignedReviewers: []uuid.UUID{uuid.New()},
+		CreatedAt:         time.Now(),
+	}
+	if err := repo.CreatePullRequest(context.Background(), pr); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	stored, err := repo.GetPullRequest(context.Background(), pr.PullRequestID)
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	return stored
+}
+
+func TestUpdatePullRequestIfStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository(zap.NewNop())
+	pr := newTestPullRequest(t, repo)
+	stale := pr.Version
+
+	updated := *pr
+	updated.PullRequestName = "renamed"
+	if err := repo.UpdatePullRequestIf(ctx, &updated, stale); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if updated.Version != stale+1 {
+		t.Fatalf("version = %d, want %d", updated.Version, stale+1)
+	}
+
+	late := *pr
+	late.Status = entity.StatusMerged
+	if err := repo.UpdatePullRequestIf(ctx, &late, stale); !errors.Is(err, ErrConflict) {
+		t.Fatalf("update with stale version: got %v, want ErrConflict", err)
+	}
+
+	stored, err := repo.GetPullRequest(ctx, pr.PullRequestID)
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	if stored.PullRequestName != "renamed" || stored.Status != entity.StatusOpen {
+		t.Fatalf("stale update was stored: name %q, status %s", stored.PullRequestName, stored.Status)
+	}
+}
+
+func TestUpdatePullRequestIfConcurrentWriters(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository(zap.NewNop())
+	pr := newTestPullRequest(t, repo)
+
+	const writers = 16
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		won       []uuid.UUID
+		conflicts int
+	)
+	for range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			reviewer := uuid.New()
+			updated := *pr
+			updated.AssignedReviewers = []uuid.UUID{reviewer}
+			err := repo.UpdatePullRequestIf(ctx, &updated, pr.Version)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				won = append(won, reviewer)
+			case errors.Is(err, ErrConflict):
+				conflicts++
+			default:
+				t.Errorf("UpdatePullRequestIf: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(won) != 1 || conflicts != writers-1 {
+		t.Fatalf("%d writers succeeded and %d conflicted, want 1 and %d", len(won), conflicts, writers-1)
+	}
+
+	stored, err := repo.GetPullRequest(ctx, pr.PullRequestID)
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	if stored.Version != pr.Version+1 {
+		t.Fatalf("version = %d, want %d", stored.Version, pr.Version+1)
+	}
+	if len(stored.AssignedReviewers) != 1 || stored.AssignedReviewers[0] != won[0] {
+		t.Fatalf("stored reviewers %v, want the winner %s", stored.AssignedReviewers, won[0])
+	}
+}
+
+func TestPurgeArchivedPullRequests(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository(zap.NewNop())
+	now := time.Now()
+
+	merge := func(mergedAt time.Time) uuid.UUID {
+		pr := newTestPullRequest(t, repo)
+		pr.Status = entity.StatusMerged
+		pr.MergedAt = &mergedAt
+		if err := repo.UpdatePullRequest(ctx, pr); err != nil {
+			t.Fatalf("UpdatePullRequest: %v", err)
+		}
+		return pr.PullRequestID
+	}
+	old := merge(now.Add(-48 * time.Hour))
+	recent := merge(now.Add(-time.Hour))
+	working := merge(now.Add(-48 * time.Hour))
+	for _, id := range []uuid.UUID{old, recent} {
+		if err := repo.ArchivePullRequest(ctx, id); err != nil {
+			t.Fatalf("ArchivePullRequest: %v", err)
+		}
+	}
+
+	deleted, err := repo.PurgeArchivedPullRequests(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("PurgeArchivedPullRequests: %v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("deleted %d archived PRs, want 1", deleted)
+	}
+	if _, err := repo.GetPullRequest(ctx, old); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("old archived PR: got %v, want ErrNotFound", err)
+	}
+	// Neither a recently merged archived PR nor one still in the working
+	// set is purged.
+	for _, id := range []uuid.UUID{recent, working} {
+		if _, err := repo.GetPullRequest(ctx, id); err != nil {
+			t.Fatalf("GetPullRequest %s: %v", id, err)
+		}
+	}
+}
+
+func TestDeleteAPITokenOfOtherOrganization(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository(zap.NewNop())
+	token := &entity.APIToken{ID: uuid.New(), SecretHash: "hash", OrgID: "acme", CreatedAt: time.Now()}
+	if err := repo.CreateAPIToken(ctx, token); err != nil {
+		t.Fatalf("CreateAPIToken: %v", err)
+	}
+
+	if err := repo.DeleteAPIToken(ctx, "other", token.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("DeleteAPIToken in another organization: got %v, want ErrNotFound", err)
+	}
+	if _, err := repo.GetAPIToken(ctx, token.SecretHash); err != nil {
+		t.Fatalf("GetAPIToken after a refused delete: %v", err)
+	}
+	if err := repo.DeleteAPIToken(ctx, "acme", token.ID); err != nil {
+		t.Fatalf("DeleteAPIToken: %v", err)
+	}
+	if _, err := repo.GetAPIToken(ctx, token.SecretHash); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetAPIToken after delete: got %v, want ErrNotFound", err)
+	}
+}
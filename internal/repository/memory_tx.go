@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// memoryTxKey marks a ctx as running inside InTx of the repository stored
+// under it.
+type memoryTxKey struct{}
+
+func (r *MemoryRepository) inTx(ctx context.Context) bool {
+	return ctx.Value(memoryTxKey{}) == r
+}
+
+// InTx holds the write lock for the whole of fn, so its calls see no other
+// writer, and restores the state from before fn when it fails.
+func (r *MemoryRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.inTx(ctx) {
+		return fn(ctx)
+	}
+	if err := r.lock(ctx); err != nil {
+		return err
+	}
+	defer r.mu.Unlock()
+
+	saved := r.snapshot()
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, r)); err != nil {
+		r.restore(saved)
+		logging.From(ctx, r.logger).Info("transaction rolled back", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// WithLock holds a lock of this process, which is the only one that can
+// see the data. It is independent of InTx: fn may run transactions.
+func (r *MemoryRepository) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return r.locks.withLock(ctx, name, fn)
+}
+
+// memorySnapshot copies the maps and slices, not the entities: stored
+// entities are replaced on write and never changed in place, so the saved
+// pointers keep the state from before the transaction.
+type memorySnapshot struct {
+	users          map[uuid.UUID]*entity.User
+	teams          map[string]*entity.Team
+	pullRequests   map[uuid.UUID]*entity.PullRequest
+	archived       map[uuid.UUID]*entity.PullRequest
+	openReviews    map[uuid.UUID]int
+	milestones     map[uuid.UUID]*entity.Milestone
+	checklists     map[string]*entity.ChecklistTemplate
+	teamOwners     map[string][]uuid.UUID
+	components     map[string][]entity.ComponentOwner
+	mergePolicies  map[string]*entity.MergePolicy
+	teamSettings   map[string]*entity.TeamSettings
+	globalSettings *entity.GlobalSettings
+	userRoles      map[uuid.UUID][]entity.Role
+	audit          []*entity.AuditEntry
+	events         []*entity.StorageEvent
+	outbox         map[uuid.UUID]*entity.OutboxMessage
+	organizations  map[string]*entity.Organization
+	apiTokens      map[string]*entity.APIToken
+}
+
+func (r *MemoryRepository) snapshot() memorySnapshot {
+	return memorySnapshot{
+		users:          maps.Clone(r.users),
+		teams:          maps.Clone(r.teams),
+		pullRequests:   maps.Clone(r.pullRequests),
+		archived:       maps.Clone(r.archived),
+		openReviews:    maps.Clone(r.openReviews),
+		milestones:     maps.Clone(r.milestones),
+		checklists:     maps.Clone(r.checklists),
+		teamOwners:     maps.Clone(r.teamOwners),
+		components:     maps.Clone(r.components),
+		mergePolicies:  maps.Clone(r.mergePolicies),
+		teamSettings:   maps.Clone(r.teamSettings),
+		globalSettings: r.globalSettings,
+		userRoles:      maps.Clone(r.userRoles),
+		audit:          slices.Clone(r.audit),
+		events:         slices.Clone(r.events),
+		outbox:         maps.Clone(r.outbox),
+		organizations:  maps.Clone(r.organizations),
+		apiTokens:      maps.Clone(r.apiTokens),
+	}
+}
+
+func (r *MemoryRepository) restore(s memorySnapshot) {
+	r.users = s.users
+	r.teams = s.teams
+	r.pullRequests = s.pullRequests
+	r.archived = s.archived
+	r.openReviews = s.openReviews
+	r.milestones = s.milestones
+	r.checklists = s.checklists
+	r.teamOwners = s.teamOwners
+	r.components = s.components
+	r.mergePolicies = s.mergePolicies
+	r.teamSettings = s.teamSettings
+	r.globalSettings = s.globalSettings
+	r.userRoles = s.userRoles
+	r.audit = s.audit
+	r.events = s.events
+	r.outbox = s.outbox
+	r.organizations = s.organizations
+	r.apiTokens = s.apiTokens
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"slices"
+	"strings"
+
+	"avito-intro/internal/entity"
+)
+
+// sortOrganizations orders orgs by ID, which is how every backend lists
+// them.
+func sortOrganizations(orgs []*entity.Organization) []*entity.Organization {
+	if orgs == nil {
+		orgs = make([]*entity.Organization, 0)
+	}
+	slices.SortFunc(orgs, func(a, b *entity.Organization) int {
+		return strings.Compare(a.OrgID, b.OrgID)
+	})
+	return orgs
+}
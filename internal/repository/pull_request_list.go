@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"avito-intro/internal/entity"
+)
+
+// listPullRequests picks the page filter asks for out of prs, which the
+// backend has already narrowed down to the team's PRs when filter has a
+// TeamName: the matching PRs past the cursor, sorted, at most Limit of
+// them.
+func listPullRequests(prs []*entity.PullRequest, filter entity.PullRequestListFilter) []*entity.PullRequest {
+	var after *entity.PullRequest
+	if filter.After != nil {
+		after = &entity.PullRequest{
+			PullRequestID:   filter.After.PullRequestID,
+			PullRequestName: filter.After.PullRequestName,
+			CreatedAt:       filter.After.CreatedAt,
+			MergedAt:        filter.After.MergedAt,
+		}
+	}
+
+	listed := make([]*entity.PullRequest, 0)
+	for _, pr := range prs {
+		if !filter.Matches(pr) {
+			continue
+		}
+		if after != nil && comparePullRequests(pr, after, filter.SortBy, filter.Order) <= 0 {
+			continue
+		}
+		listed = append(listed, pr)
+	}
+
+	sortPullRequests(listed, filter.SortBy, filter.Order)
+	if filter.Limit > 0 && len(listed) > filter.Limit {
+		listed = listed[:filter.Limit]
+	}
+	return listed
+}
@@ -0,0 +1,714 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+var _ Storage = (*RedisRepository)(nil)
+
+// maxWatchRetries bounds how often a read-modify-write is retried when
+// another instance changed a watched key in between.
+const maxWatchRetries = 10
+
+// RedisRepository keeps its state in Redis, so several instances can share
+// it. Every entity is a JSON string under prefix+"<kind>:<id>", and a set
+// per kind lists the IDs for scans. PRs are also indexed by status, author,
+// reviewer and milestone, see trackPullRequestIndexes. Writes that depend
+// on the stored value run in WATCH/MULTI transactions.
+type RedisRepository struct {
+	client redis.UniversalClient
+	prefix string
+	logger *zap.Logger
+}
+
+func NewRedisRepository(client redis.UniversalClient, prefix string, logger *zap.Logger) *RedisRepository {
+	return &RedisRepository{
+		client: client,
+		prefix: prefix,
+		logger: logger,
+	}
+}
+
+func (r *RedisRepository) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+const (
+	redisUsers        = "users"
+	redisTeams        = "teams"
+	redisPullRequests = "pull_requests"
+	redisMilestones   = "milestones"
+	// Archived PRs have no index: only reads by ID look at them.
+	redisArchivedPullRequests = "archived_pull_requests"
+)
+
+func (r *RedisRepository) key(kind, id string) string {
+	return r.prefix + kind + ":" + id
+}
+
+func (r *RedisRepository) index(kind string) string {
+	return r.prefix + kind
+}
+
+// get decodes the value at key into v; a missing key is ErrNotFound.
+func (r *RedisRepository) get(ctx context.Context, c redis.Cmdable, key string, v any) error {
+	data, err := c.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("redis get %s: %w", key, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode %s: %w", key, err)
+	}
+	return nil
+}
+
+// exists reports whether any of keys exists.
+func (r *RedisRepository) exists(ctx context.Context, keys ...string) (bool, error) {
+	n, err := r.client.Exists(ctx, keys...).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis exists %s: %w", strings.Join(keys, " "), err)
+	}
+	return n > 0, nil
+}
+
+// create stores v under kind:id unless the key exists.
+func (r *RedisRepository) create(ctx context.Context, kind, id string, v any, ttl time.Duration) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s %s: %w", kind, id, err)
+	}
+
+	var created *redis.BoolCmd
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		created = pipe.SetNX(ctx, r.key(kind, id), data, ttl)
+		pipe.SAdd(ctx, r.index(kind), id)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis create %s %s: %w", kind, id, err)
+	}
+	if !created.Val() {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// replace overwrites the existing value of kind:id.
+func (r *RedisRepository) replace(ctx context.Context, kind, id string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s %s: %w", kind, id, err)
+	}
+
+	updated, err := r.client.SetXX(ctx, r.key(kind, id), data, 0).Result()
+	if err != nil {
+		return fmt.Errorf("redis update %s %s: %w", kind, id, err)
+	}
+	if !updated {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// watch runs fn in a WATCH transaction on keys, retrying while other
+// writers get in between. fn queues its writes with tx.TxPipelined.
+func (r *RedisRepository) watch(ctx context.Context, fn func(tx *redis.Tx) error, keys ...string) error {
+	for range maxWatchRetries {
+		err := r.client.Watch(ctx, fn, keys...)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return ErrConflict
+}
+
+// loadAll decodes every entity of kind. IDs whose key is gone, like
+// expired API tokens, are removed from the index on the way.
+func loadAll[T any](ctx context.Context, r *RedisRepository, kind string) ([]*T, error) {
+	ids, err := r.client.SMembers(ctx, r.index(kind)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis scan %s: %w", kind, err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.key(kind, id)
+	}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis scan %s: %w", kind, err)
+	}
+
+	items := make([]*T, 0, len(values))
+	var gone []any
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			gone = append(gone, ids[i])
+			continue
+		}
+		item := new(T)
+		if err := json.Unmarshal([]byte(data), item); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", keys[i], err)
+		}
+		items = append(items, item)
+	}
+
+	if len(gone) > 0 {
+		if err := r.client.SRem(ctx, r.index(kind), gone...).Err(); err != nil {
+			logging.From(ctx, r.logger).Warn("failed to drop expired keys from index", zap.String("kind", kind), zap.Error(err))
+		}
+	}
+	return items, nil
+}
+
+// UserRepository implementation
+
+func (r *RedisRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	if err := r.create(ctx, redisUsers, user.UserID.String(), user, 0); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			logging.From(ctx, r.logger).Warn("user already exists", zap.String("user_id", user.UserID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("user created",
+		zap.String("user_id", user.UserID.String()),
+		zap.String("username", user.Username),
+		zap.String("team_name", user.TeamName),
+		zap.Bool("is_active", user.IsActive),
+	)
+	return nil
+}
+
+func (r *RedisRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	if err := r.replace(ctx, redisUsers, user.UserID.String(), user); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("user not found for update", zap.String("user_id", user.UserID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("user updated",
+		zap.String("user_id", user.UserID.String()),
+		zap.String("team_name", user.TeamName),
+		zap.Bool("is_active", user.IsActive),
+	)
+	return nil
+}
+
+func (r *RedisRepository) DeleteUser(ctx context.Context, userID uuid.UUID, deletedAt time.Time) error {
+	key := r.key(redisUsers, userID.String())
+	err := r.watch(ctx, func(tx *redis.Tx) error {
+		var user entity.User
+		if err := r.get(ctx, tx, key, &user); err != nil {
+			return err
+		}
+		user.DeletedAt = &deletedAt
+		data, err := json.Marshal(&user)
+		if err != nil {
+			return fmt.Errorf("encode user %s: %w", userID, err)
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, 0)
+			return nil
+		})
+		return err
+	}, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("user not found for delete", zap.String("user_id", userID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("user deleted", zap.String("user_id", userID.String()))
+	return nil
+}
+
+func (r *RedisRepository) GetUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	var user entity.User
+	if err := r.get(ctx, r.client, r.key(redisUsers, userID.String()), &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *RedisRepository) UserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return r.exists(ctx, r.key(redisUsers, userID.String()))
+}
+
+func (r *RedisRepository) GetUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
+	users, err := loadAll[entity.User](ctx, r, redisUsers)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(users, func(user *entity.User) bool {
+		return user.TeamName != teamName
+	}), nil
+}
+
+func (r *RedisRepository) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*entity.User, error) {
+	users := make([]*entity.User, 0, len(userIDs))
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = r.key(redisUsers, id.String())
+	}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis get users: %w", err)
+	}
+
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var user entity.User
+		if err := json.Unmarshal([]byte(data), &user); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", keys[i], err)
+		}
+		users = append(users, &user)
+	}
+	return users, nil
+}
+
+func (r *RedisRepository) ListUsers(ctx context.Context, filter entity.UserFilter) ([]*entity.User, int, error) {
+	users, err := loadAll[entity.User](ctx, r, redisUsers)
+	if err != nil {
+		return nil, 0, err
+	}
+	users, total := listUsers(users, filter)
+	return users, total, nil
+}
+
+// TeamRepository implementation
+
+func (r *RedisRepository) CreateTeam(ctx context.Context, team *entity.Team) error {
+	if err := r.create(ctx, redisTeams, team.TeamName, team, 0); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			logging.From(ctx, r.logger).Warn("team already exists", zap.String("team_name", team.TeamName))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("team created",
+		zap.String("team_name", team.TeamName),
+		zap.Int("members_count", len(team.Members)),
+	)
+	return nil
+}
+
+func (r *RedisRepository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	if err := r.replace(ctx, redisTeams, team.TeamName, team); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("team not found for update", zap.String("team_name", team.TeamName))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("team updated",
+		zap.String("team_name", team.TeamName),
+		zap.Int("members_count", len(team.Members)),
+	)
+	return nil
+}
+
+func (r *RedisRepository) GetTeam(ctx context.Context, teamName string) (*entity.Team, error) {
+	var team entity.Team
+	if err := r.get(ctx, r.client, r.key(redisTeams, teamName), &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *RedisRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	return r.exists(ctx, r.key(redisTeams, teamName))
+}
+
+func (r *RedisRepository) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	teams, err := loadAll[entity.Team](ctx, r, redisTeams)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(teams, func(a, b *entity.Team) int {
+		return strings.Compare(a.TeamName, b.TeamName)
+	})
+	return teams, nil
+}
+
+func (r *RedisRepository) ListTeamSummaries(ctx context.Context, filter entity.TeamListFilter) ([]entity.TeamSummary, int, error) {
+	teams, err := loadAll[entity.Team](ctx, r, redisTeams)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Only open PRs are counted.
+	prs, err := r.pullRequestsIn(ctx, r.key(redisPRsByStatus, string(entity.StatusOpen)))
+	if err != nil {
+		return nil, 0, err
+	}
+	summaries, total := summarizeTeams(teams, prs, filter)
+	return summaries, total, nil
+}
+
+// PullRequestRepository implementation
+
+func (r *RedisRepository) CreatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	if err := checkReviewers(ctx, r.logger, pr); err != nil {
+		return err
+	}
+
+	// Archived IDs stay taken; the check races with a concurrent archive
+	// of the same ID, which is fine since only merged PRs are archived.
+	archived, err := r.exists(ctx, r.key(redisArchivedPullRequests, pr.PullRequestID.String()))
+	if err != nil {
+		return err
+	}
+	if archived {
+		logging.From(ctx, r.logger).Warn("pull request already exists", zap.String("pr_id", pr.PullRequestID.String()))
+		return ErrAlreadyExists
+	}
+
+	stored := *pr
+	stored.Version = 1
+	if err := r.createPullRequest(ctx, &stored); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			logging.From(ctx, r.logger).Warn("pull request already exists", zap.String("pr_id", pr.PullRequestID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("pull request created",
+		zap.String("pr_id", pr.PullRequestID.String()),
+		zap.String("pr_name", pr.PullRequestName),
+		zap.String("author_id", pr.AuthorID.String()),
+		zap.Int("reviewers_count", len(pr.AssignedReviewers)),
+	)
+	pr.Version = 1
+	return nil
+}
+
+// createPullRequest is create for a PR, whose indexes and open reviews are
+// updated in the same transaction.
+func (r *RedisRepository) createPullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	id := pr.PullRequestID.String()
+	data, err := json.Marshal(pr)
+	if err != nil {
+		return fmt.Errorf("encode pull request %s: %w", id, err)
+	}
+
+	key := r.key(redisPullRequests, id)
+	return r.watch(ctx, func(tx *redis.Tx) error {
+		n, err := tx.Exists(ctx, key).Result()
+		if err != nil {
+			return fmt.Errorf("redis exists %s: %w", key, err)
+		}
+		if n > 0 {
+			return ErrAlreadyExists
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, 0)
+			pipe.SAdd(ctx, r.index(redisPullRequests), id)
+			r.trackPullRequestIndexes(ctx, pipe, nil, pr)
+			r.trackOpenReviews(ctx, pipe, nil, pr)
+			return nil
+		})
+		return err
+	}, key)
+}
+
+func (r *RedisRepository) GetPullRequest(ctx context.Context, prID uuid.UUID) (*entity.PullRequest, error) {
+	var pr entity.PullRequest
+	err := r.get(ctx, r.client, r.key(redisPullRequests, prID.String()), &pr)
+	if errors.Is(err, ErrNotFound) {
+		err = r.get(ctx, r.client, r.key(redisArchivedPullRequests, prID.String()), &pr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+func (r *RedisRepository) UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	return r.updatePullRequest(ctx, pr, nil)
+}
+
+func (r *RedisRepository) UpdatePullRequestIf(ctx context.Context, pr *entity.PullRequest, expectedVersion int) error {
+	return r.updatePullRequest(ctx, pr, &expectedVersion)
+}
+
+// updatePullRequest stores pr with the next version, if the stored version
+// is expectedVersion when one is given.
+func (r *RedisRepository) updatePullRequest(ctx context.Context, pr *entity.PullRequest, expectedVersion *int) error {
+	if err := checkReviewers(ctx, r.logger, pr); err != nil {
+		return err
+	}
+
+	key := r.key(redisPullRequests, pr.PullRequestID.String())
+	var version int
+	err := r.watch(ctx, func(tx *redis.Tx) error {
+		var current entity.PullRequest
+		if err := r.get(ctx, tx, key, &current); err != nil {
+			return err
+		}
+		if expectedVersion != nil && current.Version != *expectedVersion {
+			logging.From(ctx, r.logger).Info("pull request version conflict",
+				zap.String("pr_id", pr.PullRequestID.String()),
+				zap.Int("expected_version", *expectedVersion),
+				zap.Int("stored_version", current.Version),
+			)
+			return ErrConflict
+		}
+
+		stored := *pr
+		stored.Version = current.Version + 1
+		data, err := json.Marshal(&stored)
+		if err != nil {
+			return fmt.Errorf("encode pull request %s: %w", pr.PullRequestID, err)
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, 0)
+			r.trackPullRequestIndexes(ctx, pipe, &current, &stored)
+			r.trackOpenReviews(ctx, pipe, &current, &stored)
+			return nil
+		})
+		version = stored.Version
+		return err
+	}, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("pull request not found for update", zap.String("pr_id", pr.PullRequestID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("pull request updated",
+		zap.String("pr_id", pr.PullRequestID.String()),
+		zap.String("status", string(pr.Status)),
+		zap.Int("version", version),
+	)
+	pr.Version = version
+	return nil
+}
+
+func (r *RedisRepository) GetPullRequestsByReviewer(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	prs, err := r.pullRequestsIn(ctx, r.key(redisPRsByReviewer, userID.String()))
+	if err != nil {
+		return nil, err
+	}
+	prs = slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+		return !filter.Matches(pr)
+	})
+	sortPullRequests(prs, filter.SortBy, filter.Order)
+	return prs, nil
+}
+
+func (r *RedisRepository) GetPullRequestsByAuthor(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	prs, err := r.pullRequestsIn(ctx, r.key(redisPRsByAuthor, userID.String()))
+	if err != nil {
+		return nil, err
+	}
+	prs = slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+		return !filter.Matches(pr)
+	})
+	sortPullRequests(prs, filter.SortBy, filter.Order)
+	return prs, nil
+}
+
+func (r *RedisRepository) GetPullRequestsByStatus(ctx context.Context, status entity.PullRequestStatus) ([]*entity.PullRequest, error) {
+	return r.pullRequestsIn(ctx, r.key(redisPRsByStatus, string(status)))
+}
+
+func (r *RedisRepository) GetPullRequestsByTeam(ctx context.Context, teamName string, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	team, err := r.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	prs, err := r.pullRequestsByAuthors(ctx, team.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	prs = slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+		return !filter.Matches(pr)
+	})
+	sortPullRequests(prs, filter.SortBy, filter.Order)
+	return prs, nil
+}
+
+func (r *RedisRepository) ListPullRequests(ctx context.Context, filter entity.PullRequestListFilter) ([]*entity.PullRequest, error) {
+	var members []uuid.UUID
+	if filter.TeamName != "" {
+		team, err := r.GetTeam(ctx, filter.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		members = team.Members
+	}
+
+	// Only the PRs in the indexes of the filter are read; listPullRequests
+	// checks the rest of it.
+	var sets []string
+	if filter.Status != nil {
+		sets = append(sets, r.key(redisPRsByStatus, string(*filter.Status)))
+	}
+	if filter.AuthorID != nil {
+		sets = append(sets, r.key(redisPRsByAuthor, filter.AuthorID.String()))
+	}
+	if filter.ReviewerID != nil {
+		sets = append(sets, r.key(redisPRsByReviewer, filter.ReviewerID.String()))
+	}
+	var prs []*entity.PullRequest
+	var err error
+	switch {
+	case len(sets) > 0:
+		prs, err = r.pullRequestsIn(ctx, sets...)
+	case filter.TeamName != "":
+		prs, err = r.pullRequestsByAuthors(ctx, members)
+	default:
+		prs, err = loadAll[entity.PullRequest](ctx, r, redisPullRequests)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.TeamName != "" {
+		prs = slices.DeleteFunc(prs, func(pr *entity.PullRequest) bool {
+			return !slices.Contains(members, pr.AuthorID)
+		})
+	}
+	return listPullRequests(prs, filter), nil
+}
+
+func (r *RedisRepository) GetPullRequestsByMilestone(ctx context.Context, milestoneID uuid.UUID) ([]*entity.PullRequest, error) {
+	prs, err := r.pullRequestsIn(ctx, r.key(redisPRsByMilestone, milestoneID.String()))
+	if err != nil {
+		return nil, err
+	}
+	sortPullRequests(prs, entity.SortByCreatedAt, entity.SortAsc)
+	return prs, nil
+}
+
+func (r *RedisRepository) PRExists(ctx context.Context, prID uuid.UUID) (bool, error) {
+	return r.exists(ctx, r.key(redisPullRequests, prID.String()), r.key(redisArchivedPullRequests, prID.String()))
+}
+
+// ArchivePullRequest renames the PR's key and drops it from the indexes.
+func (r *RedisRepository) ArchivePullRequest(ctx context.Context, prID uuid.UUID) error {
+	id := prID.String()
+	key := r.key(redisPullRequests, id)
+	err := r.watch(ctx, func(tx *redis.Tx) error {
+		var pr entity.PullRequest
+		if err := r.get(ctx, tx, key, &pr); err != nil {
+			return err
+		}
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Rename(ctx, key, r.key(redisArchivedPullRequests, id))
+			pipe.SRem(ctx, r.index(redisPullRequests), id)
+			r.trackPullRequestIndexes(ctx, pipe, &pr, nil)
+			r.trackOpenReviews(ctx, pipe, &pr, nil)
+			return nil
+		})
+		return err
+	}, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("pull request not found for archive", zap.String("pr_id", id))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("pull request archived", zap.String("pr_id", id))
+	return nil
+}
+
+func (r *RedisRepository) DeletePullRequest(ctx context.Context, prID uuid.UUID) error {
+	id := prID.String()
+	key := r.key(redisPullRequests, id)
+	var deleted *redis.IntCmd
+	err := r.watch(ctx, func(tx *redis.Tx) error {
+		// An archived PR is not counted.
+		var pr *entity.PullRequest
+		var current entity.PullRequest
+		if err := r.get(ctx, tx, key, &current); err == nil {
+			pr = &current
+		} else if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			deleted = pipe.Del(ctx, key, r.key(redisArchivedPullRequests, id))
+			pipe.SRem(ctx, r.index(redisPullRequests), id)
+			r.trackPullRequestIndexes(ctx, pipe, pr, nil)
+			r.trackOpenReviews(ctx, pipe, pr, nil)
+			return nil
+		})
+		return err
+	}, key)
+	if err != nil {
+		return fmt.Errorf("redis delete pull request %s: %w", id, err)
+	}
+	if deleted.Val() == 0 {
+		logging.From(ctx, r.logger).Warn("pull request not found for delete", zap.String("pr_id", id))
+		return ErrNotFound
+	}
+
+	logging.From(ctx, r.logger).Info("pull request deleted", zap.String("pr_id", id))
+	return nil
+}
+
+// PurgeArchivedPullRequests scans the archived PRs, which have no index.
+// A PR archived or deleted during the scan is simply skipped.
+func (r *RedisRepository) PurgeArchivedPullRequests(ctx context.Context, olderThan time.Time) (int, error) {
+	var keys []string
+	iter := r.client.Scan(ctx, 0, r.key(redisArchivedPullRequests, "*"), 0).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		var pr entity.PullRequest
+		err := r.get(ctx, r.client, key, &pr)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if pr.MergedAt != nil && pr.MergedAt.Before(olderThan) {
+			keys = append(keys, key)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return 0, fmt.Errorf("redis scan %s: %w", redisArchivedPullRequests, err)
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	deleted, err := r.client.Del(ctx, keys...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("redis purge archived pull requests: %w", err)
+	}
+	logging.From(ctx, r.logger).Info("purged archived pull requests", zap.Int64("deleted", deleted))
+	return int(deleted), nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const redisAPITokens = "api_tokens"
+
+// APITokenRepository implementation. Tokens are keyed by the hash of their
+// secret and expire with the token, so deleting one by ID scans them;
+// there are few.
+
+func (r *RedisRepository) CreateAPIToken(ctx context.Context, token *entity.APIToken) error {
+	var ttl time.Duration
+	if token.ExpiresAt != nil {
+		ttl = time.Until(*token.ExpiresAt)
+		if ttl <= 0 {
+			return nil
+		}
+	}
+	return r.create(ctx, redisAPITokens, token.SecretHash, token, ttl)
+}
+
+func (r *RedisRepository) GetAPIToken(ctx context.Context, secretHash string) (*entity.APIToken, error) {
+	var token entity.APIToken
+	if err := r.get(ctx, r.client, r.key(redisAPITokens, secretHash), &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *RedisRepository) ListAPITokens(ctx context.Context, orgID string) ([]*entity.APIToken, error) {
+	tokens, err := loadAll[entity.APIToken](ctx, r, redisAPITokens)
+	if err != nil {
+		return nil, err
+	}
+	return listAPITokens(tokens, orgID), nil
+}
+
+func (r *RedisRepository) DeleteAPIToken(ctx context.Context, orgID string, id uuid.UUID) error {
+	tokens, err := loadAll[entity.APIToken](ctx, r, redisAPITokens)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token.ID != id || token.OrgID != orgID {
+			continue
+		}
+		var deleted *redis.IntCmd
+		_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			deleted = pipe.Del(ctx, r.key(redisAPITokens, token.SecretHash))
+			pipe.SRem(ctx, r.index(redisAPITokens), token.SecretHash)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("redis delete API token %s: %w", id, err)
+		}
+		if deleted.Val() == 0 {
+			return ErrNotFound
+		}
+		return nil
+	}
+	return ErrNotFound
+}
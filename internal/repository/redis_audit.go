@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	redisRoles = "roles"
+	redisAudit = "audit"
+)
+
+// RoleRepository implementation
+
+func (r *RedisRepository) GrantRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	if err := r.requireUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := r.client.SAdd(ctx, r.key(redisRoles, userID.String()), string(role)).Err(); err != nil {
+		return fmt.Errorf("redis grant role: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	if err := r.requireUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := r.client.SRem(ctx, r.key(redisRoles, userID.String()), string(role)).Err(); err != nil {
+		return fmt.Errorf("redis revoke role: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]entity.Role, error) {
+	exists, err := r.UserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	members, err := r.client.SMembers(ctx, r.key(redisRoles, userID.String())).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis get roles: %w", err)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	roles := make([]entity.Role, len(members))
+	for i, member := range members {
+		roles[i] = entity.Role(member)
+	}
+	slices.Sort(roles)
+	return roles, nil
+}
+
+func (r *RedisRepository) requireUser(ctx context.Context, userID uuid.UUID) error {
+	exists, err := r.UserExists(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		logging.From(ctx, r.logger).Warn("user not found for role change", zap.String("user_id", userID.String()))
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AuditRepository implementation
+
+// AppendAudit pushes the entry to a list kept in append order, so listing
+// newest first is a reverse scan.
+func (r *RedisRepository) AppendAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode audit entry: %w", err)
+	}
+	if err := r.client.RPush(ctx, r.prefix+redisAudit, data).Err(); err != nil {
+		return fmt.Errorf("redis append audit: %w", err)
+	}
+	return nil
+}
+
+// ListAudit returns matching entries newest first, along with the number of
+// matches before pagination.
+func (r *RedisRepository) ListAudit(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEntry, int, error) {
+	entries, err := r.loadAudit(ctx, r.client)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matched := make([]*entity.AuditEntry, 0)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if filter.Matches(entries[i]) {
+			matched = append(matched, entries[i])
+		}
+	}
+
+	total := len(matched)
+	from := min(max(filter.Offset, 0), total)
+	to := total
+	if filter.Limit > 0 {
+		to = min(from+filter.Limit, total)
+	}
+	return matched[from:to], total, nil
+}
+
+func (r *RedisRepository) DeleteAuditBefore(ctx context.Context, before time.Time) (int, error) {
+	key := r.prefix + redisAudit
+	var deleted int
+	err := r.watch(ctx, func(tx *redis.Tx) error {
+		entries, err := r.loadAudit(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		kept := make([]any, 0, len(entries))
+		for _, entry := range entries {
+			if entry.OccurredAt.Before(before) {
+				continue
+			}
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return fmt.Errorf("encode audit entry: %w", err)
+			}
+			kept = append(kept, data)
+		}
+		deleted = len(entries) - len(kept)
+		if deleted == 0 {
+			return nil
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, key)
+			if len(kept) > 0 {
+				pipe.RPush(ctx, key, kept...)
+			}
+			return nil
+		})
+		return err
+	}, key)
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+func (r *RedisRepository) loadAudit(ctx context.Context, c redis.Cmdable) ([]*entity.AuditEntry, error) {
+	values, err := c.LRange(ctx, r.prefix+redisAudit, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis list audit: %w", err)
+	}
+
+	entries := make([]*entity.AuditEntry, len(values))
+	for i, value := range values {
+		var entry entity.AuditEntry
+		if err := json.Unmarshal([]byte(value), &entry); err != nil {
+			return nil, fmt.Errorf("decode audit entry: %w", err)
+		}
+		entries[i] = &entry
+	}
+	return entries, nil
+}
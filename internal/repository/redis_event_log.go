@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"avito-intro/internal/entity"
+)
+
+const (
+	redisEvents   = "events"
+	redisEventSeq = "events_seq"
+)
+
+// EventLogRepository implementation
+
+// AppendEvent takes the next Seq from a counter shared by all instances.
+// Instances may push in a different order than they took their Seq, so
+// ListEvents sorts.
+func (r *RedisRepository) AppendEvent(ctx context.Context, event *entity.StorageEvent) error {
+	seq, err := r.client.Incr(ctx, r.prefix+redisEventSeq).Result()
+	if err != nil {
+		return fmt.Errorf("redis next event seq: %w", err)
+	}
+
+	stored := *event
+	stored.Seq = seq
+	data, err := json.Marshal(&stored)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	if err := r.client.RPush(ctx, r.prefix+redisEvents, data).Err(); err != nil {
+		return fmt.Errorf("redis append event: %w", err)
+	}
+	event.Seq = seq
+	return nil
+}
+
+func (r *RedisRepository) ListEvents(ctx context.Context, filter entity.StorageEventFilter) ([]*entity.StorageEvent, error) {
+	values, err := r.client.LRange(ctx, r.prefix+redisEvents, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis list events: %w", err)
+	}
+
+	events := make([]*entity.StorageEvent, 0)
+	for _, value := range values {
+		var event entity.StorageEvent
+		if err := json.Unmarshal([]byte(value), &event); err != nil {
+			return nil, fmt.Errorf("decode event: %w", err)
+		}
+		if filter.Matches(&event) {
+			events = append(events, &event)
+		}
+	}
+
+	slices.SortFunc(events, func(a, b *entity.StorageEvent) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+	return events, nil
+}
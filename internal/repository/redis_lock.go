@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+var _ Locker = (*RedisRepository)(nil)
+
+const (
+	redisLocks = "locks"
+	// redisLockTTL bounds how long a lock outlives an instance that died
+	// holding it. A live holder renews it every third of the TTL.
+	redisLockTTL = 30 * time.Second
+	// redisLockPoll is how often a waiter tries to take a held lock.
+	redisLockPoll = 50 * time.Millisecond
+)
+
+// The lock value is the holder's token, so only the holder can renew or
+// release the lock, even after it expired and was taken by someone else.
+var (
+	redisRenewLock = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	redisReleaseLock = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// WithLock takes the lock with SET NX, so it holds across every instance
+// sharing the database. The lock expires after redisLockTTL unless renewed,
+// and it is renewed while fn runs.
+func (r *RedisRepository) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	key := r.key(redisLocks, name)
+	token := uuid.NewString()
+
+	for {
+		acquired, err := r.client.SetNX(ctx, key, token, redisLockTTL).Result()
+		if err != nil {
+			return err
+		}
+		if acquired {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(redisLockPoll):
+		}
+	}
+
+	stop := make(chan struct{})
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		r.renewLock(ctx, key, token, stop)
+	}()
+	defer func() {
+		close(stop)
+		<-renewed
+		// Release even if the caller has gone away, or waiters would stall
+		// until the lock expires.
+		if err := redisReleaseLock.Run(context.WithoutCancel(ctx), r.client, []string{key}, token).Err(); err != nil {
+			logging.From(ctx, r.logger).Warn("failed to release lock", zap.String("lock", name), zap.Error(err))
+		}
+	}()
+
+	return fn(ctx)
+}
+
+func (r *RedisRepository) renewLock(ctx context.Context, key, token string, stop <-chan struct{}) {
+	ticker := time.NewTicker(redisLockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			renewed, err := redisRenewLock.Run(context.WithoutCancel(ctx), r.client, []string{key}, token, redisLockTTL.Milliseconds()).Int()
+			if err != nil || renewed == 0 {
+				logging.From(ctx, r.logger).Warn("failed to renew lock", zap.String("key", key), zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// MilestoneRepository implementation
+
+func (r *RedisRepository) CreateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	if err := r.create(ctx, redisMilestones, milestone.MilestoneID.String(), milestone, 0); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			logging.From(ctx, r.logger).Warn("milestone already exists", zap.String("milestone_id", milestone.MilestoneID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("milestone created",
+		zap.String("milestone_id", milestone.MilestoneID.String()),
+		zap.String("title", milestone.Title),
+	)
+	return nil
+}
+
+func (r *RedisRepository) GetMilestone(ctx context.Context, milestoneID uuid.UUID) (*entity.Milestone, error) {
+	var milestone entity.Milestone
+	if err := r.get(ctx, r.client, r.key(redisMilestones, milestoneID.String()), &milestone); err != nil {
+		return nil, err
+	}
+	return &milestone, nil
+}
+
+func (r *RedisRepository) ListMilestones(ctx context.Context) ([]*entity.Milestone, error) {
+	milestones, err := loadAll[entity.Milestone](ctx, r, redisMilestones)
+	if err != nil {
+		return nil, err
+	}
+	if milestones == nil {
+		milestones = make([]*entity.Milestone, 0)
+	}
+	slices.SortFunc(milestones, func(a, b *entity.Milestone) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return milestones, nil
+}
+
+func (r *RedisRepository) UpdateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	if err := r.replace(ctx, redisMilestones, milestone.MilestoneID.String(), milestone); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("milestone not found for update", zap.String("milestone_id", milestone.MilestoneID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("milestone updated", zap.String("milestone_id", milestone.MilestoneID.String()))
+	return nil
+}
+
+// DeleteMilestone removes the milestone and detaches it from its pull
+// requests in one transaction.
+func (r *RedisRepository) DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error {
+	attached, err := r.GetPullRequestsByMilestone(ctx, milestoneID)
+	if err != nil {
+		return err
+	}
+
+	milestoneKey := r.key(redisMilestones, milestoneID.String())
+	keys := []string{milestoneKey}
+	for _, pr := range attached {
+		keys = append(keys, r.key(redisPullRequests, pr.PullRequestID.String()))
+	}
+
+	err = r.watch(ctx, func(tx *redis.Tx) error {
+		n, err := tx.Exists(ctx, milestoneKey).Result()
+		if err != nil {
+			return fmt.Errorf("redis exists %s: %w", milestoneKey, err)
+		}
+		if n == 0 {
+			return ErrNotFound
+		}
+
+		detached := make(map[string][]byte, len(attached))
+		var removed []string
+		for _, key := range keys[1:] {
+			var pr entity.PullRequest
+			if err := r.get(ctx, tx, key, &pr); err != nil {
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				return err
+			}
+			if pr.MilestoneID == nil || *pr.MilestoneID != milestoneID {
+				continue
+			}
+			removed = append(removed, pr.PullRequestID.String())
+			pr.MilestoneID = nil
+			pr.Version++
+			data, err := json.Marshal(&pr)
+			if err != nil {
+				return fmt.Errorf("encode pull request %s: %w", pr.PullRequestID, err)
+			}
+			detached[key] = data
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for key, data := range detached {
+				pipe.Set(ctx, key, data, 0)
+			}
+			if len(removed) > 0 {
+				pipe.SRem(ctx, r.key(redisPRsByMilestone, milestoneID.String()), removed)
+			}
+			pipe.Del(ctx, milestoneKey)
+			pipe.SRem(ctx, r.index(redisMilestones), milestoneID.String())
+			return nil
+		})
+		return err
+	}, keys...)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logging.From(ctx, r.logger).Warn("milestone not found for delete", zap.String("milestone_id", milestoneID.String()))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("milestone deleted",
+		zap.String("milestone_id", milestoneID.String()),
+		zap.Int("detached_prs", len(attached)),
+	)
+	return nil
+}
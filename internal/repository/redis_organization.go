@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"go.uber.org/zap"
+)
+
+const redisOrganizations = "organizations"
+
+// OrganizationRepository implementation
+
+func (r *RedisRepository) CreateOrganization(ctx context.Context, org *entity.Organization) error {
+	if err := r.create(ctx, redisOrganizations, org.OrgID, org, 0); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			logging.From(ctx, r.logger).Warn("organization already exists", zap.String("org_id", org.OrgID))
+		}
+		return err
+	}
+
+	logging.From(ctx, r.logger).Info("organization created", zap.String("org_id", org.OrgID))
+	return nil
+}
+
+func (r *RedisRepository) GetOrganization(ctx context.Context, orgID string) (*entity.Organization, error) {
+	var org entity.Organization
+	if err := r.get(ctx, r.client, r.key(redisOrganizations, orgID), &org); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *RedisRepository) ListOrganizations(ctx context.Context) ([]*entity.Organization, error) {
+	orgs, err := loadAll[entity.Organization](ctx, r, redisOrganizations)
+	if err != nil {
+		return nil, err
+	}
+	return sortOrganizations(orgs), nil
+}
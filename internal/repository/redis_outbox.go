@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const redisOutbox = "outbox"
+
+// OutboxRepository implementation
+
+// AppendOutbox stores the message right after the change it describes:
+// Redis has no transactions spanning calls, so a crash in between loses the
+// message.
+func (r *RedisRepository) AppendOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	return r.create(ctx, redisOutbox, msg.ID.String(), msg, 0)
+}
+
+func (r *RedisRepository) ListOutbox(ctx context.Context, due time.Time, limit int) ([]*entity.OutboxMessage, error) {
+	messages, err := loadAll[entity.OutboxMessage](ctx, r, redisOutbox)
+	if err != nil {
+		return nil, err
+	}
+	return listOutbox(messages, due, limit), nil
+}
+
+func (r *RedisRepository) UpdateOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	return r.replace(ctx, redisOutbox, msg.ID.String(), msg)
+}
+
+func (r *RedisRepository) DeleteOutbox(ctx context.Context, id uuid.UUID) error {
+	var deleted *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		deleted = pipe.Del(ctx, r.key(redisOutbox, id.String()))
+		pipe.SRem(ctx, r.index(redisOutbox), id.String())
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis delete outbox message %s: %w", id, err)
+	}
+	if deleted.Val() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
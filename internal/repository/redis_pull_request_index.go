@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Secondary indexes of the stored PRs: a set of PR IDs per status,
+	// author, reviewer and milestone, so listing PRs reads only the ones
+	// asked for. Archived PRs are in none of them.
+	redisPRsByStatus    = "pull_requests_by_status"
+	redisPRsByAuthor    = "pull_requests_by_author"
+	redisPRsByReviewer  = "pull_requests_by_reviewer"
+	redisPRsByMilestone = "pull_requests_by_milestone"
+	// redisPRIndexesBuilt marks the indexes as covering the PRs stored
+	// before they were introduced.
+	redisPRIndexesBuilt = "pull_request_indexes_built"
+)
+
+// pullRequestIndexes returns the secondary index sets pr belongs to.
+func (r *RedisRepository) pullRequestIndexes(pr *entity.PullRequest) []string {
+	if pr == nil {
+		return nil
+	}
+	sets := []string{
+		r.key(redisPRsByStatus, string(pr.Status)),
+		r.key(redisPRsByAuthor, pr.AuthorID.String()),
+	}
+	for _, id := range pr.AssignedReviewers {
+		sets = append(sets, r.key(redisPRsByReviewer, id.String()))
+	}
+	if pr.MilestoneID != nil {
+		sets = append(sets, r.key(redisPRsByMilestone, pr.MilestoneID.String()))
+	}
+	return sets
+}
+
+// trackPullRequestIndexes queues the index updates for the stored PR old
+// being replaced by updated into pipe, the transaction that writes the PR.
+// Either may be nil, for a PR being created or removed.
+func (r *RedisRepository) trackPullRequestIndexes(ctx context.Context, pipe redis.Pipeliner, old, updated *entity.PullRequest) {
+	before := r.pullRequestIndexes(old)
+	after := r.pullRequestIndexes(updated)
+	for _, set := range before {
+		if !slices.Contains(after, set) {
+			pipe.SRem(ctx, set, old.PullRequestID.String())
+		}
+	}
+	for _, set := range after {
+		if !slices.Contains(before, set) {
+			pipe.SAdd(ctx, set, updated.PullRequestID.String())
+		}
+	}
+}
+
+// pullRequestsIn loads the PRs in every one of the index sets.
+func (r *RedisRepository) pullRequestsIn(ctx context.Context, sets ...string) ([]*entity.PullRequest, error) {
+	if err := r.buildPullRequestIndexes(ctx); err != nil {
+		return nil, err
+	}
+	ids, err := r.client.SInter(ctx, sets...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis scan pull request indexes: %w", err)
+	}
+	return r.loadPullRequests(ctx, ids)
+}
+
+// pullRequestsByAuthors loads the PRs authored by any of authorIDs.
+func (r *RedisRepository) pullRequestsByAuthors(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.PullRequest, error) {
+	if len(authorIDs) == 0 {
+		return make([]*entity.PullRequest, 0), nil
+	}
+	if err := r.buildPullRequestIndexes(ctx); err != nil {
+		return nil, err
+	}
+	sets := make([]string, len(authorIDs))
+	for i, id := range authorIDs {
+		sets[i] = r.key(redisPRsByAuthor, id.String())
+	}
+	ids, err := r.client.SUnion(ctx, sets...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis scan pull request indexes: %w", err)
+	}
+	return r.loadPullRequests(ctx, ids)
+}
+
+// loadPullRequests reads the PRs with ids in one MGET. A PR removed since
+// its ID was read is skipped.
+func (r *RedisRepository) loadPullRequests(ctx context.Context, ids []string) ([]*entity.PullRequest, error) {
+	prs := make([]*entity.PullRequest, 0, len(ids))
+	if len(ids) == 0 {
+		return prs, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.key(redisPullRequests, id)
+	}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis get pull requests: %w", err)
+	}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var pr entity.PullRequest
+		if err := json.Unmarshal([]byte(data), &pr); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", keys[i], err)
+		}
+		prs = append(prs, &pr)
+	}
+	return prs, nil
+}
+
+// countPullRequests counts the stored PRs by status from the index sizes.
+func (r *RedisRepository) countPullRequests(ctx context.Context) (map[entity.PullRequestStatus]int, error) {
+	if err := r.buildPullRequestIndexes(ctx); err != nil {
+		return nil, err
+	}
+	statuses := entity.PullRequestStatuses()
+	cards := make([]*redis.IntCmd, len(statuses))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, status := range statuses {
+			cards[i] = pipe.SCard(ctx, r.key(redisPRsByStatus, string(status)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("redis count pull requests: %w", err)
+	}
+	counts := make(map[entity.PullRequestStatus]int)
+	for i, status := range statuses {
+		if n := cards[i].Val(); n > 0 {
+			counts[status] = int(n)
+		}
+	}
+	return counts, nil
+}
+
+// buildPullRequestIndexes adds the PRs stored before the indexes were
+// introduced to them unless that was done before. Each PR is indexed in a
+// WATCH transaction on its key, so a concurrent write to it makes that PR
+// start over instead of being indexed with stale values. Merged PRs that
+// were stored with an expiration are kept for good: the retention worker
+// removes them together with their index entries.
+func (r *RedisRepository) buildPullRequestIndexes(ctx context.Context) error {
+	marker := r.index(redisPRIndexesBuilt)
+	built, err := r.exists(ctx, marker)
+	if err != nil || built {
+		return err
+	}
+
+	ids, err := r.client.SMembers(ctx, r.index(redisPullRequests)).Result()
+	if err != nil {
+		return fmt.Errorf("redis scan %s: %w", redisPullRequests, err)
+	}
+	for _, id := range ids {
+		key := r.key(redisPullRequests, id)
+		err := r.watch(ctx, func(tx *redis.Tx) error {
+			var pr entity.PullRequest
+			if err := r.get(ctx, tx, key, &pr); err != nil {
+				return err
+			}
+			_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Persist(ctx, key)
+				for _, set := range r.pullRequestIndexes(&pr) {
+					pipe.SAdd(ctx, set, id)
+				}
+				return nil
+			})
+			return err
+		}, key)
+		if errors.Is(err, ErrNotFound) {
+			// Expired before this version; no longer listed anywhere.
+			if err := r.client.SRem(ctx, r.index(redisPullRequests), id).Err(); err != nil {
+				return fmt.Errorf("redis drop pull request %s from index: %w", id, err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("index pull request %s: %w", id, err)
+		}
+	}
+	return r.client.Set(ctx, marker, 1, 0).Err()
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	redisChecklists     = "checklist"
+	redisTeamOwners     = "team_owners"
+	redisComponents     = "components"
+	redisMergePolicies  = "merge_policy"
+	redisTeamSettings   = "team_settings"
+	redisGlobalSettings = "global_settings"
+)
+
+// setTeamValue stores v as the kind record of an existing team.
+func (r *RedisRepository) setTeamValue(ctx context.Context, kind, teamName string, v any) error {
+	exists, err := r.TeamExists(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		logging.From(ctx, r.logger).Warn("team not found", zap.String("team_name", teamName), zap.String("kind", kind))
+		return ErrNotFound
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s %s: %w", kind, teamName, err)
+	}
+	if err := r.client.Set(ctx, r.key(kind, teamName), data, 0).Err(); err != nil {
+		return fmt.Errorf("redis set %s %s: %w", kind, teamName, err)
+	}
+	return nil
+}
+
+// ChecklistRepository implementation
+
+func (r *RedisRepository) SetChecklistTemplate(ctx context.Context, template *entity.ChecklistTemplate) error {
+	return r.setTeamValue(ctx, redisChecklists, template.TeamName, template)
+}
+
+func (r *RedisRepository) GetChecklistTemplate(ctx context.Context, teamName string) (*entity.ChecklistTemplate, error) {
+	var template entity.ChecklistTemplate
+	if err := r.get(ctx, r.client, r.key(redisChecklists, teamName), &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// OwnershipRepository implementation
+
+func (r *RedisRepository) SetTeamOwners(ctx context.Context, teamName string, owners []uuid.UUID) error {
+	return r.setTeamValue(ctx, redisTeamOwners, teamName, owners)
+}
+
+func (r *RedisRepository) GetTeamOwners(ctx context.Context, teamName string) ([]uuid.UUID, error) {
+	var owners []uuid.UUID
+	if err := r.get(ctx, r.client, r.key(redisTeamOwners, teamName), &owners); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return owners, nil
+}
+
+func (r *RedisRepository) SetComponentOwners(ctx context.Context, teamName string, components []entity.ComponentOwner) error {
+	return r.setTeamValue(ctx, redisComponents, teamName, components)
+}
+
+func (r *RedisRepository) GetComponentOwners(ctx context.Context, teamName string) ([]entity.ComponentOwner, error) {
+	components := make([]entity.ComponentOwner, 0)
+	if err := r.get(ctx, r.client, r.key(redisComponents, teamName), &components); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return components, nil
+}
+
+// MergePolicyRepository implementation
+
+func (r *RedisRepository) SetMergePolicy(ctx context.Context, policy *entity.MergePolicy) error {
+	return r.setTeamValue(ctx, redisMergePolicies, policy.TeamName, policy)
+}
+
+func (r *RedisRepository) GetMergePolicy(ctx context.Context, teamName string) (*entity.MergePolicy, error) {
+	var policy entity.MergePolicy
+	if err := r.get(ctx, r.client, r.key(redisMergePolicies, teamName), &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// TeamSettingsRepository implementation
+
+func (r *RedisRepository) SetTeamSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	return r.setTeamValue(ctx, redisTeamSettings, settings.TeamName, settings)
+}
+
+func (r *RedisRepository) GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	var settings entity.TeamSettings
+	if err := r.get(ctx, r.client, r.key(redisTeamSettings, teamName), &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// GlobalSettingsRepository implementation
+
+func (r *RedisRepository) SetGlobalSettings(ctx context.Context, settings *entity.GlobalSettings) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("encode global settings: %w", err)
+	}
+	if err := r.client.Set(ctx, r.prefix+redisGlobalSettings, data, 0).Err(); err != nil {
+		return fmt.Errorf("redis set global settings: %w", err)
+	}
+
+	logging.From(ctx, r.logger).Info("global settings updated")
+	return nil
+}
+
+func (r *RedisRepository) GetGlobalSettings(ctx context.Context) (*entity.GlobalSettings, error) {
+	var settings entity.GlobalSettings
+	if err := r.get(ctx, r.client, r.prefix+redisGlobalSettings, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"avito-intro/internal/entity"
+)
+
+// Stats counts users by scanning them and PRs from the sizes of their
+// status indexes. Index sizes come from MEMORY
+// USAGE when the server supports it; entity values are not measured, so
+// EstimatedBytes is a lower bound.
+func (r *RedisRepository) Stats(ctx context.Context) (entity.StorageStats, error) {
+	users, err := loadAll[entity.User](ctx, r, redisUsers)
+	if err != nil {
+		return entity.StorageStats{}, err
+	}
+	teams, err := r.client.SCard(ctx, r.index(redisTeams)).Result()
+	if err != nil {
+		return entity.StorageStats{}, fmt.Errorf("redis count teams: %w", err)
+	}
+	prs, err := r.countPullRequests(ctx)
+	if err != nil {
+		return entity.StorageStats{}, err
+	}
+	var total int
+	for _, n := range prs {
+		total += n
+	}
+	reviewers, err := r.client.HLen(ctx, r.index(redisOpenReviews)).Result()
+	if err != nil {
+		return entity.StorageStats{}, fmt.Errorf("redis count open reviews: %w", err)
+	}
+
+	stats := entity.StorageStats{
+		Backend:      "redis",
+		Users:        len(users),
+		Teams:        int(teams),
+		PullRequests: prs,
+	}
+	for _, user := range users {
+		if user.IsActive && !user.IsDeleted() {
+			stats.ActiveUsers++
+		}
+	}
+
+	for _, index := range []struct {
+		name    string
+		kind    string
+		entries int
+	}{
+		{"users_by_id", redisUsers, len(users)},
+		{"teams_by_name", redisTeams, int(teams)},
+		{"pull_requests_by_id", redisPullRequests, total},
+		{"open_reviews_by_user", redisOpenReviews, int(reviewers)},
+	} {
+		bytes, _ := r.client.MemoryUsage(ctx, r.index(index.kind)).Result()
+		stats.Indexes = append(stats.Indexes, entity.IndexStats{
+			Name:           index.name,
+			Entries:        index.entries,
+			EstimatedBytes: bytes,
+		})
+		stats.EstimatedBytes += bytes
+	}
+	return stats, nil
+}
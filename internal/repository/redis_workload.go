@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisOpenReviews is a hash of the open review count of each reviewer
+	// by user ID. Reviewers whose count dropped to zero keep a 0 field.
+	redisOpenReviews = "open_reviews"
+	// redisOpenReviewsBuilt marks the hash as covering the PRs stored
+	// before it was introduced.
+	redisOpenReviewsBuilt = "open_reviews_built"
+)
+
+// trackOpenReviews queues the counter updates for the stored PR old being
+// replaced by updated into pipe, the transaction that writes the PR.
+func (r *RedisRepository) trackOpenReviews(ctx context.Context, pipe redis.Pipeliner, old, updated *entity.PullRequest) {
+	for id, n := range openReviewDelta(old, updated) {
+		pipe.HIncrBy(ctx, r.index(redisOpenReviews), id.String(), int64(n))
+	}
+}
+
+func (r *RedisRepository) CountOpenReviews(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	if err := r.buildOpenReviews(ctx); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+	fields := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		fields[i] = id.String()
+	}
+	values, err := r.client.HMGet(ctx, r.index(redisOpenReviews), fields...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis get open reviews: %w", err)
+	}
+	for i, id := range userIDs {
+		counts[id] = 0
+		s, ok := values[i].(string)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("decode open reviews of %s: %w", id, err)
+		}
+		counts[id] = n
+	}
+	return counts, nil
+}
+
+// buildOpenReviews recounts the open reviews from the stored PRs unless
+// that was done before. It watches the hash, so a PR write changing the
+// counts meanwhile makes it start over instead of being overwritten.
+func (r *RedisRepository) buildOpenReviews(ctx context.Context) error {
+	marker := r.index(redisOpenReviewsBuilt)
+	built, err := r.exists(ctx, marker)
+	if err != nil || built {
+		return err
+	}
+
+	hash := r.index(redisOpenReviews)
+	return r.watch(ctx, func(tx *redis.Tx) error {
+		prs, err := loadAll[entity.PullRequest](ctx, r, redisPullRequests)
+		if err != nil {
+			return err
+		}
+		counts := countOpenReviews(prs)
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, hash)
+			if len(counts) > 0 {
+				fields := make(map[string]any, len(counts))
+				for id, n := range counts {
+					fields[id.String()] = n
+				}
+				pipe.HSet(ctx, hash, fields)
+			}
+			pipe.Set(ctx, marker, 1, 0)
+			return nil
+		})
+		return err
+	}, hash, marker)
+}
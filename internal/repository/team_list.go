@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"slices"
+	"strings"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// summarizeTeams picks the page filter asks for out of teams, ordered by
+// name, and counts the open PRs of each listed team's members in prs. It
+// returns the summaries along with the number of teams before pagination.
+func summarizeTeams(teams []*entity.Team, prs []*entity.PullRequest, filter entity.TeamListFilter) ([]entity.TeamSummary, int) {
+	teams = slices.SortedFunc(slices.Values(teams), func(a, b *entity.Team) int {
+		return strings.Compare(a.TeamName, b.TeamName)
+	})
+
+	total := len(teams)
+	from := min(max(filter.Offset, 0), total)
+	to := total
+	if filter.Limit > 0 {
+		to = min(from+filter.Limit, total)
+	}
+
+	summaries := make([]entity.TeamSummary, 0, to-from)
+	teamOf := make(map[uuid.UUID]int)
+	for _, team := range teams[from:to] {
+		for _, id := range team.Members {
+			teamOf[id] = len(summaries)
+		}
+		summaries = append(summaries, entity.TeamSummary{
+			TeamName: team.TeamName,
+			Members:  len(team.Members),
+		})
+	}
+
+	for _, pr := range prs {
+		if pr.Status != entity.StatusOpen {
+			continue
+		}
+		if i, ok := teamOf[pr.AuthorID]; ok {
+			summaries[i].OpenPullRequests++
+		}
+	}
+	return summaries, total
+}
@@ -0,0 +1,549 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/tenant"
+
+	"github.com/google/uuid"
+)
+
+var ErrOrganizationNotFound = errors.New("organization not found")
+
+var (
+	_ Storage                = (*TenantRepository)(nil)
+	_ OrganizationRepository = (*TenantRepository)(nil)
+	_ Transactor             = (*TenantRepository)(nil)
+	_ Locker                 = (*TenantRepository)(nil)
+)
+
+// TenantRepository keeps a separate Storage per organization and routes
+// every call to the storage of the organization found in ctx. Calls without
+// an organization go to tenant.DefaultOrganization, backed by the storage
+// passed to NewTenantRepository. The other organizations are stored in that
+// storage too, so every instance sharing it sees them; newStorage opens the
+// storage of one on its first use.
+type TenantRepository struct {
+	registry   Storage
+	newStorage func(orgID string) Storage
+	defaultOrg entity.Organization
+
+	mu sync.RWMutex
+	// storages holds the storage of every organization used so far.
+	// Organizations are never deleted, so entries never go stale.
+	storages map[string]Storage
+}
+
+func NewTenantRepository(defaultStorage Storage, newStorage func(orgID string) Storage) *TenantRepository {
+	return &TenantRepository{
+		registry:   defaultStorage,
+		newStorage: newStorage,
+		defaultOrg: entity.Organization{
+			OrgID:     tenant.DefaultOrganization,
+			Name:      "Default organization",
+			CreatedAt: time.Now(),
+		},
+		storages: map[string]Storage{tenant.DefaultOrganization: defaultStorage},
+	}
+}
+
+func (t *TenantRepository) CreateOrganization(ctx context.Context, org *entity.Organization) error {
+	if org.OrgID == tenant.DefaultOrganization {
+		return ErrAlreadyExists
+	}
+	return t.registry.CreateOrganization(ctx, org)
+}
+
+func (t *TenantRepository) GetOrganization(ctx context.Context, orgID string) (*entity.Organization, error) {
+	if orgID == tenant.DefaultOrganization {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		org := t.defaultOrg
+		return &org, nil
+	}
+	return t.registry.GetOrganization(ctx, orgID)
+}
+
+func (t *TenantRepository) ListOrganizations(ctx context.Context) ([]*entity.Organization, error) {
+	stored, err := t.registry.ListOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defaultOrg := t.defaultOrg
+	orgs := append([]*entity.Organization{&defaultOrg}, stored...)
+	slices.SortFunc(orgs, func(a, b *entity.Organization) int {
+		return strings.Compare(a.OrgID, b.OrgID)
+	})
+	return orgs, nil
+}
+
+// API tokens are looked up before the organization of a request is known,
+// so the tokens of every organization are kept with the organizations.
+
+func (t *TenantRepository) CreateAPIToken(ctx context.Context, token *entity.APIToken) error {
+	return t.registry.CreateAPIToken(ctx, token)
+}
+
+func (t *TenantRepository) GetAPIToken(ctx context.Context, secretHash string) (*entity.APIToken, error) {
+	return t.registry.GetAPIToken(ctx, secretHash)
+}
+
+func (t *TenantRepository) ListAPITokens(ctx context.Context, orgID string) ([]*entity.APIToken, error) {
+	return t.registry.ListAPITokens(ctx, orgID)
+}
+
+func (t *TenantRepository) DeleteAPIToken(ctx context.Context, orgID string, id uuid.UUID) error {
+	return t.registry.DeleteAPIToken(ctx, orgID, id)
+}
+
+// storage returns the storage of the organization in ctx, opening it when
+// the organization was created since, possibly by another instance.
+func (t *TenantRepository) storage(ctx context.Context) (Storage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	orgID := tenant.OrganizationFromContext(ctx)
+
+	t.mu.RLock()
+	s, ok := t.storages[orgID]
+	t.mu.RUnlock()
+	if ok {
+		return s, nil
+	}
+
+	if _, err := t.registry.GetOrganization(ctx, orgID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrOrganizationNotFound, orgID)
+		}
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s, ok := t.storages[orgID]; ok {
+		return s, nil
+	}
+	s = t.newStorage(orgID)
+	t.storages[orgID] = s
+	return s, nil
+}
+
+// InTx runs fn in a transaction of the organization's storage.
+func (t *TenantRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return t.exec(ctx, func(s Storage) error {
+		return InTx(ctx, s, fn)
+	})
+}
+
+// WithLock takes the lock in the organization's storage, so locks of the
+// same name in different organizations are independent.
+func (t *TenantRepository) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return t.exec(ctx, func(s Storage) error {
+		return WithLock(ctx, s, name, fn)
+	})
+}
+
+func tenantRead[T any](ctx context.Context, t *TenantRepository, read func(Storage) (T, error)) (T, error) {
+	s, err := t.storage(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return read(s)
+}
+
+func (t *TenantRepository) exec(ctx context.Context, write func(Storage) error) error {
+	s, err := t.storage(ctx)
+	if err != nil {
+		return err
+	}
+	return write(s)
+}
+
+// Ping checks the storage of every organization used so far.
+func (t *TenantRepository) Ping(ctx context.Context) error {
+	t.mu.RLock()
+	storages := maps.Clone(t.storages)
+	t.mu.RUnlock()
+
+	for orgID, s := range storages {
+		if err := s.Ping(ctx); err != nil {
+			return fmt.Errorf("organization %s: %w", orgID, err)
+		}
+	}
+	return nil
+}
+
+func (t *TenantRepository) ListUsers(ctx context.Context, filter entity.UserFilter) ([]*entity.User, int, error) {
+	s, err := t.storage(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.ListUsers(ctx, filter)
+}
+
+func (t *TenantRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.CreateUser(ctx, user)
+	})
+}
+
+func (t *TenantRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.UpdateUser(ctx, user)
+	})
+}
+
+func (t *TenantRepository) DeleteUser(ctx context.Context, userID uuid.UUID, deletedAt time.Time) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.DeleteUser(ctx, userID, deletedAt)
+	})
+}
+
+func (t *TenantRepository) GetUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	return tenantRead(ctx, t, func(s Storage) (*entity.User, error) {
+		return s.GetUser(ctx, userID)
+	})
+}
+
+func (t *TenantRepository) UserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return tenantRead(ctx, t, func(s Storage) (bool, error) {
+		return s.UserExists(ctx, userID)
+	})
+}
+
+func (t *TenantRepository) GetUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.User, error) {
+		return s.GetUsersByTeam(ctx, teamName)
+	})
+}
+
+func (t *TenantRepository) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*entity.User, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.User, error) {
+		return s.GetUsersByIDs(ctx, userIDs)
+	})
+}
+
+func (t *TenantRepository) CreateTeam(ctx context.Context, team *entity.Team) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.CreateTeam(ctx, team)
+	})
+}
+
+func (t *TenantRepository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.UpdateTeam(ctx, team)
+	})
+}
+
+func (t *TenantRepository) GetTeam(ctx context.Context, teamName string) (*entity.Team, error) {
+	return tenantRead(ctx, t, func(s Storage) (*entity.Team, error) {
+		return s.GetTeam(ctx, teamName)
+	})
+}
+
+func (t *TenantRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	return tenantRead(ctx, t, func(s Storage) (bool, error) {
+		return s.TeamExists(ctx, teamName)
+	})
+}
+
+func (t *TenantRepository) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.Team, error) {
+		return s.ListTeams(ctx)
+	})
+}
+
+func (t *TenantRepository) ListTeamSummaries(ctx context.Context, filter entity.TeamListFilter) ([]entity.TeamSummary, int, error) {
+	s, err := t.storage(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.ListTeamSummaries(ctx, filter)
+}
+
+func (t *TenantRepository) CreatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.CreatePullRequest(ctx, pr)
+	})
+}
+
+func (t *TenantRepository) GetPullRequest(ctx context.Context, prID uuid.UUID) (*entity.PullRequest, error) {
+	return tenantRead(ctx, t, func(s Storage) (*entity.PullRequest, error) {
+		return s.GetPullRequest(ctx, prID)
+	})
+}
+
+func (t *TenantRepository) UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.UpdatePullRequest(ctx, pr)
+	})
+}
+
+func (t *TenantRepository) UpdatePullRequestIf(ctx context.Context, pr *entity.PullRequest, expectedVersion int) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.UpdatePullRequestIf(ctx, pr, expectedVersion)
+	})
+}
+
+func (t *TenantRepository) GetPullRequestsByReviewer(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.PullRequest, error) {
+		return s.GetPullRequestsByReviewer(ctx, userID, filter)
+	})
+}
+
+func (t *TenantRepository) GetPullRequestsByAuthor(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.PullRequest, error) {
+		return s.GetPullRequestsByAuthor(ctx, userID, filter)
+	})
+}
+
+func (t *TenantRepository) GetPullRequestsByStatus(ctx context.Context, status entity.PullRequestStatus) ([]*entity.PullRequest, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.PullRequest, error) {
+		return s.GetPullRequestsByStatus(ctx, status)
+	})
+}
+
+func (t *TenantRepository) CountOpenReviews(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	return tenantRead(ctx, t, func(s Storage) (map[uuid.UUID]int, error) {
+		return s.CountOpenReviews(ctx, userIDs)
+	})
+}
+
+func (t *TenantRepository) ListPullRequests(ctx context.Context, filter entity.PullRequestListFilter) ([]*entity.PullRequest, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.PullRequest, error) {
+		return s.ListPullRequests(ctx, filter)
+	})
+}
+
+func (t *TenantRepository) GetPullRequestsByTeam(ctx context.Context, teamName string, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.PullRequest, error) {
+		return s.GetPullRequestsByTeam(ctx, teamName, filter)
+	})
+}
+
+func (t *TenantRepository) PRExists(ctx context.Context, prID uuid.UUID) (bool, error) {
+	return tenantRead(ctx, t, func(s Storage) (bool, error) {
+		return s.PRExists(ctx, prID)
+	})
+}
+
+func (t *TenantRepository) ArchivePullRequest(ctx context.Context, prID uuid.UUID) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.ArchivePullRequest(ctx, prID)
+	})
+}
+
+func (t *TenantRepository) DeletePullRequest(ctx context.Context, prID uuid.UUID) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.DeletePullRequest(ctx, prID)
+	})
+}
+
+func (t *TenantRepository) PurgeArchivedPullRequests(ctx context.Context, olderThan time.Time) (int, error) {
+	return tenantRead(ctx, t, func(s Storage) (int, error) {
+		return s.PurgeArchivedPullRequests(ctx, olderThan)
+	})
+}
+
+func (t *TenantRepository) SetChecklistTemplate(ctx context.Context, template *entity.ChecklistTemplate) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.SetChecklistTemplate(ctx, template)
+	})
+}
+
+func (t *TenantRepository) GetChecklistTemplate(ctx context.Context, teamName string) (*entity.ChecklistTemplate, error) {
+	return tenantRead(ctx, t, func(s Storage) (*entity.ChecklistTemplate, error) {
+		return s.GetChecklistTemplate(ctx, teamName)
+	})
+}
+
+func (t *TenantRepository) SetMergePolicy(ctx context.Context, policy *entity.MergePolicy) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.SetMergePolicy(ctx, policy)
+	})
+}
+
+func (t *TenantRepository) GetMergePolicy(ctx context.Context, teamName string) (*entity.MergePolicy, error) {
+	return tenantRead(ctx, t, func(s Storage) (*entity.MergePolicy, error) {
+		return s.GetMergePolicy(ctx, teamName)
+	})
+}
+
+func (t *TenantRepository) SetTeamSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.SetTeamSettings(ctx, settings)
+	})
+}
+
+func (t *TenantRepository) GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	return tenantRead(ctx, t, func(s Storage) (*entity.TeamSettings, error) {
+		return s.GetTeamSettings(ctx, teamName)
+	})
+}
+
+// Global settings apply to every organization and live in the storage of
+// tenant.DefaultOrganization.
+
+func (t *TenantRepository) SetGlobalSettings(ctx context.Context, settings *entity.GlobalSettings) error {
+	ctx = tenant.WithOrganization(ctx, tenant.DefaultOrganization)
+	return t.exec(ctx, func(s Storage) error {
+		return s.SetGlobalSettings(ctx, settings)
+	})
+}
+
+func (t *TenantRepository) GetGlobalSettings(ctx context.Context) (*entity.GlobalSettings, error) {
+	ctx = tenant.WithOrganization(ctx, tenant.DefaultOrganization)
+	return tenantRead(ctx, t, func(s Storage) (*entity.GlobalSettings, error) {
+		return s.GetGlobalSettings(ctx)
+	})
+}
+
+func (t *TenantRepository) CreateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.CreateMilestone(ctx, milestone)
+	})
+}
+
+func (t *TenantRepository) GetMilestone(ctx context.Context, milestoneID uuid.UUID) (*entity.Milestone, error) {
+	return tenantRead(ctx, t, func(s Storage) (*entity.Milestone, error) {
+		return s.GetMilestone(ctx, milestoneID)
+	})
+}
+
+func (t *TenantRepository) ListMilestones(ctx context.Context) ([]*entity.Milestone, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.Milestone, error) {
+		return s.ListMilestones(ctx)
+	})
+}
+
+func (t *TenantRepository) UpdateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.UpdateMilestone(ctx, milestone)
+	})
+}
+
+func (t *TenantRepository) DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.DeleteMilestone(ctx, milestoneID)
+	})
+}
+
+func (t *TenantRepository) GetPullRequestsByMilestone(ctx context.Context, milestoneID uuid.UUID) ([]*entity.PullRequest, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.PullRequest, error) {
+		return s.GetPullRequestsByMilestone(ctx, milestoneID)
+	})
+}
+
+func (t *TenantRepository) SetTeamOwners(ctx context.Context, teamName string, owners []uuid.UUID) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.SetTeamOwners(ctx, teamName, owners)
+	})
+}
+
+func (t *TenantRepository) GetTeamOwners(ctx context.Context, teamName string) ([]uuid.UUID, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]uuid.UUID, error) {
+		return s.GetTeamOwners(ctx, teamName)
+	})
+}
+
+func (t *TenantRepository) SetComponentOwners(ctx context.Context, teamName string, components []entity.ComponentOwner) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.SetComponentOwners(ctx, teamName, components)
+	})
+}
+
+func (t *TenantRepository) GetComponentOwners(ctx context.Context, teamName string) ([]entity.ComponentOwner, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]entity.ComponentOwner, error) {
+		return s.GetComponentOwners(ctx, teamName)
+	})
+}
+
+func (t *TenantRepository) GrantRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.GrantRole(ctx, userID, role)
+	})
+}
+
+func (t *TenantRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.RevokeRole(ctx, userID, role)
+	})
+}
+
+func (t *TenantRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]entity.Role, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]entity.Role, error) {
+		return s.GetUserRoles(ctx, userID)
+	})
+}
+
+func (t *TenantRepository) AppendAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.AppendAudit(ctx, entry)
+	})
+}
+
+func (t *TenantRepository) ListAudit(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEntry, int, error) {
+	s, err := t.storage(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.ListAudit(ctx, filter)
+}
+
+func (t *TenantRepository) AppendEvent(ctx context.Context, event *entity.StorageEvent) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.AppendEvent(ctx, event)
+	})
+}
+
+func (t *TenantRepository) ListEvents(ctx context.Context, filter entity.StorageEventFilter) ([]*entity.StorageEvent, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.StorageEvent, error) {
+		return s.ListEvents(ctx, filter)
+	})
+}
+
+func (t *TenantRepository) AppendOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.AppendOutbox(ctx, msg)
+	})
+}
+
+func (t *TenantRepository) ListOutbox(ctx context.Context, due time.Time, limit int) ([]*entity.OutboxMessage, error) {
+	return tenantRead(ctx, t, func(s Storage) ([]*entity.OutboxMessage, error) {
+		return s.ListOutbox(ctx, due, limit)
+	})
+}
+
+func (t *TenantRepository) UpdateOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.UpdateOutbox(ctx, msg)
+	})
+}
+
+func (t *TenantRepository) DeleteOutbox(ctx context.Context, id uuid.UUID) error {
+	return t.exec(ctx, func(s Storage) error {
+		return s.DeleteOutbox(ctx, id)
+	})
+}
+
+func (t *TenantRepository) DeleteAuditBefore(ctx context.Context, before time.Time) (int, error) {
+	return tenantRead(ctx, t, func(s Storage) (int, error) {
+		return s.DeleteAuditBefore(ctx, before)
+	})
+}
+
+func (t *TenantRepository) Stats(ctx context.Context) (entity.StorageStats, error) {
+	return tenantRead(ctx, t, func(s Storage) (entity.StorageStats, error) {
+		return s.Stats(ctx)
+	})
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/tenant"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TestTenantOrganizationsShared checks that an organization created through
+// one TenantRepository is usable through another one over the same
+// storage, as after a restart or on another instance.
+func TestTenantOrganizationsShared(t *testing.T) {
+	ctx := context.Background()
+	defaultStorage := NewMemoryRepository(zap.NewNop())
+	orgStorages := map[string]Storage{}
+	newStorage := func(orgID string) Storage {
+		if _, ok := orgStorages[orgID]; !ok {
+			orgStorages[orgID] = NewMemoryRepository(zap.NewNop())
+		}
+		return orgStorages[orgID]
+	}
+
+	first := NewTenantRepository(defaultStorage, newStorage)
+	if err := first.CreateOrganization(ctx, &entity.Organization{OrgID: "acme", Name: "Acme", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	acme := tenant.WithOrganization(ctx, "acme")
+	user := &entity.User{UserID: uuid.New(), Username: "alice", TeamName: "backend", IsActive: true}
+	if err := first.CreateUser(acme, user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	second := NewTenantRepository(defaultStorage, newStorage)
+	if _, err := second.GetUser(acme, user.UserID); err != nil {
+		t.Fatalf("GetUser through another repository: %v", err)
+	}
+	orgs, err := second.ListOrganizations(ctx)
+	if err != nil {
+		t.Fatalf("ListOrganizations: %v", err)
+	}
+	if len(orgs) != 2 || orgs[0].OrgID != "acme" || orgs[1].OrgID != tenant.DefaultOrganization {
+		t.Fatalf("organizations %v, want acme and %s", orgs, tenant.DefaultOrganization)
+	}
+	if err := second.CreateOrganization(ctx, &entity.Organization{OrgID: "acme"}); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("creating acme again: got %v, want ErrAlreadyExists", err)
+	}
+	if _, err := second.GetUser(tenant.WithOrganization(ctx, "unknown"), user.UserID); !errors.Is(err, ErrOrganizationNotFound) {
+		t.Fatalf("unknown organization: got %v, want ErrOrganizationNotFound", err)
+	}
+}
@@ -0,0 +1,483 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+var (
+	_ Storage    = (*TimeoutRepository)(nil)
+	_ Transactor = (*TimeoutRepository)(nil)
+	_ Locker     = (*TimeoutRepository)(nil)
+)
+
+// Timeouts bound how long a single storage call may take. Read and Write
+// apply to every read and write; Operations overrides them for individual
+// Storage methods by name, e.g. "ListAudit". Zero disables a bound.
+type Timeouts struct {
+	Read       time.Duration
+	Write      time.Duration
+	Operations map[string]time.Duration
+}
+
+// TimeoutRepository derives a context with the operation's timeout for every
+// call to the wrapped storage. The caller's deadline still applies when it
+// is earlier, so a cancelled request stops its storage work as soon as the
+// backend notices ctx.
+type TimeoutRepository struct {
+	next     Storage
+	timeouts Timeouts
+}
+
+// NewTimeoutRepository fails when Operations names a method Storage does not
+// have, so a typo in the configuration does not silently keep the default.
+func NewTimeoutRepository(next Storage, timeouts Timeouts) (*TimeoutRepository, error) {
+	storage := reflect.TypeOf((*Storage)(nil)).Elem()
+	for op, timeout := range timeouts.Operations {
+		if _, ok := storage.MethodByName(op); !ok {
+			return nil, fmt.Errorf("storage timeout for unknown operation %q", op)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("storage timeout for %s must not be negative", op)
+		}
+	}
+	return &TimeoutRepository{next: next, timeouts: timeouts}, nil
+}
+
+func (r *TimeoutRepository) read(ctx context.Context, op string) (context.Context, context.CancelFunc) {
+	return r.withTimeout(ctx, op, r.timeouts.Read)
+}
+
+func (r *TimeoutRepository) write(ctx context.Context, op string) (context.Context, context.CancelFunc) {
+	return r.withTimeout(ctx, op, r.timeouts.Write)
+}
+
+func (r *TimeoutRepository) withTimeout(ctx context.Context, op string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if override, ok := r.timeouts.Operations[op]; ok {
+		timeout = override
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// InTx is not bounded as a whole; every call made inside it still gets its
+// own timeout.
+func (r *TimeoutRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return InTx(ctx, r.next, fn)
+}
+
+// WithLock is not bounded either: waiting for the lock ends with ctx, and
+// the calls fn makes get their own timeouts.
+func (r *TimeoutRepository) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return WithLock(ctx, r.next, name, fn)
+}
+
+func (r *TimeoutRepository) Ping(ctx context.Context) error {
+	ctx, cancel := r.read(ctx, "Ping")
+	defer cancel()
+	return r.next.Ping(ctx)
+}
+
+func (r *TimeoutRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	ctx, cancel := r.write(ctx, "CreateUser")
+	defer cancel()
+	return r.next.CreateUser(ctx, user)
+}
+
+func (r *TimeoutRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	ctx, cancel := r.write(ctx, "UpdateUser")
+	defer cancel()
+	return r.next.UpdateUser(ctx, user)
+}
+
+func (r *TimeoutRepository) DeleteUser(ctx context.Context, userID uuid.UUID, deletedAt time.Time) error {
+	ctx, cancel := r.write(ctx, "DeleteUser")
+	defer cancel()
+	return r.next.DeleteUser(ctx, userID, deletedAt)
+}
+
+func (r *TimeoutRepository) GetUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	ctx, cancel := r.read(ctx, "GetUser")
+	defer cancel()
+	return r.next.GetUser(ctx, userID)
+}
+
+func (r *TimeoutRepository) UserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ctx, cancel := r.read(ctx, "UserExists")
+	defer cancel()
+	return r.next.UserExists(ctx, userID)
+}
+
+func (r *TimeoutRepository) GetUsersByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
+	ctx, cancel := r.read(ctx, "GetUsersByTeam")
+	defer cancel()
+	return r.next.GetUsersByTeam(ctx, teamName)
+}
+
+func (r *TimeoutRepository) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*entity.User, error) {
+	ctx, cancel := r.read(ctx, "GetUsersByIDs")
+	defer cancel()
+	return r.next.GetUsersByIDs(ctx, userIDs)
+}
+
+func (r *TimeoutRepository) ListUsers(ctx context.Context, filter entity.UserFilter) ([]*entity.User, int, error) {
+	ctx, cancel := r.read(ctx, "ListUsers")
+	defer cancel()
+	return r.next.ListUsers(ctx, filter)
+}
+
+func (r *TimeoutRepository) CreateTeam(ctx context.Context, team *entity.Team) error {
+	ctx, cancel := r.write(ctx, "CreateTeam")
+	defer cancel()
+	return r.next.CreateTeam(ctx, team)
+}
+
+func (r *TimeoutRepository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	ctx, cancel := r.write(ctx, "UpdateTeam")
+	defer cancel()
+	return r.next.UpdateTeam(ctx, team)
+}
+
+func (r *TimeoutRepository) GetTeam(ctx context.Context, teamName string) (*entity.Team, error) {
+	ctx, cancel := r.read(ctx, "GetTeam")
+	defer cancel()
+	return r.next.GetTeam(ctx, teamName)
+}
+
+func (r *TimeoutRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	ctx, cancel := r.read(ctx, "TeamExists")
+	defer cancel()
+	return r.next.TeamExists(ctx, teamName)
+}
+
+func (r *TimeoutRepository) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	ctx, cancel := r.read(ctx, "ListTeams")
+	defer cancel()
+	return r.next.ListTeams(ctx)
+}
+
+func (r *TimeoutRepository) ListTeamSummaries(ctx context.Context, filter entity.TeamListFilter) ([]entity.TeamSummary, int, error) {
+	ctx, cancel := r.read(ctx, "ListTeamSummaries")
+	defer cancel()
+	return r.next.ListTeamSummaries(ctx, filter)
+}
+
+func (r *TimeoutRepository) CreatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	ctx, cancel := r.write(ctx, "CreatePullRequest")
+	defer cancel()
+	return r.next.CreatePullRequest(ctx, pr)
+}
+
+func (r *TimeoutRepository) GetPullRequest(ctx context.Context, prID uuid.UUID) (*entity.PullRequest, error) {
+	ctx, cancel := r.read(ctx, "GetPullRequest")
+	defer cancel()
+	return r.next.GetPullRequest(ctx, prID)
+}
+
+func (r *TimeoutRepository) UpdatePullRequest(ctx context.Context, pr *entity.PullRequest) error {
+	ctx, cancel := r.write(ctx, "UpdatePullRequest")
+	defer cancel()
+	return r.next.UpdatePullRequest(ctx, pr)
+}
+
+func (r *TimeoutRepository) UpdatePullRequestIf(ctx context.Context, pr *entity.PullRequest, expectedVersion int) error {
+	ctx, cancel := r.write(ctx, "UpdatePullRequestIf")
+	defer cancel()
+	return r.next.UpdatePullRequestIf(ctx, pr, expectedVersion)
+}
+
+func (r *TimeoutRepository) GetPullRequestsByReviewer(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	ctx, cancel := r.read(ctx, "GetPullRequestsByReviewer")
+	defer cancel()
+	return r.next.GetPullRequestsByReviewer(ctx, userID, filter)
+}
+
+func (r *TimeoutRepository) GetPullRequestsByAuthor(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	ctx, cancel := r.read(ctx, "GetPullRequestsByAuthor")
+	defer cancel()
+	return r.next.GetPullRequestsByAuthor(ctx, userID, filter)
+}
+
+func (r *TimeoutRepository) GetPullRequestsByStatus(ctx context.Context, status entity.PullRequestStatus) ([]*entity.PullRequest, error) {
+	ctx, cancel := r.read(ctx, "GetPullRequestsByStatus")
+	defer cancel()
+	return r.next.GetPullRequestsByStatus(ctx, status)
+}
+
+func (r *TimeoutRepository) CountOpenReviews(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	ctx, cancel := r.read(ctx, "CountOpenReviews")
+	defer cancel()
+	return r.next.CountOpenReviews(ctx, userIDs)
+}
+
+func (r *TimeoutRepository) ListPullRequests(ctx context.Context, filter entity.PullRequestListFilter) ([]*entity.PullRequest, error) {
+	ctx, cancel := r.read(ctx, "ListPullRequests")
+	defer cancel()
+	return r.next.ListPullRequests(ctx, filter)
+}
+
+func (r *TimeoutRepository) GetPullRequestsByTeam(ctx context.Context, teamName string, filter entity.PullRequestFilter) ([]*entity.PullRequest, error) {
+	ctx, cancel := r.read(ctx, "GetPullRequestsByTeam")
+	defer cancel()
+	return r.next.GetPullRequestsByTeam(ctx, teamName, filter)
+}
+
+func (r *TimeoutRepository) PRExists(ctx context.Context, prID uuid.UUID) (bool, error) {
+	ctx, cancel := r.read(ctx, "PRExists")
+	defer cancel()
+	return r.next.PRExists(ctx, prID)
+}
+
+func (r *TimeoutRepository) ArchivePullRequest(ctx context.Context, prID uuid.UUID) error {
+	ctx, cancel := r.write(ctx, "ArchivePullRequest")
+	defer cancel()
+	return r.next.ArchivePullRequest(ctx, prID)
+}
+
+func (r *TimeoutRepository) DeletePullRequest(ctx context.Context, prID uuid.UUID) error {
+	ctx, cancel := r.write(ctx, "DeletePullRequest")
+	defer cancel()
+	return r.next.DeletePullRequest(ctx, prID)
+}
+
+func (r *TimeoutRepository) PurgeArchivedPullRequests(ctx context.Context, olderThan time.Time) (int, error) {
+	ctx, cancel := r.write(ctx, "PurgeArchivedPullRequests")
+	defer cancel()
+	return r.next.PurgeArchivedPullRequests(ctx, olderThan)
+}
+
+func (r *TimeoutRepository) SetChecklistTemplate(ctx context.Context, template *entity.ChecklistTemplate) error {
+	ctx, cancel := r.write(ctx, "SetChecklistTemplate")
+	defer cancel()
+	return r.next.SetChecklistTemplate(ctx, template)
+}
+
+func (r *TimeoutRepository) GetChecklistTemplate(ctx context.Context, teamName string) (*entity.ChecklistTemplate, error) {
+	ctx, cancel := r.read(ctx, "GetChecklistTemplate")
+	defer cancel()
+	return r.next.GetChecklistTemplate(ctx, teamName)
+}
+
+func (r *TimeoutRepository) SetMergePolicy(ctx context.Context, policy *entity.MergePolicy) error {
+	ctx, cancel := r.write(ctx, "SetMergePolicy")
+	defer cancel()
+	return r.next.SetMergePolicy(ctx, policy)
+}
+
+func (r *TimeoutRepository) GetMergePolicy(ctx context.Context, teamName string) (*entity.MergePolicy, error) {
+	ctx, cancel := r.read(ctx, "GetMergePolicy")
+	defer cancel()
+	return r.next.GetMergePolicy(ctx, teamName)
+}
+
+func (r *TimeoutRepository) SetTeamSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	ctx, cancel := r.write(ctx, "SetTeamSettings")
+	defer cancel()
+	return r.next.SetTeamSettings(ctx, settings)
+}
+
+func (r *TimeoutRepository) GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	ctx, cancel := r.read(ctx, "GetTeamSettings")
+	defer cancel()
+	return r.next.GetTeamSettings(ctx, teamName)
+}
+
+func (r *TimeoutRepository) SetGlobalSettings(ctx context.Context, settings *entity.GlobalSettings) error {
+	ctx, cancel := r.write(ctx, "SetGlobalSettings")
+	defer cancel()
+	return r.next.SetGlobalSettings(ctx, settings)
+}
+
+func (r *TimeoutRepository) GetGlobalSettings(ctx context.Context) (*entity.GlobalSettings, error) {
+	ctx, cancel := r.read(ctx, "GetGlobalSettings")
+	defer cancel()
+	return r.next.GetGlobalSettings(ctx)
+}
+
+func (r *TimeoutRepository) CreateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	ctx, cancel := r.write(ctx, "CreateMilestone")
+	defer cancel()
+	return r.next.CreateMilestone(ctx, milestone)
+}
+
+func (r *TimeoutRepository) GetMilestone(ctx context.Context, milestoneID uuid.UUID) (*entity.Milestone, error) {
+	ctx, cancel := r.read(ctx, "GetMilestone")
+	defer cancel()
+	return r.next.GetMilestone(ctx, milestoneID)
+}
+
+func (r *TimeoutRepository) ListMilestones(ctx context.Context) ([]*entity.Milestone, error) {
+	ctx, cancel := r.read(ctx, "ListMilestones")
+	defer cancel()
+	return r.next.ListMilestones(ctx)
+}
+
+func (r *TimeoutRepository) UpdateMilestone(ctx context.Context, milestone *entity.Milestone) error {
+	ctx, cancel := r.write(ctx, "UpdateMilestone")
+	defer cancel()
+	return r.next.UpdateMilestone(ctx, milestone)
+}
+
+func (r *TimeoutRepository) DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error {
+	ctx, cancel := r.write(ctx, "DeleteMilestone")
+	defer cancel()
+	return r.next.DeleteMilestone(ctx, milestoneID)
+}
+
+func (r *TimeoutRepository) GetPullRequestsByMilestone(ctx context.Context, milestoneID uuid.UUID) ([]*entity.PullRequest, error) {
+	ctx, cancel := r.read(ctx, "GetPullRequestsByMilestone")
+	defer cancel()
+	return r.next.GetPullRequestsByMilestone(ctx, milestoneID)
+}
+
+func (r *TimeoutRepository) SetTeamOwners(ctx context.Context, teamName string, owners []uuid.UUID) error {
+	ctx, cancel := r.write(ctx, "SetTeamOwners")
+	defer cancel()
+	return r.next.SetTeamOwners(ctx, teamName, owners)
+}
+
+func (r *TimeoutRepository) GetTeamOwners(ctx context.Context, teamName string) ([]uuid.UUID, error) {
+	ctx, cancel := r.read(ctx, "GetTeamOwners")
+	defer cancel()
+	return r.next.GetTeamOwners(ctx, teamName)
+}
+
+func (r *TimeoutRepository) SetComponentOwners(ctx context.Context, teamName string, components []entity.ComponentOwner) error {
+	ctx, cancel := r.write(ctx, "SetComponentOwners")
+	defer cancel()
+	return r.next.SetComponentOwners(ctx, teamName, components)
+}
+
+func (r *TimeoutRepository) GetComponentOwners(ctx context.Context, teamName string) ([]entity.ComponentOwner, error) {
+	ctx, cancel := r.read(ctx, "GetComponentOwners")
+	defer cancel()
+	return r.next.GetComponentOwners(ctx, teamName)
+}
+
+func (r *TimeoutRepository) GrantRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	ctx, cancel := r.write(ctx, "GrantRole")
+	defer cancel()
+	return r.next.GrantRole(ctx, userID, role)
+}
+
+func (r *TimeoutRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role entity.Role) error {
+	ctx, cancel := r.write(ctx, "RevokeRole")
+	defer cancel()
+	return r.next.RevokeRole(ctx, userID, role)
+}
+
+func (r *TimeoutRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]entity.Role, error) {
+	ctx, cancel := r.read(ctx, "GetUserRoles")
+	defer cancel()
+	return r.next.GetUserRoles(ctx, userID)
+}
+
+func (r *TimeoutRepository) AppendAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	ctx, cancel := r.write(ctx, "AppendAudit")
+	defer cancel()
+	return r.next.AppendAudit(ctx, entry)
+}
+
+func (r *TimeoutRepository) ListAudit(ctx context.Context, filter entity.AuditFilter) ([]*entity.AuditEntry, int, error) {
+	ctx, cancel := r.read(ctx, "ListAudit")
+	defer cancel()
+	return r.next.ListAudit(ctx, filter)
+}
+
+func (r *TimeoutRepository) AppendEvent(ctx context.Context, event *entity.StorageEvent) error {
+	ctx, cancel := r.write(ctx, "AppendEvent")
+	defer cancel()
+	return r.next.AppendEvent(ctx, event)
+}
+
+func (r *TimeoutRepository) ListEvents(ctx context.Context, filter entity.StorageEventFilter) ([]*entity.StorageEvent, error) {
+	ctx, cancel := r.read(ctx, "ListEvents")
+	defer cancel()
+	return r.next.ListEvents(ctx, filter)
+}
+
+func (r *TimeoutRepository) AppendOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	ctx, cancel := r.write(ctx, "AppendOutbox")
+	defer cancel()
+	return r.next.AppendOutbox(ctx, msg)
+}
+
+func (r *TimeoutRepository) ListOutbox(ctx context.Context, due time.Time, limit int) ([]*entity.OutboxMessage, error) {
+	ctx, cancel := r.read(ctx, "ListOutbox")
+	defer cancel()
+	return r.next.ListOutbox(ctx, due, limit)
+}
+
+func (r *TimeoutRepository) UpdateOutbox(ctx context.Context, msg *entity.OutboxMessage) error {
+	ctx, cancel := r.write(ctx, "UpdateOutbox")
+	defer cancel()
+	return r.next.UpdateOutbox(ctx, msg)
+}
+
+func (r *TimeoutRepository) DeleteOutbox(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := r.write(ctx, "DeleteOutbox")
+	defer cancel()
+	return r.next.DeleteOutbox(ctx, id)
+}
+
+func (r *TimeoutRepository) CreateOrganization(ctx context.Context, org *entity.Organization) error {
+	ctx, cancel := r.write(ctx, "CreateOrganization")
+	defer cancel()
+	return r.next.CreateOrganization(ctx, org)
+}
+
+func (r *TimeoutRepository) GetOrganization(ctx context.Context, orgID string) (*entity.Organization, error) {
+	ctx, cancel := r.read(ctx, "GetOrganization")
+	defer cancel()
+	return r.next.GetOrganization(ctx, orgID)
+}
+
+func (r *TimeoutRepository) ListOrganizations(ctx context.Context) ([]*entity.Organization, error) {
+	ctx, cancel := r.read(ctx, "ListOrganizations")
+	defer cancel()
+	return r.next.ListOrganizations(ctx)
+}
+
+func (r *TimeoutRepository) CreateAPIToken(ctx context.Context, token *entity.APIToken) error {
+	ctx, cancel := r.write(ctx, "CreateAPIToken")
+	defer cancel()
+	return r.next.CreateAPIToken(ctx, token)
+}
+
+func (r *TimeoutRepository) GetAPIToken(ctx context.Context, secretHash string) (*entity.APIToken, error) {
+	ctx, cancel := r.read(ctx, "GetAPIToken")
+	defer cancel()
+	return r.next.GetAPIToken(ctx, secretHash)
+}
+
+func (r *TimeoutRepository) ListAPITokens(ctx context.Context, orgID string) ([]*entity.APIToken, error) {
+	ctx, cancel := r.read(ctx, "ListAPITokens")
+	defer cancel()
+	return r.next.ListAPITokens(ctx, orgID)
+}
+
+func (r *TimeoutRepository) DeleteAPIToken(ctx context.Context, orgID string, id uuid.UUID) error {
+	ctx, cancel := r.write(ctx, "DeleteAPIToken")
+	defer cancel()
+	return r.next.DeleteAPIToken(ctx, orgID, id)
+}
+
+func (r *TimeoutRepository) DeleteAuditBefore(ctx context.Context, before time.Time) (int, error) {
+	ctx, cancel := r.write(ctx, "DeleteAuditBefore")
+	defer cancel()
+	return r.next.DeleteAuditBefore(ctx, before)
+}
+
+func (r *TimeoutRepository) Stats(ctx context.Context) (entity.StorageStats, error) {
+	ctx, cancel := r.read(ctx, "Stats")
+	defer cancel()
+	return r.next.Stats(ctx)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "context"
+
+// InTx runs fn in a transaction of s when the backend supports them (see
+// Transactor) and directly otherwise, with every call applied on its own.
+func InTx(ctx context.Context, s Storage, fn func(ctx context.Context) error) error {
+	if tx, ok := s.(Transactor); ok {
+		return tx.InTx(ctx, fn)
+	}
+	return fn(ctx)
+}
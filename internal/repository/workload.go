@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"maps"
+
+	"avito-intro/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// Every backend keeps a projection of the open review count of each
+// reviewer and updates it together with the PR on every write, so
+// CountOpenReviews reads counters instead of scanning all PRs.
+
+// openReviewDelta is how the open review counts change when the stored PR
+// old is replaced by updated. A nil PR stands for one not stored, e.g. old
+// on create and updated on delete.
+func openReviewDelta(old, updated *entity.PullRequest) map[uuid.UUID]int {
+	delta := make(map[uuid.UUID]int)
+	if old != nil && old.Status == entity.StatusOpen {
+		for _, id := range old.AssignedReviewers {
+			delta[id]--
+		}
+	}
+	if updated != nil && updated.Status == entity.StatusOpen {
+		for _, id := range updated.AssignedReviewers {
+			delta[id]++
+		}
+	}
+	maps.DeleteFunc(delta, func(_ uuid.UUID, n int) bool {
+		return n == 0
+	})
+	return delta
+}
+
+// countOpenReviews computes the projection from scratch, for backends
+// rebuilding it over data written before it existed.
+func countOpenReviews(prs []*entity.PullRequest) map[uuid.UUID]int {
+	counts := make(map[uuid.UUID]int)
+	for _, pr := range prs {
+		for id, n := range openReviewDelta(nil, pr) {
+			counts[id] += n
+		}
+	}
+	return counts
+}
+
+// selectCounts picks the counts of userIDs out of the projection; users
+// without open reviews get 0.
+func selectCounts(counts map[uuid.UUID]int, userIDs []uuid.UUID) map[uuid.UUID]int {
+	selected := make(map[uuid.UUID]int, len(userIDs))
+	for _, id := range userIDs {
+		selected[id] = counts[id]
+	}
+	return selected
+}
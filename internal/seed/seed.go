@@ -0,0 +1,275 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+type Fixture struct {
+	Teams        []Team        `json:"teams" yaml:"teams"`
+	PullRequests []PullRequest `json:"pull_requests" yaml:"pull_requests"`
+}
+
+type Team struct {
+	TeamName string   `json:"team_name" yaml:"team_name"`
+	Members  []Member `json:"members" yaml:"members"`
+}
+
+type Member struct {
+	UserID   string `json:"user_id" yaml:"user_id"`
+	Username string `json:"username" yaml:"username"`
+	IsActive *bool  `json:"is_active" yaml:"is_active"`
+}
+
+type PullRequest struct {
+	PullRequestID     string     `json:"pull_request_id" yaml:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name" yaml:"pull_request_name"`
+	AuthorID          string     `json:"author_id" yaml:"author_id"`
+	Status            string     `json:"status" yaml:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers" yaml:"assigned_reviewers"`
+	CreatedAt         *time.Time `json:"created_at" yaml:"created_at"`
+	MergedAt          *time.Time `json:"merged_at" yaml:"merged_at"`
+	ClosedAt          *time.Time `json:"closed_at" yaml:"closed_at"`
+}
+
+type Loader struct {
+	userRepo repository.UserRepository
+	teamRepo repository.TeamRepository
+	prRepo   repository.PullRequestRepository
+	logger   *zap.Logger
+}
+
+func NewLoader(
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	prRepo repository.PullRequestRepository,
+	logger *zap.Logger,
+) *Loader {
+	return &Loader{
+		userRepo: userRepo,
+		teamRepo: teamRepo,
+		prRepo:   prRepo,
+		logger:   logger,
+	}
+}
+
+func ReadFile(path string) (Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	var fixture Fixture
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &fixture)
+	default:
+		err = json.Unmarshal(data, &fixture)
+	}
+	if err != nil {
+		return Fixture{}, fmt.Errorf("parse seed file %s: %w", path, err)
+	}
+
+	return fixture, nil
+}
+
+func (l *Loader) LoadFile(ctx context.Context, path string) error {
+	fixture, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	l.logger.Info("loading seed data",
+		zap.String("path", path),
+		zap.Int("teams", len(fixture.Teams)),
+		zap.Int("pull_requests", len(fixture.PullRequests)),
+	)
+
+	return l.Load(ctx, fixture)
+}
+
+func (l *Loader) Load(ctx context.Context, fixture Fixture) error {
+	for _, team := range fixture.Teams {
+		if err := l.loadTeam(ctx, team); err != nil {
+			return fmt.Errorf("team %q: %w", team.TeamName, err)
+		}
+	}
+
+	for _, pr := range fixture.PullRequests {
+		if err := l.loadPullRequest(ctx, pr); err != nil {
+			return fmt.Errorf("pull request %q: %w", pr.PullRequestID, err)
+		}
+	}
+
+	l.logger.Info("seed data loaded")
+	return nil
+}
+
+func (l *Loader) loadTeam(ctx context.Context, team Team) error {
+	if team.TeamName == "" {
+		return fmt.Errorf("team_name is required")
+	}
+
+	exists, err := l.teamRepo.TeamExists(ctx, team.TeamName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		l.logger.Debug("seed team already exists, skipping", zap.String("team_name", team.TeamName))
+		return nil
+	}
+
+	memberIDs := make([]uuid.UUID, 0, len(team.Members))
+	for _, member := range team.Members {
+		user, err := member.toEntity(team.TeamName)
+		if err != nil {
+			return err
+		}
+
+		if err := l.createUserIfMissing(ctx, &user); err != nil {
+			return err
+		}
+		memberIDs = append(memberIDs, user.UserID)
+	}
+
+	return l.teamRepo.CreateTeam(ctx, &entity.Team{
+		TeamName: team.TeamName,
+		Members:  memberIDs,
+	})
+}
+
+func (l *Loader) createUserIfMissing(ctx context.Context, user *entity.User) error {
+	exists, err := l.userRepo.UserExists(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		l.logger.Debug("seed user already exists, skipping", zap.String("user_id", user.UserID.String()))
+		return nil
+	}
+	return l.userRepo.CreateUser(ctx, user)
+}
+
+func (l *Loader) loadPullRequest(ctx context.Context, seedPR PullRequest) error {
+	pr, err := seedPR.toEntity()
+	if err != nil {
+		return err
+	}
+
+	exists, err := l.prRepo.PRExists(ctx, pr.PullRequestID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		l.logger.Debug("seed pull request already exists, skipping", zap.String("pr_id", pr.PullRequestID.String()))
+		return nil
+	}
+
+	userIDs := append([]uuid.UUID{pr.AuthorID}, pr.AssignedReviewers...)
+	for _, id := range userIDs {
+		exists, err := l.userRepo.UserExists(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("unknown user %s", id)
+		}
+	}
+
+	return l.prRepo.CreatePullRequest(ctx, &pr)
+}
+
+func (m Member) toEntity(teamName string) (entity.User, error) {
+	userID, err := uuid.Parse(m.UserID)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("invalid user_id %q: %w", m.UserID, err)
+	}
+
+	isActive := true
+	if m.IsActive != nil {
+		isActive = *m.IsActive
+	}
+
+	return entity.User{
+		UserID:   userID,
+		Username: m.Username,
+		TeamName: teamName,
+		IsActive: isActive,
+	}, nil
+}
+
+func (p PullRequest) toEntity() (entity.PullRequest, error) {
+	prID, err := uuid.Parse(p.PullRequestID)
+	if err != nil {
+		return entity.PullRequest{}, fmt.Errorf("invalid pull_request_id: %w", err)
+	}
+
+	authorID, err := uuid.Parse(p.AuthorID)
+	if err != nil {
+		return entity.PullRequest{}, fmt.Errorf("invalid author_id %q: %w", p.AuthorID, err)
+	}
+
+	reviewers := make([]uuid.UUID, len(p.AssignedReviewers))
+	for i, id := range p.AssignedReviewers {
+		reviewers[i], err = uuid.Parse(id)
+		if err != nil {
+			return entity.PullRequest{}, fmt.Errorf("invalid reviewer id %q: %w", id, err)
+		}
+	}
+
+	status := entity.StatusOpen
+	if p.Status != "" {
+		status = entity.PullRequestStatus(strings.ToUpper(p.Status))
+	}
+	if !status.IsValid() {
+		return entity.PullRequest{}, fmt.Errorf("unknown status %q", p.Status)
+	}
+	// A change request names its reviewer, which the seed format lacks.
+	if status == entity.StatusChangesRequested {
+		return entity.PullRequest{}, fmt.Errorf("status %s cannot be seeded", status)
+	}
+
+	createdAt := time.Now()
+	if p.CreatedAt != nil {
+		createdAt = *p.CreatedAt
+	}
+
+	mergedAt := p.MergedAt
+	if status == entity.StatusMerged && mergedAt == nil {
+		mergedAt = &createdAt
+	}
+	if status != entity.StatusMerged {
+		mergedAt = nil
+	}
+
+	closedAt := p.ClosedAt
+	if status == entity.StatusClosed && closedAt == nil {
+		closedAt = &createdAt
+	}
+	if status != entity.StatusClosed {
+		closedAt = nil
+	}
+
+	return entity.PullRequest{
+		PullRequestID:     prID,
+		PullRequestName:   p.PullRequestName,
+		AuthorID:          authorID,
+		Status:            status,
+		AssignedReviewers: reviewers,
+		CreatedAt:         createdAt,
+		MergedAt:          mergedAt,
+		ClosedAt:          closedAt,
+	}, nil
+}
@@ -0,0 +1,22 @@
+package tenant
+
+import "context"
+
+// DefaultOrganization owns all data created without an explicit
+// organization, so single-tenant deployments keep working unchanged.
+const DefaultOrganization = "default"
+
+type organizationKey struct{}
+
+func WithOrganization(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, organizationKey{}, orgID)
+}
+
+// OrganizationFromContext returns the organization stored in ctx, or
+// DefaultOrganization when there is none.
+func OrganizationFromContext(ctx context.Context) string {
+	if orgID, ok := ctx.Value(organizationKey{}).(string); ok && orgID != "" {
+		return orgID
+	}
+	return DefaultOrganization
+}
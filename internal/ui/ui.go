@@ -0,0 +1,20 @@
+// Package ui embeds the reviewer board frontend. The build output of the
+// frontend goes to dist; a placeholder page is committed in its place.
+package ui
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed all:dist
+var dist embed.FS
+
+// FS returns the embedded frontend rooted at its index.html.
+func FS() fs.FS {
+	sub, err := fs.Sub(dist, "dist")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
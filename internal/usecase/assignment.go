@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+)
+
+var ErrUnknownStrategy = errors.New("unknown assignment strategy")
+
+type AssignmentStrategy interface {
+	Name() string
+	SelectReviewers(ctx context.Context, candidates []entity.User, count int) ([]uuid.UUID, error)
+}
+
+func NewAssignmentStrategy(name string, prRepo repository.PullRequestRepository, logger *zap.Logger) (AssignmentStrategy, error) {
+	switch name {
+	case StrategyRandom, "":
+		return NewRandomStrategy(), nil
+	case StrategyRoundRobin:
+		return NewRoundRobinStrategy(), nil
+	case StrategyLeastLoaded:
+		return NewLeastLoadedStrategy(prRepo, logger), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+	}
+}
+
+// NewAssignmentStrategies returns one instance of every strategy keyed by
+// name, for teams that override the service-wide strategy.
+func NewAssignmentStrategies(prRepo repository.PullRequestRepository, logger *zap.Logger) map[string]AssignmentStrategy {
+	strategies := make(map[string]AssignmentStrategy, len(AssignmentStrategies()))
+	for _, name := range AssignmentStrategies() {
+		strategy, _ := NewAssignmentStrategy(name, prRepo, logger)
+		strategies[name] = strategy
+	}
+	return strategies
+}
+
+func AssignmentStrategies() []string {
+	return []string{StrategyRandom, StrategyRoundRobin, StrategyLeastLoaded}
+}
+
+type RandomStrategy struct{}
+
+func NewRandomStrategy() *RandomStrategy {
+	return &RandomStrategy{}
+}
+
+func (s *RandomStrategy) Name() string {
+	return StrategyRandom
+}
+
+func (s *RandomStrategy) SelectReviewers(ctx context.Context, candidates []entity.User, count int) ([]uuid.UUID, error) {
+	count = min(len(candidates), count)
+	if count <= 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	shuffled := slices.Clone(candidates)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return userIDs(shuffled[:count]), nil
+}
+
+// RoundRobinStrategy walks each team's candidates in a stable order, continuing
+// from where the previous assignment for that team stopped.
+type RoundRobinStrategy struct {
+	mu      sync.Mutex
+	cursors map[string]int
+}
+
+func NewRoundRobinStrategy() *RoundRobinStrategy {
+	return &RoundRobinStrategy{
+		cursors: make(map[string]int),
+	}
+}
+
+func (s *RoundRobinStrategy) Name() string {
+	return StrategyRoundRobin
+}
+
+func (s *RoundRobinStrategy) SelectReviewers(ctx context.Context, candidates []entity.User, count int) ([]uuid.UUID, error) {
+	count = min(len(candidates), count)
+	if count <= 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	ordered := slices.Clone(candidates)
+	slices.SortFunc(ordered, func(a, b entity.User) int {
+		return bytes.Compare(a.UserID[:], b.UserID[:])
+	})
+
+	teamName := ordered[0].TeamName
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := s.cursors[teamName]
+	reviewers := make([]uuid.UUID, count)
+	for i := range count {
+		reviewers[i] = ordered[(start+i)%len(ordered)].UserID
+	}
+	s.cursors[teamName] = (start + count) % len(ordered)
+
+	return reviewers, nil
+}
+
+// LeastLoadedStrategy prefers candidates with the fewest open reviews, breaking
+// ties randomly.
+type LeastLoadedStrategy struct {
+	prRepo repository.PullRequestRepository
+	logger *zap.Logger
+}
+
+func NewLeastLoadedStrategy(prRepo repository.PullRequestRepository, logger *zap.Logger) *LeastLoadedStrategy {
+	return &LeastLoadedStrategy{
+		prRepo: prRepo,
+		logger: logger,
+	}
+}
+
+func (s *LeastLoadedStrategy) Name() string {
+	return StrategyLeastLoaded
+}
+
+func (s *LeastLoadedStrategy) SelectReviewers(ctx context.Context, candidates []entity.User, count int) ([]uuid.UUID, error) {
+	count = min(len(candidates), count)
+	if count <= 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	load, err := s.prRepo.CountOpenReviews(ctx, userIDs(candidates))
+	if err != nil {
+		s.logger.Error("failed to get reviewer load", zap.Error(err))
+		return nil, err
+	}
+
+	ordered := slices.Clone(candidates)
+	rand.Shuffle(len(ordered), func(i, j int) {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	})
+	slices.SortStableFunc(ordered, func(a, b entity.User) int {
+		return load[a.UserID] - load[b.UserID]
+	})
+
+	return userIDs(ordered[:count]), nil
+}
+
+func userIDs(users []entity.User) []uuid.UUID {
+	ids := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		ids[i] = user.UserID
+	}
+	return ids
+}
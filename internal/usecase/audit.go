@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+
+	"avito-intro/internal/auth"
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	auditActorAnonymous = "anonymous"
+	auditActorSystem    = "system"
+)
+
+var ErrInvalidAuditFilter = newError(CodeInvalidInput, "invalid audit filter")
+
+var _ AuditUsecase = (*AuditUsecaseImpl)(nil)
+
+type AuditUsecaseImpl struct {
+	auditRepo repository.AuditRepository
+	clock     Clock
+	logger    *zap.Logger
+}
+
+func NewAuditUsecase(auditRepo repository.AuditRepository, clock Clock, logger *zap.Logger) *AuditUsecaseImpl {
+	return &AuditUsecaseImpl{
+		auditRepo: auditRepo,
+		clock:     clock,
+		logger:    logger,
+	}
+}
+
+// Record appends an entry attributed to the principal in ctx. A failure to
+// write the audit log is logged but does not fail the audited operation.
+func (u *AuditUsecaseImpl) Record(ctx context.Context, action string, entityType entity.AuditEntityType, entityID string, details map[string]string) {
+	entry := &entity.AuditEntry{
+		ID:         uuid.New(),
+		OccurredAt: u.clock(),
+		Actor:      auditActor(ctx),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Details:    details,
+	}
+
+	if err := u.auditRepo.AppendAudit(ctx, entry); err != nil {
+		logging.From(ctx, u.logger).Error("failed to record audit entry",
+			zap.String("action", action),
+			zap.String("entity_type", string(entityType)),
+			zap.String("entity_id", entityID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (u *AuditUsecaseImpl) ListEntries(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, int, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, 0, ErrInvalidAuditFilter.Withf("invalid audit filter: from must be before to")
+	}
+
+	entries, total, err := u.auditRepo.ListAudit(ctx, filter)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list audit entries", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := make([]entity.AuditEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
+	}
+	return result, total, nil
+}
+
+// auditActor names who is acting: the authenticated subject, "anonymous"
+// for unauthenticated HTTP requests and "system" for background jobs.
+func auditActor(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok && principal.Subject != "" {
+		return principal.Subject
+	}
+	if _, ok := logging.RequestIDFromContext(ctx); ok {
+		return auditActorAnonymous
+	}
+	return auditActorSystem
+}
+
+var _ Notifier = (*AuditNotifier)(nil)
+
+// AuditNotifier records PR lifecycle events in the audit log before passing
+// them on to the next notifier.
+type AuditNotifier struct {
+	audit AuditUsecase
+	next  Notifier
+}
+
+func NewAuditNotifier(audit AuditUsecase, next Notifier) *AuditNotifier {
+	return &AuditNotifier{audit: audit, next: next}
+}
+
+func (n *AuditNotifier) Notify(ctx context.Context, event entity.PullRequestEvent) {
+	details := map[string]string{}
+	if event.UserID != uuid.Nil {
+		details["user_id"] = event.UserID.String()
+	}
+	if event.PreviousUserID != uuid.Nil {
+		details["previous_user_id"] = event.PreviousUserID.String()
+	}
+	if event.Type == entity.EventApprovalOverridden && event.PullRequest.Override != nil {
+		details["reason"] = event.PullRequest.Override.Reason
+	}
+	n.audit.Record(ctx, string(event.Type), entity.AuditPullRequest, event.PullRequest.PullRequestID.String(), details)
+	n.next.Notify(ctx, event)
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+	"avito-intro/internal/tenant"
+
+	"go.uber.org/zap"
+)
+
+var _ BackupUsecase = (*BackupUsecaseImpl)(nil)
+
+// BackupUsecaseImpl exports the state of every organization to the backup
+// store and records each backup in that organization's audit log.
+type BackupUsecaseImpl struct {
+	orgRepo repository.OrganizationRepository
+	state   StateUsecase
+	store   BackupStore
+	audit   AuditUsecase
+	clock   Clock
+	logger  *zap.Logger
+}
+
+func NewBackupUsecase(
+	orgRepo repository.OrganizationRepository,
+	state StateUsecase,
+	store BackupStore,
+	audit AuditUsecase,
+	clock Clock,
+	logger *zap.Logger,
+) *BackupUsecaseImpl {
+	return &BackupUsecaseImpl{
+		orgRepo: orgRepo,
+		state:   state,
+		store:   store,
+		audit:   audit,
+		clock:   clock,
+		logger:  logger,
+	}
+}
+
+// Backup stores one snapshot per organization, named
+// "<org_id>/pr-reviewer-<time>.json". A failed organization stops the pass;
+// the backups stored before it are reported along with the error.
+func (u *BackupUsecaseImpl) Backup(ctx context.Context) ([]entity.BackupResult, error) {
+	orgs, err := u.orgRepo.ListOrganizations(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list organizations", zap.Error(err))
+		return nil, err
+	}
+
+	results := make([]entity.BackupResult, 0, len(orgs))
+	for _, org := range orgs {
+		orgCtx := tenant.WithOrganization(ctx, org.OrgID)
+
+		snapshot, err := u.state.Export(orgCtx)
+		if err != nil {
+			return results, err
+		}
+
+		name := fmt.Sprintf("%s/pr-reviewer-%s.json", org.OrgID, snapshot.ExportedAt.UTC().Format("20060102T150405Z"))
+		location, err := u.store.Save(orgCtx, name, snapshot)
+		if err != nil {
+			logging.From(orgCtx, u.logger).Error("failed to store backup",
+				zap.String("org_id", org.OrgID),
+				zap.String("store", u.store.Name()),
+				zap.Error(err),
+			)
+			return results, fmt.Errorf("store backup of %s: %w", org.OrgID, err)
+		}
+
+		result := entity.BackupResult{
+			OrgID:        org.OrgID,
+			Location:     location,
+			CreatedAt:    snapshot.ExportedAt,
+			Teams:        len(snapshot.Teams),
+			Users:        len(snapshot.Users),
+			PullRequests: len(snapshot.PullRequests),
+		}
+		logging.From(orgCtx, u.logger).Info("backup stored",
+			zap.String("org_id", org.OrgID),
+			zap.String("location", location),
+		)
+		u.audit.Record(orgCtx, entity.AuditBackupCreated, entity.AuditOrganization, org.OrgID, map[string]string{
+			"location":      location,
+			"teams":         strconv.Itoa(result.Teams),
+			"users":         strconv.Itoa(result.Users),
+			"pull_requests": strconv.Itoa(result.PullRequests),
+		})
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrChecklistIncomplete  = newError(CodeChecklistIncomplete, "all checklist items must be checked before merge")
+	ErrUnknownChecklistItem = newError(CodeNotFound, "checklist item not found")
+)
+
+var _ ChecklistUsecase = (*ChecklistUsecaseImpl)(nil)
+
+type ChecklistUsecaseImpl struct {
+	checklistRepo repository.ChecklistRepository
+	teamRepo      repository.TeamRepository
+	prRepo        repository.PullRequestRepository
+	logger        *zap.Logger
+}
+
+func NewChecklistUsecase(
+	checklistRepo repository.ChecklistRepository,
+	teamRepo repository.TeamRepository,
+	prRepo repository.PullRequestRepository,
+	logger *zap.Logger,
+) *ChecklistUsecaseImpl {
+	return &ChecklistUsecaseImpl{
+		checklistRepo: checklistRepo,
+		teamRepo:      teamRepo,
+		prRepo:        prRepo,
+		logger:        logger,
+	}
+}
+
+func (u *ChecklistUsecaseImpl) SetTemplate(ctx context.Context, template entity.ChecklistTemplate) (entity.ChecklistTemplate, error) {
+	logging.From(ctx, u.logger).Info("setting checklist template",
+		zap.String("team_name", template.TeamName),
+		zap.Int("items", len(template.Items)),
+	)
+
+	if err := u.checklistRepo.SetChecklistTemplate(ctx, &template); err != nil {
+		logging.From(ctx, u.logger).Error("failed to set checklist template", zap.Error(err))
+		return entity.ChecklistTemplate{}, notFound(err, "team %s not found", template.TeamName)
+	}
+
+	return template, nil
+}
+
+func (u *ChecklistUsecaseImpl) GetTemplate(ctx context.Context, teamName string) (entity.ChecklistTemplate, error) {
+	exists, err := u.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to check team existence", zap.Error(err))
+		return entity.ChecklistTemplate{}, err
+	}
+	if !exists {
+		return entity.ChecklistTemplate{}, ErrNotFound.Withf("team %s not found", teamName)
+	}
+
+	return loadChecklistTemplate(ctx, u.checklistRepo, teamName)
+}
+
+func (u *ChecklistUsecaseImpl) CheckItem(ctx context.Context, prID uuid.UUID, itemID int, userID uuid.UUID, checked bool) (entity.PullRequest, error) {
+	return retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		return u.checkItem(ctx, prID, itemID, userID, checked)
+	})
+}
+
+func (u *ChecklistUsecaseImpl) checkItem(ctx context.Context, prID uuid.UUID, itemID int, userID uuid.UUID, checked bool) (entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("updating checklist item",
+		zap.String("pr_id", prID.String()),
+		zap.Int("item_id", itemID),
+		zap.String("user_id", userID.String()),
+		zap.Bool("checked", checked),
+	)
+
+	stored, err := u.prRepo.GetPullRequest(ctx, prID)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get PR", zap.String("pr_id", prID.String()), zap.Error(err))
+		return entity.PullRequest{}, notFound(err, "PR %s not found", prID)
+	}
+	pr := *stored
+
+	switch pr.Status {
+	case entity.StatusMerged:
+		return entity.PullRequest{}, ErrPRMerged.Withf("cannot update checklist on merged PR")
+	case entity.StatusClosed:
+		return entity.PullRequest{}, ErrPRClosed.Withf("cannot update checklist on closed PR")
+	}
+	if !slices.Contains(pr.AssignedReviewers, userID) {
+		logging.From(ctx, u.logger).Warn("checklist updated by non-reviewer",
+			zap.String("pr_id", prID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return entity.PullRequest{}, ErrNotAssigned.Withf("only assigned reviewers can update the checklist")
+	}
+
+	idx := slices.IndexFunc(pr.Checklist.Items, func(item entity.ChecklistItem) bool {
+		return item.ItemID == itemID
+	})
+	if idx < 0 {
+		return entity.PullRequest{}, ErrUnknownChecklistItem.WithDetail("item_id", strconv.Itoa(itemID))
+	}
+
+	now := time.Now()
+	pr.Checklist.Items = slices.Clone(pr.Checklist.Items)
+	item := &pr.Checklist.Items[idx]
+	item.Checked = checked
+	item.CheckedBy, item.CheckedAt = nil, nil
+	if checked {
+		item.CheckedBy = &userID
+		item.CheckedAt = &now
+	}
+	pr.RecordResponse(userID, now)
+
+	if err := u.prRepo.UpdatePullRequestIf(ctx, &pr, pr.Version); err != nil {
+		logConflictOr(ctx, u.logger, "failed to update PR", err)
+		return entity.PullRequest{}, invalidReviewers(err, &pr)
+	}
+
+	return pr, nil
+}
+
+// loadChecklistTemplate returns an empty template for teams that never
+// configured one.
+func loadChecklistTemplate(ctx context.Context, checklistRepo repository.ChecklistRepository, teamName string) (entity.ChecklistTemplate, error) {
+	template, err := checklistRepo.GetChecklistTemplate(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return entity.ChecklistTemplate{TeamName: teamName}, nil
+		}
+		return entity.ChecklistTemplate{}, err
+	}
+	return *template, nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// maxConflictAttempts bounds how often a read-modify-write of a PR is
+// replayed after losing the race to a concurrent writer.
+const maxConflictAttempts = 3
+
+// retryOnConflict runs op, which reads a PR, changes it and stores it with
+// UpdatePullRequestIf, again from a fresh read whenever another request
+// modified the PR in between. Replaying re-runs every check, so e.g. a
+// reassignment racing a merge ends with ErrPRMerged instead of reopening
+// the PR. After maxConflictAttempts ErrConcurrentUpdate is returned.
+func retryOnConflict[T any](ctx context.Context, logger *zap.Logger, op func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := op()
+		if !errors.Is(err, repository.ErrConflict) {
+			return result, err
+		}
+		if attempt == maxConflictAttempts {
+			return result, ErrConcurrentUpdate.because(err)
+		}
+		logging.From(ctx, logger).Info("pull request modified concurrently, retrying", zap.Int("attempt", attempt))
+	}
+}
+
+// logConflictOr logs a failed conditional update: a lost race is expected
+// and retried, anything else is an error.
+func logConflictOr(ctx context.Context, logger *zap.Logger, msg string, err error) {
+	if errors.Is(err, repository.ErrConflict) {
+		logging.From(ctx, logger).Debug("conditional update lost the race", zap.Error(err))
+		return
+	}
+	logging.From(ctx, logger).Error(msg, zap.Error(err))
+}
@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+	"avito-intro/internal/tenant"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var ErrUnrepairableAnomaly = newError(CodeInvalidInput, "anomaly kind cannot be repaired")
+
+var _ ConsistencyUsecase = (*ConsistencyUsecaseImpl)(nil)
+
+// ConsistencyUsecaseImpl looks for stored data that breaks invariants the
+// usecases rely on but the storage does not enforce, such as reviewers who
+// left the author's team or member lists pointing at unknown users.
+type ConsistencyUsecaseImpl struct {
+	userRepo repository.UserRepository
+	teamRepo repository.TeamRepository
+	prRepo   repository.PullRequestRepository
+	orgRepo  repository.OrganizationRepository
+	prUC     PullRequestUsecase
+	audit    AuditUsecase
+	clock    Clock
+	logger   *zap.Logger
+}
+
+func NewConsistencyUsecase(
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	prRepo repository.PullRequestRepository,
+	orgRepo repository.OrganizationRepository,
+	prUC PullRequestUsecase,
+	audit AuditUsecase,
+	clock Clock,
+	logger *zap.Logger,
+) *ConsistencyUsecaseImpl {
+	return &ConsistencyUsecaseImpl{
+		userRepo: userRepo,
+		teamRepo: teamRepo,
+		prRepo:   prRepo,
+		orgRepo:  orgRepo,
+		prUC:     prUC,
+		audit:    audit,
+		clock:    clock,
+		logger:   logger,
+	}
+}
+
+// Check inspects the organization of ctx. Reviewer anomalies are only
+// reported for open PRs: merged PRs are history and keep whoever reviewed
+// them.
+func (u *ConsistencyUsecaseImpl) Check(ctx context.Context) (entity.ConsistencyReport, error) {
+	report := entity.ConsistencyReport{
+		OrgID:     tenant.OrganizationFromContext(ctx),
+		CheckedAt: u.clock(),
+	}
+
+	teams, err := u.teamRepo.ListTeams(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list teams", zap.Error(err))
+		return entity.ConsistencyReport{}, err
+	}
+	report.Teams = len(teams)
+
+	users, _, err := u.userRepo.ListUsers(ctx, entity.UserFilter{IncludeDeleted: true})
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list users", zap.Error(err))
+		return entity.ConsistencyReport{}, err
+	}
+	report.Users = len(users)
+
+	byID := make(map[uuid.UUID]*entity.User, len(users))
+	for _, user := range users {
+		byID[user.UserID] = user
+	}
+
+	for _, team := range teams {
+		for _, memberID := range team.Members {
+			member, ok := byID[memberID]
+			switch {
+			case !ok:
+				report.Anomalies = append(report.Anomalies, entity.Anomaly{
+					Kind:     entity.AnomalyMemberMissing,
+					TeamName: team.TeamName,
+					UserID:   memberID,
+					Detail:   "team lists a user that does not exist",
+				})
+			case member.TeamName != team.TeamName:
+				report.Anomalies = append(report.Anomalies, entity.Anomaly{
+					Kind:     entity.AnomalyMemberTeamMismatch,
+					TeamName: team.TeamName,
+					UserID:   memberID,
+					Detail:   fmt.Sprintf("user belongs to team %q", member.TeamName),
+				})
+			}
+		}
+	}
+
+	for _, status := range entity.PullRequestStatuses() {
+		prs, err := u.prRepo.GetPullRequestsByStatus(ctx, status)
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get PRs by status", zap.Error(err))
+			return entity.ConsistencyReport{}, err
+		}
+		report.PullRequests += len(prs)
+
+		for _, pr := range prs {
+			report.Anomalies = append(report.Anomalies, prAnomalies(pr, byID)...)
+		}
+	}
+
+	logging.From(ctx, u.logger).Info("consistency check completed",
+		zap.String("org_id", report.OrgID),
+		zap.Int("anomalies", len(report.Anomalies)),
+	)
+	return report, nil
+}
+
+// CheckAll runs Check in every organization and logs each anomaly found.
+func (u *ConsistencyUsecaseImpl) CheckAll(ctx context.Context) ([]entity.ConsistencyReport, error) {
+	orgs, err := u.orgRepo.ListOrganizations(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list organizations", zap.Error(err))
+		return nil, err
+	}
+
+	reports := make([]entity.ConsistencyReport, 0, len(orgs))
+	for _, org := range orgs {
+		orgCtx := tenant.WithOrganization(ctx, org.OrgID)
+		report, err := u.Check(orgCtx)
+		if err != nil {
+			return reports, err
+		}
+		for _, anomaly := range report.Anomalies {
+			logging.From(orgCtx, u.logger).Warn("data inconsistency detected",
+				zap.String("org_id", org.OrgID),
+				zap.String("kind", string(anomaly.Kind)),
+				zap.String("team_name", anomaly.TeamName),
+				zap.String("pr_id", anomaly.PullRequestID.String()),
+				zap.String("user_id", anomaly.UserID.String()),
+				zap.String("detail", anomaly.Detail),
+			)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Repair fixes the anomalies of the given kinds, or of every repairable
+// kind when none are given, in the organization of ctx. A reviewer who was
+// deleted or left the author's team is replaced by an active member of that
+// team, and a team list entry for a missing user or a member of another
+// team is dropped; the user's own team is taken as the truth. Each change
+// is recorded in the audit log. Anomalies that cannot be fixed, e.g.
+// because the team has no candidate left, are returned as unresolved. With
+// dryRun nothing is stored and every anomaly is planned on its own, so two
+// stale reviewers of one PR may be shown the same replacement.
+func (u *ConsistencyUsecaseImpl) Repair(ctx context.Context, kinds []entity.AnomalyKind, dryRun bool) (entity.RepairResult, error) {
+	kinds, err := repairKinds(kinds)
+	if err != nil {
+		return entity.RepairResult{}, err
+	}
+
+	logging.From(ctx, u.logger).Info("repairing data inconsistencies",
+		zap.Int("kinds", len(kinds)),
+		zap.Bool("dry_run", dryRun),
+	)
+
+	report, err := u.Check(ctx)
+	if err != nil {
+		return entity.RepairResult{}, err
+	}
+
+	result := entity.RepairResult{
+		OrgID:  report.OrgID,
+		DryRun: dryRun,
+		Kinds:  kinds,
+	}
+	for _, anomaly := range report.Anomalies {
+		if !slices.Contains(kinds, anomaly.Kind) {
+			continue
+		}
+
+		var replacement uuid.UUID
+		switch anomaly.Kind {
+		case entity.AnomalyReviewerMissing, entity.AnomalyReviewerNotInTeam:
+			replacement, err = u.prUC.ReplaceReviewer(ctx, anomaly.PullRequestID, anomaly.UserID, dryRun)
+		default:
+			err = u.dropMember(ctx, anomaly.TeamName, anomaly.UserID, dryRun)
+		}
+
+		// A usecase error means this anomaly cannot be fixed right now, such
+		// as no candidate left or a PR merged since the check; the rest of
+		// the repair goes on.
+		var ucErr *Error
+		if errors.As(err, &ucErr) {
+			anomaly.Detail = ucErr.Message
+			result.Unresolved = append(result.Unresolved, anomaly)
+			continue
+		}
+		if err != nil {
+			return entity.RepairResult{}, err
+		}
+
+		action := entity.RepairAction{Anomaly: anomaly, ReplacementID: replacement}
+		result.Repaired = append(result.Repaired, action)
+		if !dryRun {
+			u.recordRepair(ctx, action)
+		}
+	}
+
+	logging.From(ctx, u.logger).Info("data inconsistencies repaired",
+		zap.Bool("dry_run", dryRun),
+		zap.Int("repaired", len(result.Repaired)),
+		zap.Int("unresolved", len(result.Unresolved)),
+	)
+	return result, nil
+}
+
+func repairKinds(kinds []entity.AnomalyKind) ([]entity.AnomalyKind, error) {
+	if len(kinds) == 0 {
+		return entity.RepairableAnomalyKinds(), nil
+	}
+	for _, kind := range kinds {
+		if !slices.Contains(entity.RepairableAnomalyKinds(), kind) {
+			return nil, ErrUnrepairableAnomaly.Withf("anomaly kind %q cannot be repaired", kind).WithDetail("kind", string(kind))
+		}
+	}
+	return kinds, nil
+}
+
+func (u *ConsistencyUsecaseImpl) dropMember(ctx context.Context, teamName string, userID uuid.UUID, dryRun bool) error {
+	team, err := u.teamRepo.GetTeam(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team", zap.String("team_name", teamName), zap.Error(err))
+		return notFound(err, "team %s not found", teamName)
+	}
+	if !slices.Contains(team.Members, userID) {
+		return ErrNotFound.Withf("user %s is no longer listed in team %s", userID, teamName)
+	}
+	if dryRun {
+		return nil
+	}
+
+	updated := *team
+	updated.Members = slices.DeleteFunc(slices.Clone(team.Members), func(id uuid.UUID) bool {
+		return id == userID
+	})
+	if err := u.teamRepo.UpdateTeam(ctx, &updated); err != nil {
+		logging.From(ctx, u.logger).Error("failed to update team", zap.String("team_name", teamName), zap.Error(err))
+		return notFound(err, "team %s not found", teamName)
+	}
+
+	logging.From(ctx, u.logger).Info("stale team member dropped",
+		zap.String("team_name", teamName),
+		zap.String("user_id", userID.String()),
+	)
+	return nil
+}
+
+func (u *ConsistencyUsecaseImpl) recordRepair(ctx context.Context, action entity.RepairAction) {
+	details := map[string]string{
+		"kind":    string(action.Anomaly.Kind),
+		"user_id": action.Anomaly.UserID.String(),
+	}
+	if action.ReplacementID == uuid.Nil {
+		u.audit.Record(ctx, entity.AuditConsistencyRepaired, entity.AuditTeam, action.Anomaly.TeamName, details)
+		return
+	}
+	details["replacement_id"] = action.ReplacementID.String()
+	u.audit.Record(ctx, entity.AuditConsistencyRepaired, entity.AuditPullRequest, action.Anomaly.PullRequestID.String(), details)
+}
+
+func prAnomalies(pr *entity.PullRequest, users map[uuid.UUID]*entity.User) []entity.Anomaly {
+	var anomalies []entity.Anomaly
+
+	author, authorExists := users[pr.AuthorID]
+	if !authorExists {
+		anomalies = append(anomalies, entity.Anomaly{
+			Kind:          entity.AnomalyAuthorMissing,
+			PullRequestID: pr.PullRequestID,
+			UserID:        pr.AuthorID,
+			Detail:        "PR author does not exist",
+		})
+	}
+
+	if pr.Status != entity.StatusOpen {
+		return anomalies
+	}
+
+	for _, reviewerID := range pr.AssignedReviewers {
+		reviewer, ok := users[reviewerID]
+		switch {
+		case !ok:
+			anomalies = append(anomalies, entity.Anomaly{
+				Kind:          entity.AnomalyReviewerMissing,
+				PullRequestID: pr.PullRequestID,
+				UserID:        reviewerID,
+				Detail:        "assigned reviewer does not exist",
+			})
+		case authorExists && reviewer.TeamName != author.TeamName:
+			anomalies = append(anomalies, entity.Anomaly{
+				Kind:          entity.AnomalyReviewerNotInTeam,
+				TeamName:      author.TeamName,
+				PullRequestID: pr.PullRequestID,
+				UserID:        reviewerID,
+				Detail:        fmt.Sprintf("reviewer moved to team %q", reviewer.TeamName),
+			})
+		}
+	}
+	return anomalies
+}
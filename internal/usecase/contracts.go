@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"avito-intro/internal/entity"
 
@@ -11,15 +12,205 @@ import (
 type TeamUsecase interface {
 	AddTeam(ctx context.Context, team entity.Team, members []entity.User) (entity.Team, error)
 	GetTeam(ctx context.Context, teamName string) (entity.Team, []entity.User, error)
+	UpsertTeam(ctx context.Context, teamName string, members []entity.User) (entity.Team, bool, error)
+	SetTeamMembers(ctx context.Context, teamName string, members []entity.User) (entity.Team, bool, error)
+	UpdateTeam(ctx context.Context, teamName string, members []entity.User, replace bool) (entity.Team, error)
+	ListTeams(ctx context.Context, filter entity.TeamListFilter) ([]entity.TeamSummary, int, error)
 }
 
 type UserUsecase interface {
+	GetUser(ctx context.Context, userID uuid.UUID) (entity.User, error)
+	ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, int, error)
+	GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]entity.User, []uuid.UUID, error)
+	UpsertUser(ctx context.Context, user entity.User) (entity.User, bool, error)
 	SetIsActive(ctx context.Context, userID uuid.UUID, isActive bool) (entity.User, error)
+	SetIsActiveBulk(ctx context.Context, userIDs []uuid.UUID, isActive bool) ([]entity.ActiveStatusChange, error)
+	Snooze(ctx context.Context, userID uuid.UUID, until *time.Time) (entity.User, error)
+	DeleteUser(ctx context.Context, userID uuid.UUID) (entity.User, error)
 }
 
 type PullRequestUsecase interface {
-	CreatePR(ctx context.Context, prID uuid.UUID, prName string, authorID uuid.UUID) (entity.PullRequest, error)
+	CreatePR(ctx context.Context, prID uuid.UUID, prName string, authorID uuid.UUID, externalID string, reviewers []uuid.UUID) (entity.PullRequest, bool, error)
 	MergePR(ctx context.Context, prID uuid.UUID) (entity.PullRequest, error)
+	ClosePR(ctx context.Context, prID uuid.UUID) (entity.PullRequest, error)
+	ApprovePR(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID) (entity.PullRequest, error)
+	RequestChanges(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID) (entity.PullRequest, error)
+	ReopenPR(ctx context.Context, prID uuid.UUID, authorID uuid.UUID) (entity.PullRequest, error)
+	OverrideApproval(ctx context.Context, prID uuid.UUID, leadID uuid.UUID, reason string) (entity.PullRequest, error)
+	SetAutoMerge(ctx context.Context, prID uuid.UUID, enabled bool) (entity.PullRequest, error)
+	UpdatePR(ctx context.Context, prID uuid.UUID, update entity.PullRequestUpdate) (entity.PullRequest, error)
 	ReassignReviewer(ctx context.Context, prID uuid.UUID, oldReviewerID uuid.UUID) (entity.PullRequest, uuid.UUID, error)
-	GetUserReviews(ctx context.Context, userID uuid.UUID) ([]entity.PullRequest, error)
+	DeclineReview(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID, reason string) (entity.PullRequest, uuid.UUID, error)
+	ReplaceReviewer(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID, dryRun bool) (uuid.UUID, error)
+	GetUserReviews(ctx context.Context, userID uuid.UUID, filter entity.PullRequestListFilter) (entity.PullRequestPage, error)
+	GetUserAuthored(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]entity.PullRequest, error)
+	GetOverduePRs(ctx context.Context, olderThan time.Duration) ([]entity.PullRequest, error)
+	GetOpenReviewCounts(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	GetTeamPRs(ctx context.Context, teamName string, filter entity.PullRequestFilter) ([]entity.PullRequest, error)
+	ListPRs(ctx context.Context, filter entity.PullRequestListFilter) (entity.PullRequestPage, error)
+	BackfillReviewers(ctx context.Context) ([]entity.PullRequest, error)
+	RebalanceReviews(ctx context.Context, teamName string, dryRun bool) (entity.RebalanceResult, error)
+	TransferReviews(ctx context.Context, fromID uuid.UUID, toID uuid.UUID) (entity.ReviewTransfer, error)
+}
+
+// Notifier receives PR lifecycle events after they are persisted. Delivery
+// problems are the implementation's concern and never fail the operation.
+type Notifier interface {
+	Notify(ctx context.Context, event entity.PullRequestEvent)
+}
+
+// IdempotencyStore keeps the responses replayed to retried requests; the
+// retention cleanup drops the old ones.
+type IdempotencyStore interface {
+	// Purge forgets the responses stored before olderThan and returns how
+	// many it dropped in each organization.
+	Purge(olderThan time.Time) map[string]int
+}
+
+// ChangedFilesProvider looks up the files touched by a PR in the Git
+// provider by its external ID.
+type ChangedFilesProvider interface {
+	ChangedFiles(ctx context.Context, externalID string) ([]string, error)
+}
+
+type MilestoneUsecase interface {
+	CreateMilestone(ctx context.Context, milestone entity.Milestone) (entity.Milestone, error)
+	GetMilestone(ctx context.Context, milestoneID uuid.UUID) (entity.Milestone, error)
+	ListMilestones(ctx context.Context) ([]entity.Milestone, error)
+	UpdateMilestone(ctx context.Context, milestone entity.Milestone) (entity.Milestone, error)
+	DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error
+	GetMilestoneStats(ctx context.Context, milestoneID uuid.UUID) (entity.MilestoneStats, error)
+	SetPRMilestone(ctx context.Context, prID uuid.UUID, milestoneID *uuid.UUID) (entity.PullRequest, error)
+}
+
+type ChecklistUsecase interface {
+	SetTemplate(ctx context.Context, template entity.ChecklistTemplate) (entity.ChecklistTemplate, error)
+	GetTemplate(ctx context.Context, teamName string) (entity.ChecklistTemplate, error)
+	CheckItem(ctx context.Context, prID uuid.UUID, itemID int, userID uuid.UUID, checked bool) (entity.PullRequest, error)
+}
+
+type OwnershipUsecase interface {
+	SetTeamOwners(ctx context.Context, teamName string, owners []uuid.UUID) ([]uuid.UUID, error)
+	GetTeamOwners(ctx context.Context, teamName string) ([]uuid.UUID, error)
+	SetComponentOwners(ctx context.Context, teamName string, components []entity.ComponentOwner) ([]entity.ComponentOwner, error)
+	GetComponentOwners(ctx context.Context, teamName string) ([]entity.ComponentOwner, error)
+}
+
+// TeamSettingsUsecase returns a team's overrides and the settings in effect
+// for it.
+type TeamSettingsUsecase interface {
+	SetSettings(ctx context.Context, settings entity.TeamSettings) (entity.TeamSettings, entity.TeamSettings, error)
+	GetSettings(ctx context.Context, teamName string) (entity.TeamSettings, entity.TeamSettings, error)
+}
+
+// GlobalSettingsUsecase returns the instance-wide overrides and the
+// settings in effect.
+type GlobalSettingsUsecase interface {
+	SetSettings(ctx context.Context, settings entity.GlobalSettings) (entity.GlobalSettings, entity.GlobalSettings, error)
+	GetSettings(ctx context.Context) (entity.GlobalSettings, entity.GlobalSettings, error)
+}
+
+type MergePolicyUsecase interface {
+	SetPolicy(ctx context.Context, policy entity.MergePolicy) (entity.MergePolicy, error)
+	GetPolicy(ctx context.Context, teamName string) (entity.MergePolicy, error)
+}
+
+type RoleUsecase interface {
+	GrantRole(ctx context.Context, userID uuid.UUID, role entity.Role) ([]entity.Role, error)
+	RevokeRole(ctx context.Context, userID uuid.UUID, role entity.Role) ([]entity.Role, error)
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]entity.Role, error)
+}
+
+type OrganizationUsecase interface {
+	CreateOrganization(ctx context.Context, org entity.Organization) (entity.Organization, error)
+	GetOrganization(ctx context.Context, orgID string) (entity.Organization, error)
+	ListOrganizations(ctx context.Context) ([]entity.Organization, error)
+}
+
+// QuotaUsecase enforces the configured quotas before writes that would
+// grow an organization or team, and reports current usage.
+type QuotaUsecase interface {
+	CheckTeamCreate(ctx context.Context) error
+	CheckMembers(ctx context.Context, teamName string, members []entity.User, replace bool) error
+	CheckOpenPR(ctx context.Context, teamName string) error
+	GetUsage(ctx context.Context) ([]entity.QuotaUsage, error)
+}
+
+// AuditUsecase keeps the trail of who changed what. Record never fails the
+// caller: write errors are only logged.
+type AuditUsecase interface {
+	Record(ctx context.Context, action string, entityType entity.AuditEntityType, entityID string, details map[string]string)
+	ListEntries(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, int, error)
+}
+
+// StateUsecase dumps and restores the teams, users and PRs of the
+// organization of ctx. Import creates missing entities and overwrites
+// existing ones; stored entities absent from the snapshot are kept.
+type StateUsecase interface {
+	Export(ctx context.Context) (entity.StateSnapshot, error)
+	Import(ctx context.Context, snapshot entity.StateSnapshot) (entity.ImportResult, error)
+}
+
+// EventLogUsecase reads the storage event log of the organization of ctx.
+type EventLogUsecase interface {
+	ListEvents(ctx context.Context, filter entity.StorageEventFilter) ([]entity.StorageEvent, error)
+}
+
+// OutboxUsecase queues PR events in the outbox and delivers them to the
+// outbox sinks. Enqueue must run in the transaction of the change the event
+// describes; Dispatch delivers the due messages of every organization.
+type OutboxUsecase interface {
+	Enqueue(ctx context.Context, event entity.PullRequestEvent) error
+	Dispatch(ctx context.Context) ([]entity.DispatchResult, error)
+}
+
+// OutboxSink receives PR events from the outbox dispatcher. A failed
+// Publish is retried later, and a message may be published again after a
+// crash, so sinks should use msg.ID to drop repeats.
+type OutboxSink interface {
+	Name() string
+	Publish(ctx context.Context, msg entity.OutboxMessage) error
+}
+
+// BackupUsecase stores a snapshot of the state of every organization in the
+// backup store.
+type BackupUsecase interface {
+	Backup(ctx context.Context) ([]entity.BackupResult, error)
+}
+
+// BackupStore keeps state snapshots under a name made of the organization
+// and the time of the backup, and returns where the snapshot went.
+type BackupStore interface {
+	Name() string
+	Save(ctx context.Context, name string, snapshot entity.StateSnapshot) (string, error)
+}
+
+type RetentionUsecase interface {
+	Cleanup(ctx context.Context) ([]entity.PurgeResult, error)
+}
+
+// ConsistencyUsecase detects stored data that violates cross-entity
+// invariants. Check covers the organization of ctx, CheckAll every one.
+type ConsistencyUsecase interface {
+	Check(ctx context.Context) (entity.ConsistencyReport, error)
+	CheckAll(ctx context.Context) ([]entity.ConsistencyReport, error)
+	Repair(ctx context.Context, kinds []entity.AnomalyKind, dryRun bool) (entity.RepairResult, error)
+}
+
+// IdleUsecase applies the idle user policy. Check covers the organization
+// of ctx, CheckAll every one.
+type IdleUsecase interface {
+	Check(ctx context.Context, dryRun bool) (entity.IdleReport, error)
+	CheckAll(ctx context.Context) ([]entity.IdleReport, error)
+}
+
+type StatsUsecase interface {
+	GetStorageStats(ctx context.Context) (entity.StorageStats, error)
+	GetReviewStats(ctx context.Context, teamName string) (entity.ReviewStats, error)
+}
+
+var ErrSyncInProgress = newError(CodeInProgress, "sync already in progress")
+
+type TeamSyncUsecase interface {
+	Sync(ctx context.Context) (entity.SyncResult, error)
 }
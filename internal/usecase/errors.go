@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/repository"
+)
+
+// ErrorCode classifies a usecase failure independently of the transport;
+// the controllers map each code to an HTTP status in one place.
+type ErrorCode string
+
+const (
+	CodeInvalidInput        ErrorCode = "INVALID_INPUT"
+	CodeNotFound            ErrorCode = "NOT_FOUND"
+	CodeTeamExists          ErrorCode = "TEAM_EXISTS"
+	CodePRExists            ErrorCode = "PR_EXISTS"
+	CodeMilestoneExists     ErrorCode = "MILESTONE_EXISTS"
+	CodeOrgExists           ErrorCode = "ORG_EXISTS"
+	CodePRMerged            ErrorCode = "PR_MERGED"
+	CodePRClosed            ErrorCode = "PR_CLOSED"
+	CodeChangesRequested    ErrorCode = "CHANGES_REQUESTED"
+	CodeNotAssigned         ErrorCode = "NOT_ASSIGNED"
+	CodeNoCandidate         ErrorCode = "NO_CANDIDATE"
+	CodeInvalidReviewer     ErrorCode = "INVALID_REVIEWER"
+	CodeInvalidOwner        ErrorCode = "INVALID_OWNER"
+	CodeForbidden           ErrorCode = "FORBIDDEN"
+	CodeQuotaExceeded       ErrorCode = "QUOTA_EXCEEDED"
+	CodeInProgress          ErrorCode = "IN_PROGRESS"
+	CodeChecklistIncomplete ErrorCode = "CHECKLIST_INCOMPLETE"
+	CodeMergePolicy         ErrorCode = "MERGE_POLICY_VIOLATION"
+	CodeApprovalsPending    ErrorCode = "APPROVALS_PENDING"
+	CodeConcurrentUpdate    ErrorCode = "CONCURRENT_UPDATE"
+	CodeFeatureDisabled     ErrorCode = "FEATURE_DISABLED"
+	CodeInvalidPRName       ErrorCode = "INVALID_PR_NAME"
+)
+
+var (
+	ErrNotFound         = newError(CodeNotFound, "not found")
+	ErrTeamExists       = newError(CodeTeamExists, "team already exists")
+	ErrPRExists         = newError(CodePRExists, "PR already exists")
+	ErrMilestoneExists  = newError(CodeMilestoneExists, "milestone already exists")
+	ErrOrgExists        = newError(CodeOrgExists, "organization already exists")
+	ErrConcurrentUpdate = newError(CodeConcurrentUpdate, "PR was modified concurrently, retry the request")
+)
+
+// Error is a failure the caller can act on. Message is safe to show to
+// clients and Details carries machine-readable context such as the
+// offending field. Errors are derived from the package sentinels with
+// Withf and WithDetail, so errors.Is matches both the sentinel and, for
+// translated storage errors, the repository error underneath.
+type Error struct {
+	Code    ErrorCode
+	Message string
+	Details map[string]string
+
+	kind  *Error
+	cause error
+}
+
+func newError(code ErrorCode, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+	var errs []error
+	if e.kind != nil {
+		errs = append(errs, e.kind)
+	}
+	if e.cause != nil {
+		errs = append(errs, e.cause)
+	}
+	return errs
+}
+
+// Withf returns a copy of e with a more specific message.
+func (e *Error) Withf(format string, args ...any) *Error {
+	derived := e.derive()
+	derived.Message = fmt.Sprintf(format, args...)
+	return derived
+}
+
+// WithDetail returns a copy of e with one more detail set.
+func (e *Error) WithDetail(key, value string) *Error {
+	derived := e.derive()
+	derived.Details[key] = value
+	return derived
+}
+
+func (e *Error) because(cause error) *Error {
+	derived := e.derive()
+	derived.cause = cause
+	return derived
+}
+
+func (e *Error) derive() *Error {
+	kind := e
+	if e.kind != nil {
+		kind = e.kind
+	}
+	details := maps.Clone(e.Details)
+	if details == nil {
+		details = make(map[string]string)
+	}
+	return &Error{
+		Code:    e.Code,
+		Message: e.Message,
+		Details: details,
+		kind:    kind,
+		cause:   e.cause,
+	}
+}
+
+// notFound translates a repository miss into ErrNotFound with the given
+// message; any other error is returned unchanged.
+func notFound(err error, format string, args ...any) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrNotFound.Withf(format, args...).because(err)
+	}
+	return err
+}
+
+// alreadyExists translates a repository duplicate into kind.
+func alreadyExists(err error, kind *Error) error {
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return kind.because(err)
+	}
+	return err
+}
+
+// invalidReviewers translates a PR write the repository refused because of
+// its reviewer list into ErrInvalidReviewer naming the offending reviewer;
+// any other error is returned unchanged.
+func invalidReviewers(err error, pr *entity.PullRequest) error {
+	if !errors.Is(err, repository.ErrInvalid) {
+		return err
+	}
+	id, reason, _ := pr.InvalidReviewer()
+	return ErrInvalidReviewer.Withf("invalid reviewer %s: %s", id, reason).
+		WithDetail("reviewer_id", id.String()).
+		WithDetail("reason", reason).
+		because(err)
+}
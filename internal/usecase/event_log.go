@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+var _ EventLogUsecase = (*EventLogUsecaseImpl)(nil)
+
+type EventLogUsecaseImpl struct {
+	eventRepo repository.EventLogRepository
+	logger    *zap.Logger
+}
+
+func NewEventLogUsecase(eventRepo repository.EventLogRepository, logger *zap.Logger) *EventLogUsecaseImpl {
+	return &EventLogUsecaseImpl{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+func (u *EventLogUsecaseImpl) ListEvents(ctx context.Context, filter entity.StorageEventFilter) ([]entity.StorageEvent, error) {
+	events, err := u.eventRepo.ListEvents(ctx, filter)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list storage events", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]entity.StorageEvent, len(events))
+	for i, event := range events {
+		result[i] = *event
+	}
+	return result, nil
+}
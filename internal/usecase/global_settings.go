@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidGlobalSettings = newError(CodeInvalidInput, "invalid global settings")
+	ErrFeatureDisabled       = newError(CodeFeatureDisabled, "feature is disabled")
+)
+
+var _ GlobalSettingsUsecase = (*GlobalSettingsUsecaseImpl)(nil)
+
+// GlobalSettingsUsecaseImpl manages the instance-wide overrides of the
+// review settings the service is started with and the feature toggles.
+type GlobalSettingsUsecaseImpl struct {
+	settingsRepo repository.GlobalSettingsRepository
+	review       ReviewSettings
+	audit        AuditUsecase
+	logger       *zap.Logger
+}
+
+func NewGlobalSettingsUsecase(
+	settingsRepo repository.GlobalSettingsRepository,
+	review ReviewSettings,
+	audit AuditUsecase,
+	logger *zap.Logger,
+) *GlobalSettingsUsecaseImpl {
+	return &GlobalSettingsUsecaseImpl{
+		settingsRepo: settingsRepo,
+		review:       review,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// SetSettings replaces the stored overrides: fields left unset go back to
+// the configured defaults and unlisted features are switched on.
+func (u *GlobalSettingsUsecaseImpl) SetSettings(ctx context.Context, settings entity.GlobalSettings) (entity.GlobalSettings, entity.GlobalSettings, error) {
+	logging.From(ctx, u.logger).Info("setting global settings")
+
+	if err := validateGlobalSettings(settings); err != nil {
+		return entity.GlobalSettings{}, entity.GlobalSettings{}, err
+	}
+
+	if err := u.settingsRepo.SetGlobalSettings(ctx, &settings); err != nil {
+		logging.From(ctx, u.logger).Error("failed to set global settings", zap.Error(err))
+		return entity.GlobalSettings{}, entity.GlobalSettings{}, err
+	}
+
+	u.audit.Record(ctx, entity.AuditGlobalSettingsUpdated, entity.AuditSettings, "global", globalSettingsDetails(settings))
+	return settings, u.effective(settings), nil
+}
+
+// GetSettings returns the stored overrides together with the settings in
+// effect once the configured defaults are filled in.
+func (u *GlobalSettingsUsecaseImpl) GetSettings(ctx context.Context) (entity.GlobalSettings, entity.GlobalSettings, error) {
+	settings, err := loadGlobalSettings(ctx, u.settingsRepo)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get global settings", zap.Error(err))
+		return entity.GlobalSettings{}, entity.GlobalSettings{}, err
+	}
+	return settings, u.effective(settings), nil
+}
+
+func (u *GlobalSettingsUsecaseImpl) effective(settings entity.GlobalSettings) entity.GlobalSettings {
+	review := u.review.WithGlobal(settings)
+	effective := entity.GlobalSettings{
+		RequiredReviewers: &review.RequiredReviewers,
+		OptionalReviewers: &review.OptionalReviewers,
+		SLA:               &review.SLA,
+		Features:          make(map[entity.Feature]bool, len(entity.Features())),
+	}
+	for _, feature := range entity.Features() {
+		effective.Features[feature] = settings.FeatureEnabled(feature)
+	}
+	return effective
+}
+
+func validateGlobalSettings(settings entity.GlobalSettings) error {
+	invalid := func(field, format string, args ...any) error {
+		return ErrInvalidGlobalSettings.Withf("invalid global settings: "+format, args...).WithDetail("field", field)
+	}
+
+	if settings.RequiredReviewers != nil && *settings.RequiredReviewers < 0 {
+		return invalid("required_reviewers", "required_reviewers must not be negative")
+	}
+	if settings.OptionalReviewers != nil && *settings.OptionalReviewers < 0 {
+		return invalid("optional_reviewers", "optional_reviewers must not be negative")
+	}
+	if settings.SLA != nil && *settings.SLA <= 0 {
+		return invalid("sla", "sla must be positive")
+	}
+	for feature := range settings.Features {
+		if !slices.Contains(entity.Features(), feature) {
+			return invalid("features", "unknown feature %q", feature)
+		}
+	}
+	return nil
+}
+
+func globalSettingsDetails(settings entity.GlobalSettings) map[string]string {
+	details := map[string]string{}
+	if settings.RequiredReviewers != nil {
+		details["required_reviewers"] = strconv.Itoa(*settings.RequiredReviewers)
+	}
+	if settings.OptionalReviewers != nil {
+		details["optional_reviewers"] = strconv.Itoa(*settings.OptionalReviewers)
+	}
+	if settings.SLA != nil {
+		details["sla"] = settings.SLA.String()
+	}
+	for feature, enabled := range settings.Features {
+		details["feature."+string(feature)] = strconv.FormatBool(enabled)
+	}
+	return details
+}
+
+// loadGlobalSettings returns empty overrides until the settings are first
+// set.
+func loadGlobalSettings(ctx context.Context, settingsRepo repository.GlobalSettingsRepository) (entity.GlobalSettings, error) {
+	settings, err := settingsRepo.GetGlobalSettings(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return entity.GlobalSettings{}, nil
+		}
+		return entity.GlobalSettings{}, err
+	}
+	return *settings, nil
+}
+
+// requireFeature returns ErrFeatureDisabled when an admin switched feature
+// off.
+func requireFeature(ctx context.Context, settingsRepo repository.GlobalSettingsRepository, logger *zap.Logger, feature entity.Feature) error {
+	settings, err := loadGlobalSettings(ctx, settingsRepo)
+	if err != nil {
+		logging.From(ctx, logger).Error("failed to get global settings", zap.Error(err))
+		return err
+	}
+	if !settings.FeatureEnabled(feature) {
+		return ErrFeatureDisabled.Withf("feature %s is disabled", feature).WithDetail("feature", string(feature))
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+	"avito-intro/internal/tenant"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IdleUsecase = (*IdleUsecaseImpl)(nil)
+
+// IdleUsecaseImpl finds active users who stopped authoring and approving
+// PRs. A user's last activity is the latest of the PRs they authored, the
+// approvals they gave and their last reactivation.
+type IdleUsecaseImpl struct {
+	userRepo  repository.UserRepository
+	prRepo    repository.PullRequestRepository
+	auditRepo repository.AuditRepository
+	orgRepo   repository.OrganizationRepository
+	userUC    UserUsecase
+	audit     AuditUsecase
+	policy    entity.IdlePolicy
+	clock     Clock
+	logger    *zap.Logger
+}
+
+func NewIdleUsecase(
+	userRepo repository.UserRepository,
+	prRepo repository.PullRequestRepository,
+	auditRepo repository.AuditRepository,
+	orgRepo repository.OrganizationRepository,
+	userUC UserUsecase,
+	audit AuditUsecase,
+	policy entity.IdlePolicy,
+	clock Clock,
+	logger *zap.Logger,
+) *IdleUsecaseImpl {
+	return &IdleUsecaseImpl{
+		userRepo:  userRepo,
+		prRepo:    prRepo,
+		auditRepo: auditRepo,
+		orgRepo:   orgRepo,
+		userUC:    userUC,
+		audit:     audit,
+		policy:    policy,
+		clock:     clock,
+		logger:    logger,
+	}
+}
+
+// Check applies the policy in the organization of ctx. A user entering the
+// last WarnBefore of the idle period gets a user.idle_warning audit entry,
+// once per idle period; with Deactivate they are deactivated after the
+// period ends and the warning is at least WarnBefore old. Users without any
+// activity on record are only flagged, as there is nothing to measure the
+// idle period from. With dryRun nothing is recorded or changed.
+func (u *IdleUsecaseImpl) Check(ctx context.Context, dryRun bool) (entity.IdleReport, error) {
+	report := entity.IdleReport{
+		OrgID:     tenant.OrganizationFromContext(ctx),
+		CheckedAt: u.clock(),
+		DryRun:    dryRun,
+	}
+
+	isActive := true
+	users, _, err := u.userRepo.ListUsers(ctx, entity.UserFilter{IsActive: &isActive})
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list users", zap.Error(err))
+		return entity.IdleReport{}, err
+	}
+
+	lastActive, err := u.lastActivity(ctx)
+	if err != nil {
+		return entity.IdleReport{}, err
+	}
+
+	for _, user := range users {
+		if slices.Contains(u.policy.Exempt, user.UserID) {
+			continue
+		}
+
+		idle := entity.IdleUser{UserID: user.UserID, TeamName: user.TeamName}
+		last, ok := lastActive[user.UserID]
+		if !ok {
+			idle.Action = entity.IdleFlagged
+			report.Users = append(report.Users, idle)
+			continue
+		}
+		idle.LastActiveAt = &last
+
+		action, err := u.apply(ctx, *user, last, report.CheckedAt, dryRun)
+		if err != nil {
+			return report, err
+		}
+		if action != "" {
+			idle.Action = action
+			report.Users = append(report.Users, idle)
+		}
+	}
+
+	logging.From(ctx, u.logger).Info("idle user check completed",
+		zap.String("org_id", report.OrgID),
+		zap.Bool("dry_run", dryRun),
+		zap.Int("users", len(report.Users)),
+	)
+	return report, nil
+}
+
+// CheckAll applies the policy in every organization.
+func (u *IdleUsecaseImpl) CheckAll(ctx context.Context) ([]entity.IdleReport, error) {
+	orgs, err := u.orgRepo.ListOrganizations(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list organizations", zap.Error(err))
+		return nil, err
+	}
+
+	reports := make([]entity.IdleReport, 0, len(orgs))
+	for _, org := range orgs {
+		report, err := u.Check(tenant.WithOrganization(ctx, org.OrgID), false)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// apply returns what the policy does to a user last active at last, or ""
+// when the user is not idle yet.
+func (u *IdleUsecaseImpl) apply(ctx context.Context, user entity.User, last, now time.Time, dryRun bool) (entity.IdleAction, error) {
+	idleFor := now.Sub(last)
+	if !u.policy.Deactivate {
+		if idleFor < u.policy.IdleAfter {
+			return "", nil
+		}
+		return entity.IdleFlagged, nil
+	}
+
+	if idleFor < u.policy.IdleAfter-u.policy.WarnBefore {
+		return "", nil
+	}
+
+	warnedAt, warned, err := u.warnedSince(ctx, user.UserID, last)
+	if err != nil {
+		return "", err
+	}
+	if !warned {
+		if !dryRun {
+			u.warn(ctx, user, last)
+		}
+		return entity.IdleWarned, nil
+	}
+	if idleFor < u.policy.IdleAfter || now.Sub(warnedAt) < u.policy.WarnBefore {
+		return entity.IdleWarned, nil
+	}
+
+	if !dryRun {
+		if _, err := u.userUC.SetIsActive(ctx, user.UserID, false); err != nil {
+			return "", err
+		}
+		logging.From(ctx, u.logger).Info("idle user deactivated",
+			zap.String("user_id", user.UserID.String()),
+			zap.Time("last_active_at", last),
+		)
+	}
+	return entity.IdleDeactivated, nil
+}
+
+func (u *IdleUsecaseImpl) warn(ctx context.Context, user entity.User, last time.Time) {
+	deactivateAt := last.Add(u.policy.IdleAfter)
+	logging.From(ctx, u.logger).Warn("user is idle and will be deactivated",
+		zap.String("user_id", user.UserID.String()),
+		zap.Time("last_active_at", last),
+		zap.Time("deactivate_at", deactivateAt),
+	)
+	u.audit.Record(ctx, entity.AuditUserIdleWarning, entity.AuditUser, user.UserID.String(), map[string]string{
+		"team_name":      user.TeamName,
+		"last_active_at": last.Format(time.RFC3339),
+		"deactivate_at":  deactivateAt.Format(time.RFC3339),
+	})
+}
+
+// warnedSince returns the latest idle warning of the user recorded after
+// since.
+func (u *IdleUsecaseImpl) warnedSince(ctx context.Context, userID uuid.UUID, since time.Time) (time.Time, bool, error) {
+	entries, _, err := u.auditRepo.ListAudit(ctx, entity.AuditFilter{
+		Action:     entity.AuditUserIdleWarning,
+		EntityType: entity.AuditUser,
+		EntityID:   userID.String(),
+		From:       &since,
+		Limit:      1,
+	})
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list idle warnings", zap.String("user_id", userID.String()), zap.Error(err))
+		return time.Time{}, false, err
+	}
+	if len(entries) == 0 {
+		return time.Time{}, false, nil
+	}
+	return entries[0].OccurredAt, true, nil
+}
+
+func (u *IdleUsecaseImpl) lastActivity(ctx context.Context) (map[uuid.UUID]time.Time, error) {
+	last := make(map[uuid.UUID]time.Time)
+	seen := func(userID uuid.UUID, at time.Time) {
+		if at.After(last[userID]) {
+			last[userID] = at
+		}
+	}
+
+	for _, status := range entity.PullRequestStatuses() {
+		prs, err := u.prRepo.GetPullRequestsByStatus(ctx, status)
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get PRs by status", zap.Error(err))
+			return nil, err
+		}
+		for _, pr := range prs {
+			seen(pr.AuthorID, pr.CreatedAt)
+			for _, approval := range pr.Approvals {
+				seen(approval.UserID, approval.ApprovedAt)
+			}
+		}
+	}
+
+	// Reactivating a user restarts their idle period, so an admin bringing
+	// back a deactivated user is not overruled on the next run.
+	activations, _, err := u.auditRepo.ListAudit(ctx, entity.AuditFilter{
+		Action:     entity.AuditUserActivated,
+		EntityType: entity.AuditUser,
+	})
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list user activations", zap.Error(err))
+		return nil, err
+	}
+	for _, entry := range activations {
+		if userID, err := uuid.Parse(entry.EntityID); err == nil {
+			seen(userID, entry.OccurredAt)
+		}
+	}
+	return last, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidMergePolicy = newError(CodeInvalidInput, "invalid merge policy")
+	// ErrMergePolicyViolation errors carry the failed rule in the "rule"
+	// detail.
+	ErrMergePolicyViolation = newError(CodeMergePolicy, "merge policy violation")
+)
+
+var _ MergePolicyUsecase = (*MergePolicyUsecaseImpl)(nil)
+
+type MergePolicyUsecaseImpl struct {
+	mergePolicyRepo repository.MergePolicyRepository
+	teamRepo        repository.TeamRepository
+	logger          *zap.Logger
+}
+
+func NewMergePolicyUsecase(
+	mergePolicyRepo repository.MergePolicyRepository,
+	teamRepo repository.TeamRepository,
+	logger *zap.Logger,
+) *MergePolicyUsecaseImpl {
+	return &MergePolicyUsecaseImpl{
+		mergePolicyRepo: mergePolicyRepo,
+		teamRepo:        teamRepo,
+		logger:          logger,
+	}
+}
+
+func (u *MergePolicyUsecaseImpl) SetPolicy(ctx context.Context, policy entity.MergePolicy) (entity.MergePolicy, error) {
+	logging.From(ctx, u.logger).Info("setting merge policy",
+		zap.String("team_name", policy.TeamName),
+		zap.Int("min_approvals", policy.MinApprovals),
+	)
+
+	if policy.MinApprovals < 0 {
+		return entity.MergePolicy{}, ErrInvalidMergePolicy.Withf("invalid merge policy: min_approvals must not be negative").
+			WithDetail("field", "min_approvals")
+	}
+
+	if err := u.mergePolicyRepo.SetMergePolicy(ctx, &policy); err != nil {
+		logging.From(ctx, u.logger).Error("failed to set merge policy", zap.Error(err))
+		return entity.MergePolicy{}, notFound(err, "team %s not found", policy.TeamName)
+	}
+
+	return policy, nil
+}
+
+func (u *MergePolicyUsecaseImpl) GetPolicy(ctx context.Context, teamName string) (entity.MergePolicy, error) {
+	exists, err := u.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to check team existence", zap.Error(err))
+		return entity.MergePolicy{}, err
+	}
+	if !exists {
+		return entity.MergePolicy{}, ErrNotFound.Withf("team %s not found", teamName)
+	}
+
+	return loadMergePolicy(ctx, u.mergePolicyRepo, teamName)
+}
+
+// loadMergePolicy returns an empty policy for teams that never configured one.
+func loadMergePolicy(ctx context.Context, mergePolicyRepo repository.MergePolicyRepository, teamName string) (entity.MergePolicy, error) {
+	policy, err := mergePolicyRepo.GetMergePolicy(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return entity.MergePolicy{TeamName: teamName}, nil
+		}
+		return entity.MergePolicy{}, err
+	}
+	return *policy, nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ MilestoneUsecase = (*MilestoneUsecaseImpl)(nil)
+
+type MilestoneUsecaseImpl struct {
+	milestoneRepo repository.MilestoneRepository
+	prRepo        repository.PullRequestRepository
+	logger        *zap.Logger
+}
+
+func NewMilestoneUsecase(
+	milestoneRepo repository.MilestoneRepository,
+	prRepo repository.PullRequestRepository,
+	logger *zap.Logger,
+) *MilestoneUsecaseImpl {
+	return &MilestoneUsecaseImpl{
+		milestoneRepo: milestoneRepo,
+		prRepo:        prRepo,
+		logger:        logger,
+	}
+}
+
+func (u *MilestoneUsecaseImpl) CreateMilestone(ctx context.Context, milestone entity.Milestone) (entity.Milestone, error) {
+	if milestone.MilestoneID == uuid.Nil {
+		milestone.MilestoneID = uuid.New()
+	}
+	milestone.CreatedAt = time.Now()
+
+	logging.From(ctx, u.logger).Info("creating milestone",
+		zap.String("milestone_id", milestone.MilestoneID.String()),
+		zap.String("title", milestone.Title),
+	)
+
+	if err := u.milestoneRepo.CreateMilestone(ctx, &milestone); err != nil {
+		logging.From(ctx, u.logger).Error("failed to create milestone", zap.Error(err))
+		return entity.Milestone{}, alreadyExists(err, ErrMilestoneExists.Withf("milestone %s already exists", milestone.MilestoneID))
+	}
+
+	return milestone, nil
+}
+
+func (u *MilestoneUsecaseImpl) GetMilestone(ctx context.Context, milestoneID uuid.UUID) (entity.Milestone, error) {
+	milestone, err := u.milestoneRepo.GetMilestone(ctx, milestoneID)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get milestone", zap.String("milestone_id", milestoneID.String()), zap.Error(err))
+		return entity.Milestone{}, notFound(err, "milestone %s not found", milestoneID)
+	}
+	return *milestone, nil
+}
+
+func (u *MilestoneUsecaseImpl) ListMilestones(ctx context.Context) ([]entity.Milestone, error) {
+	milestones, err := u.milestoneRepo.ListMilestones(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list milestones", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]entity.Milestone, len(milestones))
+	for i, milestone := range milestones {
+		result[i] = *milestone
+	}
+	return result, nil
+}
+
+func (u *MilestoneUsecaseImpl) UpdateMilestone(ctx context.Context, milestone entity.Milestone) (entity.Milestone, error) {
+	logging.From(ctx, u.logger).Info("updating milestone", zap.String("milestone_id", milestone.MilestoneID.String()))
+
+	existing, err := u.GetMilestone(ctx, milestone.MilestoneID)
+	if err != nil {
+		return entity.Milestone{}, err
+	}
+	milestone.CreatedAt = existing.CreatedAt
+
+	if err := u.milestoneRepo.UpdateMilestone(ctx, &milestone); err != nil {
+		logging.From(ctx, u.logger).Error("failed to update milestone", zap.Error(err))
+		return entity.Milestone{}, notFound(err, "milestone %s not found", milestone.MilestoneID)
+	}
+
+	return milestone, nil
+}
+
+func (u *MilestoneUsecaseImpl) DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error {
+	logging.From(ctx, u.logger).Info("deleting milestone", zap.String("milestone_id", milestoneID.String()))
+
+	if err := u.milestoneRepo.DeleteMilestone(ctx, milestoneID); err != nil {
+		logging.From(ctx, u.logger).Error("failed to delete milestone", zap.Error(err))
+		return notFound(err, "milestone %s not found", milestoneID)
+	}
+	return nil
+}
+
+func (u *MilestoneUsecaseImpl) GetMilestoneStats(ctx context.Context, milestoneID uuid.UUID) (entity.MilestoneStats, error) {
+	if _, err := u.GetMilestone(ctx, milestoneID); err != nil {
+		return entity.MilestoneStats{}, err
+	}
+
+	prs, err := u.prRepo.GetPullRequestsByMilestone(ctx, milestoneID)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get milestone PRs", zap.Error(err))
+		return entity.MilestoneStats{}, err
+	}
+
+	stats := entity.MilestoneStats{
+		MilestoneID: milestoneID,
+		Total:       len(prs),
+	}
+	for _, pr := range prs {
+		switch pr.Status {
+		case entity.StatusOpen, entity.StatusChangesRequested:
+			stats.Open++
+		case entity.StatusMerged:
+			stats.Merged++
+		case entity.StatusClosed:
+			stats.Closed++
+		}
+	}
+
+	return stats, nil
+}
+
+func (u *MilestoneUsecaseImpl) SetPRMilestone(ctx context.Context, prID uuid.UUID, milestoneID *uuid.UUID) (entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("setting PR milestone", zap.String("pr_id", prID.String()))
+
+	if milestoneID != nil {
+		if _, err := u.GetMilestone(ctx, *milestoneID); err != nil {
+			return entity.PullRequest{}, err
+		}
+	}
+
+	return retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		stored, err := u.prRepo.GetPullRequest(ctx, prID)
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get PR", zap.String("pr_id", prID.String()), zap.Error(err))
+			return entity.PullRequest{}, notFound(err, "PR %s not found", prID)
+		}
+
+		pr := *stored
+		pr.MilestoneID = milestoneID
+
+		if err := u.prRepo.UpdatePullRequestIf(ctx, &pr, pr.Version); err != nil {
+			logConflictOr(ctx, u.logger, "failed to update PR", err)
+			return entity.PullRequest{}, invalidReviewers(err, &pr)
+		}
+
+		return pr, nil
+	})
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Clock returns the current time; tests and simulations can substitute a
+// fixed or accelerated one.
+type Clock func() time.Time
+
+var _ Notifier = (*LogNotifier)(nil)
+
+// LogNotifier is the default Notifier: it only writes events to the log.
+type LogNotifier struct {
+	logger *zap.Logger
+}
+
+func NewLogNotifier(logger *zap.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, event entity.PullRequestEvent) {
+	fields := []zap.Field{
+		zap.String("event", string(event.Type)),
+		zap.String("pr_id", event.PullRequest.PullRequestID.String()),
+		zap.Time("occurred_at", event.OccurredAt),
+	}
+	if event.UserID != uuid.Nil {
+		fields = append(fields, zap.String("user_id", event.UserID.String()))
+	}
+	if event.PreviousUserID != uuid.Nil {
+		fields = append(fields, zap.String("previous_user_id", event.PreviousUserID.String()))
+	}
+	logging.From(ctx, n.logger).Info("pull request event", fields...)
+}
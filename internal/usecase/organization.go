@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+var ErrInvalidOrganization = newError(CodeInvalidInput, "invalid organization")
+
+var orgIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+var _ OrganizationUsecase = (*OrganizationUsecaseImpl)(nil)
+
+type OrganizationUsecaseImpl struct {
+	orgRepo repository.OrganizationRepository
+	audit   AuditUsecase
+	logger  *zap.Logger
+}
+
+func NewOrganizationUsecase(orgRepo repository.OrganizationRepository, audit AuditUsecase, logger *zap.Logger) *OrganizationUsecaseImpl {
+	return &OrganizationUsecaseImpl{
+		orgRepo: orgRepo,
+		audit:   audit,
+		logger:  logger,
+	}
+}
+
+func (u *OrganizationUsecaseImpl) CreateOrganization(ctx context.Context, org entity.Organization) (entity.Organization, error) {
+	logging.From(ctx, u.logger).Info("creating organization", zap.String("org_id", org.OrgID))
+
+	if !orgIDPattern.MatchString(org.OrgID) {
+		return entity.Organization{}, ErrInvalidOrganization.Withf("invalid organization: org_id must be 1-63 lowercase letters, digits or dashes").
+			WithDetail("field", "org_id")
+	}
+	if org.Name == "" {
+		org.Name = org.OrgID
+	}
+	org.CreatedAt = time.Now()
+
+	if err := u.orgRepo.CreateOrganization(ctx, &org); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			logging.From(ctx, u.logger).Warn("organization already exists", zap.String("org_id", org.OrgID))
+		} else {
+			logging.From(ctx, u.logger).Error("failed to create organization", zap.Error(err))
+		}
+		return entity.Organization{}, alreadyExists(err, ErrOrgExists)
+	}
+
+	logging.From(ctx, u.logger).Info("organization created", zap.String("org_id", org.OrgID))
+	u.audit.Record(ctx, entity.AuditOrganizationCreated, entity.AuditOrganization, org.OrgID, map[string]string{"name": org.Name})
+	return org, nil
+}
+
+func (u *OrganizationUsecaseImpl) GetOrganization(ctx context.Context, orgID string) (entity.Organization, error) {
+	org, err := u.orgRepo.GetOrganization(ctx, orgID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			logging.From(ctx, u.logger).Error("failed to get organization", zap.Error(err))
+		}
+		return entity.Organization{}, notFound(err, "organization %s not found", orgID)
+	}
+	return *org, nil
+}
+
+func (u *OrganizationUsecaseImpl) ListOrganizations(ctx context.Context) ([]entity.Organization, error) {
+	orgs, err := u.orgRepo.ListOrganizations(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list organizations", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]entity.Organization, len(orgs))
+	for i, org := range orgs {
+		result[i] = *org
+	}
+	return result, nil
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+	"avito-intro/internal/tenant"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ OutboxUsecase = (*OutboxUsecaseImpl)(nil)
+
+// OutboxUsecaseImpl stores PR events in the outbox of the organization
+// they happened in and delivers them to every sink. Without sinks the
+// outbox is off and Enqueue stores nothing.
+type OutboxUsecaseImpl struct {
+	orgRepo    repository.OrganizationRepository
+	outboxRepo repository.OutboxRepository
+	sinks      []OutboxSink
+	policy     entity.OutboxPolicy
+	clock      Clock
+	logger     *zap.Logger
+}
+
+func NewOutboxUsecase(
+	orgRepo repository.OrganizationRepository,
+	outboxRepo repository.OutboxRepository,
+	sinks []OutboxSink,
+	policy entity.OutboxPolicy,
+	clock Clock,
+	logger *zap.Logger,
+) *OutboxUsecaseImpl {
+	return &OutboxUsecaseImpl{
+		orgRepo:    orgRepo,
+		outboxRepo: outboxRepo,
+		sinks:      sinks,
+		policy:     policy,
+		clock:      clock,
+		logger:     logger,
+	}
+}
+
+func (u *OutboxUsecaseImpl) Enqueue(ctx context.Context, event entity.PullRequestEvent) error {
+	if len(u.sinks) == 0 {
+		return nil
+	}
+
+	now := u.clock()
+	msg := &entity.OutboxMessage{
+		ID:            uuid.New(),
+		Event:         event,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+	if err := u.outboxRepo.AppendOutbox(ctx, msg); err != nil {
+		logging.From(ctx, u.logger).Error("failed to queue outbox message",
+			zap.String("event", string(event.Type)),
+			zap.String("pr_id", event.PullRequest.PullRequestID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// Dispatch delivers up to a batch of due messages per organization.
+// Results are reported only for organizations that had messages due.
+func (u *OutboxUsecaseImpl) Dispatch(ctx context.Context) ([]entity.DispatchResult, error) {
+	if len(u.sinks) == 0 {
+		return nil, nil
+	}
+
+	orgs, err := u.orgRepo.ListOrganizations(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list organizations", zap.Error(err))
+		return nil, err
+	}
+
+	var results []entity.DispatchResult
+	for _, org := range orgs {
+		orgCtx := tenant.WithOrganization(ctx, org.OrgID)
+
+		messages, err := u.outboxRepo.ListOutbox(orgCtx, u.clock(), u.policy.BatchSize)
+		if err != nil {
+			logging.From(orgCtx, u.logger).Error("failed to list outbox messages",
+				zap.String("org_id", org.OrgID),
+				zap.Error(err),
+			)
+			return results, err
+		}
+		if len(messages) == 0 {
+			continue
+		}
+
+		result := entity.DispatchResult{OrgID: org.OrgID}
+		for _, msg := range messages {
+			delivered, err := u.deliver(orgCtx, msg)
+			if err != nil {
+				return append(results, result), err
+			}
+			if delivered {
+				result.Delivered++
+			} else {
+				result.Failed++
+			}
+		}
+		logging.From(orgCtx, u.logger).Info("outbox dispatched",
+			zap.String("org_id", org.OrgID),
+			zap.Int("delivered", result.Delivered),
+			zap.Int("failed", result.Failed),
+		)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// deliver publishes msg to every sink. A delivered message is removed from
+// the outbox; a failed one is rescheduled with the next backoff and
+// published to every sink again on retry. The error is about the outbox
+// itself, not the sinks.
+func (u *OutboxUsecaseImpl) deliver(ctx context.Context, msg *entity.OutboxMessage) (bool, error) {
+	var failures []string
+	for _, sink := range u.sinks {
+		if err := sink.Publish(ctx, *msg); err != nil {
+			failures = append(failures, sink.Name()+": "+err.Error())
+		}
+	}
+
+	if len(failures) == 0 {
+		// Another instance may have delivered and removed it meanwhile.
+		if err := u.outboxRepo.DeleteOutbox(ctx, msg.ID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			logging.From(ctx, u.logger).Error("failed to remove delivered outbox message", zap.String("message_id", msg.ID.String()), zap.Error(err))
+			return true, err
+		}
+		return true, nil
+	}
+
+	msg.Attempts++
+	msg.LastError = strings.Join(failures, "; ")
+	msg.NextAttemptAt = u.clock().Add(u.backoff(msg.Attempts))
+	logging.From(ctx, u.logger).Warn("failed to deliver outbox message",
+		zap.String("message_id", msg.ID.String()),
+		zap.String("event", string(msg.Event.Type)),
+		zap.Int("attempts", msg.Attempts),
+		zap.Time("next_attempt_at", msg.NextAttemptAt),
+		zap.String("error", msg.LastError),
+	)
+	if err := u.outboxRepo.UpdateOutbox(ctx, msg); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		logging.From(ctx, u.logger).Error("failed to reschedule outbox message", zap.String("message_id", msg.ID.String()), zap.Error(err))
+		return false, err
+	}
+	return false, nil
+}
+
+// backoff is the delay before retry number attempts: RetryBackoff doubled
+// per earlier attempt, capped at MaxRetryBackoff.
+func (u *OutboxUsecaseImpl) backoff(attempts int) time.Duration {
+	delay := u.policy.RetryBackoff
+	for range attempts - 1 {
+		if u.policy.MaxRetryBackoff > 0 && delay >= u.policy.MaxRetryBackoff {
+			break
+		}
+		delay *= 2
+	}
+	if u.policy.MaxRetryBackoff > 0 && delay > u.policy.MaxRetryBackoff {
+		delay = u.policy.MaxRetryBackoff
+	}
+	return delay
+}
+
+var _ OutboxSink = (*LogSink)(nil)
+
+// LogSink is the outbox sink that only writes messages to the log.
+type LogSink struct {
+	logger *zap.Logger
+}
+
+func NewLogSink(logger *zap.Logger) *LogSink {
+	return &LogSink{logger: logger}
+}
+
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+func (s *LogSink) Publish(ctx context.Context, msg entity.OutboxMessage) error {
+	logging.From(ctx, s.logger).Info("outbox message published",
+		zap.String("message_id", msg.ID.String()),
+		zap.String("event", string(msg.Event.Type)),
+		zap.String("pr_id", msg.Event.PullRequest.PullRequestID.String()),
+		zap.Time("occurred_at", msg.Event.OccurredAt),
+	)
+	return nil
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fillOwnerPeerReviewers implements the two-tier mode: one reviewer from the
+// team owners in the OWNER slot plus one teammate in the PEER slot. Reviewers
+// that are already on the PR keep their slots; unslotted ones (explicitly
+// requested or assigned before the mode was enabled) are classified first.
+func (u *PullRequestUsecaseImpl) fillOwnerPeerReviewers(ctx context.Context, strategy AssignmentStrategy, author entity.User, teamMembers []*entity.User, pr *entity.PullRequest) error {
+	owners, err := u.ownershipRepo.GetTeamOwners(ctx, author.TeamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team owners", zap.String("team_name", author.TeamName), zap.Error(err))
+		return err
+	}
+
+	slots := maps.Clone(pr.ReviewerSlots)
+	if slots == nil {
+		slots = make(map[uuid.UUID]entity.ReviewerSlot, 2)
+	}
+	hasSlot := func(slot entity.ReviewerSlot) bool {
+		for _, s := range slots {
+			if s == slot {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, id := range pr.AssignedReviewers {
+		if _, ok := slots[id]; ok {
+			continue
+		}
+		switch {
+		case !hasSlot(entity.SlotOwner) && slices.Contains(owners, id):
+			slots[id] = entity.SlotOwner
+		case !hasSlot(entity.SlotPeer):
+			slots[id] = entity.SlotPeer
+		default:
+			slots[id] = entity.SlotOptional
+		}
+	}
+
+	candidates := u.filterReplacementCandidates(teamMembers, author.UserID, pr.AssignedReviewers)
+	ownerCandidates, peerCandidates := splitByOwnership(candidates, owners)
+
+	if !hasSlot(entity.SlotOwner) {
+		selected, err := strategy.SelectReviewers(ctx, ownerCandidates, 1)
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to select owner reviewer", zap.Error(err))
+			return err
+		}
+		if len(selected) == 0 {
+			logging.From(ctx, u.logger).Warn("no active owner available for PR",
+				zap.String("pr_id", pr.PullRequestID.String()),
+				zap.String("team_name", author.TeamName),
+			)
+		}
+		for _, id := range selected {
+			slots[id] = entity.SlotOwner
+			pr.AssignedReviewers = append(slices.Clone(pr.AssignedReviewers), id)
+			ownerCandidates = slices.DeleteFunc(ownerCandidates, func(c entity.User) bool { return c.UserID == id })
+		}
+	}
+
+	if !hasSlot(entity.SlotPeer) {
+		// Owners can act as peers when the team has no one else available
+		if len(peerCandidates) == 0 {
+			peerCandidates = ownerCandidates
+		}
+		selected, err := strategy.SelectReviewers(ctx, peerCandidates, 1)
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to select peer reviewer", zap.Error(err))
+			return err
+		}
+		for _, id := range selected {
+			slots[id] = entity.SlotPeer
+			pr.AssignedReviewers = append(slices.Clone(pr.AssignedReviewers), id)
+		}
+	}
+
+	pr.ReviewerSlots = slots
+	return nil
+}
+
+func (u *PullRequestUsecaseImpl) validateOwnerPeerRequest(ctx context.Context, review teamReview, teamName string, requested []uuid.UUID) error {
+	if review.Mode != ReviewModeOwnerPeer || len(requested) < 2 {
+		return nil
+	}
+
+	owners, err := u.ownershipRepo.GetTeamOwners(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team owners", zap.String("team_name", teamName), zap.Error(err))
+		return err
+	}
+
+	if !slices.ContainsFunc(requested, func(id uuid.UUID) bool { return slices.Contains(owners, id) }) {
+		return ErrInvalidReviewer.Withf("invalid reviewer: in owner_peer mode one of the requested reviewers must be a team owner")
+	}
+	return nil
+}
+
+func splitByOwnership(users []entity.User, owners []uuid.UUID) (ownerUsers, others []entity.User) {
+	for _, user := range users {
+		if slices.Contains(owners, user.UserID) {
+			ownerUsers = append(ownerUsers, user)
+		} else {
+			others = append(others, user)
+		}
+	}
+	return ownerUsers, others
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidOwner     = newError(CodeInvalidOwner, "invalid owner")
+	ErrInvalidComponent = newError(CodeInvalidInput, "invalid component")
+)
+
+var _ OwnershipUsecase = (*OwnershipUsecaseImpl)(nil)
+
+type OwnershipUsecaseImpl struct {
+	ownershipRepo repository.OwnershipRepository
+	teamRepo      repository.TeamRepository
+	userRepo      repository.UserRepository
+	logger        *zap.Logger
+}
+
+func NewOwnershipUsecase(
+	ownershipRepo repository.OwnershipRepository,
+	teamRepo repository.TeamRepository,
+	userRepo repository.UserRepository,
+	logger *zap.Logger,
+) *OwnershipUsecaseImpl {
+	return &OwnershipUsecaseImpl{
+		ownershipRepo: ownershipRepo,
+		teamRepo:      teamRepo,
+		userRepo:      userRepo,
+		logger:        logger,
+	}
+}
+
+func (u *OwnershipUsecaseImpl) SetTeamOwners(ctx context.Context, teamName string, owners []uuid.UUID) ([]uuid.UUID, error) {
+	logging.From(ctx, u.logger).Info("setting team owners",
+		zap.String("team_name", teamName),
+		zap.Int("owners", len(owners)),
+	)
+
+	team, err := u.teamRepo.GetTeam(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team", zap.String("team_name", teamName), zap.Error(err))
+		return nil, notFound(err, "team %s not found", teamName)
+	}
+
+	members, err := u.userRepo.GetUsersByIDs(ctx, team.Members)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team members", zap.Error(err))
+		return nil, err
+	}
+
+	unique, err := checkOwners(teamName, members, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.ownershipRepo.SetTeamOwners(ctx, teamName, unique); err != nil {
+		logging.From(ctx, u.logger).Error("failed to set team owners", zap.Error(err))
+		return nil, err
+	}
+
+	return unique, nil
+}
+
+func (u *OwnershipUsecaseImpl) GetTeamOwners(ctx context.Context, teamName string) ([]uuid.UUID, error) {
+	exists, err := u.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to check team existence", zap.Error(err))
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound.Withf("team %s not found", teamName)
+	}
+
+	owners, err := u.ownershipRepo.GetTeamOwners(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team owners", zap.Error(err))
+		return nil, err
+	}
+	return owners, nil
+}
+
+// SetComponentOwners replaces the team's component map. Paths are relative
+// to the repository root; leading and trailing slashes are dropped.
+func (u *OwnershipUsecaseImpl) SetComponentOwners(ctx context.Context, teamName string, components []entity.ComponentOwner) ([]entity.ComponentOwner, error) {
+	logging.From(ctx, u.logger).Info("setting component owners",
+		zap.String("team_name", teamName),
+		zap.Int("components", len(components)),
+	)
+
+	team, err := u.teamRepo.GetTeam(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team", zap.String("team_name", teamName), zap.Error(err))
+		return nil, notFound(err, "team %s not found", teamName)
+	}
+
+	members, err := u.userRepo.GetUsersByIDs(ctx, team.Members)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team members", zap.Error(err))
+		return nil, err
+	}
+
+	saved := make([]entity.ComponentOwner, 0, len(components))
+	for _, component := range components {
+		path := strings.Trim(component.Path, "/")
+		if path == "" {
+			return nil, ErrInvalidComponent.Withf("invalid component: path is required")
+		}
+		if slices.ContainsFunc(saved, func(c entity.ComponentOwner) bool { return c.Path == path }) {
+			return nil, ErrInvalidComponent.Withf("invalid component %s: listed more than once", path).
+				WithDetail("path", path)
+		}
+		if len(component.Owners) == 0 {
+			return nil, ErrInvalidComponent.Withf("invalid component %s: at least one owner is required", path).
+				WithDetail("path", path)
+		}
+
+		owners, err := checkOwners(teamName, members, component.Owners)
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, entity.ComponentOwner{Path: path, Owners: owners})
+	}
+
+	if err := u.ownershipRepo.SetComponentOwners(ctx, teamName, saved); err != nil {
+		logging.From(ctx, u.logger).Error("failed to set component owners", zap.Error(err))
+		return nil, notFound(err, "team %s not found", teamName)
+	}
+
+	return saved, nil
+}
+
+func (u *OwnershipUsecaseImpl) GetComponentOwners(ctx context.Context, teamName string) ([]entity.ComponentOwner, error) {
+	exists, err := u.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to check team existence", zap.Error(err))
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound.Withf("team %s not found", teamName)
+	}
+
+	components, err := u.ownershipRepo.GetComponentOwners(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get component owners", zap.Error(err))
+		return nil, err
+	}
+	return components, nil
+}
+
+// checkOwners requires every owner to be an active member of the team and
+// returns them without duplicates.
+func checkOwners(teamName string, members []*entity.User, owners []uuid.UUID) ([]uuid.UUID, error) {
+	unique := make([]uuid.UUID, 0, len(owners))
+	for _, id := range owners {
+		if slices.Contains(unique, id) {
+			continue
+		}
+
+		idx := slices.IndexFunc(members, func(m *entity.User) bool { return m.UserID == id })
+		switch {
+		case idx < 0:
+			return nil, ErrInvalidOwner.Withf("invalid owner %s: not a member of team %s", id, teamName).
+				WithDetail("user_id", id.String())
+		case members[idx].IsDeleted():
+			return nil, ErrInvalidOwner.Withf("invalid owner %s: user is deleted", id).
+				WithDetail("user_id", id.String())
+		case !members[idx].IsActive:
+			return nil, ErrInvalidOwner.Withf("invalid owner %s: user is inactive", id).
+				WithDetail("user_id", id.String())
+		}
+		unique = append(unique, id)
+	}
+	return unique, nil
+}
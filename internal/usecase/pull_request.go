@@ -3,11 +3,13 @@ package usecase
 import (
 	"context"
 	"errors"
-	"math/rand"
+	"maps"
 	"slices"
+	"strings"
 	"time"
 
 	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
 	"avito-intro/internal/repository"
 
 	"github.com/google/uuid"
@@ -15,77 +17,190 @@ import (
 )
 
 var (
-	ErrPRMerged    = errors.New("PR is already merged")
-	ErrNotAssigned = errors.New("reviewer is not assigned to this PR")
-	ErrNoCandidate = errors.New("no active replacement candidate in team")
+	ErrPRMerged         = newError(CodePRMerged, "PR is already merged")
+	ErrPRClosed         = newError(CodePRClosed, "PR is closed")
+	ErrChangesRequested = newError(CodeChangesRequested, "changes are requested on PR")
+	ErrNotAssigned      = newError(CodeNotAssigned, "reviewer is not assigned to this PR")
+	ErrNoCandidate      = newError(CodeNoCandidate, "no active replacement candidate in team")
+
+	ErrInvalidReviewer  = newError(CodeInvalidReviewer, "invalid reviewer")
+	ErrApprovalsPending = newError(CodeApprovalsPending, "required reviewers have not approved")
+
+	ErrOverrideReason = newError(CodeInvalidInput, "reason is required").WithDetail("field", "reason")
+	ErrDeclineReason  = newError(CodeInvalidInput, "reason is required").WithDetail("field", "reason")
+	ErrEmptyPRName    = newError(CodeInvalidInput, "pull_request_name must not be empty").WithDetail("field", "pull_request_name")
+	ErrInvalidPRName  = newError(CodeInvalidPRName, "PR name does not match the team's pattern")
 )
 
 var _ PullRequestUsecase = (*PullRequestUsecaseImpl)(nil)
 
 type PullRequestUsecaseImpl struct {
-	userRepo repository.UserRepository
-	prRepo   repository.PullRequestRepository
-	logger   *zap.Logger
+	userRepo        repository.UserRepository
+	prRepo          repository.PullRequestRepository
+	checklistRepo   repository.ChecklistRepository
+	ownershipRepo   repository.OwnershipRepository
+	mergePolicyRepo repository.MergePolicyRepository
+	settingsRepo    repository.TeamSettingsRepository
+	globalRepo      repository.GlobalSettingsRepository
+	tx              repository.Transactor
+	locker          repository.Locker
+	changedFiles    ChangedFilesProvider
+	strategy        AssignmentStrategy
+	strategies      map[string]AssignmentStrategy
+	review          ReviewSettings
+	quota           QuotaUsecase
+	clock           Clock
+	notifier        Notifier
+	outbox          OutboxUsecase
+	logger          *zap.Logger
 }
 
 func NewPullRequestUsecase(
 	userRepo repository.UserRepository,
 	prRepo repository.PullRequestRepository,
+	checklistRepo repository.ChecklistRepository,
+	ownershipRepo repository.OwnershipRepository,
+	mergePolicyRepo repository.MergePolicyRepository,
+	settingsRepo repository.TeamSettingsRepository,
+	globalRepo repository.GlobalSettingsRepository,
+	tx repository.Transactor,
+	locker repository.Locker,
+	changedFiles ChangedFilesProvider,
+	strategy AssignmentStrategy,
+	strategies map[string]AssignmentStrategy,
+	review ReviewSettings,
+	quota QuotaUsecase,
+	clock Clock,
+	notifier Notifier,
+	outbox OutboxUsecase,
 	logger *zap.Logger,
 ) *PullRequestUsecaseImpl {
 	return &PullRequestUsecaseImpl{
-		userRepo: userRepo,
-		prRepo:   prRepo,
-		logger:   logger,
+		userRepo:        userRepo,
+		prRepo:          prRepo,
+		checklistRepo:   checklistRepo,
+		ownershipRepo:   ownershipRepo,
+		mergePolicyRepo: mergePolicyRepo,
+		settingsRepo:    settingsRepo,
+		globalRepo:      globalRepo,
+		tx:              tx,
+		locker:          locker,
+		changedFiles:    changedFiles,
+		strategy:        strategy,
+		strategies:      strategies,
+		review:          review,
+		quota:           quota,
+		clock:           clock,
+		notifier:        notifier,
+		outbox:          outbox,
+		logger:          logger,
 	}
 }
 
-func (u *PullRequestUsecaseImpl) CreatePR(ctx context.Context, prID uuid.UUID, prName string, authorID uuid.UUID) (entity.PullRequest, error) {
-	u.logger.Info("creating pull request",
+func (u *PullRequestUsecaseImpl) CreatePR(ctx context.Context, prID uuid.UUID, prName string, authorID uuid.UUID, externalID string, reviewers []uuid.UUID) (entity.PullRequest, bool, error) {
+	logging.From(ctx, u.logger).Info("creating pull request",
 		zap.String("pr_id", prID.String()),
 		zap.String("pr_name", prName),
 		zap.String("author_id", authorID.String()),
+		zap.Int("requested_reviewers", len(reviewers)),
 	)
 
-	if err := u.checkPRNotExists(ctx, prID); err != nil {
-		return entity.PullRequest{}, err
+	if existing, found, err := u.findExistingPR(ctx, prID, prName, authorID); err != nil || found {
+		return existing, false, err
 	}
 
 	author, err := u.getAuthor(ctx, authorID)
 	if err != nil {
+		return entity.PullRequest{}, false, err
+	}
+
+	var pr entity.PullRequest
+	err = u.withAssignmentLock(ctx, author.TeamName, func(ctx context.Context) error {
+		var err error
+		pr, err = u.createPR(ctx, author, prID, prName, externalID, reviewers)
+		return err
+	})
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		// Lost a race with a concurrent delivery of the same PR
+		existing, _, err := u.findExistingPR(ctx, prID, prName, authorID)
+		return existing, false, err
+	}
+	if err != nil {
+		return entity.PullRequest{}, false, err
+	}
+
+	logging.From(ctx, u.logger).Info("pull request created successfully",
+		zap.String("pr_id", prID.String()),
+		zap.Int("reviewers_count", len(pr.AssignedReviewers)),
+	)
+
+	return pr, true, nil
+}
+
+// createPR assigns reviewers to a new PR of author and stores it. It runs
+// under the assignment lock of the author's team, which also covers the
+// open PR quota check.
+func (u *PullRequestUsecaseImpl) createPR(ctx context.Context, author entity.User, prID uuid.UUID, prName string, externalID string, reviewers []uuid.UUID) (entity.PullRequest, error) {
+	if err := u.checkPRName(ctx, author.TeamName, prName); err != nil {
 		return entity.PullRequest{}, err
 	}
 
-	reviewers, err := u.assignReviewers(ctx, author)
+	if err := u.quota.CheckOpenPR(ctx, author.TeamName); err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	template, err := loadChecklistTemplate(ctx, u.checklistRepo, author.TeamName)
 	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get checklist template", zap.Error(err))
 		return entity.PullRequest{}, err
 	}
 
 	pr := entity.PullRequest{
-		PullRequestID:     prID,
-		PullRequestName:   prName,
-		AuthorID:          authorID,
-		Status:            entity.StatusOpen,
-		AssignedReviewers: reviewers,
-		CreatedAt:         time.Now(),
-		MergedAt:          nil,
+		PullRequestID:   prID,
+		PullRequestName: prName,
+		AuthorID:        author.UserID,
+		Status:          entity.StatusOpen,
+		CreatedAt:       u.clock(),
+		MergedAt:        nil,
+		Checklist:       entity.NewChecklist(template),
+		Iteration:       1,
+		ExternalID:      externalID,
+		ChangedFiles:    u.lookupChangedFiles(ctx, externalID),
 	}
 
-	if err := u.prRepo.CreatePullRequest(ctx, &pr); err != nil {
-		u.logger.Error("failed to create PR", zap.Error(err))
+	if err := u.assignReviewers(ctx, author, &pr, reviewers); err != nil {
 		return entity.PullRequest{}, err
 	}
 
-	u.logger.Info("pull request created successfully",
-		zap.String("pr_id", prID.String()),
-		zap.Int("reviewers_count", len(reviewers)),
-	)
-
+	err = u.storeWithEvents(ctx, &pr, func(ctx context.Context) error {
+		return u.prRepo.CreatePullRequest(ctx, &pr)
+	}, entity.PullRequestEvent{Type: entity.EventPRCreated})
+	if err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return entity.PullRequest{}, err
+		}
+		logging.From(ctx, u.logger).Error("failed to create PR", zap.Error(err))
+		return entity.PullRequest{}, invalidReviewers(err, &pr)
+	}
 	return pr, nil
 }
 
+// withAssignmentLock runs fn holding the reviewer assignment lock of a team,
+// so instances sharing the storage pick the team's reviewers one at a time
+// and each sees the load assigned before it. The lock is taken before any
+// transaction, never inside one.
+func (u *PullRequestUsecaseImpl) withAssignmentLock(ctx context.Context, teamName string, fn func(ctx context.Context) error) error {
+	return u.locker.WithLock(ctx, "assignment:"+teamName, fn)
+}
+
 func (u *PullRequestUsecaseImpl) MergePR(ctx context.Context, prID uuid.UUID) (entity.PullRequest, error) {
-	u.logger.Info("merging pull request", zap.String("pr_id", prID.String()))
+	return retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		return u.mergePR(ctx, prID)
+	})
+}
+
+func (u *PullRequestUsecaseImpl) mergePR(ctx context.Context, prID uuid.UUID) (entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("merging pull request", zap.String("pr_id", prID.String()))
 
 	pr, err := u.getPR(ctx, prID)
 	if err != nil {
@@ -93,27 +208,115 @@ func (u *PullRequestUsecaseImpl) MergePR(ctx context.Context, prID uuid.UUID) (e
 	}
 
 	if pr.Status == entity.StatusMerged {
-		u.logger.Info("PR already merged", zap.String("pr_id", prID.String()))
+		logging.From(ctx, u.logger).Info("PR already merged", zap.String("pr_id", prID.String()))
 		return pr, nil
 	}
 
-	pr.Status = entity.StatusMerged
-	now := time.Now()
-	pr.MergedAt = &now
+	if err := u.checkReviewable(ctx, pr, "merge"); err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	if err := u.checkMergeable(ctx, pr); err != nil {
+		if isMergeBlocked(err) {
+			logging.From(ctx, u.logger).Warn("cannot merge PR", zap.String("pr_id", prID.String()), zap.Error(err))
+		}
+		return entity.PullRequest{}, err
+	}
+
+	u.markMerged(&pr)
+
+	if err := u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{Type: entity.EventPRMerged}); err != nil {
+		logConflictOr(ctx, u.logger, "failed to update PR", err)
+		return entity.PullRequest{}, invalidReviewers(err, &pr)
+	}
+
+	logging.From(ctx, u.logger).Info("pull request merged successfully", zap.String("pr_id", prID.String()))
+	return pr, nil
+}
+
+func (u *PullRequestUsecaseImpl) ClosePR(ctx context.Context, prID uuid.UUID) (entity.PullRequest, error) {
+	return retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		return u.closePR(ctx, prID)
+	})
+}
+
+// closePR moves an open PR to CLOSED. Its reviewers stay assigned for the
+// record, but a closed PR is no longer an open review of theirs.
+func (u *PullRequestUsecaseImpl) closePR(ctx context.Context, prID uuid.UUID) (entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("closing pull request", zap.String("pr_id", prID.String()))
 
-	if err := u.prRepo.UpdatePullRequest(ctx, &pr); err != nil {
-		u.logger.Error("failed to update PR", zap.Error(err))
+	pr, err := u.getPR(ctx, prID)
+	if err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	if pr.Status == entity.StatusClosed {
+		logging.From(ctx, u.logger).Warn("PR already closed", zap.String("pr_id", prID.String()))
+		return entity.PullRequest{}, ErrPRClosed.Withf("PR is already closed")
+	}
+	if err := u.checkPROpen(ctx, pr, "close"); err != nil {
 		return entity.PullRequest{}, err
 	}
 
-	u.logger.Info("pull request merged successfully", zap.String("pr_id", prID.String()))
+	now := u.clock()
+	pr.Status = entity.StatusClosed
+	pr.ClosedAt = &now
+	pr.ChangesRequested = nil
+
+	if err := u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{Type: entity.EventPRClosed}); err != nil {
+		logConflictOr(ctx, u.logger, "failed to update PR", err)
+		return entity.PullRequest{}, invalidReviewers(err, &pr)
+	}
+
+	logging.From(ctx, u.logger).Info("pull request closed", zap.String("pr_id", prID.String()))
 	return pr, nil
 }
 
 func (u *PullRequestUsecaseImpl) ReassignReviewer(ctx context.Context, prID uuid.UUID, oldReviewerID uuid.UUID) (entity.PullRequest, uuid.UUID, error) {
-	u.logger.Info("reassigning reviewer",
+	var newReviewerID uuid.UUID
+	pr, err := retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		pr, replacedBy, err := u.reassignReviewer(ctx, prID, oldReviewerID, nil)
+		newReviewerID = replacedBy
+		return pr, err
+	})
+	if err != nil {
+		return entity.PullRequest{}, uuid.Nil, err
+	}
+	return pr, newReviewerID, nil
+}
+
+// DeclineReview lets an assigned reviewer step down from the PR. The
+// replacement is picked as by ReassignReviewer, and the reason is kept on
+// the PR.
+func (u *PullRequestUsecaseImpl) DeclineReview(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID, reason string) (entity.PullRequest, uuid.UUID, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return entity.PullRequest{}, uuid.Nil, ErrDeclineReason
+	}
+
+	var newReviewerID uuid.UUID
+	pr, err := retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		pr, replacedBy, err := u.reassignReviewer(ctx, prID, reviewerID, &entity.ReviewerDecline{
+			UserID: reviewerID,
+			Reason: reason,
+		})
+		newReviewerID = replacedBy
+		return pr, err
+	})
+	if err != nil {
+		return entity.PullRequest{}, uuid.Nil, err
+	}
+	return pr, newReviewerID, nil
+}
+
+// reassignReviewer replaces oldReviewerID with a teammate. A non-nil
+// decline is completed and recorded on the PR: the reviewer stepped down
+// themselves.
+func (u *PullRequestUsecaseImpl) reassignReviewer(ctx context.Context, prID uuid.UUID, oldReviewerID uuid.UUID, decline *entity.ReviewerDecline) (entity.PullRequest, uuid.UUID, error) {
+	logging.From(ctx, u.logger).Info("reassigning reviewer",
 		zap.String("pr_id", prID.String()),
 		zap.String("old_reviewer_id", oldReviewerID.String()),
+		zap.Bool("declined", decline != nil),
 	)
 
 	pr, err := u.getPR(ctx, prID)
@@ -121,11 +324,15 @@ func (u *PullRequestUsecaseImpl) ReassignReviewer(ctx context.Context, prID uuid
 		return entity.PullRequest{}, uuid.Nil, err
 	}
 
-	if err := u.checkPRNotMerged(pr); err != nil {
+	action := "reassign on"
+	if decline != nil {
+		action = "decline review of"
+	}
+	if err := u.checkPROpen(ctx, pr, action); err != nil {
 		return entity.PullRequest{}, uuid.Nil, err
 	}
 
-	if err := u.checkReviewerAssigned(pr, oldReviewerID); err != nil {
+	if err := u.checkReviewerAssigned(ctx, pr, oldReviewerID); err != nil {
 		return entity.PullRequest{}, uuid.Nil, err
 	}
 
@@ -134,201 +341,1212 @@ func (u *PullRequestUsecaseImpl) ReassignReviewer(ctx context.Context, prID uuid
 		return entity.PullRequest{}, uuid.Nil, err
 	}
 
-	newReviewer, err := u.findReplacementReviewer(ctx, oldReviewer.TeamName, pr.AuthorID, pr.AssignedReviewers)
-	if err != nil {
-		return entity.PullRequest{}, uuid.Nil, err
-	}
+	ownersOnly := pr.SlotOf(oldReviewerID) == entity.SlotOwner
+	var newReviewerID uuid.UUID
+	err = u.withAssignmentLock(ctx, oldReviewer.TeamName, func(ctx context.Context) error {
+		newReviewer, err := u.findReplacementReviewer(ctx, oldReviewer.TeamName, pr.AuthorID, pr.AssignedReviewers, ownersOnly)
+		if err != nil {
+			return err
+		}
 
-	u.replaceReviewer(&pr, oldReviewerID, newReviewer.UserID)
+		u.replaceReviewer(&pr, oldReviewerID, newReviewer.UserID)
+		if decline != nil {
+			decline.ReplacedBy = newReviewer.UserID
+			decline.DeclinedAt = u.clock()
+			pr.Declines = append(slices.Clone(pr.Declines), *decline)
+		}
 
-	if err := u.prRepo.UpdatePullRequest(ctx, &pr); err != nil {
-		u.logger.Error("failed to update PR", zap.Error(err))
+		err = u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{
+			Type:           entity.EventReviewerReassigned,
+			UserID:         newReviewer.UserID,
+			PreviousUserID: oldReviewerID,
+		})
+		if err != nil {
+			logConflictOr(ctx, u.logger, "failed to update PR", err)
+			return invalidReviewers(err, &pr)
+		}
+		newReviewerID = newReviewer.UserID
+		return nil
+	})
+	if err != nil {
 		return entity.PullRequest{}, uuid.Nil, err
 	}
 
-	u.logger.Info("reviewer reassigned successfully",
+	logging.From(ctx, u.logger).Info("reviewer reassigned successfully",
 		zap.String("pr_id", prID.String()),
-		zap.String("new_reviewer_id", newReviewer.UserID.String()),
+		zap.String("new_reviewer_id", newReviewerID.String()),
 	)
 
-	return pr, newReviewer.UserID, nil
+	return pr, newReviewerID, nil
 }
 
-func (u *PullRequestUsecaseImpl) GetUserReviews(ctx context.Context, userID uuid.UUID) ([]entity.PullRequest, error) {
-	u.logger.Debug("getting user reviews", zap.String("user_id", userID.String()))
+// ReplaceReviewer hands reviewerID's assignment on an open PR to an active
+// member of the author's team. Unlike ReassignReviewer it does not look the
+// old reviewer up, so it also works for reviewers who were deleted or moved
+// to another team. With dryRun the replacement is chosen but not stored.
+func (u *PullRequestUsecaseImpl) ReplaceReviewer(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID, dryRun bool) (uuid.UUID, error) {
+	return retryOnConflict(ctx, u.logger, func() (uuid.UUID, error) {
+		return u.replaceStaleReviewer(ctx, prID, reviewerID, dryRun)
+	})
+}
 
-	prs, err := u.prRepo.GetPullRequestsByReviewer(ctx, userID)
+func (u *PullRequestUsecaseImpl) replaceStaleReviewer(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID, dryRun bool) (uuid.UUID, error) {
+	pr, err := u.getPR(ctx, prID)
 	if err != nil {
-		u.logger.Error("failed to get PRs by reviewer", zap.Error(err))
-		return nil, err
+		return uuid.Nil, err
 	}
 
-	result := make([]entity.PullRequest, len(prs))
-	for i, pr := range prs {
-		result[i] = *pr
+	if err := u.checkPROpen(ctx, pr, "replace reviewer on"); err != nil {
+		return uuid.Nil, err
 	}
 
-	u.logger.Debug("user reviews retrieved",
-		zap.String("user_id", userID.String()),
-		zap.Int("count", len(result)),
-	)
-
-	return result, nil
-}
+	if err := u.checkReviewerAssigned(ctx, pr, reviewerID); err != nil {
+		return uuid.Nil, err
+	}
 
-func (u *PullRequestUsecaseImpl) checkPRNotExists(ctx context.Context, prID uuid.UUID) error {
-	exists, err := u.prRepo.PRExists(ctx, prID)
+	author, err := u.getAuthor(ctx, pr.AuthorID)
 	if err != nil {
-		u.logger.Error("failed to check PR existence", zap.Error(err))
-		return err
+		return uuid.Nil, err
 	}
 
-	if exists {
-		u.logger.Warn("PR already exists", zap.String("pr_id", prID.String()))
-		return repository.ErrAlreadyExists
+	ownersOnly := pr.SlotOf(reviewerID) == entity.SlotOwner
+	if dryRun {
+		newReviewer, err := u.findReplacementReviewer(ctx, author.TeamName, pr.AuthorID, pr.AssignedReviewers, ownersOnly)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return newReviewer.UserID, nil
 	}
 
-	return nil
-}
+	var newReviewerID uuid.UUID
+	err = u.withAssignmentLock(ctx, author.TeamName, func(ctx context.Context) error {
+		newReviewer, err := u.findReplacementReviewer(ctx, author.TeamName, pr.AuthorID, pr.AssignedReviewers, ownersOnly)
+		if err != nil {
+			return err
+		}
 
-func (u *PullRequestUsecaseImpl) getAuthor(ctx context.Context, authorID uuid.UUID) (entity.User, error) {
-	author, err := u.userRepo.GetUser(ctx, authorID)
+		u.replaceReviewer(&pr, reviewerID, newReviewer.UserID)
+
+		err = u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{
+			Type:           entity.EventReviewerReassigned,
+			UserID:         newReviewer.UserID,
+			PreviousUserID: reviewerID,
+		})
+		if err != nil {
+			logConflictOr(ctx, u.logger, "failed to update PR", err)
+			return invalidReviewers(err, &pr)
+		}
+		newReviewerID = newReviewer.UserID
+		return nil
+	})
 	if err != nil {
-		u.logger.Error("failed to get author", zap.String("author_id", authorID.String()), zap.Error(err))
-		return entity.User{}, err
+		return uuid.Nil, err
 	}
-	return *author, nil
+
+	logging.From(ctx, u.logger).Info("stale reviewer replaced",
+		zap.String("pr_id", prID.String()),
+		zap.String("old_reviewer_id", reviewerID.String()),
+		zap.String("new_reviewer_id", newReviewerID.String()),
+	)
+
+	return newReviewerID, nil
 }
 
-func (u *PullRequestUsecaseImpl) assignReviewers(ctx context.Context, author entity.User) ([]uuid.UUID, error) {
-	teamMembers, err := u.userRepo.GetUsersByTeam(ctx, author.TeamName)
+func (u *PullRequestUsecaseImpl) ApprovePR(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID) (entity.PullRequest, error) {
+	return retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		return u.approvePR(ctx, prID, reviewerID)
+	})
+}
+
+func (u *PullRequestUsecaseImpl) approvePR(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID) (entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("approving pull request",
+		zap.String("pr_id", prID.String()),
+		zap.String("reviewer_id", reviewerID.String()),
+	)
+
+	pr, err := u.getPR(ctx, prID)
 	if err != nil {
-		u.logger.Error("failed to get team members", zap.Error(err))
-		return nil, err
+		return entity.PullRequest{}, err
+	}
+
+	if err := u.checkReviewable(ctx, pr, "approve"); err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	if err := u.checkReviewerAssigned(ctx, pr, reviewerID); err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	if pr.IsApprovedBy(reviewerID) {
+		return pr, nil
 	}
 
-	candidates := u.filterActiveCandidates(teamMembers, author.UserID)
-	reviewers := u.selectRandomReviewers(candidates, 2)
+	now := u.clock()
+	pr.Approvals = append(slices.Clone(pr.Approvals), entity.Approval{
+		UserID:     reviewerID,
+		ApprovedAt: now,
+	})
+	pr.RecordResponse(reviewerID, now)
+
+	if err := u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{Type: entity.EventPRApproved, UserID: reviewerID}); err != nil {
+		logConflictOr(ctx, u.logger, "failed to update PR", err)
+		return entity.PullRequest{}, invalidReviewers(err, &pr)
+	}
 
-	u.logger.Info("reviewers assigned",
-		zap.Int("candidates", len(candidates)),
-		zap.Int("selected", len(reviewers)),
+	logging.From(ctx, u.logger).Info("pull request approved",
+		zap.String("pr_id", prID.String()),
+		zap.String("reviewer_id", reviewerID.String()),
+		zap.String("slot", string(pr.SlotOf(reviewerID))),
 	)
 
-	return reviewers, nil
+	return u.tryAutoMerge(ctx, pr)
 }
 
-func (u *PullRequestUsecaseImpl) filterActiveCandidates(teamMembers []*entity.User, authorID uuid.UUID) []entity.User {
-	var candidates []entity.User
-	for _, member := range teamMembers {
-		if member.UserID != authorID && member.IsActive {
-			candidates = append(candidates, *member)
-		}
-	}
-	return candidates
+// RequestChanges sends the PR back to its author on behalf of an assigned
+// reviewer. The approvals and a lead override are dropped: the PR needs a
+// new round of review once the author reopens it.
+func (u *PullRequestUsecaseImpl) RequestChanges(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID) (entity.PullRequest, error) {
+	return retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		return u.requestChanges(ctx, prID, reviewerID)
+	})
 }
 
-func (u *PullRequestUsecaseImpl) selectRandomReviewers(candidates []entity.User, maxCount int) []uuid.UUID {
-	count := min(len(candidates), maxCount)
-	if count == 0 {
-		return []uuid.UUID{}
+func (u *PullRequestUsecaseImpl) requestChanges(ctx context.Context, prID uuid.UUID, reviewerID uuid.UUID) (entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("requesting changes on pull request",
+		zap.String("pr_id", prID.String()),
+		zap.String("reviewer_id", reviewerID.String()),
+	)
+
+	pr, err := u.getPR(ctx, prID)
+	if err != nil {
+		return entity.PullRequest{}, err
 	}
 
-	rand.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
+	if pr.Status == entity.StatusChangesRequested {
+		logging.From(ctx, u.logger).Warn("changes already requested", zap.String("pr_id", prID.String()))
+		return entity.PullRequest{}, ErrChangesRequested.Withf("changes are already requested on PR")
+	}
+	if err := u.checkPROpen(ctx, pr, "request changes on"); err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	if err := u.checkReviewerAssigned(ctx, pr, reviewerID); err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	now := u.clock()
+	pr.Status = entity.StatusChangesRequested
+	pr.ChangesRequested = &entity.ChangeRequest{
+		UserID:      reviewerID,
+		RequestedAt: now,
+	}
+	pr.Approvals = nil
+	pr.Override = nil
+	pr.RecordResponse(reviewerID, now)
 
-	reviewers := make([]uuid.UUID, count)
-	for i := range count {
-		reviewers[i] = candidates[i].UserID
+	if err := u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{Type: entity.EventChangesRequested, UserID: reviewerID}); err != nil {
+		logConflictOr(ctx, u.logger, "failed to update PR", err)
+		return entity.PullRequest{}, invalidReviewers(err, &pr)
 	}
 
-	return reviewers
+	logging.From(ctx, u.logger).Info("changes requested on pull request",
+		zap.String("pr_id", prID.String()),
+		zap.String("reviewer_id", reviewerID.String()),
+	)
+	return pr, nil
+}
+
+// ReopenPR returns a PR with requested changes to review and starts its
+// next iteration. Only the author may reopen it; reopening an open PR is a
+// no-op.
+func (u *PullRequestUsecaseImpl) ReopenPR(ctx context.Context, prID uuid.UUID, authorID uuid.UUID) (entity.PullRequest, error) {
+	return retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		return u.reopenPR(ctx, prID, authorID)
+	})
 }
 
-func (u *PullRequestUsecaseImpl) getPR(ctx context.Context, prID uuid.UUID) (entity.PullRequest, error) {
-	pr, err := u.prRepo.GetPullRequest(ctx, prID)
+func (u *PullRequestUsecaseImpl) reopenPR(ctx context.Context, prID uuid.UUID, authorID uuid.UUID) (entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("reopening pull request",
+		zap.String("pr_id", prID.String()),
+		zap.String("author_id", authorID.String()),
+	)
+
+	pr, err := u.getPR(ctx, prID)
 	if err != nil {
-		u.logger.Error("failed to get PR", zap.String("pr_id", prID.String()), zap.Error(err))
 		return entity.PullRequest{}, err
 	}
-	return *pr, nil
-}
 
-func (u *PullRequestUsecaseImpl) getUser(ctx context.Context, userID uuid.UUID) (entity.User, error) {
-	user, err := u.userRepo.GetUser(ctx, userID)
-	if err != nil {
-		u.logger.Error("failed to get user", zap.String("user_id", userID.String()), zap.Error(err))
-		return entity.User{}, err
+	if err := u.checkPROpen(ctx, pr, "reopen"); err != nil {
+		return entity.PullRequest{}, err
 	}
-	return *user, nil
-}
 
-func (u *PullRequestUsecaseImpl) checkPRNotMerged(pr entity.PullRequest) error {
-	if pr.Status == entity.StatusMerged {
-		u.logger.Warn("cannot reassign on merged PR", zap.String("pr_id", pr.PullRequestID.String()))
-		return ErrPRMerged
+	if pr.AuthorID != authorID {
+		logging.From(ctx, u.logger).Warn("only the author can reopen PR",
+			zap.String("pr_id", prID.String()),
+			zap.String("user_id", authorID.String()),
+		)
+		return entity.PullRequest{}, ErrPermissionDenied.Withf("only the author can reopen PR")
 	}
-	return nil
-}
 
-func (u *PullRequestUsecaseImpl) checkReviewerAssigned(pr entity.PullRequest, reviewerID uuid.UUID) error {
-	if slices.Contains(pr.AssignedReviewers, reviewerID) {
-		return nil
+	if pr.Status == entity.StatusOpen {
+		logging.From(ctx, u.logger).Info("PR already open", zap.String("pr_id", prID.String()))
+		return pr, nil
 	}
 
-	u.logger.Warn("reviewer not assigned to PR",
-		zap.String("pr_id", pr.PullRequestID.String()),
-		zap.String("reviewer_id", reviewerID.String()),
+	pr.Status = entity.StatusOpen
+	pr.ChangesRequested = nil
+	pr.Iteration = pr.ReviewIterations() + 1
+
+	if err := u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{Type: entity.EventPRReopened, UserID: authorID}); err != nil {
+		logConflictOr(ctx, u.logger, "failed to update PR", err)
+		return entity.PullRequest{}, invalidReviewers(err, &pr)
+	}
+
+	logging.From(ctx, u.logger).Info("pull request reopened",
+		zap.String("pr_id", prID.String()),
+		zap.Int("iteration", pr.Iteration),
 	)
-	return ErrNotAssigned
+	return pr, nil
 }
 
-func (u *PullRequestUsecaseImpl) findReplacementReviewer(ctx context.Context, teamName string, authorID uuid.UUID, currentReviewers []uuid.UUID) (entity.User, error) {
-	teamMembers, err := u.userRepo.GetUsersByTeam(ctx, teamName)
+// OverrideApproval records a lead's approval that satisfies the approval
+// gates in place of the reviewers; the reason is kept on the PR. Whether
+// the caller may pr.override_approval is checked by the route.
+func (u *PullRequestUsecaseImpl) OverrideApproval(ctx context.Context, prID uuid.UUID, leadID uuid.UUID, reason string) (entity.PullRequest, error) {
+	return retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		return u.overrideApproval(ctx, prID, leadID, reason)
+	})
+}
+
+func (u *PullRequestUsecaseImpl) overrideApproval(ctx context.Context, prID uuid.UUID, leadID uuid.UUID, reason string) (entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("overriding pull request approval",
+		zap.String("pr_id", prID.String()),
+		zap.String("lead_id", leadID.String()),
+	)
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return entity.PullRequest{}, ErrOverrideReason
+	}
+
+	pr, err := u.getPR(ctx, prID)
 	if err != nil {
-		u.logger.Error("failed to get team members", zap.Error(err))
-		return entity.User{}, err
+		return entity.PullRequest{}, err
 	}
 
-	candidates := u.filterReplacementCandidates(teamMembers, authorID, currentReviewers)
-	if len(candidates) == 0 {
-		u.logger.Warn("no replacement candidates available")
-		return entity.User{}, ErrNoCandidate
+	if err := u.checkReviewable(ctx, pr, "override approval of"); err != nil {
+		return entity.PullRequest{}, err
 	}
 
-	selected := candidates[rand.Intn(len(candidates))]
-	return selected, nil
-}
+	pr.Override = &entity.ApprovalOverride{
+		UserID:       leadID,
+		Reason:       reason,
+		OverriddenAt: u.clock(),
+	}
 
-func (u *PullRequestUsecaseImpl) filterReplacementCandidates(teamMembers []*entity.User, authorID uuid.UUID, currentReviewers []uuid.UUID) []entity.User {
-	var candidates []entity.User
-	for _, member := range teamMembers {
-		if !member.IsActive {
-			continue
-		}
-		if member.UserID == authorID {
-			continue
-		}
-		if u.isAlreadyReviewer(member.UserID, currentReviewers) {
-			continue
-		}
-		candidates = append(candidates, *member)
+	if err := u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{Type: entity.EventApprovalOverridden, UserID: leadID}); err != nil {
+		logConflictOr(ctx, u.logger, "failed to update PR", err)
+		return entity.PullRequest{}, invalidReviewers(err, &pr)
 	}
-	return candidates
+
+	logging.From(ctx, u.logger).Info("pull request approval overridden",
+		zap.String("pr_id", prID.String()),
+		zap.String("lead_id", leadID.String()),
+		zap.Int("pending_approvals", len(pr.PendingRequiredApprovals())),
+	)
+
+	return u.tryAutoMerge(ctx, pr)
 }
 
-func (u *PullRequestUsecaseImpl) isAlreadyReviewer(userID uuid.UUID, reviewers []uuid.UUID) bool {
-	return slices.Contains(reviewers, userID)
+func (u *PullRequestUsecaseImpl) SetAutoMerge(ctx context.Context, prID uuid.UUID, enabled bool) (entity.PullRequest, error) {
+	return retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		return u.setAutoMerge(ctx, prID, enabled)
+	})
 }
 
-func (u *PullRequestUsecaseImpl) replaceReviewer(pr *entity.PullRequest, oldReviewerID, newReviewerID uuid.UUID) {
-	for i, id := range pr.AssignedReviewers {
-		if id == oldReviewerID {
-			pr.AssignedReviewers[i] = newReviewerID
-			return
+func (u *PullRequestUsecaseImpl) setAutoMerge(ctx context.Context, prID uuid.UUID, enabled bool) (entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("setting PR auto-merge",
+		zap.String("pr_id", prID.String()),
+		zap.Bool("auto_merge", enabled),
+	)
+
+	pr, err := u.getPR(ctx, prID)
+	if err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	if err := u.checkPROpen(ctx, pr, "change auto-merge on"); err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	if pr.AutoMerge == enabled {
+		return pr, nil
+	}
+	if enabled {
+		if err := requireFeature(ctx, u.globalRepo, u.logger, entity.FeatureAutoMerge); err != nil {
+			return entity.PullRequest{}, err
 		}
 	}
+
+	pr.AutoMerge = enabled
+
+	if err := u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{Type: entity.EventAutoMergeChanged}); err != nil {
+		logConflictOr(ctx, u.logger, "failed to update PR", err)
+		return entity.PullRequest{}, invalidReviewers(err, &pr)
+	}
+
+	return u.tryAutoMerge(ctx, pr)
+}
+
+// UpdatePR changes the metadata of a PR that is not merged or closed. The
+// new name must match the pattern of the author's team, like on creation.
+func (u *PullRequestUsecaseImpl) UpdatePR(ctx context.Context, prID uuid.UUID, update entity.PullRequestUpdate) (entity.PullRequest, error) {
+	return retryOnConflict(ctx, u.logger, func() (entity.PullRequest, error) {
+		return u.updatePR(ctx, prID, update)
+	})
+}
+
+func (u *PullRequestUsecaseImpl) updatePR(ctx context.Context, prID uuid.UUID, update entity.PullRequestUpdate) (entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("updating pull request", zap.String("pr_id", prID.String()))
+
+	if update.Name != nil && strings.TrimSpace(*update.Name) == "" {
+		return entity.PullRequest{}, ErrEmptyPRName
+	}
+
+	pr, err := u.getPR(ctx, prID)
+	if err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	if err := u.checkPROpen(ctx, pr, "update"); err != nil {
+		return entity.PullRequest{}, err
+	}
+
+	changed := false
+	if update.Name != nil && *update.Name != pr.PullRequestName {
+		author, err := u.getAuthor(ctx, pr.AuthorID)
+		if err != nil {
+			return entity.PullRequest{}, err
+		}
+		if err := u.checkPRName(ctx, author.TeamName, *update.Name); err != nil {
+			return entity.PullRequest{}, err
+		}
+		pr.PullRequestName = *update.Name
+		changed = true
+	}
+	if !changed {
+		return pr, nil
+	}
+
+	if err := u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{Type: entity.EventPRUpdated}); err != nil {
+		logConflictOr(ctx, u.logger, "failed to update PR", err)
+		return entity.PullRequest{}, invalidReviewers(err, &pr)
+	}
+
+	logging.From(ctx, u.logger).Info("pull request updated",
+		zap.String("pr_id", prID.String()),
+		zap.String("pr_name", pr.PullRequestName),
+	)
+	return pr, nil
+}
+
+// GetUserReviews returns a page of the PRs the user is assigned to review,
+// narrowed down and paged by filter like ListPRs.
+func (u *PullRequestUsecaseImpl) GetUserReviews(ctx context.Context, userID uuid.UUID, filter entity.PullRequestListFilter) (entity.PullRequestPage, error) {
+	logging.From(ctx, u.logger).Debug("getting user reviews", zap.String("user_id", userID.String()))
+
+	filter.ReviewerID = &userID
+	page, err := u.ListPRs(ctx, filter)
+	if err != nil {
+		return entity.PullRequestPage{}, err
+	}
+
+	logging.From(ctx, u.logger).Debug("user reviews retrieved",
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(page.PullRequests)),
+	)
+
+	return page, nil
+}
+
+func (u *PullRequestUsecaseImpl) GetUserAuthored(ctx context.Context, userID uuid.UUID, filter entity.PullRequestFilter) ([]entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Debug("getting user authored PRs", zap.String("user_id", userID.String()))
+
+	prs, err := u.prRepo.GetPullRequestsByAuthor(ctx, userID, filter)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get PRs by author", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]entity.PullRequest, len(prs))
+	for i, pr := range prs {
+		result[i] = *pr
+	}
+
+	logging.From(ctx, u.logger).Debug("user authored PRs retrieved",
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(result)),
+	)
+
+	return result, nil
+}
+
+// GetOverduePRs returns open PRs older than olderThan or, when it is not
+// positive, older than the SLA of the author's team.
+func (u *PullRequestUsecaseImpl) GetOverduePRs(ctx context.Context, olderThan time.Duration) ([]entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Debug("getting overdue pull requests", zap.Duration("older_than", olderThan))
+
+	prs, err := u.prRepo.GetPullRequestsByStatus(ctx, entity.StatusOpen)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get open PRs", zap.Error(err))
+		return nil, err
+	}
+
+	now := u.clock()
+	slas := make(map[uuid.UUID]time.Duration)
+	result := make([]entity.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		limit := olderThan
+		if limit <= 0 {
+			sla, ok := slas[pr.AuthorID]
+			if !ok {
+				review, err := u.reviewForAuthor(ctx, pr.AuthorID)
+				if err != nil {
+					return nil, err
+				}
+				sla = review.SLA
+				slas[pr.AuthorID] = sla
+			}
+			limit = sla
+		}
+		if pr.CreatedAt.Before(now.Add(-limit)) {
+			result = append(result, *pr)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b entity.PullRequest) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	logging.From(ctx, u.logger).Debug("overdue pull requests retrieved", zap.Int("count", len(result)))
+	return result, nil
+}
+
+func (u *PullRequestUsecaseImpl) GetOpenReviewCounts(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts, err := u.prRepo.CountOpenReviews(ctx, userIDs)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to count open reviews", zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (u *PullRequestUsecaseImpl) GetTeamPRs(ctx context.Context, teamName string, filter entity.PullRequestFilter) ([]entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Debug("getting team pull requests", zap.String("team_name", teamName))
+
+	prs, err := u.prRepo.GetPullRequestsByTeam(ctx, teamName, filter)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get PRs by team", zap.String("team_name", teamName), zap.Error(err))
+		return nil, notFound(err, "team %s not found", teamName)
+	}
+
+	result := make([]entity.PullRequest, len(prs))
+	for i, pr := range prs {
+		result[i] = *pr
+	}
+
+	logging.From(ctx, u.logger).Debug("team pull requests retrieved",
+		zap.String("team_name", teamName),
+		zap.Int("count", len(result)),
+	)
+
+	return result, nil
+}
+
+// ListPRs returns up to filter.Limit PRs, all of them without a limit. It
+// asks the storage for one more to tell whether another page follows.
+func (u *PullRequestUsecaseImpl) ListPRs(ctx context.Context, filter entity.PullRequestListFilter) (entity.PullRequestPage, error) {
+	limit := filter.Limit
+	if limit > 0 {
+		filter.Limit = limit + 1
+	}
+
+	prs, err := u.prRepo.ListPullRequests(ctx, filter)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list PRs", zap.Error(err))
+		return entity.PullRequestPage{}, notFound(err, "team %s not found", filter.TeamName)
+	}
+
+	var page entity.PullRequestPage
+	if limit > 0 && len(prs) > limit {
+		prs = prs[:limit]
+		next := entity.CursorOf(*prs[limit-1])
+		page.Next = &next
+	}
+	page.PullRequests = make([]entity.PullRequest, len(prs))
+	for i, pr := range prs {
+		page.PullRequests[i] = *pr
+	}
+
+	logging.From(ctx, u.logger).Debug("pull requests listed", zap.Int("count", len(page.PullRequests)))
+	return page, nil
+}
+
+func (u *PullRequestUsecaseImpl) BackfillReviewers(ctx context.Context) ([]entity.PullRequest, error) {
+	logging.From(ctx, u.logger).Info("backfilling reviewers on open pull requests")
+
+	prs, err := u.prRepo.GetPullRequestsByStatus(ctx, entity.StatusOpen)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get open PRs", zap.Error(err))
+		return nil, err
+	}
+
+	updated := make([]entity.PullRequest, 0)
+	for _, stored := range prs {
+		pr := *stored
+		added, err := u.topUpReviewers(ctx, &pr)
+		if err != nil {
+			return nil, err
+		}
+		if added == 0 {
+			continue
+		}
+
+		if err := u.prRepo.UpdatePullRequestIf(ctx, &pr, pr.Version); err != nil {
+			if errors.Is(err, repository.ErrConflict) {
+				logging.From(ctx, u.logger).Info("skipping PR modified during backfill", zap.String("pr_id", pr.PullRequestID.String()))
+				continue
+			}
+			logging.From(ctx, u.logger).Error("failed to update PR", zap.Error(err))
+			return nil, invalidReviewers(err, &pr)
+		}
+		updated = append(updated, pr)
+	}
+
+	logging.From(ctx, u.logger).Info("reviewers backfilled",
+		zap.Int("open_prs", len(prs)),
+		zap.Int("updated", len(updated)),
+	)
+
+	return updated, nil
+}
+
+func (u *PullRequestUsecaseImpl) topUpReviewers(ctx context.Context, pr *entity.PullRequest) (int, error) {
+	author, err := u.userRepo.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			logging.From(ctx, u.logger).Warn("skipping PR with unknown author", zap.String("pr_id", pr.PullRequestID.String()))
+			return 0, nil
+		}
+		logging.From(ctx, u.logger).Error("failed to get author", zap.String("author_id", pr.AuthorID.String()), zap.Error(err))
+		return 0, err
+	}
+
+	review, err := u.reviewFor(ctx, author.TeamName)
+	if err != nil {
+		return 0, err
+	}
+	if len(pr.AssignedReviewers) >= review.totalReviewers() {
+		return 0, nil
+	}
+
+	teamMembers, err := u.userRepo.GetUsersByTeam(ctx, author.TeamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team members", zap.Error(err))
+		return 0, err
+	}
+
+	before := len(pr.AssignedReviewers)
+	if err := u.fillReviewers(ctx, review, *author, teamMembers, pr); err != nil {
+		return 0, err
+	}
+	return len(pr.AssignedReviewers) - before, nil
+}
+
+// checkPRName rejects names that do not match the pattern configured for
+// the author's team.
+func (u *PullRequestUsecaseImpl) checkPRName(ctx context.Context, teamName, prName string) error {
+	settings, err := loadTeamSettings(ctx, u.settingsRepo, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team settings", zap.String("team_name", teamName), zap.Error(err))
+		return err
+	}
+	pattern, err := settings.NameRegexp()
+	if err != nil {
+		return err
+	}
+	if pattern != nil && !pattern.MatchString(prName) {
+		logging.From(ctx, u.logger).Warn("PR name rejected",
+			zap.String("team_name", teamName),
+			zap.String("pr_name", prName),
+		)
+		return ErrInvalidPRName.Withf("PR name %q does not match pattern %q of team %s", prName, settings.NamePattern, teamName).
+			WithDetail("pattern", settings.NamePattern)
+	}
+	return nil
+}
+
+// findExistingPR reports an already stored PR with the same ID. Identical
+// name and author are treated as a retried request, anything else conflicts.
+func (u *PullRequestUsecaseImpl) findExistingPR(ctx context.Context, prID uuid.UUID, prName string, authorID uuid.UUID) (entity.PullRequest, bool, error) {
+	existing, err := u.prRepo.GetPullRequest(ctx, prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return entity.PullRequest{}, false, nil
+		}
+		logging.From(ctx, u.logger).Error("failed to check PR existence", zap.Error(err))
+		return entity.PullRequest{}, false, err
+	}
+
+	if existing.PullRequestName != prName || existing.AuthorID != authorID {
+		logging.From(ctx, u.logger).Warn("PR already exists", zap.String("pr_id", prID.String()))
+		return entity.PullRequest{}, false, ErrPRExists.Withf("PR id already exists")
+	}
+
+	logging.From(ctx, u.logger).Info("PR already exists with identical payload", zap.String("pr_id", prID.String()))
+	return *existing, true, nil
+}
+
+// lookupChangedFiles is best effort: a Git provider outage must not block PR
+// creation, the PR is then assigned without file information.
+func (u *PullRequestUsecaseImpl) lookupChangedFiles(ctx context.Context, externalID string) []string {
+	if externalID == "" || u.changedFiles == nil {
+		return nil
+	}
+
+	files, err := u.changedFiles.ChangedFiles(ctx, externalID)
+	if err != nil {
+		logging.From(ctx, u.logger).Warn("failed to look up changed files",
+			zap.String("external_id", externalID),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	logging.From(ctx, u.logger).Debug("changed files looked up",
+		zap.String("external_id", externalID),
+		zap.Int("files", len(files)),
+	)
+	return files
+}
+
+func (u *PullRequestUsecaseImpl) getAuthor(ctx context.Context, authorID uuid.UUID) (entity.User, error) {
+	author, err := u.userRepo.GetUser(ctx, authorID)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get author", zap.String("author_id", authorID.String()), zap.Error(err))
+		return entity.User{}, notFound(err, "author %s not found", authorID)
+	}
+	return *author, nil
+}
+
+func (u *PullRequestUsecaseImpl) assignReviewers(ctx context.Context, author entity.User, pr *entity.PullRequest, requested []uuid.UUID) error {
+	teamMembers, err := u.userRepo.GetUsersByTeam(ctx, author.TeamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team members", zap.Error(err))
+		return notFound(err, "team %s not found", author.TeamName)
+	}
+
+	review, err := u.reviewFor(ctx, author.TeamName)
+	if err != nil {
+		return err
+	}
+
+	if err := u.validateRequestedReviewers(ctx, review, teamMembers, author.UserID, requested); err != nil {
+		return err
+	}
+	if err := u.validateOwnerPeerRequest(ctx, review, author.TeamName, requested); err != nil {
+		return err
+	}
+
+	pr.AssignedReviewers = slices.Clone(requested)
+	if err := u.fillReviewers(ctx, review, author, teamMembers, pr); err != nil {
+		return err
+	}
+
+	logging.From(ctx, u.logger).Info("reviewers assigned",
+		zap.String("strategy", review.strategy.Name()),
+		zap.String("mode", review.Mode),
+		zap.Int("requested", len(requested)),
+		zap.Int("assigned", len(pr.AssignedReviewers)),
+	)
+
+	return nil
+}
+
+// fillReviewers tops the PR up to the number of reviewers configured for the
+// author's team and assigns slots to reviewers that have none yet. Owners
+// of the components the PR touches are picked before other teammates.
+func (u *PullRequestUsecaseImpl) fillReviewers(ctx context.Context, review teamReview, author entity.User, teamMembers []*entity.User, pr *entity.PullRequest) error {
+	if review.Mode == ReviewModeOwnerPeer {
+		return u.fillOwnerPeerReviewers(ctx, review.strategy, author, teamMembers, pr)
+	}
+
+	owners, err := u.componentOwners(ctx, author.TeamName, pr.ChangedFiles)
+	if err != nil {
+		return err
+	}
+
+	count := review.totalReviewers() - len(pr.AssignedReviewers)
+	candidates := u.filterReplacementCandidates(teamMembers, author.UserID, pr.AssignedReviewers)
+	ownerCandidates, others := splitByOwnership(candidates, owners)
+
+	selected, err := review.strategy.SelectReviewers(ctx, ownerCandidates, count)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to select component owners", zap.Error(err))
+		return err
+	}
+	rest, err := review.strategy.SelectReviewers(ctx, others, count-len(selected))
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to select reviewers", zap.Error(err))
+		return err
+	}
+
+	pr.AssignedReviewers = append(slices.Clone(pr.AssignedReviewers), append(selected, rest...)...)
+	fillReviewerSlots(pr, review.RequiredReviewers)
+	return nil
+}
+
+// componentOwners returns the owners of the team's components touched by
+// files, or nil when the changed files are unknown.
+func (u *PullRequestUsecaseImpl) componentOwners(ctx context.Context, teamName string, files []string) ([]uuid.UUID, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	components, err := u.ownershipRepo.GetComponentOwners(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get component owners", zap.String("team_name", teamName), zap.Error(err))
+		return nil, err
+	}
+
+	var owners []uuid.UUID
+	for _, component := range entity.TouchedComponents(components, files) {
+		for _, id := range component.Owners {
+			if !slices.Contains(owners, id) {
+				owners = append(owners, id)
+			}
+		}
+	}
+	return owners, nil
+}
+
+func (u *PullRequestUsecaseImpl) validateRequestedReviewers(ctx context.Context, review teamReview, teamMembers []*entity.User, authorID uuid.UUID, requested []uuid.UUID) error {
+	if total := review.totalReviewers(); len(requested) > total {
+		return ErrInvalidReviewer.Withf("invalid reviewer: at most %d reviewers can be assigned", total)
+	}
+
+	members := make(map[uuid.UUID]*entity.User, len(teamMembers))
+	for _, member := range teamMembers {
+		members[member.UserID] = member
+	}
+
+	for i, id := range requested {
+		var reason string
+		member, inTeam := members[id]
+		switch {
+		case id == authorID:
+			reason = "author cannot review own PR"
+		case slices.Contains(requested[:i], id):
+			reason = "duplicate reviewer"
+		case !inTeam:
+			reason = "not a member of author's team"
+		case member.IsDeleted():
+			reason = "user is deleted"
+		case !member.IsActive:
+			reason = "user is inactive"
+		case member.IsSnoozed(u.clock()):
+			reason = "user is snoozed"
+		default:
+			continue
+		}
+
+		logging.From(ctx, u.logger).Warn("requested reviewer rejected",
+			zap.String("reviewer_id", id.String()),
+			zap.String("reason", reason),
+		)
+		return ErrInvalidReviewer.Withf("invalid reviewer %s: %s", id, reason).
+			WithDetail("reviewer_id", id.String()).
+			WithDetail("reason", reason)
+	}
+
+	return nil
+}
+
+func (u *PullRequestUsecaseImpl) getPR(ctx context.Context, prID uuid.UUID) (entity.PullRequest, error) {
+	pr, err := u.prRepo.GetPullRequest(ctx, prID)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get PR", zap.String("pr_id", prID.String()), zap.Error(err))
+		return entity.PullRequest{}, notFound(err, "PR %s not found", prID)
+	}
+	return *pr, nil
+}
+
+func (u *PullRequestUsecaseImpl) getUser(ctx context.Context, userID uuid.UUID) (entity.User, error) {
+	user, err := u.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get user", zap.String("user_id", userID.String()), zap.Error(err))
+		return entity.User{}, notFound(err, "user %s not found", userID)
+	}
+	return *user, nil
+}
+
+// teamReview is the review configuration in effect for one team: the
+// configured settings with the global and then the team's overrides applied
+// and the strategy the team selects reviewers with.
+type teamReview struct {
+	ReviewSettings
+	strategy AssignmentStrategy
+}
+
+// reviewFor returns the configuration of teamName; an empty name, used when
+// a PR's author no longer exists, gives the instance-wide one.
+func (u *PullRequestUsecaseImpl) reviewFor(ctx context.Context, teamName string) (teamReview, error) {
+	global, err := loadGlobalSettings(ctx, u.globalRepo)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get global settings", zap.Error(err))
+		return teamReview{}, err
+	}
+
+	var settings entity.TeamSettings
+	if teamName != "" {
+		if settings, err = loadTeamSettings(ctx, u.settingsRepo, teamName); err != nil {
+			logging.From(ctx, u.logger).Error("failed to get team settings", zap.String("team_name", teamName), zap.Error(err))
+			return teamReview{}, err
+		}
+	}
+
+	review := teamReview{ReviewSettings: u.review.WithGlobal(global).ForTeam(settings), strategy: u.strategy}
+	if name := settings.AssignmentStrategy; name != "" && name != u.strategy.Name() {
+		if strategy, ok := u.strategies[name]; ok {
+			review.strategy = strategy
+		}
+	}
+	return review, nil
+}
+
+// reviewForAuthor returns the configuration of the author's team, or the
+// instance-wide one when the author no longer exists.
+func (u *PullRequestUsecaseImpl) reviewForAuthor(ctx context.Context, authorID uuid.UUID) (teamReview, error) {
+	author, err := u.userRepo.GetUser(ctx, authorID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return u.reviewFor(ctx, "")
+		}
+		logging.From(ctx, u.logger).Error("failed to get PR author", zap.String("author_id", authorID.String()), zap.Error(err))
+		return teamReview{}, err
+	}
+	return u.reviewFor(ctx, author.TeamName)
+}
+
+// checkPROpen rejects changes to a merged or closed PR; action completes
+// the message "cannot <action> merged PR".
+func (u *PullRequestUsecaseImpl) checkPROpen(ctx context.Context, pr entity.PullRequest, action string) error {
+	switch pr.Status {
+	case entity.StatusMerged:
+		logging.From(ctx, u.logger).Warn("cannot "+action+" merged PR", zap.String("pr_id", pr.PullRequestID.String()))
+		return ErrPRMerged.Withf("cannot %s merged PR", action)
+	case entity.StatusClosed:
+		logging.From(ctx, u.logger).Warn("cannot "+action+" closed PR", zap.String("pr_id", pr.PullRequestID.String()))
+		return ErrPRClosed.Withf("cannot %s closed PR", action)
+	}
+	return nil
+}
+
+// checkReviewable rejects approving or merging a PR that is not open: on
+// top of checkPROpen, it waits for the author to address requested changes.
+func (u *PullRequestUsecaseImpl) checkReviewable(ctx context.Context, pr entity.PullRequest, action string) error {
+	if err := u.checkPROpen(ctx, pr, action); err != nil {
+		return err
+	}
+	if pr.Status == entity.StatusChangesRequested {
+		logging.From(ctx, u.logger).Warn("cannot "+action+" PR with requested changes", zap.String("pr_id", pr.PullRequestID.String()))
+		return ErrChangesRequested.Withf("cannot %s PR with requested changes", action)
+	}
+	return nil
+}
+
+// checkMergeable applies the merge gates configured for the author's team
+// and then the team's merge policy. Already merged PRs are handled by the
+// callers.
+func (u *PullRequestUsecaseImpl) checkMergeable(ctx context.Context, pr entity.PullRequest) error {
+	author, err := u.userRepo.GetUser(ctx, pr.AuthorID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		logging.From(ctx, u.logger).Error("failed to get PR author", zap.Error(err))
+		return err
+	}
+
+	var teamName string
+	if author != nil {
+		teamName = author.TeamName
+	}
+	review, err := u.reviewFor(ctx, teamName)
+	if err != nil {
+		return err
+	}
+
+	if !pr.IsOverridden() {
+		switch review.MergeApprovals {
+		case MergeApprovalsRequired:
+			if len(pr.PendingRequiredApprovals()) > 0 {
+				return ErrApprovalsPending
+			}
+		case MergeApprovalsAll:
+			if pending := pr.PendingApprovals(); len(pending) > 0 {
+				return ErrApprovalsPending.Withf("%d of %d assigned reviewers have not approved", len(pending), len(pr.AssignedReviewers))
+			}
+		}
+	}
+
+	if pr.Checklist.RequiredForMerge && !pr.Checklist.Complete() {
+		return ErrChecklistIncomplete
+	}
+
+	if author == nil {
+		return nil
+	}
+
+	policy, err := loadMergePolicy(ctx, u.mergePolicyRepo, author.TeamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get merge policy", zap.Error(err))
+		return err
+	}
+
+	if violation := policy.Check(pr, u.clock(), review.SLA); violation != nil {
+		return ErrMergePolicyViolation.Withf("merge policy rule %s failed: %s", violation.Rule, violation.Detail).
+			WithDetail("rule", string(violation.Rule))
+	}
+
+	return nil
+}
+
+func isMergeBlocked(err error) bool {
+	return errors.Is(err, ErrApprovalsPending) ||
+		errors.Is(err, ErrChecklistIncomplete) ||
+		errors.Is(err, ErrMergePolicyViolation)
+}
+
+// tryAutoMerge merges a PR that opted into auto-merge once every blocking
+// reviewer has approved, regardless of REVIEW_MERGE_APPROVALS. PRs with no
+// approvals at all are left for a manual merge; a lead override counts as
+// the approval of every blocking reviewer. Nothing is merged while the
+// auto_merge feature is switched off.
+func (u *PullRequestUsecaseImpl) tryAutoMerge(ctx context.Context, pr entity.PullRequest) (entity.PullRequest, error) {
+	if !pr.AutoMerge || pr.Status != entity.StatusOpen {
+		return pr, nil
+	}
+
+	if err := requireFeature(ctx, u.globalRepo, u.logger, entity.FeatureAutoMerge); err != nil {
+		if errors.Is(err, ErrFeatureDisabled) {
+			return pr, nil
+		}
+		return entity.PullRequest{}, err
+	}
+
+	if !pr.IsOverridden() && (len(pr.Approvals) == 0 || len(pr.PendingRequiredApprovals()) > 0) {
+		return pr, nil
+	}
+
+	if err := u.checkMergeable(ctx, pr); err != nil {
+		if isMergeBlocked(err) {
+			return pr, nil
+		}
+		return entity.PullRequest{}, err
+	}
+
+	merged := pr
+	u.markMerged(&merged)
+
+	if err := u.updateWithEvents(ctx, &merged, pr.Version, entity.PullRequestEvent{Type: entity.EventPRAutoMerged}); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			// Whoever changed the PR in between runs the auto-merge check
+			// for their own change; ours is already stored.
+			logging.From(ctx, u.logger).Info("PR modified before auto-merge, leaving it open", zap.String("pr_id", pr.PullRequestID.String()))
+			return pr, nil
+		}
+		logging.From(ctx, u.logger).Error("failed to auto-merge PR", zap.Error(err))
+		return entity.PullRequest{}, invalidReviewers(err, &merged)
+	}
+	pr = merged
+
+	logging.From(ctx, u.logger).Info("pull request auto-merged",
+		zap.String("pr_id", pr.PullRequestID.String()),
+		zap.String("author_id", pr.AuthorID.String()),
+		zap.Int("approvals", len(pr.Approvals)),
+	)
+
+	return pr, nil
+}
+
+func (u *PullRequestUsecaseImpl) markMerged(pr *entity.PullRequest) {
+	now := u.clock()
+	pr.Status = entity.StatusMerged
+	pr.MergedAt = &now
+}
+
+// updateWithEvents stores pr with UpdatePullRequestIf and queues events
+// for it, see storeWithEvents.
+func (u *PullRequestUsecaseImpl) updateWithEvents(ctx context.Context, pr *entity.PullRequest, expectedVersion int, events ...entity.PullRequestEvent) error {
+	return u.storeWithEvents(ctx, pr, func(ctx context.Context) error {
+		return u.prRepo.UpdatePullRequestIf(ctx, pr, expectedVersion)
+	}, events...)
+}
+
+// storeWithEvents runs write, which stores pr, and queues events about pr
+// in the outbox in one transaction, so an event is queued exactly when its
+// change is stored. The events are passed to the notifier after commit.
+func (u *PullRequestUsecaseImpl) storeWithEvents(ctx context.Context, pr *entity.PullRequest, write func(ctx context.Context) error, events ...entity.PullRequestEvent) error {
+	now := u.clock()
+	err := u.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+		for i := range events {
+			events[i].PullRequest = *pr
+			events[i].OccurredAt = now
+			if err := u.outbox.Enqueue(ctx, events[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		u.notifier.Notify(ctx, event)
+	}
+	return nil
+}
+
+func (u *PullRequestUsecaseImpl) checkReviewerAssigned(ctx context.Context, pr entity.PullRequest, reviewerID uuid.UUID) error {
+	if slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return nil
+	}
+
+	logging.From(ctx, u.logger).Warn("reviewer not assigned to PR",
+		zap.String("pr_id", pr.PullRequestID.String()),
+		zap.String("reviewer_id", reviewerID.String()),
+	)
+	return ErrNotAssigned
+}
+
+func (u *PullRequestUsecaseImpl) findReplacementReviewer(ctx context.Context, teamName string, authorID uuid.UUID, currentReviewers []uuid.UUID, ownersOnly bool) (entity.User, error) {
+	teamMembers, err := u.userRepo.GetUsersByTeam(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team members", zap.Error(err))
+		return entity.User{}, err
+	}
+
+	candidates := u.filterReplacementCandidates(teamMembers, authorID, currentReviewers)
+	if ownersOnly {
+		owners, err := u.ownershipRepo.GetTeamOwners(ctx, teamName)
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get team owners", zap.String("team_name", teamName), zap.Error(err))
+			return entity.User{}, err
+		}
+		candidates, _ = splitByOwnership(candidates, owners)
+	}
+	if len(candidates) == 0 {
+		logging.From(ctx, u.logger).Warn("no replacement candidates available")
+		return entity.User{}, ErrNoCandidate
+	}
+
+	review, err := u.reviewFor(ctx, teamName)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	selected, err := review.strategy.SelectReviewers(ctx, candidates, 1)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to select replacement reviewer", zap.Error(err))
+		return entity.User{}, err
+	}
+
+	for _, candidate := range candidates {
+		if candidate.UserID == selected[0] {
+			return candidate, nil
+		}
+	}
+	return entity.User{}, ErrNoCandidate
+}
+
+func (u *PullRequestUsecaseImpl) filterReplacementCandidates(teamMembers []*entity.User, authorID uuid.UUID, currentReviewers []uuid.UUID) []entity.User {
+	now := u.clock()
+	var candidates []entity.User
+	for _, member := range teamMembers {
+		if !member.IsAssignable(now) {
+			continue
+		}
+		if member.UserID == authorID {
+			continue
+		}
+		if u.isAlreadyReviewer(member.UserID, currentReviewers) {
+			continue
+		}
+		candidates = append(candidates, *member)
+	}
+	return candidates
+}
+
+func (u *PullRequestUsecaseImpl) isAlreadyReviewer(userID uuid.UUID, reviewers []uuid.UUID) bool {
+	return slices.Contains(reviewers, userID)
+}
+
+// replaceReviewer hands the old reviewer's slot to the new one and drops the
+// old reviewer's approval.
+func (u *PullRequestUsecaseImpl) replaceReviewer(pr *entity.PullRequest, oldReviewerID, newReviewerID uuid.UUID) {
+	slot := pr.SlotOf(oldReviewerID)
+
+	pr.AssignedReviewers = slices.Clone(pr.AssignedReviewers)
+	for i, id := range pr.AssignedReviewers {
+		if id == oldReviewerID {
+			pr.AssignedReviewers[i] = newReviewerID
+			break
+		}
+	}
+
+	pr.ReviewerSlots = maps.Clone(pr.ReviewerSlots)
+	if pr.ReviewerSlots == nil {
+		pr.ReviewerSlots = make(map[uuid.UUID]entity.ReviewerSlot)
+	}
+	delete(pr.ReviewerSlots, oldReviewerID)
+	pr.ReviewerSlots[newReviewerID] = slot
+
+	pr.Approvals = slices.DeleteFunc(slices.Clone(pr.Approvals), func(a entity.Approval) bool {
+		return a.UserID == oldReviewerID
+	})
+}
+
+// fillReviewerSlots assigns slots to reviewers that have none yet: the first
+// requiredReviewers slots are required in assignment order, the rest become
+// optional.
+func fillReviewerSlots(pr *entity.PullRequest, requiredReviewers int) {
+	slots := maps.Clone(pr.ReviewerSlots)
+	if slots == nil {
+		slots = make(map[uuid.UUID]entity.ReviewerSlot, len(pr.AssignedReviewers))
+	}
+
+	required := 0
+	for _, slot := range slots {
+		if slot == entity.SlotRequired {
+			required++
+		}
+	}
+
+	for _, id := range pr.AssignedReviewers {
+		if _, ok := slots[id]; ok {
+			continue
+		}
+		if required < requiredReviewers {
+			slots[id] = entity.SlotRequired
+			required++
+		} else {
+			slots[id] = entity.SlotOptional
+		}
+	}
+
+	pr.ReviewerSlots = slots
 }
 
 func min(a, b int) int {
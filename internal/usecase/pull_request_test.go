@@ -0,0 +1,151 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/repository"
+	"avito-intro/internal/usecase"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// newTestPullRequestUsecase wires the PR usecase to a fresh in-memory
+// repository with one active team of size members, the same way
+// prctl simulate does.
+func newTestPullRequestUsecase(t *testing.T, size int) (usecase.PullRequestUsecase, *repository.MemoryRepository, []entity.User) {
+	t.Helper()
+
+	logger := zap.NewNop()
+	repo := repository.NewMemoryRepository(logger)
+	strategy, err := usecase.NewAssignmentStrategy(usecase.StrategyRandom, repo, logger)
+	if err != nil {
+		t.Fatalf("NewAssignmentStrategy: %v", err)
+	}
+
+	quotaUC := usecase.NewQuotaUsecase(repo, repo, repo, repo, entity.Quotas{}, logger)
+	auditUC := usecase.NewAuditUsecase(repo, time.Now, logger)
+	teamUC := usecase.NewTeamUsecase(repo, repo, repo, quotaUC, auditUC, logger)
+	outboxUC := usecase.NewOutboxUsecase(nil, repo, nil, entity.OutboxPolicy{}, time.Now, logger)
+	prUC := usecase.NewPullRequestUsecase(repo, repo, repo, repo, repo, repo, repo, repo, repo, nil, strategy, usecase.NewAssignmentStrategies(repo, logger), usecase.DefaultReviewSettings(), quotaUC, time.Now, usecase.NewLogNotifier(logger), outboxUC, logger)
+
+	team := entity.Team{TeamName: "backend"}
+	members := make([]entity.User, size)
+	for i := range members {
+		members[i] = entity.User{
+			UserID:   uuid.New(),
+			Username: fmt.Sprintf("user%d", i),
+			TeamName: team.TeamName,
+			IsActive: true,
+		}
+		team.Members = append(team.Members, members[i].UserID)
+	}
+	if _, err := teamUC.AddTeam(context.Background(), team, members); err != nil {
+		t.Fatalf("AddTeam: %v", err)
+	}
+	return prUC, repo, members
+}
+
+type reassignment struct {
+	old, new uuid.UUID
+}
+
+// TestMergeRacingReassign merges PRs while their reviewers are being
+// reassigned. Every reassignment that reports success must be visible on
+// the merged PR, and none may land after the merge.
+func TestMergeRacingReassign(t *testing.T) {
+	ctx := context.Background()
+	prUC, repo, members := newTestPullRequestUsecase(t, 10)
+
+	for i := range 20 {
+		pr, _, err := prUC.CreatePR(ctx, uuid.New(), fmt.Sprintf("PR %d", i), members[0].UserID, "", nil)
+		if err != nil {
+			t.Fatalf("CreatePR: %v", err)
+		}
+		if len(pr.AssignedReviewers) == 0 {
+			t.Fatalf("PR %s has no reviewers", pr.PullRequestID)
+		}
+
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			merged   entity.PullRequest
+			reassign []reassignment
+		)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := prUC.MergePR(ctx, pr.PullRequestID)
+			if err != nil {
+				t.Errorf("MergePR: %v", err)
+				return
+			}
+			mu.Lock()
+			merged = result
+			mu.Unlock()
+		}()
+		for _, reviewerID := range pr.AssignedReviewers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, newReviewerID, err := prUC.ReassignReviewer(ctx, pr.PullRequestID, reviewerID)
+				switch {
+				case err == nil:
+					mu.Lock()
+					reassign = append(reassign, reassignment{old: reviewerID, new: newReviewerID})
+					mu.Unlock()
+				case errors.Is(err, usecase.ErrPRMerged),
+					errors.Is(err, usecase.ErrNoCandidate),
+					errors.Is(err, usecase.ErrConcurrentUpdate):
+				default:
+					t.Errorf("ReassignReviewer: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if t.Failed() {
+			return
+		}
+
+		stored, err := repo.GetPullRequest(ctx, pr.PullRequestID)
+		if err != nil {
+			t.Fatalf("GetPullRequest: %v", err)
+		}
+		if stored.Status != entity.StatusMerged {
+			t.Fatalf("PR %s status = %s, want MERGED", pr.PullRequestID, stored.Status)
+		}
+		if !slices.Equal(stored.AssignedReviewers, merged.AssignedReviewers) {
+			t.Fatalf("reviewers changed after merge: merged with %v, stored %v", merged.AssignedReviewers, stored.AssignedReviewers)
+		}
+		for _, re := range reassign {
+			if !slices.Contains(stored.AssignedReviewers, re.new) || slices.Contains(stored.AssignedReviewers, re.old) {
+				t.Fatalf("reassignment %s -> %s was lost: reviewers %v", re.old, re.new, stored.AssignedReviewers)
+			}
+		}
+	}
+}
+
+// TestReassignAfterMerge checks that the PR stays as merged when a
+// reassignment comes too late.
+func TestReassignAfterMerge(t *testing.T) {
+	ctx := context.Background()
+	prUC, _, members := newTestPullRequestUsecase(t, 4)
+
+	pr, _, err := prUC.CreatePR(ctx, uuid.New(), "PR", members[0].UserID, "", nil)
+	if err != nil {
+		t.Fatalf("CreatePR: %v", err)
+	}
+	if _, err := prUC.MergePR(ctx, pr.PullRequestID); err != nil {
+		t.Fatalf("MergePR: %v", err)
+	}
+	if _, _, err := prUC.ReassignReviewer(ctx, pr.PullRequestID, pr.AssignedReviewers[0]); !errors.Is(err, usecase.ErrPRMerged) {
+		t.Fatalf("ReassignReviewer after merge: got %v, want ErrPRMerged", err)
+	}
+}
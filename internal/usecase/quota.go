@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ErrQuotaExceeded errors report the exceeded limit in the "resource",
+// "limit" and, for per-team quotas, "team_name" details.
+var ErrQuotaExceeded = newError(CodeQuotaExceeded, "quota exceeded")
+
+var openStatus = entity.StatusOpen
+
+var _ QuotaUsecase = (*QuotaUsecaseImpl)(nil)
+
+type QuotaUsecaseImpl struct {
+	statsRepo repository.StatsRepository
+	teamRepo  repository.TeamRepository
+	userRepo  repository.UserRepository
+	prRepo    repository.PullRequestRepository
+	quotas    entity.Quotas
+	logger    *zap.Logger
+}
+
+func NewQuotaUsecase(
+	statsRepo repository.StatsRepository,
+	teamRepo repository.TeamRepository,
+	userRepo repository.UserRepository,
+	prRepo repository.PullRequestRepository,
+	quotas entity.Quotas,
+	logger *zap.Logger,
+) *QuotaUsecaseImpl {
+	return &QuotaUsecaseImpl{
+		statsRepo: statsRepo,
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		prRepo:    prRepo,
+		quotas:    quotas,
+		logger:    logger,
+	}
+}
+
+func (u *QuotaUsecaseImpl) CheckTeamCreate(ctx context.Context) error {
+	if u.quotas.MaxTeams == 0 {
+		return nil
+	}
+
+	stats, err := u.statsRepo.Stats(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get storage stats", zap.Error(err))
+		return err
+	}
+	if stats.Teams >= u.quotas.MaxTeams {
+		return u.exceeded(ctx, entity.QuotaTeams, "")
+	}
+	return nil
+}
+
+// CheckMembers verifies that adding members to teamName keeps both the
+// organization's user count and the team size within limits. With replace
+// the members become the whole team rather than being added to it. Teams
+// already over the limit may keep their members but cannot grow.
+func (u *QuotaUsecaseImpl) CheckMembers(ctx context.Context, teamName string, members []entity.User, replace bool) error {
+	if u.quotas.MaxUsers > 0 {
+		newUsers := 0
+		for _, member := range members {
+			exists, err := u.userRepo.UserExists(ctx, member.UserID)
+			if err != nil {
+				logging.From(ctx, u.logger).Error("failed to check user existence", zap.Error(err))
+				return err
+			}
+			if !exists {
+				newUsers++
+			}
+		}
+
+		if newUsers > 0 {
+			stats, err := u.statsRepo.Stats(ctx)
+			if err != nil {
+				logging.From(ctx, u.logger).Error("failed to get storage stats", zap.Error(err))
+				return err
+			}
+			if stats.Users+newUsers > u.quotas.MaxUsers {
+				return u.exceeded(ctx, entity.QuotaUsers, "")
+			}
+		}
+	}
+
+	if u.quotas.MaxTeamMembers == 0 || teamName == "" {
+		return nil
+	}
+
+	var current []uuid.UUID
+	team, err := u.teamRepo.GetTeam(ctx, teamName)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		logging.From(ctx, u.logger).Error("failed to get team", zap.Error(err))
+		return err
+	}
+	if team != nil {
+		current = team.Members
+	}
+
+	resulting := slices.Clone(current)
+	if replace {
+		resulting = nil
+	}
+	for _, member := range members {
+		if !slices.Contains(resulting, member.UserID) {
+			resulting = append(resulting, member.UserID)
+		}
+	}
+
+	if len(resulting) > u.quotas.MaxTeamMembers && len(resulting) > len(current) {
+		return u.exceeded(ctx, entity.QuotaTeamMembers, teamName)
+	}
+	return nil
+}
+
+func (u *QuotaUsecaseImpl) CheckOpenPR(ctx context.Context, teamName string) error {
+	if u.quotas.MaxOpenPRs > 0 {
+		stats, err := u.statsRepo.Stats(ctx)
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get storage stats", zap.Error(err))
+			return err
+		}
+		if stats.PullRequests[entity.StatusOpen] >= u.quotas.MaxOpenPRs {
+			return u.exceeded(ctx, entity.QuotaOpenPRs, "")
+		}
+	}
+
+	if u.quotas.MaxTeamOpenPRs == 0 || teamName == "" {
+		return nil
+	}
+
+	open, err := u.teamOpenPRs(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if open >= u.quotas.MaxTeamOpenPRs {
+		return u.exceeded(ctx, entity.QuotaTeamOpenPRs, teamName)
+	}
+	return nil
+}
+
+// GetUsage reports the organization-wide resources first, then the
+// per-team ones for every team with members.
+func (u *QuotaUsecaseImpl) GetUsage(ctx context.Context) ([]entity.QuotaUsage, error) {
+	stats, err := u.statsRepo.Stats(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get storage stats", zap.Error(err))
+		return nil, err
+	}
+
+	usage := []entity.QuotaUsage{
+		u.usage(entity.QuotaTeams, "", stats.Teams),
+		u.usage(entity.QuotaUsers, "", stats.Users),
+		u.usage(entity.QuotaOpenPRs, "", stats.PullRequests[entity.StatusOpen]),
+	}
+
+	users, _, err := u.userRepo.ListUsers(ctx, entity.UserFilter{})
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list users", zap.Error(err))
+		return nil, err
+	}
+
+	var teams []string
+	for _, user := range users {
+		if user.TeamName != "" && !slices.Contains(teams, user.TeamName) {
+			teams = append(teams, user.TeamName)
+		}
+	}
+	slices.Sort(teams)
+
+	for _, teamName := range teams {
+		team, err := u.teamRepo.GetTeam(ctx, teamName)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get team", zap.Error(err))
+			return nil, err
+		}
+
+		open, err := u.teamOpenPRs(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		usage = append(usage,
+			u.usage(entity.QuotaTeamMembers, teamName, len(team.Members)),
+			u.usage(entity.QuotaTeamOpenPRs, teamName, open),
+		)
+	}
+
+	return usage, nil
+}
+
+func (u *QuotaUsecaseImpl) teamOpenPRs(ctx context.Context, teamName string) (int, error) {
+	prs, err := u.prRepo.GetPullRequestsByTeam(ctx, teamName, entity.PullRequestFilter{Status: &openStatus})
+	if errors.Is(err, repository.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team PRs", zap.Error(err))
+		return 0, err
+	}
+	return len(prs), nil
+}
+
+func (u *QuotaUsecaseImpl) usage(resource entity.QuotaResource, teamName string, used int) entity.QuotaUsage {
+	return entity.QuotaUsage{
+		Resource: resource,
+		TeamName: teamName,
+		Used:     used,
+		Limit:    u.quotas.Limit(resource),
+	}
+}
+
+func (u *QuotaUsecaseImpl) exceeded(ctx context.Context, resource entity.QuotaResource, teamName string) error {
+	limit := u.quotas.Limit(resource)
+	logging.From(ctx, u.logger).Warn("quota exceeded",
+		zap.String("resource", string(resource)),
+		zap.String("team_name", teamName),
+		zap.Int("limit", limit),
+	)
+
+	err := ErrQuotaExceeded.
+		WithDetail("resource", string(resource)).
+		WithDetail("limit", strconv.Itoa(limit))
+	if teamName != "" {
+		return err.Withf("quota exceeded: %s of team %s is limited to %d", resource, teamName, limit).
+			WithDetail("team_name", teamName)
+	}
+	return err.Withf("quota exceeded: %s is limited to %d", resource, limit)
+}
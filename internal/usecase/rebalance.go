@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RebalanceReviews evens out open review assignments across the team's
+// active members; snoozed members are left out on both ends. An assignment is moved from the busiest member to the
+// least busy one while their loads differ by at least two, so no move can
+// make the spread worse. Only assignments the reviewer has not acted on
+// (no approval, no checklist response) are moved, and the usual exclusions
+// apply: the author never reviews their own PR, nobody is assigned twice,
+// and owner slots only go to team owners. A PR modified by someone else
+// while the rebalance runs keeps its newer state and its moves are dropped.
+// With dryRun the moves are planned and returned but not stored.
+func (u *PullRequestUsecaseImpl) RebalanceReviews(ctx context.Context, teamName string, dryRun bool) (entity.RebalanceResult, error) {
+	logging.From(ctx, u.logger).Info("rebalancing team reviews",
+		zap.String("team_name", teamName),
+		zap.Bool("dry_run", dryRun),
+	)
+
+	openStatus := entity.StatusOpen
+	stored, err := u.prRepo.GetPullRequestsByTeam(ctx, teamName, entity.PullRequestFilter{Status: &openStatus})
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team PRs", zap.String("team_name", teamName), zap.Error(err))
+		return entity.RebalanceResult{}, notFound(err, "team %s not found", teamName)
+	}
+	prs := make([]entity.PullRequest, len(stored))
+	for i, pr := range stored {
+		prs[i] = *pr
+	}
+
+	members, err := u.userRepo.GetUsersByTeam(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team members", zap.String("team_name", teamName), zap.Error(err))
+		return entity.RebalanceResult{}, err
+	}
+	now := u.clock()
+	var active []uuid.UUID
+	for _, member := range members {
+		if member.IsAssignable(now) {
+			active = append(active, member.UserID)
+		}
+	}
+
+	owners, err := u.ownershipRepo.GetTeamOwners(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team owners", zap.String("team_name", teamName), zap.Error(err))
+		return entity.RebalanceResult{}, err
+	}
+
+	// Load counts every open review, including PRs of other teams, since
+	// that is what the assignment strategies balance on as well.
+	load, err := u.prRepo.CountOpenReviews(ctx, active)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to count open reviews", zap.Error(err))
+		return entity.RebalanceResult{}, err
+	}
+
+	result := entity.RebalanceResult{
+		TeamName:   teamName,
+		DryRun:     dryRun,
+		LoadBefore: maps.Clone(load),
+	}
+
+	changed := make(map[int]bool)
+	for {
+		move, index, ok := nextRebalanceMove(prs, active, owners, load)
+		if !ok {
+			break
+		}
+		u.replaceReviewer(&prs[index], move.FromUserID, move.ToUserID)
+		load[move.FromUserID]--
+		load[move.ToUserID]++
+		changed[index] = true
+		result.Moves = append(result.Moves, move)
+	}
+	result.LoadAfter = load
+
+	if dryRun {
+		return result, nil
+	}
+
+	for index := range prs {
+		if !changed[index] {
+			continue
+		}
+		var events []entity.PullRequestEvent
+		for _, move := range result.Moves {
+			if move.PullRequestID == prs[index].PullRequestID {
+				events = append(events, entity.PullRequestEvent{
+					Type:           entity.EventReviewerReassigned,
+					UserID:         move.ToUserID,
+					PreviousUserID: move.FromUserID,
+				})
+			}
+		}
+		if err := u.updateWithEvents(ctx, &prs[index], prs[index].Version, events...); err != nil {
+			if !errors.Is(err, repository.ErrConflict) {
+				logging.From(ctx, u.logger).Error("failed to update PR", zap.String("pr_id", prs[index].PullRequestID.String()), zap.Error(err))
+				return entity.RebalanceResult{}, invalidReviewers(err, &prs[index])
+			}
+			// The PR changed since it was read; its moves are dropped
+			// rather than overwriting the newer state.
+			logging.From(ctx, u.logger).Info("skipping PR modified during rebalance", zap.String("pr_id", prs[index].PullRequestID.String()))
+			result.Moves = dropMoves(result.Moves, prs[index].PullRequestID, load)
+		}
+	}
+
+	logging.From(ctx, u.logger).Info("team reviews rebalanced",
+		zap.String("team_name", teamName),
+		zap.Int("moves", len(result.Moves)),
+	)
+
+	return result, nil
+}
+
+// nextRebalanceMove finds a movable assignment between the most and the
+// least loaded members whose loads differ by at least two, trying the
+// pairs with the largest difference first.
+func nextRebalanceMove(prs []entity.PullRequest, active, owners []uuid.UUID, load map[uuid.UUID]int) (entity.ReviewerMove, int, bool) {
+	byLoad := slices.Clone(active)
+	slices.SortStableFunc(byLoad, func(a, b uuid.UUID) int {
+		return cmp.Compare(load[b], load[a])
+	})
+
+	for _, from := range byLoad {
+		for i := len(byLoad) - 1; i >= 0; i-- {
+			to := byLoad[i]
+			if load[from]-load[to] < 2 {
+				break
+			}
+			for index, pr := range prs {
+				if canMoveReview(pr, from, to, owners) {
+					return entity.ReviewerMove{
+						PullRequestID: pr.PullRequestID,
+						FromUserID:    from,
+						ToUserID:      to,
+					}, index, true
+				}
+			}
+		}
+	}
+	return entity.ReviewerMove{}, 0, false
+}
+
+// dropMoves removes the moves of one PR and takes them back out of load.
+func dropMoves(moves []entity.ReviewerMove, prID uuid.UUID, load map[uuid.UUID]int) []entity.ReviewerMove {
+	return slices.DeleteFunc(moves, func(move entity.ReviewerMove) bool {
+		if move.PullRequestID != prID {
+			return false
+		}
+		load[move.FromUserID]++
+		load[move.ToUserID]--
+		return true
+	})
+}
+
+func canMoveReview(pr entity.PullRequest, from, to uuid.UUID, owners []uuid.UUID) bool {
+	if !slices.Contains(pr.AssignedReviewers, from) || slices.Contains(pr.AssignedReviewers, to) {
+		return false
+	}
+	if pr.AuthorID == to {
+		return false
+	}
+	if pr.IsApprovedBy(from) {
+		return false
+	}
+	if _, responded := pr.FirstResponses[from]; responded {
+		return false
+	}
+	if pr.SlotOf(from) == entity.SlotOwner && !slices.Contains(owners, to) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+	"avito-intro/internal/tenant"
+
+	"go.uber.org/zap"
+)
+
+var _ RetentionUsecase = (*RetentionUsecaseImpl)(nil)
+
+// RetentionUsecaseImpl purges data older than the retention policy in every
+// organization and records each purge in that organization's audit log.
+type RetentionUsecaseImpl struct {
+	orgRepo     repository.OrganizationRepository
+	auditRepo   repository.AuditRepository
+	prRepo      repository.PullRequestRepository
+	audit       AuditUsecase
+	idempotency IdempotencyStore
+	policy      entity.RetentionPolicy
+	clock       Clock
+	logger      *zap.Logger
+}
+
+func NewRetentionUsecase(
+	orgRepo repository.OrganizationRepository,
+	auditRepo repository.AuditRepository,
+	prRepo repository.PullRequestRepository,
+	audit AuditUsecase,
+	idempotency IdempotencyStore,
+	policy entity.RetentionPolicy,
+	clock Clock,
+	logger *zap.Logger,
+) *RetentionUsecaseImpl {
+	return &RetentionUsecaseImpl{
+		orgRepo:     orgRepo,
+		auditRepo:   auditRepo,
+		prRepo:      prRepo,
+		audit:       audit,
+		idempotency: idempotency,
+		policy:      policy,
+		clock:       clock,
+		logger:      logger,
+	}
+}
+
+// Cleanup runs one retention pass. Results are reported only for resources
+// where something was deleted or archived.
+func (u *RetentionUsecaseImpl) Cleanup(ctx context.Context) ([]entity.PurgeResult, error) {
+	orgs, err := u.orgRepo.ListOrganizations(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list organizations", zap.Error(err))
+		return nil, err
+	}
+
+	now := u.clock()
+	var results []entity.PurgeResult
+	for _, org := range orgs {
+		orgCtx := tenant.WithOrganization(ctx, org.OrgID)
+
+		if u.policy.AuditEntries > 0 {
+			before := now.Add(-u.policy.AuditEntries)
+			deleted, err := u.auditRepo.DeleteAuditBefore(orgCtx, before)
+			if err != nil {
+				logging.From(orgCtx, u.logger).Error("failed to purge audit entries",
+					zap.String("org_id", org.OrgID),
+					zap.Error(err),
+				)
+				return results, err
+			}
+			if deleted > 0 {
+				results = append(results, u.recordPurge(orgCtx, entity.PurgeResult{
+					OrgID:    org.OrgID,
+					Resource: entity.RetentionAuditEntries,
+					Before:   before,
+					Deleted:  deleted,
+				}))
+			}
+		}
+
+		if u.policy.MergedPullRequests > 0 {
+			before := now.Add(-u.policy.MergedPullRequests)
+			removed, err := u.purgeMergedPullRequests(orgCtx, before)
+			if err != nil {
+				logging.From(orgCtx, u.logger).Error("failed to purge merged pull requests",
+					zap.String("org_id", org.OrgID),
+					zap.Error(err),
+				)
+				return results, err
+			}
+			if removed > 0 {
+				results = append(results, u.recordPurge(orgCtx, entity.PurgeResult{
+					OrgID:    org.OrgID,
+					Resource: entity.RetentionMergedPullRequests,
+					Before:   before,
+					Deleted:  removed,
+					Archived: !u.policy.DeleteMergedPullRequests,
+				}))
+			}
+		}
+
+		if u.policy.ArchivedPullRequests > 0 {
+			before := now.Add(-u.policy.ArchivedPullRequests)
+			deleted, err := u.prRepo.PurgeArchivedPullRequests(orgCtx, before)
+			if err != nil {
+				logging.From(orgCtx, u.logger).Error("failed to purge archived pull requests",
+					zap.String("org_id", org.OrgID),
+					zap.Error(err),
+				)
+				return results, err
+			}
+			if deleted > 0 {
+				results = append(results, u.recordPurge(orgCtx, entity.PurgeResult{
+					OrgID:    org.OrgID,
+					Resource: entity.RetentionArchivedPullRequests,
+					Before:   before,
+					Deleted:  deleted,
+				}))
+			}
+		}
+	}
+
+	// Stored responses are not in the storage but in the store shared by
+	// all organizations, so they are purged at once and the deletions are
+	// audited in the organization each response belonged to.
+	if u.idempotency != nil && u.policy.IdempotencyKeys > 0 {
+		before := now.Add(-u.policy.IdempotencyKeys)
+		purged := u.idempotency.Purge(before)
+		for _, orgID := range slices.Sorted(maps.Keys(purged)) {
+			results = append(results, u.recordPurge(tenant.WithOrganization(ctx, orgID), entity.PurgeResult{
+				OrgID:    orgID,
+				Resource: entity.RetentionIdempotencyKeys,
+				Before:   before,
+				Deleted:  purged[orgID],
+			}))
+		}
+	}
+
+	return results, nil
+}
+
+// purgeMergedPullRequests archives, or deletes, the PRs merged before the
+// given time and returns how many it removed from the working set.
+func (u *RetentionUsecaseImpl) purgeMergedPullRequests(ctx context.Context, before time.Time) (int, error) {
+	prs, err := u.prRepo.GetPullRequestsByStatus(ctx, entity.StatusMerged)
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int
+	for _, pr := range prs {
+		if pr.MergedAt == nil || !pr.MergedAt.Before(before) {
+			continue
+		}
+		if u.policy.DeleteMergedPullRequests {
+			err = u.prRepo.DeletePullRequest(ctx, pr.PullRequestID)
+		} else {
+			err = u.prRepo.ArchivePullRequest(ctx, pr.PullRequestID)
+		}
+		// The PR may have expired or been purged by another instance.
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (u *RetentionUsecaseImpl) recordPurge(ctx context.Context, result entity.PurgeResult) entity.PurgeResult {
+	logging.From(ctx, u.logger).Info("retention purge completed",
+		zap.String("org_id", result.OrgID),
+		zap.String("resource", string(result.Resource)),
+		zap.Time("before", result.Before),
+		zap.Int("deleted", result.Deleted),
+		zap.Bool("archived", result.Archived),
+	)
+	details := map[string]string{
+		"resource": string(result.Resource),
+		"before":   result.Before.Format(time.RFC3339),
+		"deleted":  strconv.Itoa(result.Deleted),
+	}
+	if result.Archived {
+		details["archived"] = "true"
+	}
+	u.audit.Record(ctx, entity.AuditRetentionPurged, entity.AuditOrganization, result.OrgID, details)
+	return result
+}
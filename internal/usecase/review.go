@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"avito-intro/internal/entity"
+)
+
+const (
+	MergeApprovalsNone     = "none"
+	MergeApprovalsRequired = "required"
+	MergeApprovalsAll      = "all"
+)
+
+const (
+	ReviewModeStandard  = "standard"
+	ReviewModeOwnerPeer = "owner_peer"
+)
+
+var ErrInvalidReviewSettings = errors.New("invalid review settings")
+
+type ReviewSettings struct {
+	SLA               time.Duration
+	Mode              string
+	RequiredReviewers int
+	OptionalReviewers int
+	MergeApprovals    string
+}
+
+func DefaultReviewSettings() ReviewSettings {
+	return ReviewSettings{
+		Mode:              ReviewModeStandard,
+		RequiredReviewers: 2,
+		MergeApprovals:    MergeApprovalsNone,
+	}
+}
+
+func (s ReviewSettings) Validate() error {
+	if s.RequiredReviewers < 0 || s.OptionalReviewers < 0 {
+		return fmt.Errorf("%w: reviewer counts must not be negative", ErrInvalidReviewSettings)
+	}
+	switch s.Mode {
+	case ReviewModeStandard, ReviewModeOwnerPeer:
+	default:
+		return fmt.Errorf("%w: unknown review mode %q", ErrInvalidReviewSettings, s.Mode)
+	}
+	switch s.MergeApprovals {
+	case MergeApprovalsNone, MergeApprovalsRequired, MergeApprovalsAll:
+	default:
+		return fmt.Errorf("%w: unknown merge approvals mode %q", ErrInvalidReviewSettings, s.MergeApprovals)
+	}
+	return nil
+}
+
+// WithGlobal returns s with the instance-wide overrides applied.
+func (s ReviewSettings) WithGlobal(global entity.GlobalSettings) ReviewSettings {
+	if global.RequiredReviewers != nil {
+		s.RequiredReviewers = *global.RequiredReviewers
+	}
+	if global.OptionalReviewers != nil {
+		s.OptionalReviewers = *global.OptionalReviewers
+	}
+	if global.SLA != nil {
+		s.SLA = *global.SLA
+	}
+	return s
+}
+
+// ForTeam returns s with the team's overrides applied. The review mode
+// stays service-wide.
+func (s ReviewSettings) ForTeam(team entity.TeamSettings) ReviewSettings {
+	if team.RequiredReviewers != nil {
+		s.RequiredReviewers = *team.RequiredReviewers
+	}
+	if team.OptionalReviewers != nil {
+		s.OptionalReviewers = *team.OptionalReviewers
+	}
+	if team.SLA != nil {
+		s.SLA = *team.SLA
+	}
+	if team.MergeApprovals != "" {
+		s.MergeApprovals = team.MergeApprovals
+	}
+	return s
+}
+
+// totalReviewers is the number of reviewer slots on a PR; owner+peer mode
+// always uses exactly one slot of each kind.
+func (s ReviewSettings) totalReviewers() int {
+	if s.Mode == ReviewModeOwnerPeer {
+		return 2
+	}
+	return s.RequiredReviewers + s.OptionalReviewers
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"avito-intro/internal/auth"
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidRole      = newError(CodeInvalidInput, "invalid role")
+	ErrPermissionDenied = newError(CodeForbidden, "permission denied")
+)
+
+var _ RoleUsecase = (*RoleUsecaseImpl)(nil)
+
+type RoleUsecaseImpl struct {
+	roleRepo repository.RoleRepository
+	authz    *auth.Authorizer
+	audit    AuditUsecase
+	logger   *zap.Logger
+}
+
+func NewRoleUsecase(roleRepo repository.RoleRepository, authz *auth.Authorizer, audit AuditUsecase, logger *zap.Logger) *RoleUsecaseImpl {
+	return &RoleUsecaseImpl{
+		roleRepo: roleRepo,
+		authz:    authz,
+		audit:    audit,
+		logger:   logger,
+	}
+}
+
+func (u *RoleUsecaseImpl) GrantRole(ctx context.Context, userID uuid.UUID, role entity.Role) ([]entity.Role, error) {
+	logging.From(ctx, u.logger).Info("granting role",
+		zap.String("user_id", userID.String()),
+		zap.String("role", string(role)),
+	)
+
+	if err := u.checkCanManageRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	if err := u.roleRepo.GrantRole(ctx, userID, role); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			logging.From(ctx, u.logger).Error("failed to grant role", zap.Error(err))
+		}
+		return nil, notFound(err, "user %s not found", userID)
+	}
+	u.audit.Record(ctx, entity.AuditRoleGranted, entity.AuditUser, userID.String(), map[string]string{"role": string(role)})
+
+	return u.GetUserRoles(ctx, userID)
+}
+
+func (u *RoleUsecaseImpl) RevokeRole(ctx context.Context, userID uuid.UUID, role entity.Role) ([]entity.Role, error) {
+	logging.From(ctx, u.logger).Info("revoking role",
+		zap.String("user_id", userID.String()),
+		zap.String("role", string(role)),
+	)
+
+	if err := u.checkCanManageRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	if err := u.roleRepo.RevokeRole(ctx, userID, role); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			logging.From(ctx, u.logger).Error("failed to revoke role", zap.Error(err))
+		}
+		return nil, notFound(err, "user %s not found", userID)
+	}
+	u.audit.Record(ctx, entity.AuditRoleRevoked, entity.AuditUser, userID.String(), map[string]string{"role": string(role)})
+
+	return u.GetUserRoles(ctx, userID)
+}
+
+func (u *RoleUsecaseImpl) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]entity.Role, error) {
+	roles, err := u.roleRepo.GetUserRoles(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			logging.From(ctx, u.logger).Error("failed to get user roles", zap.Error(err))
+		}
+		return nil, notFound(err, "user %s not found", userID)
+	}
+	return roles, nil
+}
+
+// checkCanManageRole checks role.manage_member for the member role and
+// role.manage for the others. Calls without an authenticated principal
+// (anonymous access with authentication disabled, internal callers) are not
+// restricted.
+func (u *RoleUsecaseImpl) checkCanManageRole(ctx context.Context, role entity.Role) error {
+	if !role.Valid() {
+		return ErrInvalidRole.Withf("invalid role %q: must be one of %v", role, entity.Roles()).
+			WithDetail("field", "role")
+	}
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	action := auth.ActionRoleManage
+	if role == entity.RoleMember {
+		action = auth.ActionRoleManageMember
+	}
+	if u.authz.Allows(principal.Roles, action) {
+		return nil
+	}
+	return ErrPermissionDenied.Withf("permission denied: managing role %s requires one of roles %v", role, u.authz.Roles(action))
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+	"avito-intro/internal/tenant"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Entity kinds counted in ImportResult.
+const (
+	stateTeams        = "teams"
+	stateUsers        = "users"
+	statePullRequests = "pull_requests"
+)
+
+var ErrInvalidSnapshot = newError(CodeInvalidInput, "invalid state snapshot")
+
+var _ StateUsecase = (*StateUsecaseImpl)(nil)
+
+type StateUsecaseImpl struct {
+	userRepo repository.UserRepository
+	teamRepo repository.TeamRepository
+	prRepo   repository.PullRequestRepository
+	tx       repository.Transactor
+	audit    AuditUsecase
+	clock    Clock
+	logger   *zap.Logger
+}
+
+func NewStateUsecase(
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	prRepo repository.PullRequestRepository,
+	tx repository.Transactor,
+	audit AuditUsecase,
+	clock Clock,
+	logger *zap.Logger,
+) *StateUsecaseImpl {
+	return &StateUsecaseImpl{
+		userRepo: userRepo,
+		teamRepo: teamRepo,
+		prRepo:   prRepo,
+		tx:       tx,
+		audit:    audit,
+		clock:    clock,
+		logger:   logger,
+	}
+}
+
+func (u *StateUsecaseImpl) Export(ctx context.Context) (entity.StateSnapshot, error) {
+	snapshot := entity.StateSnapshot{
+		Version:    entity.StateSnapshotVersion,
+		ExportedAt: u.clock(),
+	}
+
+	teams, err := u.teamRepo.ListTeams(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list teams for export", zap.Error(err))
+		return entity.StateSnapshot{}, err
+	}
+	for _, team := range teams {
+		snapshot.Teams = append(snapshot.Teams, *team)
+	}
+
+	users, _, err := u.userRepo.ListUsers(ctx, entity.UserFilter{IncludeDeleted: true})
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list users for export", zap.Error(err))
+		return entity.StateSnapshot{}, err
+	}
+	for _, user := range users {
+		snapshot.Users = append(snapshot.Users, *user)
+	}
+
+	for _, status := range entity.PullRequestStatuses() {
+		prs, err := u.prRepo.GetPullRequestsByStatus(ctx, status)
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to list pull requests for export", zap.Error(err))
+			return entity.StateSnapshot{}, err
+		}
+		for _, pr := range prs {
+			snapshot.PullRequests = append(snapshot.PullRequests, *pr)
+		}
+	}
+
+	logging.From(ctx, u.logger).Info("state exported",
+		zap.Int("teams", len(snapshot.Teams)),
+		zap.Int("users", len(snapshot.Users)),
+		zap.Int("pull_requests", len(snapshot.PullRequests)),
+	)
+	return snapshot, nil
+}
+
+// Import writes users, then teams, then PRs in one transaction, so a
+// snapshot that fails halfway leaves nothing behind on backends with
+// transactions.
+func (u *StateUsecaseImpl) Import(ctx context.Context, snapshot entity.StateSnapshot) (entity.ImportResult, error) {
+	if err := u.validateSnapshot(ctx, snapshot); err != nil {
+		return entity.ImportResult{}, err
+	}
+
+	var result entity.ImportResult
+	err := u.tx.InTx(ctx, func(ctx context.Context) error {
+		result = entity.ImportResult{Created: make(map[string]int), Updated: make(map[string]int)}
+
+		for _, user := range snapshot.Users {
+			err := upsert(&result, stateUsers, u.userRepo.CreateUser(ctx, &user), func() error {
+				return u.userRepo.UpdateUser(ctx, &user)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, team := range snapshot.Teams {
+			err := upsert(&result, stateTeams, u.teamRepo.CreateTeam(ctx, &team), func() error {
+				return u.teamRepo.UpdateTeam(ctx, &team)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, pr := range snapshot.PullRequests {
+			err := upsert(&result, statePullRequests, u.prRepo.CreatePullRequest(ctx, &pr), func() error {
+				return u.prRepo.UpdatePullRequest(ctx, &pr)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to import state", zap.Error(err))
+		return entity.ImportResult{}, err
+	}
+
+	logging.From(ctx, u.logger).Info("state imported",
+		zap.Any("created", result.Created),
+		zap.Any("updated", result.Updated),
+	)
+	details := make(map[string]string)
+	for _, kind := range []string{stateTeams, stateUsers, statePullRequests} {
+		details[kind+"_created"] = strconv.Itoa(result.Created[kind])
+		details[kind+"_updated"] = strconv.Itoa(result.Updated[kind])
+	}
+	u.audit.Record(ctx, entity.AuditStateImported, entity.AuditOrganization, tenant.OrganizationFromContext(ctx), details)
+	return result, nil
+}
+
+// upsert counts a create that succeeded and falls back to update when the
+// entity already exists.
+func upsert(result *entity.ImportResult, kind string, createErr error, update func() error) error {
+	if createErr == nil {
+		result.Created[kind]++
+		return nil
+	}
+	if !errors.Is(createErr, repository.ErrAlreadyExists) {
+		return createErr
+	}
+	if err := update(); err != nil {
+		return err
+	}
+	result.Updated[kind]++
+	return nil
+}
+
+// validateSnapshot checks the format version and that every user a team or
+// PR refers to is either in the snapshot or already stored.
+func (u *StateUsecaseImpl) validateSnapshot(ctx context.Context, snapshot entity.StateSnapshot) error {
+	if snapshot.Version != entity.StateSnapshotVersion {
+		return ErrInvalidSnapshot.Withf("unsupported snapshot version %d, expected %d", snapshot.Version, entity.StateSnapshotVersion)
+	}
+
+	users := make(map[uuid.UUID]bool, len(snapshot.Users))
+	for _, user := range snapshot.Users {
+		if user.UserID == uuid.Nil {
+			return ErrInvalidSnapshot.Withf("user %q has no user id", user.Username)
+		}
+		users[user.UserID] = true
+	}
+	requireUser := func(userID uuid.UUID, owner string) error {
+		if users[userID] {
+			return nil
+		}
+		exists, err := u.userRepo.UserExists(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrInvalidSnapshot.Withf("%s refers to unknown user %s", owner, userID)
+		}
+		users[userID] = true
+		return nil
+	}
+
+	for _, team := range snapshot.Teams {
+		if team.TeamName == "" {
+			return ErrInvalidSnapshot.Withf("team has no name")
+		}
+		for _, memberID := range team.Members {
+			if err := requireUser(memberID, "team "+team.TeamName); err != nil {
+				return err
+			}
+		}
+	}
+	for _, pr := range snapshot.PullRequests {
+		if pr.PullRequestID == uuid.Nil {
+			return ErrInvalidSnapshot.Withf("pull request %q has no id", pr.PullRequestName)
+		}
+		owner := "pull request " + pr.PullRequestID.String()
+		for _, userID := range append([]uuid.UUID{pr.AuthorID}, pr.AssignedReviewers...) {
+			if err := requireUser(userID, owner); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ StatsUsecase = (*StatsUsecaseImpl)(nil)
+
+type StatsUsecaseImpl struct {
+	statsRepo repository.StatsRepository
+	prRepo    repository.PullRequestRepository
+	userRepo  repository.UserRepository
+	logger    *zap.Logger
+}
+
+func NewStatsUsecase(
+	statsRepo repository.StatsRepository,
+	prRepo repository.PullRequestRepository,
+	userRepo repository.UserRepository,
+	logger *zap.Logger,
+) *StatsUsecaseImpl {
+	return &StatsUsecaseImpl{
+		statsRepo: statsRepo,
+		prRepo:    prRepo,
+		userRepo:  userRepo,
+		logger:    logger,
+	}
+}
+
+func (u *StatsUsecaseImpl) GetStorageStats(ctx context.Context) (entity.StorageStats, error) {
+	logging.From(ctx, u.logger).Debug("getting storage stats")
+
+	stats, err := u.statsRepo.Stats(ctx)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get storage stats", zap.Error(err))
+		return entity.StorageStats{}, err
+	}
+
+	return stats, nil
+}
+
+func (u *StatsUsecaseImpl) GetReviewStats(ctx context.Context, teamName string) (entity.ReviewStats, error) {
+	logging.From(ctx, u.logger).Debug("getting review stats", zap.String("team_name", teamName))
+
+	prs, err := u.reviewStatsPRs(ctx, teamName)
+	if err != nil {
+		return entity.ReviewStats{}, err
+	}
+
+	stats := entity.ReviewStats{TeamName: teamName}
+	responses := make(map[uuid.UUID]*entity.ResponseStats)
+	for _, pr := range prs {
+		iterations := pr.ReviewIterations()
+		stats.PullRequests++
+		stats.TotalIterations += iterations
+		stats.MaxIterations = max(stats.MaxIterations, iterations)
+		if iterations > 1 {
+			stats.MultiIterationPRs++
+		}
+		if pr.IsOverridden() {
+			stats.OverriddenPRs++
+		}
+
+		for userID, at := range pr.FirstResponses {
+			if responses[userID] == nil {
+				responses[userID] = &entity.ResponseStats{}
+			}
+			elapsed := at.Sub(pr.CreatedAt)
+			responses[userID].Add(elapsed)
+			stats.FirstResponse.Add(elapsed)
+		}
+	}
+
+	if err := u.fillResponseStats(ctx, &stats, responses); err != nil {
+		return entity.ReviewStats{}, err
+	}
+
+	return stats, nil
+}
+
+// fillResponseStats breaks first-response times down by reviewer and by the
+// reviewer's current team.
+func (u *StatsUsecaseImpl) fillResponseStats(ctx context.Context, stats *entity.ReviewStats, responses map[uuid.UUID]*entity.ResponseStats) error {
+	if len(responses) == 0 {
+		return nil
+	}
+
+	users, err := u.userRepo.GetUsersByIDs(ctx, slices.Collect(maps.Keys(responses)))
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get reviewers", zap.Error(err))
+		return err
+	}
+	teamOf := make(map[uuid.UUID]string, len(users))
+	for _, user := range users {
+		teamOf[user.UserID] = user.TeamName
+	}
+
+	teams := make(map[string]*entity.ResponseStats)
+	for userID, rs := range responses {
+		teamName := teamOf[userID]
+		stats.Reviewers = append(stats.Reviewers, entity.ReviewerResponseStats{
+			UserID:        userID,
+			TeamName:      teamName,
+			ResponseStats: *rs,
+		})
+
+		if teams[teamName] == nil {
+			teams[teamName] = &entity.ResponseStats{}
+		}
+		teams[teamName].Responses += rs.Responses
+		teams[teamName].Total += rs.Total
+	}
+
+	for teamName, rs := range teams {
+		stats.Teams = append(stats.Teams, entity.TeamResponseStats{
+			TeamName:      teamName,
+			ResponseStats: *rs,
+		})
+	}
+
+	slices.SortFunc(stats.Reviewers, func(a, b entity.ReviewerResponseStats) int {
+		return strings.Compare(a.UserID.String(), b.UserID.String())
+	})
+	slices.SortFunc(stats.Teams, func(a, b entity.TeamResponseStats) int {
+		return strings.Compare(a.TeamName, b.TeamName)
+	})
+
+	return nil
+}
+
+func (u *StatsUsecaseImpl) reviewStatsPRs(ctx context.Context, teamName string) ([]*entity.PullRequest, error) {
+	if teamName != "" {
+		prs, err := u.prRepo.GetPullRequestsByTeam(ctx, teamName, entity.PullRequestFilter{})
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get team PRs", zap.String("team_name", teamName), zap.Error(err))
+			return nil, notFound(err, "team %s not found", teamName)
+		}
+		return prs, nil
+	}
+
+	var prs []*entity.PullRequest
+	for _, status := range entity.PullRequestStatuses() {
+		byStatus, err := u.prRepo.GetPullRequestsByStatus(ctx, status)
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get PRs by status", zap.Error(err))
+			return nil, err
+		}
+		prs = append(prs, byStatus...)
+	}
+	return prs, nil
+}
@@ -2,8 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strconv"
 
 	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
 	"avito-intro/internal/repository"
 
 	"github.com/google/uuid"
@@ -15,45 +19,67 @@ var _ TeamUsecase = (*TeamUsecaseImpl)(nil)
 type TeamUsecaseImpl struct {
 	userRepo repository.UserRepository
 	teamRepo repository.TeamRepository
+	tx       repository.Transactor
+	quota    QuotaUsecase
+	audit    AuditUsecase
 	logger   *zap.Logger
 }
 
 func NewTeamUsecase(
 	userRepo repository.UserRepository,
 	teamRepo repository.TeamRepository,
+	tx repository.Transactor,
+	quota QuotaUsecase,
+	audit AuditUsecase,
 	logger *zap.Logger,
 ) *TeamUsecaseImpl {
 	return &TeamUsecaseImpl{
 		userRepo: userRepo,
 		teamRepo: teamRepo,
+		tx:       tx,
+		quota:    quota,
+		audit:    audit,
 		logger:   logger,
 	}
 }
 
 func (u *TeamUsecaseImpl) AddTeam(ctx context.Context, team entity.Team, members []entity.User) (entity.Team, error) {
-	u.logger.Info("adding team",
+	logging.From(ctx, u.logger).Info("adding team",
 		zap.String("team_name", team.TeamName),
 		zap.Int("members_count", len(members)),
 	)
 
-	if err := u.checkTeamNotExists(ctx, team.TeamName); err != nil {
-		return entity.Team{}, err
-	}
+	// Members are written before the team; without the transaction a
+	// failure in between would leave them pointing at a missing team.
+	err := u.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := u.checkTeamNotExists(ctx, team.TeamName); err != nil {
+			return err
+		}
 
-	if err := u.createOrUpdateMembers(ctx, members); err != nil {
-		return entity.Team{}, err
-	}
+		if err := u.quota.CheckTeamCreate(ctx); err != nil {
+			return err
+		}
+		if err := u.quota.CheckMembers(ctx, team.TeamName, members, true); err != nil {
+			return err
+		}
+
+		if err := u.createOrUpdateMembers(ctx, members); err != nil {
+			return err
+		}
 
-	if err := u.createTeam(ctx, &team); err != nil {
+		return u.createTeam(ctx, &team)
+	})
+	if err != nil {
 		return entity.Team{}, err
 	}
 
-	u.logger.Info("team created successfully", zap.String("team_name", team.TeamName))
+	logging.From(ctx, u.logger).Info("team created successfully", zap.String("team_name", team.TeamName))
+	u.recordTeam(ctx, entity.AuditTeamCreated, team)
 	return team, nil
 }
 
 func (u *TeamUsecaseImpl) GetTeam(ctx context.Context, teamName string) (entity.Team, []entity.User, error) {
-	u.logger.Debug("getting team", zap.String("team_name", teamName))
+	logging.From(ctx, u.logger).Debug("getting team", zap.String("team_name", teamName))
 
 	team, err := u.getTeamByName(ctx, teamName)
 	if err != nil {
@@ -65,7 +91,7 @@ func (u *TeamUsecaseImpl) GetTeam(ctx context.Context, teamName string) (entity.
 		return entity.Team{}, nil, err
 	}
 
-	u.logger.Debug("team retrieved successfully",
+	logging.From(ctx, u.logger).Debug("team retrieved successfully",
 		zap.String("team_name", teamName),
 		zap.Int("members_count", len(users)),
 	)
@@ -73,16 +99,168 @@ func (u *TeamUsecaseImpl) GetTeam(ctx context.Context, teamName string) (entity.
 	return team, users, nil
 }
 
+func (u *TeamUsecaseImpl) ListTeams(ctx context.Context, filter entity.TeamListFilter) ([]entity.TeamSummary, int, error) {
+	logging.From(ctx, u.logger).Debug("listing teams")
+
+	summaries, total, err := u.teamRepo.ListTeamSummaries(ctx, filter)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list teams", zap.Error(err))
+		return nil, 0, err
+	}
+	return summaries, total, nil
+}
+
+// UpsertTeam creates the team if it is missing, otherwise adds the given
+// members to it. Users moving in from another team are detached from it.
+// The returned flag reports whether the team was created.
+func (u *TeamUsecaseImpl) UpsertTeam(ctx context.Context, teamName string, members []entity.User) (entity.Team, bool, error) {
+	return u.upsertTeam(ctx, teamName, members, false)
+}
+
+// SetTeamMembers works like UpsertTeam but makes the given members the
+// complete member list: users no longer listed are removed from the team.
+func (u *TeamUsecaseImpl) SetTeamMembers(ctx context.Context, teamName string, members []entity.User) (entity.Team, bool, error) {
+	return u.upsertTeam(ctx, teamName, members, true)
+}
+
+func (u *TeamUsecaseImpl) upsertTeam(ctx context.Context, teamName string, members []entity.User, replace bool) (entity.Team, bool, error) {
+	logging.From(ctx, u.logger).Info("upserting team",
+		zap.String("team_name", teamName),
+		zap.Int("members_count", len(members)),
+		zap.Bool("replace_members", replace),
+	)
+
+	var (
+		team    entity.Team
+		created bool
+	)
+	err := u.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		team, created, err = u.writeTeam(ctx, teamName, members, replace)
+		return err
+	})
+	if err != nil {
+		return entity.Team{}, false, err
+	}
+
+	if created {
+		logging.From(ctx, u.logger).Info("team created successfully", zap.String("team_name", teamName))
+		u.recordTeam(ctx, entity.AuditTeamCreated, team)
+	} else {
+		logging.From(ctx, u.logger).Info("team updated successfully", zap.String("team_name", teamName))
+		u.recordTeam(ctx, entity.AuditTeamUpdated, team)
+	}
+	return team, created, nil
+}
+
+// UpdateTeam changes the members of an existing team like UpsertTeam, or
+// like SetTeamMembers with replace, but never creates the team.
+func (u *TeamUsecaseImpl) UpdateTeam(ctx context.Context, teamName string, members []entity.User, replace bool) (entity.Team, error) {
+	logging.From(ctx, u.logger).Info("updating team",
+		zap.String("team_name", teamName),
+		zap.Int("members_count", len(members)),
+		zap.Bool("replace_members", replace),
+	)
+
+	var team entity.Team
+	err := u.tx.InTx(ctx, func(ctx context.Context) error {
+		if _, err := u.getTeamByName(ctx, teamName); err != nil {
+			return err
+		}
+		var err error
+		team, _, err = u.writeTeam(ctx, teamName, members, replace)
+		return err
+	})
+	if err != nil {
+		return entity.Team{}, err
+	}
+
+	logging.From(ctx, u.logger).Info("team updated successfully", zap.String("team_name", teamName))
+	u.recordTeam(ctx, entity.AuditTeamUpdated, team)
+	return team, nil
+}
+
+// writeTeam makes the writes of upsertTeam; it runs in a transaction.
+func (u *TeamUsecaseImpl) writeTeam(ctx context.Context, teamName string, members []entity.User, replace bool) (entity.Team, bool, error) {
+	exists, err := u.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to check team existence", zap.Error(err))
+		return entity.Team{}, false, err
+	}
+
+	if !exists {
+		if err := u.quota.CheckTeamCreate(ctx); err != nil {
+			return entity.Team{}, false, err
+		}
+	}
+	if err := u.quota.CheckMembers(ctx, teamName, members, replace); err != nil {
+		return entity.Team{}, false, err
+	}
+
+	if err := u.detachFromPreviousTeams(ctx, teamName, members); err != nil {
+		return entity.Team{}, false, err
+	}
+
+	if err := u.createOrUpdateMembers(ctx, members); err != nil {
+		return entity.Team{}, false, err
+	}
+
+	if !exists {
+		team := entity.Team{
+			TeamName: teamName,
+			Members:  userIDs(members),
+		}
+		if err := u.createTeam(ctx, &team); err != nil {
+			return entity.Team{}, false, err
+		}
+		return team, true, nil
+	}
+
+	team, err := u.getTeamByName(ctx, teamName)
+	if err != nil {
+		return entity.Team{}, false, err
+	}
+
+	ids := userIDs(members)
+	if replace {
+		removed := slices.DeleteFunc(slices.Clone(team.Members), func(id uuid.UUID) bool {
+			return slices.Contains(ids, id)
+		})
+		if err := u.removeMembers(ctx, teamName, removed); err != nil {
+			return entity.Team{}, false, err
+		}
+		team.Members = ids
+	} else {
+		team.Members = slices.Clone(team.Members)
+		for _, id := range ids {
+			if !slices.Contains(team.Members, id) {
+				team.Members = append(team.Members, id)
+			}
+		}
+	}
+
+	if err := u.updateTeam(ctx, &team); err != nil {
+		return entity.Team{}, false, err
+	}
+	return team, false, nil
+}
+
+func (u *TeamUsecaseImpl) recordTeam(ctx context.Context, action string, team entity.Team) {
+	u.audit.Record(ctx, action, entity.AuditTeam, team.TeamName, map[string]string{
+		"members_count": strconv.Itoa(len(team.Members)),
+	})
+}
+
 func (u *TeamUsecaseImpl) checkTeamNotExists(ctx context.Context, teamName string) error {
 	exists, err := u.teamRepo.TeamExists(ctx, teamName)
 	if err != nil {
-		u.logger.Error("failed to check team existence", zap.Error(err))
+		logging.From(ctx, u.logger).Error("failed to check team existence", zap.Error(err))
 		return err
 	}
 
 	if exists {
-		u.logger.Warn("team already exists", zap.String("team_name", teamName))
-		return repository.ErrAlreadyExists
+		logging.From(ctx, u.logger).Warn("team already exists", zap.String("team_name", teamName))
+		return ErrTeamExists.Withf("team_name already exists").WithDetail("team_name", teamName)
 	}
 
 	return nil
@@ -90,18 +268,20 @@ func (u *TeamUsecaseImpl) checkTeamNotExists(ctx context.Context, teamName strin
 
 func (u *TeamUsecaseImpl) createOrUpdateMembers(ctx context.Context, members []entity.User) error {
 	for _, member := range members {
-		exists, err := u.userRepo.UserExists(ctx, member.UserID)
-		if err != nil {
-			u.logger.Error("failed to check user existence",
+		existing, err := u.userRepo.GetUser(ctx, member.UserID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			logging.From(ctx, u.logger).Error("failed to check user existence",
 				zap.String("user_id", member.UserID.String()),
 				zap.Error(err),
 			)
 			return err
 		}
 
-		if exists {
+		if existing != nil {
+			// A snooze is set per user, team payloads do not carry it.
+			member.SnoozedUntil = existing.SnoozedUntil
 			if err := u.userRepo.UpdateUser(ctx, &member); err != nil {
-				u.logger.Error("failed to update user",
+				logging.From(ctx, u.logger).Error("failed to update user",
 					zap.String("user_id", member.UserID.String()),
 					zap.Error(err),
 				)
@@ -111,7 +291,7 @@ func (u *TeamUsecaseImpl) createOrUpdateMembers(ctx context.Context, members []e
 		}
 
 		if err := u.userRepo.CreateUser(ctx, &member); err != nil {
-			u.logger.Error("failed to create user",
+			logging.From(ctx, u.logger).Error("failed to create user",
 				zap.String("user_id", member.UserID.String()),
 				zap.Error(err),
 			)
@@ -123,17 +303,97 @@ func (u *TeamUsecaseImpl) createOrUpdateMembers(ctx context.Context, members []e
 
 func (u *TeamUsecaseImpl) createTeam(ctx context.Context, team *entity.Team) error {
 	if err := u.teamRepo.CreateTeam(ctx, team); err != nil {
-		u.logger.Error("failed to create team", zap.Error(err))
+		logging.From(ctx, u.logger).Error("failed to create team", zap.Error(err))
+		return alreadyExists(err, ErrTeamExists.Withf("team_name already exists").WithDetail("team_name", team.TeamName))
+	}
+	return nil
+}
+
+func (u *TeamUsecaseImpl) updateTeam(ctx context.Context, team *entity.Team) error {
+	if err := u.teamRepo.UpdateTeam(ctx, team); err != nil {
+		logging.From(ctx, u.logger).Error("failed to update team", zap.Error(err))
 		return err
 	}
 	return nil
 }
 
+func (u *TeamUsecaseImpl) detachFromPreviousTeams(ctx context.Context, teamName string, members []entity.User) error {
+	for _, member := range members {
+		existing, err := u.userRepo.GetUser(ctx, member.UserID)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get user", zap.String("user_id", member.UserID.String()), zap.Error(err))
+			return err
+		}
+
+		if existing.TeamName == "" || existing.TeamName == teamName {
+			continue
+		}
+
+		previous, err := u.teamRepo.GetTeam(ctx, existing.TeamName)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get team", zap.Error(err))
+			return err
+		}
+
+		updated := *previous
+		updated.Members = slices.DeleteFunc(slices.Clone(previous.Members), func(id uuid.UUID) bool {
+			return id == member.UserID
+		})
+
+		logging.From(ctx, u.logger).Info("moving user between teams",
+			zap.String("user_id", member.UserID.String()),
+			zap.String("from_team", existing.TeamName),
+			zap.String("to_team", teamName),
+		)
+
+		if err := u.updateTeam(ctx, &updated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *TeamUsecaseImpl) removeMembers(ctx context.Context, teamName string, userIDs []uuid.UUID) error {
+	users, err := u.userRepo.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get removed members", zap.Error(err))
+		return err
+	}
+
+	for _, user := range users {
+		if user.TeamName != teamName {
+			continue
+		}
+
+		updated := *user
+		updated.TeamName = ""
+		if err := u.userRepo.UpdateUser(ctx, &updated); err != nil {
+			logging.From(ctx, u.logger).Error("failed to update user",
+				zap.String("user_id", user.UserID.String()),
+				zap.Error(err),
+			)
+			return err
+		}
+
+		logging.From(ctx, u.logger).Info("user removed from team",
+			zap.String("user_id", user.UserID.String()),
+			zap.String("team_name", teamName),
+		)
+	}
+	return nil
+}
+
 func (u *TeamUsecaseImpl) getTeamByName(ctx context.Context, teamName string) (entity.Team, error) {
 	team, err := u.teamRepo.GetTeam(ctx, teamName)
 	if err != nil {
-		u.logger.Error("failed to get team", zap.Error(err))
-		return entity.Team{}, err
+		logging.From(ctx, u.logger).Error("failed to get team", zap.Error(err))
+		return entity.Team{}, notFound(err, "team %s not found", teamName)
 	}
 	return *team, nil
 }
@@ -141,7 +401,7 @@ func (u *TeamUsecaseImpl) getTeamByName(ctx context.Context, teamName string) (e
 func (u *TeamUsecaseImpl) getTeamMembers(ctx context.Context, memberIDs []uuid.UUID) ([]entity.User, error) {
 	users, err := u.userRepo.GetUsersByIDs(ctx, memberIDs)
 	if err != nil {
-		u.logger.Error("failed to get team members", zap.Error(err))
+		logging.From(ctx, u.logger).Error("failed to get team members", zap.Error(err))
 		return nil, err
 	}
 
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+	"avito-intro/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+var ErrInvalidTeamSettings = newError(CodeInvalidInput, "invalid team settings")
+
+var _ TeamSettingsUsecase = (*TeamSettingsUsecaseImpl)(nil)
+
+// TeamSettingsUsecaseImpl manages the per-team overrides of the review
+// settings the service is started with.
+type TeamSettingsUsecaseImpl struct {
+	settingsRepo repository.TeamSettingsRepository
+	globalRepo   repository.GlobalSettingsRepository
+	teamRepo     repository.TeamRepository
+	review       ReviewSettings
+	strategy     string
+	audit        AuditUsecase
+	logger       *zap.Logger
+}
+
+func NewTeamSettingsUsecase(
+	settingsRepo repository.TeamSettingsRepository,
+	globalRepo repository.GlobalSettingsRepository,
+	teamRepo repository.TeamRepository,
+	review ReviewSettings,
+	strategy string,
+	audit AuditUsecase,
+	logger *zap.Logger,
+) *TeamSettingsUsecaseImpl {
+	return &TeamSettingsUsecaseImpl{
+		settingsRepo: settingsRepo,
+		globalRepo:   globalRepo,
+		teamRepo:     teamRepo,
+		review:       review,
+		strategy:     strategy,
+		audit:        audit,
+		logger:       logger,
+	}
+}
+
+// SetSettings replaces the team's overrides: fields left unset go back to
+// the service-wide defaults.
+func (u *TeamSettingsUsecaseImpl) SetSettings(ctx context.Context, settings entity.TeamSettings) (entity.TeamSettings, entity.TeamSettings, error) {
+	logging.From(ctx, u.logger).Info("setting team settings", zap.String("team_name", settings.TeamName))
+
+	if err := validateTeamSettings(settings); err != nil {
+		return entity.TeamSettings{}, entity.TeamSettings{}, err
+	}
+
+	if err := u.settingsRepo.SetTeamSettings(ctx, &settings); err != nil {
+		logging.From(ctx, u.logger).Error("failed to set team settings", zap.Error(err))
+		return entity.TeamSettings{}, entity.TeamSettings{}, notFound(err, "team %s not found", settings.TeamName)
+	}
+
+	u.audit.Record(ctx, entity.AuditTeamSettingsUpdated, entity.AuditTeam, settings.TeamName, teamSettingsDetails(settings))
+	return u.withEffective(ctx, settings)
+}
+
+// GetSettings returns the team's overrides together with the settings in
+// effect once the defaults are filled in.
+func (u *TeamSettingsUsecaseImpl) GetSettings(ctx context.Context, teamName string) (entity.TeamSettings, entity.TeamSettings, error) {
+	exists, err := u.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to check team existence", zap.Error(err))
+		return entity.TeamSettings{}, entity.TeamSettings{}, err
+	}
+	if !exists {
+		return entity.TeamSettings{}, entity.TeamSettings{}, ErrNotFound.Withf("team %s not found", teamName)
+	}
+
+	settings, err := loadTeamSettings(ctx, u.settingsRepo, teamName)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team settings", zap.Error(err))
+		return entity.TeamSettings{}, entity.TeamSettings{}, err
+	}
+	return u.withEffective(ctx, settings)
+}
+
+// withEffective returns settings along with the settings in effect for the
+// team: its overrides on top of the global ones on top of the configured
+// defaults.
+func (u *TeamSettingsUsecaseImpl) withEffective(ctx context.Context, settings entity.TeamSettings) (entity.TeamSettings, entity.TeamSettings, error) {
+	global, err := loadGlobalSettings(ctx, u.globalRepo)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get global settings", zap.Error(err))
+		return entity.TeamSettings{}, entity.TeamSettings{}, err
+	}
+
+	review := u.review.WithGlobal(global).ForTeam(settings)
+	effective := entity.TeamSettings{
+		TeamName:           settings.TeamName,
+		RequiredReviewers:  &review.RequiredReviewers,
+		OptionalReviewers:  &review.OptionalReviewers,
+		SLA:                &review.SLA,
+		AssignmentStrategy: settings.AssignmentStrategy,
+		MergeApprovals:     review.MergeApprovals,
+		Timezone:           settings.Location().String(),
+		NamePattern:        settings.NamePattern,
+	}
+	if effective.AssignmentStrategy == "" {
+		effective.AssignmentStrategy = u.strategy
+	}
+	return settings, effective, nil
+}
+
+func validateTeamSettings(settings entity.TeamSettings) error {
+	invalid := func(field, format string, args ...any) error {
+		return ErrInvalidTeamSettings.Withf("invalid team settings: "+format, args...).WithDetail("field", field)
+	}
+
+	if settings.RequiredReviewers != nil && *settings.RequiredReviewers < 0 {
+		return invalid("required_reviewers", "required_reviewers must not be negative")
+	}
+	if settings.OptionalReviewers != nil && *settings.OptionalReviewers < 0 {
+		return invalid("optional_reviewers", "optional_reviewers must not be negative")
+	}
+	if settings.SLA != nil && *settings.SLA <= 0 {
+		return invalid("sla", "sla must be positive")
+	}
+	if settings.AssignmentStrategy != "" && !slices.Contains(AssignmentStrategies(), settings.AssignmentStrategy) {
+		return invalid("assignment_strategy", "unknown assignment strategy %q", settings.AssignmentStrategy)
+	}
+	switch settings.MergeApprovals {
+	case "", MergeApprovalsNone, MergeApprovalsRequired, MergeApprovalsAll:
+	default:
+		return invalid("merge_approvals", "unknown merge approvals mode %q", settings.MergeApprovals)
+	}
+	if settings.Timezone != "" {
+		if _, err := time.LoadLocation(settings.Timezone); err != nil {
+			return invalid("timezone", "unknown timezone %q", settings.Timezone)
+		}
+	}
+	if _, err := settings.NameRegexp(); err != nil {
+		return invalid("pull_request_name_pattern", "pull_request_name_pattern is not a valid regular expression")
+	}
+	return nil
+}
+
+func teamSettingsDetails(settings entity.TeamSettings) map[string]string {
+	details := map[string]string{}
+	if settings.RequiredReviewers != nil {
+		details["required_reviewers"] = strconv.Itoa(*settings.RequiredReviewers)
+	}
+	if settings.OptionalReviewers != nil {
+		details["optional_reviewers"] = strconv.Itoa(*settings.OptionalReviewers)
+	}
+	if settings.SLA != nil {
+		details["sla"] = settings.SLA.String()
+	}
+	if settings.AssignmentStrategy != "" {
+		details["assignment_strategy"] = settings.AssignmentStrategy
+	}
+	if settings.MergeApprovals != "" {
+		details["merge_approvals"] = settings.MergeApprovals
+	}
+	if settings.Timezone != "" {
+		details["timezone"] = settings.Timezone
+	}
+	if settings.NamePattern != "" {
+		details["pull_request_name_pattern"] = settings.NamePattern
+	}
+	return details
+}
+
+// loadTeamSettings returns empty overrides for teams that never set any.
+func loadTeamSettings(ctx context.Context, settingsRepo repository.TeamSettingsRepository, teamName string) (entity.TeamSettings, error) {
+	settings, err := settingsRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return entity.TeamSettings{TeamName: teamName}, nil
+		}
+		return entity.TeamSettings{}, err
+	}
+	return *settings, nil
+}
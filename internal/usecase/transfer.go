@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var ErrInvalidTransfer = newError(CodeInvalidInput, "invalid review transfer")
+
+// TransferReviews moves every open review assigned to fromID in one go,
+// e.g. when someone leaves the company. With a toID each assignment is
+// handed to that user; with uuid.Nil the assignment strategy picks a
+// replacement from each PR author's team, as a manual reassignment would.
+// A PR that cannot take the new reviewer (the target authored it, already
+// reviews it, is not in the author's team or cannot fill an owner slot, or
+// the team has no candidate) keeps its reviewer and is reported as skipped.
+func (u *PullRequestUsecaseImpl) TransferReviews(ctx context.Context, fromID uuid.UUID, toID uuid.UUID) (entity.ReviewTransfer, error) {
+	logging.From(ctx, u.logger).Info("transferring reviews",
+		zap.String("from_user_id", fromID.String()),
+		zap.String("to_user_id", toID.String()),
+	)
+
+	if fromID == toID {
+		return entity.ReviewTransfer{}, ErrInvalidTransfer.Withf("cannot transfer reviews to the same user").
+			WithDetail("field", "to_user_id")
+	}
+	if _, err := u.getUser(ctx, fromID); err != nil {
+		return entity.ReviewTransfer{}, err
+	}
+	var target entity.User
+	if toID != uuid.Nil {
+		var err error
+		target, err = u.getUser(ctx, toID)
+		if err != nil {
+			return entity.ReviewTransfer{}, err
+		}
+		if target.IsDeleted() {
+			return entity.ReviewTransfer{}, ErrInvalidTransfer.Withf("user %s is deleted", toID).
+				WithDetail("field", "to_user_id")
+		}
+		if !target.IsActive {
+			return entity.ReviewTransfer{}, ErrInvalidTransfer.Withf("user %s is inactive", toID).
+				WithDetail("field", "to_user_id")
+		}
+		if target.IsSnoozed(u.clock()) {
+			return entity.ReviewTransfer{}, ErrInvalidTransfer.Withf("user %s is snoozed", toID).
+				WithDetail("field", "to_user_id")
+		}
+	}
+
+	openStatus := entity.StatusOpen
+	prs, err := u.prRepo.GetPullRequestsByReviewer(ctx, fromID, entity.PullRequestFilter{Status: &openStatus})
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get user reviews", zap.String("user_id", fromID.String()), zap.Error(err))
+		return entity.ReviewTransfer{}, err
+	}
+
+	result := entity.ReviewTransfer{FromUserID: fromID, ToUserID: toID}
+	for _, pr := range prs {
+		newReviewerID, err := retryOnConflict(ctx, u.logger, func() (uuid.UUID, error) {
+			if toID == uuid.Nil {
+				return u.replaceStaleReviewer(ctx, pr.PullRequestID, fromID, false)
+			}
+			return u.moveReview(ctx, pr.PullRequestID, fromID, target)
+		})
+
+		var ucErr *Error
+		if errors.As(err, &ucErr) {
+			result.Skipped = append(result.Skipped, entity.SkippedTransfer{
+				PullRequestID: pr.PullRequestID,
+				Reason:        ucErr.Message,
+			})
+			continue
+		}
+		if err != nil {
+			return entity.ReviewTransfer{}, err
+		}
+
+		result.Moves = append(result.Moves, entity.ReviewerMove{
+			PullRequestID: pr.PullRequestID,
+			FromUserID:    fromID,
+			ToUserID:      newReviewerID,
+		})
+	}
+
+	logging.From(ctx, u.logger).Info("reviews transferred",
+		zap.String("from_user_id", fromID.String()),
+		zap.Int("moved", len(result.Moves)),
+		zap.Int("skipped", len(result.Skipped)),
+	)
+	return result, nil
+}
+
+// moveReview hands fromID's assignment on a PR to target.
+func (u *PullRequestUsecaseImpl) moveReview(ctx context.Context, prID, fromID uuid.UUID, target entity.User) (uuid.UUID, error) {
+	toID := target.UserID
+
+	pr, err := u.getPR(ctx, prID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := u.checkPROpen(ctx, pr, "transfer review on"); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := u.checkReviewerAssigned(ctx, pr, fromID); err != nil {
+		return uuid.Nil, err
+	}
+
+	author, err := u.getAuthor(ctx, pr.AuthorID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	switch {
+	case pr.AuthorID == toID:
+		return uuid.Nil, ErrInvalidReviewer.Withf("invalid reviewer %s: author cannot review own PR", toID)
+	case slices.Contains(pr.AssignedReviewers, toID):
+		return uuid.Nil, ErrInvalidReviewer.Withf("invalid reviewer %s: already assigned", toID)
+	case target.TeamName != author.TeamName:
+		return uuid.Nil, ErrInvalidReviewer.Withf("invalid reviewer %s: not a member of author's team", toID)
+	}
+
+	if pr.SlotOf(fromID) == entity.SlotOwner {
+		owners, err := u.ownershipRepo.GetTeamOwners(ctx, author.TeamName)
+		if err != nil {
+			logging.From(ctx, u.logger).Error("failed to get team owners", zap.String("team_name", author.TeamName), zap.Error(err))
+			return uuid.Nil, err
+		}
+		if !slices.Contains(owners, toID) {
+			return uuid.Nil, ErrInvalidReviewer.Withf("invalid reviewer %s: owner slot requires an owner of team %s", toID, author.TeamName)
+		}
+	}
+
+	u.replaceReviewer(&pr, fromID, toID)
+
+	err = u.updateWithEvents(ctx, &pr, pr.Version, entity.PullRequestEvent{
+		Type:           entity.EventReviewerReassigned,
+		UserID:         toID,
+		PreviousUserID: fromID,
+	})
+	if err != nil {
+		logConflictOr(ctx, u.logger, "failed to update PR", err)
+		return uuid.Nil, invalidReviewers(err, &pr)
+	}
+	return toID, nil
+}
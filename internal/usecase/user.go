@@ -2,38 +2,68 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
 
 	"avito-intro/internal/entity"
+	"avito-intro/internal/logging"
 	"avito-intro/internal/repository"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+var ErrInvalidSnooze = newError(CodeInvalidInput, "invalid snooze").WithDetail("field", "until")
+
 var _ UserUsecase = (*UserUsecaseImpl)(nil)
 
 type UserUsecaseImpl struct {
-	userRepo repository.UserRepository
-	logger   *zap.Logger
+	userRepo   repository.UserRepository
+	teamRepo   repository.TeamRepository
+	globalRepo repository.GlobalSettingsRepository
+	tx         repository.Transactor
+	quota      QuotaUsecase
+	audit      AuditUsecase
+	clock      Clock
+	logger     *zap.Logger
 }
 
 func NewUserUsecase(
 	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	globalRepo repository.GlobalSettingsRepository,
+	tx repository.Transactor,
+	quota QuotaUsecase,
+	audit AuditUsecase,
+	clock Clock,
 	logger *zap.Logger,
 ) *UserUsecaseImpl {
 	return &UserUsecaseImpl{
-		userRepo: userRepo,
-		logger:   logger,
+		userRepo:   userRepo,
+		teamRepo:   teamRepo,
+		globalRepo: globalRepo,
+		tx:         tx,
+		quota:      quota,
+		audit:      audit,
+		clock:      clock,
+		logger:     logger,
 	}
 }
 
+func (u *UserUsecaseImpl) GetUser(ctx context.Context, userID uuid.UUID) (entity.User, error) {
+	logging.From(ctx, u.logger).Debug("getting user", zap.String("user_id", userID.String()))
+	return u.getUser(ctx, userID)
+}
+
 func (u *UserUsecaseImpl) SetIsActive(ctx context.Context, userID uuid.UUID, isActive bool) (entity.User, error) {
-	u.logger.Info("setting user active status",
+	logging.From(ctx, u.logger).Info("setting user active status",
 		zap.String("user_id", userID.String()),
 		zap.Bool("is_active", isActive),
 	)
 
-	user, err := u.getUser(ctx, userID)
+	user, err := u.getLiveUser(ctx, userID)
 	if err != nil {
 		return entity.User{}, err
 	}
@@ -44,23 +74,334 @@ func (u *UserUsecaseImpl) SetIsActive(ctx context.Context, userID uuid.UUID, isA
 		return entity.User{}, err
 	}
 
-	u.logger.Info("user active status updated successfully",
+	logging.From(ctx, u.logger).Info("user active status updated successfully",
 		zap.String("user_id", userID.String()),
 		zap.Bool("is_active", isActive),
 	)
+	action := entity.AuditUserDeactivated
+	if isActive {
+		action = entity.AuditUserActivated
+	}
+	u.audit.Record(ctx, action, entity.AuditUser, userID.String(), map[string]string{"team_name": user.TeamName})
 
 	return updatedUser, nil
 }
 
+// SetIsActiveBulk sets the active flag of every listed user in one
+// transaction. All users are checked before any is written: if one does not
+// exist or is deleted nothing changes, and the error lists them under the
+// user_ids detail.
+func (u *UserUsecaseImpl) SetIsActiveBulk(ctx context.Context, userIDs []uuid.UUID, isActive bool) ([]entity.ActiveStatusChange, error) {
+	logging.From(ctx, u.logger).Info("setting active status of users",
+		zap.Int("users", len(userIDs)),
+		zap.Bool("is_active", isActive),
+	)
+
+	var changes []entity.ActiveStatusChange
+	err := u.tx.InTx(ctx, func(ctx context.Context) error {
+		changes = make([]entity.ActiveStatusChange, 0, len(userIDs))
+		var missing []string
+		for _, userID := range userIDs {
+			user, err := u.getLiveUser(ctx, userID)
+			if errors.Is(err, ErrNotFound) {
+				missing = append(missing, userID.String())
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			changes = append(changes, entity.ActiveStatusChange{User: user, Changed: user.IsActive != isActive})
+		}
+		if len(missing) > 0 {
+			return ErrNotFound.
+				Withf("%d of %d users not found, nothing was changed", len(missing), len(userIDs)).
+				WithDetail("user_ids", strings.Join(missing, ","))
+		}
+
+		for i := range changes {
+			if !changes[i].Changed {
+				continue
+			}
+			changes[i].User = u.updateUserActiveStatus(changes[i].User, isActive)
+			if err := u.saveUser(ctx, &changes[i].User); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	action := entity.AuditUserDeactivated
+	if isActive {
+		action = entity.AuditUserActivated
+	}
+	changed := 0
+	for _, change := range changes {
+		if !change.Changed {
+			continue
+		}
+		changed++
+		u.audit.Record(ctx, action, entity.AuditUser, change.User.UserID.String(), map[string]string{"team_name": change.User.TeamName})
+	}
+	logging.From(ctx, u.logger).Info("active status of users updated",
+		zap.Int("users", len(changes)),
+		zap.Int("changed", changed),
+		zap.Bool("is_active", isActive),
+	)
+	return changes, nil
+}
+
+// Snooze keeps the user out of review assignment and reassignment until the
+// given time without deactivating them; nil ends a snooze early. Reviews
+// the user already has are not touched.
+func (u *UserUsecaseImpl) Snooze(ctx context.Context, userID uuid.UUID, until *time.Time) (entity.User, error) {
+	logging.From(ctx, u.logger).Info("setting user snooze", zap.String("user_id", userID.String()))
+
+	if until != nil && !until.After(u.clock()) {
+		return entity.User{}, ErrInvalidSnooze.Withf("until must be in the future")
+	}
+	// Clearing a snooze stays possible while the feature is switched off.
+	if until != nil {
+		if err := requireFeature(ctx, u.globalRepo, u.logger, entity.FeatureSnooze); err != nil {
+			return entity.User{}, err
+		}
+	}
+
+	user, err := u.getLiveUser(ctx, userID)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	user.SnoozedUntil = until
+	if err := u.saveUser(ctx, &user); err != nil {
+		return entity.User{}, err
+	}
+
+	if until == nil {
+		logging.From(ctx, u.logger).Info("user snooze cleared", zap.String("user_id", userID.String()))
+		u.audit.Record(ctx, entity.AuditUserUnsnoozed, entity.AuditUser, userID.String(), map[string]string{"team_name": user.TeamName})
+		return user, nil
+	}
+
+	logging.From(ctx, u.logger).Info("user snoozed",
+		zap.String("user_id", userID.String()),
+		zap.Time("until", *until),
+	)
+	u.audit.Record(ctx, entity.AuditUserSnoozed, entity.AuditUser, userID.String(), map[string]string{
+		"team_name": user.TeamName,
+		"until":     until.UTC().Format(time.RFC3339),
+	})
+	return user, nil
+}
+
+// DeleteUser soft-deletes a user: they are never assigned again and drop
+// out of user lists, but stay readable by ID and in their team, so PRs they
+// authored or reviewed still resolve. Reviews the user already has are not
+// touched; move them with TransferReviews.
+func (u *UserUsecaseImpl) DeleteUser(ctx context.Context, userID uuid.UUID) (entity.User, error) {
+	logging.From(ctx, u.logger).Info("deleting user", zap.String("user_id", userID.String()))
+
+	user, err := u.getLiveUser(ctx, userID)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	deletedAt := u.clock()
+	if err := u.userRepo.DeleteUser(ctx, userID, deletedAt); err != nil {
+		logging.From(ctx, u.logger).Error("failed to delete user", zap.String("user_id", userID.String()), zap.Error(err))
+		return entity.User{}, notFound(err, "user %s not found", userID)
+	}
+	user.DeletedAt = &deletedAt
+
+	logging.From(ctx, u.logger).Info("user deleted successfully", zap.String("user_id", userID.String()))
+	u.audit.Record(ctx, entity.AuditUserDeleted, entity.AuditUser, userID.String(), map[string]string{"team_name": user.TeamName})
+	return user, nil
+}
+
+func (u *UserUsecaseImpl) ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, int, error) {
+	logging.From(ctx, u.logger).Debug("listing users")
+
+	users, total, err := u.userRepo.ListUsers(ctx, filter)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to list users", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := make([]entity.User, len(users))
+	for i, user := range users {
+		result[i] = *user
+	}
+	return result, total, nil
+}
+
+// GetUsersByIDs returns the found users in request order along with the IDs
+// that do not exist.
+func (u *UserUsecaseImpl) GetUsersByIDs(ctx context.Context, userIDs []uuid.UUID) ([]entity.User, []uuid.UUID, error) {
+	logging.From(ctx, u.logger).Debug("getting users by IDs", zap.Int("requested", len(userIDs)))
+
+	users, err := u.userRepo.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get users by IDs", zap.Error(err))
+		return nil, nil, err
+	}
+
+	found := make(map[uuid.UUID]struct{}, len(users))
+	result := make([]entity.User, len(users))
+	for i, user := range users {
+		found[user.UserID] = struct{}{}
+		result[i] = *user
+	}
+
+	missing := make([]uuid.UUID, 0)
+	for _, id := range userIDs {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	return result, missing, nil
+}
+
+// UpsertUser creates or replaces a user and keeps team member lists in sync:
+// the user is removed from the previous team and added to the new one, which
+// is created on demand. The returned flag reports whether the user was created.
+func (u *UserUsecaseImpl) UpsertUser(ctx context.Context, user entity.User) (entity.User, bool, error) {
+	logging.From(ctx, u.logger).Info("upserting user",
+		zap.String("user_id", user.UserID.String()),
+		zap.String("team_name", user.TeamName),
+	)
+
+	existing, err := u.userRepo.GetUser(ctx, user.UserID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		logging.From(ctx, u.logger).Error("failed to get user", zap.String("user_id", user.UserID.String()), zap.Error(err))
+		return entity.User{}, false, err
+	}
+	created := existing == nil
+
+	if created || existing.TeamName != user.TeamName {
+		if err := u.quota.CheckMembers(ctx, user.TeamName, []entity.User{user}, false); err != nil {
+			return entity.User{}, false, err
+		}
+	}
+
+	if !created && existing.TeamName != user.TeamName {
+		if err := u.removeFromTeam(ctx, existing.TeamName, user.UserID); err != nil {
+			return entity.User{}, false, err
+		}
+	}
+
+	if !created {
+		user.SnoozedUntil = existing.SnoozedUntil
+		user.DeletedAt = existing.DeletedAt
+	}
+
+	if created {
+		err = u.userRepo.CreateUser(ctx, &user)
+	} else {
+		err = u.userRepo.UpdateUser(ctx, &user)
+	}
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to save user", zap.String("user_id", user.UserID.String()), zap.Error(err))
+		return entity.User{}, false, err
+	}
+
+	if err := u.addToTeam(ctx, user.TeamName, user.UserID); err != nil {
+		return entity.User{}, false, err
+	}
+
+	logging.From(ctx, u.logger).Info("user upserted successfully",
+		zap.String("user_id", user.UserID.String()),
+		zap.Bool("created", created),
+	)
+
+	return user, created, nil
+}
+
+func (u *UserUsecaseImpl) removeFromTeam(ctx context.Context, teamName string, userID uuid.UUID) error {
+	if teamName == "" {
+		return nil
+	}
+
+	team, err := u.teamRepo.GetTeam(ctx, teamName)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team", zap.String("team_name", teamName), zap.Error(err))
+		return err
+	}
+
+	updated := *team
+	updated.Members = slices.DeleteFunc(slices.Clone(team.Members), func(id uuid.UUID) bool {
+		return id == userID
+	})
+
+	if err := u.teamRepo.UpdateTeam(ctx, &updated); err != nil {
+		logging.From(ctx, u.logger).Error("failed to update team", zap.String("team_name", teamName), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (u *UserUsecaseImpl) addToTeam(ctx context.Context, teamName string, userID uuid.UUID) error {
+	if teamName == "" {
+		return nil
+	}
+
+	team, err := u.teamRepo.GetTeam(ctx, teamName)
+	if errors.Is(err, repository.ErrNotFound) {
+		newTeam := entity.Team{
+			TeamName: teamName,
+			Members:  []uuid.UUID{userID},
+		}
+		if err := u.teamRepo.CreateTeam(ctx, &newTeam); err != nil {
+			logging.From(ctx, u.logger).Error("failed to create team", zap.String("team_name", teamName), zap.Error(err))
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		logging.From(ctx, u.logger).Error("failed to get team", zap.String("team_name", teamName), zap.Error(err))
+		return err
+	}
+
+	if slices.Contains(team.Members, userID) {
+		return nil
+	}
+
+	updated := *team
+	updated.Members = append(slices.Clone(team.Members), userID)
+	if err := u.teamRepo.UpdateTeam(ctx, &updated); err != nil {
+		logging.From(ctx, u.logger).Error("failed to update team", zap.String("team_name", teamName), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func (u *UserUsecaseImpl) getUser(ctx context.Context, userID uuid.UUID) (entity.User, error) {
 	user, err := u.userRepo.GetUser(ctx, userID)
 	if err != nil {
-		u.logger.Error("failed to get user", zap.String("user_id", userID.String()), zap.Error(err))
-		return entity.User{}, err
+		logging.From(ctx, u.logger).Error("failed to get user", zap.String("user_id", userID.String()), zap.Error(err))
+		return entity.User{}, notFound(err, "user %s not found", userID)
 	}
 	return *user, nil
 }
 
+// getLiveUser is getUser for changes: a deleted user counts as not found.
+func (u *UserUsecaseImpl) getLiveUser(ctx context.Context, userID uuid.UUID) (entity.User, error) {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return entity.User{}, err
+	}
+	if user.IsDeleted() {
+		logging.From(ctx, u.logger).Warn("user is deleted", zap.String("user_id", userID.String()))
+		return entity.User{}, ErrNotFound.Withf("user %s is deleted", userID)
+	}
+	return user, nil
+}
+
 func (u *UserUsecaseImpl) updateUserActiveStatus(user entity.User, isActive bool) entity.User {
 	user.IsActive = isActive
 	return user
@@ -68,7 +409,7 @@ func (u *UserUsecaseImpl) updateUserActiveStatus(user entity.User, isActive bool
 
 func (u *UserUsecaseImpl) saveUser(ctx context.Context, user *entity.User) error {
 	if err := u.userRepo.UpdateUser(ctx, user); err != nil {
-		u.logger.Error("failed to update user",
+		logging.From(ctx, u.logger).Error("failed to update user",
 			zap.String("user_id", user.UserID.String()),
 			zap.Error(err),
 		)
@@ -0,0 +1,176 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type State string
+
+const (
+	// StatePending workers are registered but the app has not started yet.
+	StatePending State = "pending"
+	StateRunning State = "running"
+	// StateStopped workers exited because the app is shutting down.
+	StateStopped State = "stopped"
+	// StateCrashed workers panicked and are no longer running.
+	StateCrashed State = "crashed"
+)
+
+// Status is a snapshot of one background worker for readiness reporting.
+type Status struct {
+	Name          string
+	Critical      bool
+	State         State
+	StartedAt     time.Time
+	LastRunAt     time.Time
+	LastSuccessAt time.Time
+	LastError     string
+	Runs          int
+	Failures      int
+}
+
+// Registry owns the app's periodic background workers: it starts them,
+// keeps their status and recovers their panics, so a crashed worker shows
+// up in /readyz instead of silently disappearing.
+type Registry struct {
+	mu      sync.RWMutex
+	workers []*periodic
+	clock   func() time.Time
+	logger  *zap.Logger
+}
+
+type periodic struct {
+	status   Status
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+func NewRegistry(clock func() time.Time, logger *zap.Logger) *Registry {
+	return &Registry{
+		clock:  clock,
+		logger: logger,
+	}
+}
+
+// Periodic registers a worker that calls run once on start and then every
+// interval until the context passed to Start is cancelled. A failed run is
+// logged and retried on the next tick; a panic stops the worker. A crashed
+// critical worker makes the app unready.
+func (r *Registry) Periodic(name string, critical bool, interval time.Duration, run func(ctx context.Context) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.workers = append(r.workers, &periodic{
+		status: Status{
+			Name:     name,
+			Critical: critical,
+			State:    StatePending,
+		},
+		interval: interval,
+		run:      run,
+	})
+}
+
+// Start launches every registered worker in its own goroutine.
+func (r *Registry) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, w := range r.workers {
+		w.status.State = StateRunning
+		w.status.StartedAt = r.clock()
+		go r.loop(ctx, w)
+	}
+}
+
+// Statuses returns the workers in registration order.
+func (r *Registry) Statuses() []Status {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	statuses := make([]Status, len(r.workers))
+	for i, w := range r.workers {
+		statuses[i] = w.status
+	}
+	return statuses
+}
+
+// Crashed returns the names of critical workers that are no longer running
+// because they panicked.
+func (r *Registry) Crashed() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var crashed []string
+	for _, w := range r.workers {
+		if w.status.Critical && w.status.State == StateCrashed {
+			crashed = append(crashed, w.status.Name)
+		}
+	}
+	return crashed
+}
+
+func (r *Registry) loop(ctx context.Context, w *periodic) {
+	defer func() {
+		if p := recover(); p != nil {
+			r.logger.Error("background worker crashed",
+				zap.String("worker", w.status.Name),
+				zap.Any("panic", p),
+				zap.Stack("stack"),
+			)
+			r.update(w, func(s *Status) {
+				s.State = StateCrashed
+				s.LastError = fmt.Sprintf("panic: %v", p)
+			})
+		}
+	}()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		err := w.run(ctx)
+		if ctx.Err() == nil {
+			r.record(w, err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+
+	r.update(w, func(s *Status) { s.State = StateStopped })
+}
+
+func (r *Registry) record(w *periodic, err error) {
+	now := r.clock()
+	r.update(w, func(s *Status) {
+		s.Runs++
+		s.LastRunAt = now
+		if err != nil {
+			s.Failures++
+			s.LastError = err.Error()
+			return
+		}
+		s.LastSuccessAt = now
+		s.LastError = ""
+	})
+	if err != nil {
+		r.logger.Error("background worker run failed",
+			zap.String("worker", w.status.Name),
+			zap.Error(err),
+		)
+	}
+}
+
+func (r *Registry) update(w *periodic, fn func(s *Status)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(&w.status)
+}
@@ -0,0 +1,626 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Client struct {
+	baseURL    string
+	token      string
+	org        string
+	httpClient *http.Client
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithOrganization scopes every request to the given organization.
+func WithOrganization(orgID string) Option {
+	return func(c *Client) {
+		c.org = orgID
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) AddTeam(ctx context.Context, team Team) (Team, error) {
+	var resp struct {
+		Team Team `json:"team"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/team/add", nil, team, &resp); err != nil {
+		return Team{}, err
+	}
+	return resp.Team, nil
+}
+
+// UpdateTeam adds team's members to the existing team or, with replace,
+// makes them its complete member list.
+func (c *Client) UpdateTeam(ctx context.Context, team Team, replace bool) (Team, error) {
+	req := struct {
+		Team
+		Replace bool `json:"replace"`
+	}{
+		Team:    team,
+		Replace: replace,
+	}
+
+	var resp struct {
+		Team Team `json:"team"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/team/update", nil, req, &resp); err != nil {
+		return Team{}, err
+	}
+	return resp.Team, nil
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (Team, error) {
+	var resp Team
+	query := url.Values{"team_name": {teamName}}
+	if err := c.do(ctx, http.MethodGet, "/team/get", query, nil, &resp); err != nil {
+		return Team{}, err
+	}
+	return resp, nil
+}
+
+func (c *Client) ListTeams(ctx context.Context, page, pageSize int) (TeamPage, error) {
+	var resp TeamPage
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	if err := c.do(ctx, http.MethodGet, "/team/list", query, nil, &resp); err != nil {
+		return TeamPage{}, err
+	}
+	return resp, nil
+}
+
+func (c *Client) SetIsActive(ctx context.Context, userID string, isActive bool) (User, error) {
+	req := struct {
+		UserID   string `json:"user_id"`
+		IsActive bool   `json:"is_active"`
+	}{
+		UserID:   userID,
+		IsActive: isActive,
+	}
+
+	var resp struct {
+		User User `json:"user"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/users/setIsActive", nil, req, &resp); err != nil {
+		return User{}, err
+	}
+	return resp.User, nil
+}
+
+// SetIsActiveBulk sets isActive for all userIDs at once; if any of them is
+// missing, none is changed.
+func (c *Client) SetIsActiveBulk(ctx context.Context, userIDs []string, isActive bool) ([]ActiveStatusResult, error) {
+	req := struct {
+		UserIDs  []string `json:"user_ids"`
+		IsActive bool     `json:"is_active"`
+	}{
+		UserIDs:  userIDs,
+		IsActive: isActive,
+	}
+
+	var resp struct {
+		Results []ActiveStatusResult `json:"results"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/users/setIsActiveBulk", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+func (c *Client) Snooze(ctx context.Context, userID string, until *time.Time) (User, error) {
+	req := struct {
+		UserID string  `json:"user_id"`
+		Until  *string `json:"until"`
+	}{
+		UserID: userID,
+	}
+	if until != nil {
+		formatted := until.Format(time.RFC3339)
+		req.Until = &formatted
+	}
+
+	var resp struct {
+		User User `json:"user"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/users/snooze", nil, req, &resp); err != nil {
+		return User{}, err
+	}
+	return resp.User, nil
+}
+
+func (c *Client) TransferReviews(ctx context.Context, fromUserID, toUserID string) (ReviewTransfer, error) {
+	req := struct {
+		FromUserID string `json:"from_user_id"`
+		ToUserID   string `json:"to_user_id,omitempty"`
+	}{
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+	}
+
+	var resp ReviewTransfer
+	if err := c.do(ctx, http.MethodPost, "/users/transferReviews", nil, req, &resp); err != nil {
+		return ReviewTransfer{}, err
+	}
+	return resp, nil
+}
+
+func (c *Client) ListUsers(ctx context.Context, teamName string, isActive *bool, page, pageSize int) (UserPage, error) {
+	var resp UserPage
+	query := url.Values{}
+	if teamName != "" {
+		query.Set("team_name", teamName)
+	}
+	if isActive != nil {
+		query.Set("is_active", strconv.FormatBool(*isActive))
+	}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	if err := c.do(ctx, http.MethodGet, "/users/list", query, nil, &resp); err != nil {
+		return UserPage{}, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetUsersByIDs(ctx context.Context, userIDs []string) ([]User, []string, error) {
+	req := struct {
+		UserIDs []string `json:"user_ids"`
+	}{
+		UserIDs: userIDs,
+	}
+
+	var resp struct {
+		Users   []User   `json:"users"`
+		Missing []string `json:"missing"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/users/getByIDs", nil, req, &resp); err != nil {
+		return nil, nil, err
+	}
+	return resp.Users, resp.Missing, nil
+}
+
+func (c *Client) GetReview(ctx context.Context, userID string, opts ReviewListOptions) (ReviewPage, error) {
+	query := url.Values{"user_id": {userID}}
+	opts.apply(query)
+
+	var page ReviewPage
+	if err := c.do(ctx, http.MethodGet, "/users/getReview", query, nil, &page); err != nil {
+		return ReviewPage{}, err
+	}
+	return page, nil
+}
+
+// GetAuthored returns the PRs userID authored; a non-empty status keeps
+// only the PRs in it.
+func (c *Client) GetAuthored(ctx context.Context, userID, status string) ([]PullRequestShort, error) {
+	var resp struct {
+		PullRequests []PullRequestShort `json:"pull_requests"`
+	}
+	query := url.Values{"user_id": {userID}}
+	if status != "" {
+		query.Set("status", status)
+	}
+	if err := c.do(ctx, http.MethodGet, "/users/getAuthored", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PullRequests, nil
+}
+
+func (c *Client) CreatePR(ctx context.Context, prID, prName, authorID string, reviewers ...string) (PullRequest, error) {
+	req := struct {
+		PullRequestID   string   `json:"pull_request_id"`
+		PullRequestName string   `json:"pull_request_name"`
+		AuthorID        string   `json:"author_id"`
+		Reviewers       []string `json:"reviewers,omitempty"`
+	}{
+		PullRequestID:   prID,
+		PullRequestName: prName,
+		AuthorID:        authorID,
+		Reviewers:       reviewers,
+	}
+
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/create", nil, req, &resp); err != nil {
+		return PullRequest{}, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) MergePR(ctx context.Context, prID string) (PullRequest, error) {
+	req := struct {
+		PullRequestID string `json:"pull_request_id"`
+	}{
+		PullRequestID: prID,
+	}
+
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/merge", nil, req, &resp); err != nil {
+		return PullRequest{}, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) ClosePR(ctx context.Context, prID string) (PullRequest, error) {
+	req := struct {
+		PullRequestID string `json:"pull_request_id"`
+	}{
+		PullRequestID: prID,
+	}
+
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/close", nil, req, &resp); err != nil {
+		return PullRequest{}, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) ApprovePR(ctx context.Context, prID, userID string) (PullRequest, error) {
+	req := struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}{
+		PullRequestID: prID,
+		UserID:        userID,
+	}
+
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/approve", nil, req, &resp); err != nil {
+		return PullRequest{}, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) RequestChanges(ctx context.Context, prID, userID string) (PullRequest, error) {
+	req := struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}{
+		PullRequestID: prID,
+		UserID:        userID,
+	}
+
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/requestChanges", nil, req, &resp); err != nil {
+		return PullRequest{}, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) ReopenPR(ctx context.Context, prID, userID string) (PullRequest, error) {
+	req := struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}{
+		PullRequestID: prID,
+		UserID:        userID,
+	}
+
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/reopen", nil, req, &resp); err != nil {
+		return PullRequest{}, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) OverrideApproval(ctx context.Context, prID, leadID, reason string) (PullRequest, error) {
+	req := struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		Reason        string `json:"reason"`
+	}{
+		PullRequestID: prID,
+		UserID:        leadID,
+		Reason:        reason,
+	}
+
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/override", nil, req, &resp); err != nil {
+		return PullRequest{}, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) SetAutoMerge(ctx context.Context, prID string, enabled bool) (PullRequest, error) {
+	req := struct {
+		PullRequestID string `json:"pull_request_id"`
+		AutoMerge     bool   `json:"auto_merge"`
+	}{
+		PullRequestID: prID,
+		AutoMerge:     enabled,
+	}
+
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/setAutoMerge", nil, req, &resp); err != nil {
+		return PullRequest{}, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) UpdatePR(ctx context.Context, prID string, update PRUpdate) (PullRequest, error) {
+	req := struct {
+		PullRequestID string `json:"pull_request_id"`
+		PRUpdate
+	}{
+		PullRequestID: prID,
+		PRUpdate:      update,
+	}
+
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/update", nil, req, &resp); err != nil {
+		return PullRequest{}, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) ReassignReviewer(ctx context.Context, prID, oldUserID string) (PullRequest, string, error) {
+	req := struct {
+		PullRequestID string `json:"pull_request_id"`
+		OldUserID     string `json:"old_user_id"`
+	}{
+		PullRequestID: prID,
+		OldUserID:     oldUserID,
+	}
+
+	var resp struct {
+		PR         PullRequest `json:"pr"`
+		ReplacedBy string      `json:"replaced_by"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/reassign", nil, req, &resp); err != nil {
+		return PullRequest{}, "", err
+	}
+	return resp.PR, resp.ReplacedBy, nil
+}
+
+// DeclineReview removes userID from the PR's reviewers on their own
+// behalf and returns the PR together with the replacement's ID.
+// DeclineReview steps the reviewer down from the PR. userID may be empty
+// when the client is authenticated: the server then declines for the user
+// linked to the token.
+func (c *Client) DeclineReview(ctx context.Context, prID, userID, reason string) (PullRequest, string, error) {
+	req := struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		Reason        string `json:"reason"`
+	}{
+		PullRequestID: prID,
+		UserID:        userID,
+		Reason:        reason,
+	}
+
+	var resp struct {
+		PR         PullRequest `json:"pr"`
+		ReplacedBy string      `json:"replaced_by"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/decline", nil, req, &resp); err != nil {
+		return PullRequest{}, "", err
+	}
+	return resp.PR, resp.ReplacedBy, nil
+}
+
+func (c *Client) GetOverduePRs(ctx context.Context, olderThan time.Duration) ([]PullRequest, error) {
+	var resp struct {
+		PullRequests []PullRequest `json:"pull_requests"`
+	}
+	query := url.Values{}
+	if olderThan > 0 {
+		query.Set("older_than", olderThan.String())
+	}
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/overdue", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PullRequests, nil
+}
+
+func (c *Client) GetTeamPRs(ctx context.Context, teamName string, opts ListOptions) ([]PullRequest, error) {
+	var resp struct {
+		PullRequests []PullRequest `json:"pull_requests"`
+	}
+	query := url.Values{"team_name": {teamName}}
+	opts.apply(query)
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/byTeam", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PullRequests, nil
+}
+
+func (c *Client) ListPRs(ctx context.Context, opts PRListOptions) (PullRequestPage, error) {
+	query := url.Values{}
+	opts.apply(query)
+
+	var page PullRequestPage
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/list", query, nil, &page); err != nil {
+		return PullRequestPage{}, err
+	}
+	return page, nil
+}
+
+func (c *Client) BackfillReviewers(ctx context.Context) ([]PullRequest, error) {
+	var resp struct {
+		Updated []PullRequest `json:"updated"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/admin/backfillReviewers", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Updated, nil
+}
+
+func (c *Client) RebalanceTeam(ctx context.Context, teamName string, dryRun bool) (Rebalance, error) {
+	query := url.Values{"team_name": {teamName}}
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+
+	var resp Rebalance
+	if err := c.do(ctx, http.MethodPost, "/admin/rebalance", query, nil, &resp); err != nil {
+		return Rebalance{}, err
+	}
+	return resp, nil
+}
+
+func (c *Client) CheckConsistency(ctx context.Context) (ConsistencyReport, error) {
+	var resp ConsistencyReport
+	if err := c.do(ctx, http.MethodGet, "/admin/consistency", nil, nil, &resp); err != nil {
+		return ConsistencyReport{}, err
+	}
+	return resp, nil
+}
+
+func (c *Client) Repair(ctx context.Context, kinds []string, dryRun bool) (RepairResult, error) {
+	query := url.Values{"kind": kinds}
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+
+	var resp RepairResult
+	if err := c.do(ctx, http.MethodPost, "/admin/repair", query, nil, &resp); err != nil {
+		return RepairResult{}, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetSettings(ctx context.Context) (GlobalSettingsView, error) {
+	var resp GlobalSettingsView
+	if err := c.do(ctx, http.MethodGet, "/admin/settings", nil, nil, &resp); err != nil {
+		return GlobalSettingsView{}, err
+	}
+	return resp, nil
+}
+
+func (c *Client) SetSettings(ctx context.Context, settings GlobalSettings) (GlobalSettingsView, error) {
+	var resp GlobalSettingsView
+	if err := c.do(ctx, http.MethodPost, "/admin/settings", nil, settings, &resp); err != nil {
+		return GlobalSettingsView{}, err
+	}
+	return resp, nil
+}
+
+func (c *Client) WhoAmI(ctx context.Context) (Principal, error) {
+	var resp Principal
+	if err := c.do(ctx, http.MethodGet, "/auth/me", nil, nil, &resp); err != nil {
+		return Principal{}, err
+	}
+	return resp, nil
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey makes calls made with the returned ctx send key as the
+// Idempotency-Key header. Retrying CreatePR, MergePR or ReassignReviewer
+// with the same key returns the first response instead of repeating it.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.org != "" {
+		req.Header.Set("X-Organization-ID", c.org)
+	}
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok && key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeAPIError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
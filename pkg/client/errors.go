@@ -0,0 +1,62 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    map[string]string
+	// Fields lists the request fields the server rejected, if it named any.
+	Fields []FieldError
+	// RequestID identifies the request in the server logs.
+	RequestID string
+}
+
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("api error: status %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("api error: status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func decodeAPIError(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+
+	var payload struct {
+		Error struct {
+			Code      string            `json:"code"`
+			Message   string            `json:"message"`
+			Details   map[string]string `json:"details"`
+			Fields    []FieldError      `json:"fields"`
+			RequestID string            `json:"request_id"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil || payload.Error.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+
+	apiErr.Code = payload.Error.Code
+	apiErr.Message = payload.Error.Message
+	apiErr.Details = payload.Error.Details
+	apiErr.Fields = payload.Error.Fields
+	apiErr.RequestID = payload.Error.RequestID
+	return apiErr
+}
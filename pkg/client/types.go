@@ -0,0 +1,330 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Error codes of APIError.Code the client tells apart.
+//
+// Since error format v2 every request field the server cannot parse or
+// validate, in the body or in the query (a malformed UUID, status, limit,
+// cursor or timestamp, a missing parameter), is reported as
+// ErrorCodeValidationFailed and named in APIError.Fields; before v2 most of
+// them were ErrorCodeInvalidInput without Fields. ErrorCodeInvalidInput
+// remains for requests that are wrong as a whole, such as an oversized
+// body. Details keeps its v1 shape, an object of extra context like the
+// resource and limit of a quota error, and never lists fields.
+const (
+	ErrorCodeInvalidInput     = "INVALID_INPUT"
+	ErrorCodeValidationFailed = "VALIDATION_FAILED"
+	ErrorCodeMalformedJSON    = "MALFORMED_JSON"
+)
+
+type ListOptions struct {
+	Status string
+	Query  string
+	Sort   string
+	Order  string
+}
+
+func (o ListOptions) apply(query url.Values) {
+	if o.Status != "" {
+		query.Set("status", o.Status)
+	}
+	if o.Query != "" {
+		query.Set("q", o.Query)
+	}
+	if o.Sort != "" {
+		query.Set("sort", o.Sort)
+	}
+	if o.Order != "" {
+		query.Set("order", o.Order)
+	}
+}
+
+// PRListOptions filters GET /pullRequest/list. Zero fields are left out;
+// Cursor is the NextCursor of the previous page.
+type PRListOptions struct {
+	Status      string
+	AuthorID    string
+	TeamName    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Sort        string
+	Order       string
+	Limit       int
+	Cursor      string
+}
+
+func (o PRListOptions) apply(query url.Values) {
+	if o.Status != "" {
+		query.Set("status", o.Status)
+	}
+	if o.AuthorID != "" {
+		query.Set("author_id", o.AuthorID)
+	}
+	if o.TeamName != "" {
+		query.Set("team_name", o.TeamName)
+	}
+	if o.CreatedFrom != nil {
+		query.Set("created_from", o.CreatedFrom.Format(time.RFC3339))
+	}
+	if o.CreatedTo != nil {
+		query.Set("created_to", o.CreatedTo.Format(time.RFC3339))
+	}
+	if o.Sort != "" {
+		query.Set("sort", o.Sort)
+	}
+	if o.Order != "" {
+		query.Set("order", o.Order)
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		query.Set("cursor", o.Cursor)
+	}
+}
+
+// ReviewListOptions filters GET /users/getReview. An empty Status lists
+// open reviews only, "ALL" lists every status; Cursor is the NextCursor of
+// the previous page.
+type ReviewListOptions struct {
+	Status string
+	Sort   string
+	Order  string
+	Limit  int
+	Cursor string
+}
+
+func (o ReviewListOptions) apply(query url.Values) {
+	if o.Status != "" {
+		query.Set("status", o.Status)
+	}
+	if o.Sort != "" {
+		query.Set("sort", o.Sort)
+	}
+	if o.Order != "" {
+		query.Set("order", o.Order)
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		query.Set("cursor", o.Cursor)
+	}
+}
+
+type TeamMember struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	IsActive bool   `json:"is_active"`
+}
+
+type Team struct {
+	TeamName string       `json:"team_name"`
+	Members  []TeamMember `json:"members"`
+}
+
+type User struct {
+	UserID       string  `json:"user_id"`
+	Username     string  `json:"username"`
+	TeamName     string  `json:"team_name"`
+	IsActive     bool    `json:"is_active"`
+	SnoozedUntil *string `json:"snoozed_until,omitempty"`
+}
+
+// ActiveStatusResult is the per-user outcome of SetIsActiveBulk.
+type ActiveStatusResult struct {
+	UserID  string `json:"user_id"`
+	Changed bool   `json:"changed"`
+	User    User   `json:"user"`
+}
+
+type UserWithLoad struct {
+	User
+	OpenReviews int `json:"open_reviews"`
+}
+
+type TeamSummary struct {
+	TeamName         string `json:"team_name"`
+	MembersCount     int    `json:"members_count"`
+	OpenPullRequests int    `json:"open_pull_requests"`
+}
+
+type TeamPage struct {
+	Teams    []TeamSummary `json:"teams"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"page_size"`
+	Total    int           `json:"total"`
+}
+
+type UserPage struct {
+	Users    []UserWithLoad `json:"users"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+	Total    int            `json:"total"`
+}
+
+type PullRequestPage struct {
+	PullRequests []PullRequest `json:"pull_requests"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PRUpdate lists the PR fields to change; nil fields are kept.
+type PRUpdate struct {
+	Name *string `json:"pull_request_name,omitempty"`
+}
+
+type PullRequest struct {
+	PullRequestID     string         `json:"pull_request_id"`
+	PullRequestName   string         `json:"pull_request_name"`
+	AuthorID          string         `json:"author_id"`
+	Status            string         `json:"status"`
+	AssignedReviewers []string       `json:"assigned_reviewers"`
+	Reviewers         []Reviewer     `json:"reviewers,omitempty"`
+	CreatedAt         *string        `json:"createdAt,omitempty"`
+	MergedAt          *string        `json:"mergedAt,omitempty"`
+	ClosedAt          *string        `json:"closedAt,omitempty"`
+	MilestoneID       *string        `json:"milestone_id,omitempty"`
+	Checklist         *Checklist     `json:"checklist,omitempty"`
+	Override          *Override      `json:"override,omitempty"`
+	ChangesRequested  *ChangeRequest `json:"changes_requested,omitempty"`
+	Declines          []Decline      `json:"declines,omitempty"`
+	AutoMerge         bool           `json:"auto_merge"`
+	Iteration         int            `json:"iteration,omitempty"`
+	ExternalID        string         `json:"external_id,omitempty"`
+	ChangedFiles      []string       `json:"changed_files,omitempty"`
+}
+
+type Override struct {
+	UserID       string `json:"user_id"`
+	Reason       string `json:"reason"`
+	OverriddenAt string `json:"overridden_at"`
+}
+
+type ChangeRequest struct {
+	UserID      string `json:"user_id"`
+	RequestedAt string `json:"requested_at"`
+}
+
+type Decline struct {
+	UserID     string `json:"user_id"`
+	ReplacedBy string `json:"replaced_by"`
+	Reason     string `json:"reason"`
+	DeclinedAt string `json:"declined_at"`
+}
+
+type Reviewer struct {
+	UserID     string  `json:"user_id"`
+	Slot       string  `json:"slot"`
+	Approved   bool    `json:"approved"`
+	ApprovedAt *string `json:"approved_at,omitempty"`
+}
+
+type Checklist struct {
+	RequiredForMerge bool            `json:"required_for_merge"`
+	Items            []ChecklistItem `json:"items"`
+}
+
+type ChecklistItem struct {
+	ItemID    int     `json:"item_id"`
+	Text      string  `json:"text"`
+	Checked   bool    `json:"checked"`
+	CheckedBy *string `json:"checked_by,omitempty"`
+	CheckedAt *string `json:"checked_at,omitempty"`
+}
+
+type PullRequestShort struct {
+	PullRequestID   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+	AuthorID        string `json:"author_id"`
+	Status          string `json:"status"`
+}
+
+type ReviewPage struct {
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type Rebalance struct {
+	TeamName   string         `json:"team_name"`
+	DryRun     bool           `json:"dry_run"`
+	Moves      []ReviewerMove `json:"moves"`
+	LoadBefore map[string]int `json:"load_before"`
+	LoadAfter  map[string]int `json:"load_after"`
+}
+
+type ReviewTransfer struct {
+	FromUserID string            `json:"from_user_id"`
+	ToUserID   string            `json:"to_user_id,omitempty"`
+	Moves      []ReviewerMove    `json:"moves"`
+	Skipped    []SkippedTransfer `json:"skipped"`
+}
+
+type SkippedTransfer struct {
+	PullRequestID string `json:"pull_request_id"`
+	Reason        string `json:"reason"`
+}
+
+type ReviewerMove struct {
+	PullRequestID string `json:"pull_request_id"`
+	FromUserID    string `json:"from_user_id"`
+	ToUserID      string `json:"to_user_id"`
+}
+
+type ConsistencyReport struct {
+	OrgID        string    `json:"org_id"`
+	CheckedAt    string    `json:"checked_at"`
+	Teams        int       `json:"teams"`
+	Users        int       `json:"users"`
+	PullRequests int       `json:"pull_requests"`
+	Anomalies    []Anomaly `json:"anomalies"`
+}
+
+type Anomaly struct {
+	Kind          string `json:"kind"`
+	TeamName      string `json:"team_name,omitempty"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	UserID        string `json:"user_id"`
+	Detail        string `json:"detail"`
+}
+
+type RepairResult struct {
+	OrgID      string         `json:"org_id"`
+	DryRun     bool           `json:"dry_run"`
+	Kinds      []string       `json:"kinds"`
+	Repaired   []RepairAction `json:"repaired"`
+	Unresolved []Anomaly      `json:"unresolved"`
+}
+
+// GlobalSettings leaves unset fields at the server's configured defaults;
+// SLA is a duration string such as "24h".
+type GlobalSettings struct {
+	RequiredReviewers *int            `json:"required_reviewers,omitempty"`
+	OptionalReviewers *int            `json:"optional_reviewers,omitempty"`
+	SLA               *string         `json:"sla,omitempty"`
+	Features          map[string]bool `json:"features,omitempty"`
+}
+
+type GlobalSettingsView struct {
+	Overrides GlobalSettings `json:"overrides"`
+	Effective GlobalSettings `json:"effective"`
+}
+
+type RepairAction struct {
+	Anomaly
+	ReplacementID string `json:"replacement_id,omitempty"`
+}
+
+type Principal struct {
+	Subject string   `json:"subject"`
+	Email   string   `json:"email,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Roles   []string `json:"roles"`
+	Method  string   `json:"method"`
+}